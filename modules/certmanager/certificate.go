@@ -127,8 +127,10 @@ func (c *Certificate) CreateOrPatch(
 
 		if owner != nil {
 			err = controllerutil.SetControllerReference(owner, cert, h.GetScheme())
-		} else {
+		} else if h.HasBeforeObject() {
 			err = controllerutil.SetControllerReference(h.GetBeforeObject(), cert, h.GetScheme())
+		} else {
+			err = helper.ErrNoBeforeObject
 		}
 		if err != nil {
 			return err
@@ -138,13 +140,13 @@ func (c *Certificate) CreateOrPatch(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Certificate %s not found, reconcile in %s", cert.Name, c.timeout))
+			h.GetLogger().Info("Certificate not found, reconcile later", "object", cert.Name, "requeueAfter", c.timeout)
 			return ctrl.Result{RequeueAfter: c.timeout}, op, nil
 		}
 		return ctrl.Result{}, op, err
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("Route %s - %s", cert.Name, op))
+		h.GetLogger().Info("Certificate reconciled", "object", cert.Name, "operation", op)
 	}
 
 	return ctrl.Result{}, op, nil
@@ -167,15 +169,18 @@ func (c *Certificate) Delete(
 // EnsureCert - creates a certificate, ensures the secret has the required key/cert and return the secret
 func EnsureCert(
 	ctx context.Context,
-	helper *helper.Helper,
+	h *helper.Helper,
 	request CertificateRequest,
 	owner client.Object,
 ) (*k8s_corev1.Secret, ctrl.Result, error) {
 	// get issuer
 	issuer := &certmgrv1.Issuer{}
-	namespace := helper.GetBeforeObject().GetNamespace()
+	if !h.HasBeforeObject() {
+		return nil, ctrl.Result{}, helper.ErrNoBeforeObject
+	}
+	namespace := h.GetBeforeObject().GetNamespace()
 
-	err := helper.GetClient().Get(ctx, types.NamespacedName{Name: request.IssuerName, Namespace: namespace}, issuer)
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: request.IssuerName, Namespace: namespace}, issuer)
 	if err != nil {
 		err = fmt.Errorf("error getting issuer %s/%s - %w", request.IssuerName, namespace, err)
 
@@ -241,7 +246,7 @@ func EnsureCert(
 	)
 
 	cert := NewCertificate(certReq, 5)
-	ctrlResult, op, err := cert.CreateOrPatch(ctx, helper, owner)
+	ctrlResult, op, err := cert.CreateOrPatch(ctx, h, owner)
 	if err != nil {
 		return nil, ctrlResult, err
 	} else if (ctrlResult != ctrl.Result{}) {
@@ -249,10 +254,10 @@ func EnsureCert(
 	}
 
 	// get cert secret
-	certSecret, _, err := secret.GetSecret(ctx, helper, certSecretName, namespace)
+	certSecret, _, err := secret.GetSecret(ctx, h, certSecretName, namespace)
 	if err != nil {
 		if k8s_errors.IsNotFound(err) && op == controllerutil.OperationResultCreated {
-			helper.GetLogger().Info(fmt.Sprintf("Secret %s not found, reconcile in %s", certSecretName, cert.timeout))
+			h.GetLogger().Info("Secret not found, reconcile later", "object", certSecretName, "requeueAfter", cert.timeout)
 			return nil, ctrl.Result{RequeueAfter: cert.timeout}, nil
 		}
 		return nil, ctrl.Result{}, err
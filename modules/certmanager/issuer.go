@@ -130,6 +130,9 @@ func (i *Issuer) CreateOrPatch(
 		issuer.Annotations = util.MergeStringMaps(issuer.Annotations, i.issuer.Annotations)
 		issuer.Spec = i.issuer.Spec
 
+		if !h.HasBeforeObject() {
+			return helper.ErrNoBeforeObject
+		}
 		err := controllerutil.SetControllerReference(h.GetBeforeObject(), issuer, h.GetScheme())
 		if err != nil {
 			return err
@@ -139,13 +142,13 @@ func (i *Issuer) CreateOrPatch(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Issuer %s not found, reconcile in %s", issuer.Name, i.timeout))
+			h.GetLogger().Info("Issuer not found, reconcile later", "object", issuer.Name, "requeueAfter", i.timeout)
 			return ctrl.Result{RequeueAfter: i.timeout}, nil
 		}
 		return ctrl.Result{}, err
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("Issuer %s - %s", issuer.Name, op))
+		h.GetLogger().Info("Issuer reconciled", "object", issuer.Name, "operation", op)
 	}
 
 	return ctrl.Result{}, nil
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	certmgrv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certmgrmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// SimulateIssuerReady sets the Ready condition of the Issuer to true, like
+// cert-manager does when the issuer is usable.
+//
+// Example:
+//
+//	th.SimulateIssuerReady(types.NamespacedName{Name: "my-issuer", Namespace: "my-namespace"})
+func (tc *TestHelper) SimulateIssuerReady(name types.NamespacedName) {
+	tc.setIssuerReadyCondition(name, certmgrmetav1.ConditionTrue, "KeyPairVerified", "Signing CA verified")
+	tc.Logger.Info("Simulated Issuer ready", "on", name)
+}
+
+// SimulateIssuerFailure sets the Ready condition of the Issuer to false
+// with the given reason and message, e.g. for a missing CA secret.
+//
+// Example:
+//
+//	th.SimulateIssuerFailure(
+//		types.NamespacedName{Name: "my-issuer", Namespace: "my-namespace"},
+//		"ErrGetKeyPair",
+//		"Error getting keypair for CA issuer: secret not found",
+//	)
+func (tc *TestHelper) SimulateIssuerFailure(name types.NamespacedName, reason string, message string) {
+	tc.setIssuerReadyCondition(name, certmgrmetav1.ConditionFalse, reason, message)
+	tc.Logger.Info("Simulated Issuer failure", "on", name, "reason", reason)
+}
+
+func (tc *TestHelper) setIssuerReadyCondition(
+	name types.NamespacedName,
+	status certmgrmetav1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		issuer := tc.GetIssuer(name)
+		now := metav1.Now()
+		issuer.Status.Conditions = []certmgrv1.IssuerCondition{
+			{
+				Type:               certmgrv1.IssuerConditionReady,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: &now,
+				ObservedGeneration: issuer.Generation,
+			},
+		}
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, issuer)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+}
+
+// SimulateCertificateReady generates a real self-signed certificate and key
+// for the DNS names, IPs and common name of the Certificate, stores them in
+// the target secret of the Certificate, including ca.crt, and sets the
+// Ready condition and validity of the Certificate status, like cert-manager
+// does when it issued the certificate. Returns the secret.
+//
+// Example:
+//
+//	secret := th.SimulateCertificateReady(types.NamespacedName{Name: "my-cert", Namespace: "my-namespace"})
+func (tc *TestHelper) SimulateCertificateReady(name types.NamespacedName) *corev1.Secret {
+	cert := tc.GetCert(name)
+
+	duration := 90 * 24 * time.Hour
+	if cert.Spec.Duration != nil {
+		duration = cert.Spec.Duration.Duration
+	}
+	notBefore := time.Now().Truncate(time.Second)
+	notAfter := notBefore.Add(duration)
+
+	certPEM, keyPEM := generateSelfSignedCert(cert, notBefore, notAfter)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cert.Spec.SecretName,
+			Namespace: name.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(tc.Ctx, tc.K8sClient, secret, func() error {
+		if cert.Spec.SecretTemplate != nil {
+			secret.Labels = cert.Spec.SecretTemplate.Labels
+			secret.Annotations = cert.Spec.SecretTemplate.Annotations
+		}
+		if secret.CreationTimestamp.IsZero() {
+			secret.Type = corev1.SecretTypeTLS
+		}
+		secret.Data = map[string][]byte{
+			"tls.crt": certPEM,
+			"tls.key": keyPEM,
+			"ca.crt":  certPEM,
+		}
+		return nil
+	})
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	gomega.Eventually(func(g gomega.Gomega) {
+		cert := tc.GetCert(name)
+		now := metav1.Now()
+		cert.Status.Conditions = []certmgrv1.CertificateCondition{
+			{
+				Type:               certmgrv1.CertificateConditionReady,
+				Status:             certmgrmetav1.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "Certificate is up to date and has not expired",
+				LastTransitionTime: &now,
+				ObservedGeneration: cert.Generation,
+			},
+		}
+		cert.Status.NotBefore = &metav1.Time{Time: notBefore}
+		cert.Status.NotAfter = &metav1.Time{Time: notAfter}
+		renewBefore := duration / 3
+		if cert.Spec.RenewBefore != nil {
+			renewBefore = cert.Spec.RenewBefore.Duration
+		}
+		cert.Status.RenewalTime = &metav1.Time{Time: notAfter.Add(-renewBefore)}
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, cert)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated Certificate ready", "on", name, "secret", secret.Name)
+
+	return secret
+}
+
+// SimulateCertificateFailure sets the Ready condition of the Certificate to
+// false with the given reason and message, e.g. when the issuer is not ready.
+//
+// Example:
+//
+//	th.SimulateCertificateFailure(
+//		types.NamespacedName{Name: "my-cert", Namespace: "my-namespace"},
+//		"IssuerNotReady",
+//		"Issuer my-issuer not ready",
+//	)
+func (tc *TestHelper) SimulateCertificateFailure(name types.NamespacedName, reason string, message string) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		cert := tc.GetCert(name)
+		now := metav1.Now()
+		cert.Status.Conditions = []certmgrv1.CertificateCondition{
+			{
+				Type:               certmgrv1.CertificateConditionReady,
+				Status:             certmgrmetav1.ConditionFalse,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: &now,
+				ObservedGeneration: cert.Generation,
+			},
+		}
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, cert)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated Certificate failure", "on", name, "reason", reason)
+}
+
+// generateSelfSignedCert - returns a PEM encoded self-signed certificate and
+// key matching the subject of the Certificate spec
+func generateSelfSignedCert(
+	cert *certmgrv1.Certificate,
+	notBefore time.Time,
+	notAfter time.Time,
+) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cert.Spec.CommonName},
+		DNSNames:              cert.Spec.DNSNames,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  cert.Spec.IsCA,
+	}
+	for _, ip := range cert.Spec.IPAddresses {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			template.IPAddresses = append(template.IPAddresses, parsed)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
@@ -13,6 +13,7 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 */
+// +kubebuilder:object:generate:=true
 
 // Package affinity provides utilities for managing Kubernetes pod affinity and anti-affinity rules
 package affinity
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrInvalidSelectorOperator indicates a label selector operator which can not be used for node affinity
+var ErrInvalidSelectorOperator = errors.New("invalid label selector operator")
+
+// NodeSelectorTerm - returns a node selector term requiring all the node
+// labels of the nodeSelector, and if nodeNames is not empty, that the
+// kubernetes.io/hostname label of the node is one of nodeNames. The
+// metadata.name field selector can not be used, as it only allows a single
+// value. The requirements are sorted by key to get a stable result.
+func NodeSelectorTerm(
+	nodeSelector map[string]string,
+	nodeNames []string,
+) corev1.NodeSelectorTerm {
+	term := corev1.NodeSelectorTerm{}
+
+	requirements := map[string][]string{}
+	for k, v := range nodeSelector {
+		requirements[k] = []string{v}
+	}
+	if len(nodeNames) > 0 {
+		names := append([]string{}, nodeNames...)
+		sort.Strings(names)
+		requirements[corev1.LabelHostname] = names
+	}
+
+	keys := make([]string, 0, len(requirements))
+	for k := range requirements {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      k,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   requirements[k],
+		})
+	}
+
+	return term
+}
+
+// RequireNodes - returns a node affinity which requires the pods to be
+// scheduled on nodes matching the nodeSelector labels and, if not empty,
+// nodeNames. Returns nil if both are empty.
+func RequireNodes(
+	nodeSelector map[string]string,
+	nodeNames []string,
+) *corev1.NodeAffinity {
+	if len(nodeSelector) == 0 && len(nodeNames) == 0 {
+		return nil
+	}
+	return &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{NodeSelectorTerm(nodeSelector, nodeNames)},
+		},
+	}
+}
+
+// PreferNodes - returns a node affinity which prefers nodes matching the
+// nodeSelector labels with the given weight. Returns nil if the
+// nodeSelector is empty.
+func PreferNodes(
+	nodeSelector map[string]string,
+	weight int32,
+) (*corev1.NodeAffinity, error) {
+	if len(nodeSelector) == 0 {
+		return nil, nil
+	}
+	err := ValidateWeight(weight)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.NodeAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+			{
+				Weight:     weight,
+				Preference: NodeSelectorTerm(nodeSelector, nil),
+			},
+		},
+	}, nil
+}
+
+// NodeSelectorTermFromLabelSelector - converts a metav1.LabelSelector, e.g.
+// from a CR spec, into a node selector term. Returns nil if the selector is
+// nil, as an empty term matches no nodes.
+func NodeSelectorTermFromLabelSelector(
+	selector *metav1.LabelSelector,
+) (*corev1.NodeSelectorTerm, error) {
+	if selector == nil {
+		return nil, nil
+	}
+
+	term := NodeSelectorTerm(selector.MatchLabels, nil)
+	for _, e := range selector.MatchExpressions {
+		var op corev1.NodeSelectorOperator
+		switch e.Operator {
+		case metav1.LabelSelectorOpIn:
+			op = corev1.NodeSelectorOpIn
+		case metav1.LabelSelectorOpNotIn:
+			op = corev1.NodeSelectorOpNotIn
+		case metav1.LabelSelectorOpExists:
+			op = corev1.NodeSelectorOpExists
+		case metav1.LabelSelectorOpDoesNotExist:
+			op = corev1.NodeSelectorOpDoesNotExist
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSelectorOperator, e.Operator)
+		}
+		term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      e.Key,
+			Operator: op,
+			Values:   append([]string{}, e.Values...),
+		})
+	}
+
+	return &term, nil
+}
+
+// MergeNodeAffinity - merges the user provided override into the default
+// node affinity. Nodes have to match both, the required terms of the
+// default and of the override, as the terms of a NodeSelector are ORed the
+// result contains each combination of a default and an override term.
+// Preferred terms get appended.
+func MergeNodeAffinity(
+	defaults *corev1.NodeAffinity,
+	override *corev1.NodeAffinity,
+) *corev1.NodeAffinity {
+	if defaults == nil && override == nil {
+		return nil
+	}
+	if defaults == nil {
+		return override.DeepCopy()
+	}
+	if override == nil {
+		return defaults.DeepCopy()
+	}
+
+	merged := defaults.DeepCopy()
+	merged.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		merged.PreferredDuringSchedulingIgnoredDuringExecution,
+		override.DeepCopy().PreferredDuringSchedulingIgnoredDuringExecution...)
+
+	if override.RequiredDuringSchedulingIgnoredDuringExecution == nil ||
+		len(override.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+		return merged
+	}
+	if merged.RequiredDuringSchedulingIgnoredDuringExecution == nil ||
+		len(merged.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+		merged.RequiredDuringSchedulingIgnoredDuringExecution = override.RequiredDuringSchedulingIgnoredDuringExecution.DeepCopy()
+		return merged
+	}
+
+	terms := []corev1.NodeSelectorTerm{}
+	for _, d := range merged.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, o := range override.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			t := d.DeepCopy()
+			o := o.DeepCopy()
+			t.MatchExpressions = append(t.MatchExpressions, o.MatchExpressions...)
+			t.MatchFields = append(t.MatchFields, o.MatchFields...)
+			terms = append(terms, *t)
+		}
+	}
+	merged.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = terms
+
+	return merged
+}
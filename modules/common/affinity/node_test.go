@@ -0,0 +1,109 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeSelectorTerm(t *testing.T) {
+	g := NewWithT(t)
+
+	term := NodeSelectorTerm(map[string]string{"role": "storage", "disk": "ssd"}, []string{"worker-1", "worker-0"})
+	g.Expect(term.MatchExpressions).To(Equal([]corev1.NodeSelectorRequirement{
+		{Key: "disk", Operator: corev1.NodeSelectorOpIn, Values: []string{"ssd"}},
+		{Key: corev1.LabelHostname, Operator: corev1.NodeSelectorOpIn, Values: []string{"worker-0", "worker-1"}},
+		{Key: "role", Operator: corev1.NodeSelectorOpIn, Values: []string{"storage"}},
+	}))
+	g.Expect(term.MatchFields).To(BeEmpty())
+
+	g.Expect(RequireNodes(nil, nil)).To(BeNil())
+	na := RequireNodes(map[string]string{"role": "storage"}, nil)
+	g.Expect(na.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(HaveLen(1))
+
+	na, err := PreferNodes(map[string]string{"role": "storage"}, 20)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(na.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight).To(BeEquivalentTo(20))
+	_, err = PreferNodes(map[string]string{"role": "storage"}, 0)
+	g.Expect(err).To(MatchError(ErrInvalidWeight))
+}
+
+func TestNodeSelectorTermFromLabelSelector(t *testing.T) {
+	g := NewWithT(t)
+
+	term, err := NodeSelectorTermFromLabelSelector(&metav1.LabelSelector{
+		MatchLabels: map[string]string{"role": "storage"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "zone", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"a"}},
+			{Key: "gpu", Operator: metav1.LabelSelectorOpDoesNotExist},
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(term.MatchExpressions).To(Equal([]corev1.NodeSelectorRequirement{
+		{Key: "role", Operator: corev1.NodeSelectorOpIn, Values: []string{"storage"}},
+		{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"a"}},
+		{Key: "gpu", Operator: corev1.NodeSelectorOpDoesNotExist, Values: []string{}},
+	}))
+
+	_, err = NodeSelectorTermFromLabelSelector(&metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "zone", Operator: "Foo"}},
+	})
+	g.Expect(err).To(MatchError(ErrInvalidSelectorOperator))
+
+	term, err = NodeSelectorTermFromLabelSelector(nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(term).To(BeNil())
+}
+
+func TestMergeNodeAffinity(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(MergeNodeAffinity(nil, nil)).To(BeNil())
+
+	defaults := RequireNodes(map[string]string{"role": "storage"}, nil)
+	g.Expect(MergeNodeAffinity(defaults, nil)).To(Equal(defaults))
+	g.Expect(MergeNodeAffinity(nil, defaults)).To(Equal(defaults))
+
+	override := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				NodeSelectorTerm(map[string]string{"zone": "a"}, nil),
+				NodeSelectorTerm(map[string]string{"zone": "b"}, nil),
+			},
+		},
+	}
+	preferred, err := PreferNodes(map[string]string{"disk": "ssd"}, 50)
+	g.Expect(err).ToNot(HaveOccurred())
+	override.PreferredDuringSchedulingIgnoredDuringExecution = preferred.PreferredDuringSchedulingIgnoredDuringExecution
+
+	merged := MergeNodeAffinity(defaults, override)
+	terms := merged.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	g.Expect(terms).To(HaveLen(2))
+	g.Expect(terms[0].MatchExpressions).To(HaveLen(2))
+	g.Expect(terms[0].MatchExpressions[0].Key).To(Equal("role"))
+	g.Expect(terms[0].MatchExpressions[1].Values).To(Equal([]string{"a"}))
+	g.Expect(terms[1].MatchExpressions[1].Values).To(Equal([]string{"b"}))
+	g.Expect(merged.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+
+	// defaults are not modified
+	g.Expect(defaults.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions).To(HaveLen(1))
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Define static errors
+var (
+	// ErrInvalidWeight indicates that a preferred term weight is not in the range 1-100
+	ErrInvalidWeight = errors.New("weight must be in the range 1-100")
+	// ErrInvalidOverrideAction indicates an unknown override action
+	ErrInvalidOverrideAction = errors.New("invalid override action")
+)
+
+const (
+	// MinWeight - minimum weight of a preferred scheduling term
+	MinWeight int32 = 1
+	// MaxWeight - maximum weight of a preferred scheduling term
+	MaxWeight int32 = 100
+)
+
+// OverrideAction - how an AntiAffinityOverride gets applied to the default
+// pod anti-affinity of a service
+type OverrideAction string
+
+const (
+	// OverrideAppend - the terms of the override get added to the default terms
+	OverrideAppend OverrideAction = "Append"
+	// OverrideReplace - the terms of the override replace the default terms
+	OverrideReplace OverrideAction = "Replace"
+	// OverrideRemove - the default pod anti-affinity gets removed
+	OverrideRemove OverrideAction = "Remove"
+)
+
+// DistributeOptions - options for DistributePodsWithOptions
+// +kubebuilder:object:generate:=false
+type DistributeOptions struct {
+	// Required - use a requiredDuringSchedulingIgnoredDuringExecution rule
+	// instead of a preferred one
+	Required bool
+	// Weight - weight of the preferred rule, defaults to MaxWeight if 0
+	Weight int32
+}
+
+// AntiAffinityOverride - user provided changes to the default pod
+// anti-affinity of a service
+type AntiAffinityOverride struct {
+	// Action - how to apply the override, defaults to OverrideAppend
+	Action OverrideAction `json:"action,omitempty"`
+	// Required - hard anti-affinity terms
+	Required []corev1.PodAffinityTerm `json:"required,omitempty"`
+	// Preferred - soft anti-affinity terms
+	Preferred []corev1.WeightedPodAffinityTerm `json:"preferred,omitempty"`
+}
+
+// DistributePodsWithOptions - returns a pod anti-affinity rule like
+// DistributePods, but allows to select a required rule and the weight of
+// the preferred rule
+func DistributePodsWithOptions(
+	selectorKey string,
+	selectorValues []string,
+	topologyKey string,
+	opts DistributeOptions,
+) (*corev1.Affinity, error) {
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      selectorKey,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   selectorValues,
+				},
+			},
+		},
+		TopologyKey: topologyKey,
+	}
+
+	if opts.Required {
+		return &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+			},
+		}, nil
+	}
+
+	weight := opts.Weight
+	if weight == 0 {
+		weight = MaxWeight
+	}
+	err := ValidateWeight(weight)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					PodAffinityTerm: term,
+					Weight:          weight,
+				},
+			},
+		},
+	}, nil
+}
+
+// ValidateWeight - returns an error if the weight is not in the range of
+// MinWeight to MaxWeight
+func ValidateWeight(weight int32) error {
+	if weight < MinWeight || weight > MaxWeight {
+		return fmt.Errorf("%w: %d", ErrInvalidWeight, weight)
+	}
+	return nil
+}
+
+// ApplyAntiAffinityOverride - returns a copy of the affinity with the
+// override applied to its pod anti-affinity. Other parts of the affinity,
+// like the node affinity, are kept. A nil override returns an unchanged copy.
+func ApplyAntiAffinityOverride(
+	affinity *corev1.Affinity,
+	override *AntiAffinityOverride,
+) (*corev1.Affinity, error) {
+	result := &corev1.Affinity{}
+	if affinity != nil {
+		result = affinity.DeepCopy()
+	}
+	if override == nil {
+		return result, nil
+	}
+
+	for _, t := range override.Preferred {
+		err := ValidateWeight(t.Weight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	override = override.DeepCopy()
+	switch override.Action {
+	case OverrideRemove:
+		result.PodAntiAffinity = nil
+	case OverrideReplace:
+		result.PodAntiAffinity = &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  override.Required,
+			PreferredDuringSchedulingIgnoredDuringExecution: override.Preferred,
+		}
+	case OverrideAppend, "":
+		if result.PodAntiAffinity == nil {
+			result.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
+		result.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			result.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, override.Required...)
+		result.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			result.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, override.Preferred...)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOverrideAction, override.Action)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDistributePodsWithOptions(t *testing.T) {
+	t.Run("Default options match DistributePods", func(t *testing.T) {
+		g := NewWithT(t)
+		d, err := DistributePodsWithOptions("ThisSelector", []string{"selectorValue1", "selectorValue2"}, "ThisTopologyKey", DistributeOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(d).To(BeEquivalentTo(affinityObj))
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		g := NewWithT(t)
+		d, err := DistributePodsWithOptions("ThisSelector", []string{"selectorValue1"}, corev1.LabelHostname, DistributeOptions{Required: true})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(d.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+		g.Expect(d.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+		g.Expect(d.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey).To(Equal(corev1.LabelHostname))
+	})
+
+	t.Run("Weight", func(t *testing.T) {
+		g := NewWithT(t)
+		d, err := DistributePodsWithOptions("ThisSelector", []string{"selectorValue1"}, corev1.LabelHostname, DistributeOptions{Weight: 50})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(d.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight).To(BeEquivalentTo(50))
+
+		_, err = DistributePodsWithOptions("ThisSelector", []string{"selectorValue1"}, corev1.LabelHostname, DistributeOptions{Weight: 101})
+		g.Expect(err).To(MatchError(ErrInvalidWeight))
+	})
+}
+
+func TestApplyAntiAffinityOverride(t *testing.T) {
+	extra := corev1.WeightedPodAffinityTerm{
+		Weight:          10,
+		PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: corev1.LabelTopologyZone},
+	}
+	required := corev1.PodAffinityTerm{TopologyKey: corev1.LabelHostname}
+
+	t.Run("Nil override", func(t *testing.T) {
+		g := NewWithT(t)
+		a, err := ApplyAntiAffinityOverride(affinityObj, nil)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(a).To(Equal(affinityObj))
+		g.Expect(a).ToNot(BeIdenticalTo(affinityObj))
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		g := NewWithT(t)
+		a, err := ApplyAntiAffinityOverride(affinityObj, &AntiAffinityOverride{
+			Required:  []corev1.PodAffinityTerm{required},
+			Preferred: []corev1.WeightedPodAffinityTerm{extra},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(2))
+		g.Expect(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(Equal([]corev1.PodAffinityTerm{required}))
+		// the default is not modified
+		g.Expect(affinityObj.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		g := NewWithT(t)
+		a, err := ApplyAntiAffinityOverride(affinityObj, &AntiAffinityOverride{
+			Action:   OverrideReplace,
+			Required: []corev1.PodAffinityTerm{required},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
+		g.Expect(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(Equal([]corev1.PodAffinityTerm{required}))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		g := NewWithT(t)
+		a, err := ApplyAntiAffinityOverride(affinityObj, &AntiAffinityOverride{Action: OverrideRemove})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(a.PodAntiAffinity).To(BeNil())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := ApplyAntiAffinityOverride(affinityObj, &AntiAffinityOverride{Action: "Merge"})
+		g.Expect(err).To(MatchError(ErrInvalidOverrideAction))
+
+		_, err = ApplyAntiAffinityOverride(affinityObj, &AntiAffinityOverride{
+			Preferred: []corev1.WeightedPodAffinityTerm{{Weight: 0}},
+		})
+		g.Expect(err).To(MatchError(ErrInvalidWeight))
+	})
+}
+
+func TestAntiAffinityOverrideDeepCopy(t *testing.T) {
+	g := NewWithT(t)
+
+	o := &AntiAffinityOverride{
+		Action:   OverrideReplace,
+		Required: []corev1.PodAffinityTerm{{TopologyKey: corev1.LabelHostname}},
+		Preferred: []corev1.WeightedPodAffinityTerm{
+			{Weight: 50, PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: corev1.LabelTopologyZone}},
+		},
+	}
+	var out AntiAffinityOverride
+	o.DeepCopyInto(&out)
+	g.Expect(out).To(Equal(*o))
+
+	out.Required[0].TopologyKey = "other"
+	out.Preferred[0].Weight = 1
+	g.Expect(o.Required[0].TopologyKey).To(Equal(corev1.LabelHostname))
+	g.Expect(o.Preferred[0].Weight).To(BeEquivalentTo(50))
+
+	var nilOverride *AntiAffinityOverride
+	g.Expect(nilOverride.DeepCopy()).To(BeNil())
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Policy - the affinity and topology spread constraints of the pods of a
+// service. Used for the defaults of a service, the settings of a referenced
+// Topology CR and, as returned by EffectivePolicy, to report the policy
+// applied in the status of a CR.
+type Policy struct {
+	// Affinity - affinity of the pods
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// TopologySpreadConstraints - topology spread constraints of the pods
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// PolicyOverride - per-service changes to the Policy from the defaults and
+// the Topology CR
+type PolicyOverride struct {
+	// AntiAffinity - changes to the pod anti-affinity
+	AntiAffinity *AntiAffinityOverride `json:"antiAffinity,omitempty"`
+	// TopologySpreadConstraints - changes to the topology spread
+	// constraints, applied in order
+	TopologySpreadConstraints []TopologySpreadOverride `json:"topologySpreadConstraints,omitempty"`
+}
+
+// EffectivePolicy - returns the policy of the pods of a service, which is
+// - the defaults of the service
+// - with the node affinity, pod affinity and pod anti-affinity of the
+// Topology CR, if set, replacing the ones of the defaults and its topology
+// spread constraints merged via MergeTopologySpreadConstraints
+// - with the override applied
+//
+// The result is validated with ValidatePolicy. topology and override may be
+// nil.
+//
+// Example:
+//
+//	policy, err := affinity.EffectivePolicy(defaults, topology, instance.Spec.SchedulingOverride)
+//	if err != nil {
+//		instance.Status.Conditions.MarkFalse(condition.TopologyReadyCondition, condition.ErrorReason,
+//			condition.SeverityWarning, condition.TopologyReadyErrorMessage, err.Error())
+//		return ctrl.Result{}, err
+//	}
+//	policy.Apply(&deployment.Spec.Template.Spec)
+//	instance.Status.SchedulingPolicy = policy
+func EffectivePolicy(defaults Policy, topology *Policy, override *PolicyOverride) (*Policy, error) {
+	p := defaults.DeepCopy()
+
+	if topology != nil {
+		if topology.Affinity != nil {
+			if p.Affinity == nil {
+				p.Affinity = &corev1.Affinity{}
+			}
+			if topology.Affinity.NodeAffinity != nil {
+				p.Affinity.NodeAffinity = topology.Affinity.NodeAffinity.DeepCopy()
+			}
+			if topology.Affinity.PodAffinity != nil {
+				p.Affinity.PodAffinity = topology.Affinity.PodAffinity.DeepCopy()
+			}
+			if topology.Affinity.PodAntiAffinity != nil {
+				p.Affinity.PodAntiAffinity = topology.Affinity.PodAntiAffinity.DeepCopy()
+			}
+		}
+		p.TopologySpreadConstraints = MergeTopologySpreadConstraints(
+			p.TopologySpreadConstraints, topology.TopologySpreadConstraints)
+	}
+
+	if override != nil {
+		var err error
+		if override.AntiAffinity != nil {
+			p.Affinity, err = ApplyAntiAffinityOverride(p.Affinity, override.AntiAffinity)
+			if err != nil {
+				return nil, err
+			}
+		}
+		p.TopologySpreadConstraints, err = ApplyTopologySpreadOverrides(
+			p.TopologySpreadConstraints, override.TopologySpreadConstraints)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := ValidatePolicy(p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ValidatePolicy - returns an error if the topology spread constraints are
+// invalid, see ValidateTopologySpreadConstraints, or if a required pod
+// affinity term is also a required pod anti-affinity term, which no pod
+// can satisfy
+func ValidatePolicy(p *Policy) error {
+	err := ValidateTopologySpreadConstraints(p.TopologySpreadConstraints)
+	if err != nil {
+		return err
+	}
+
+	if p.Affinity == nil || p.Affinity.PodAffinity == nil || p.Affinity.PodAntiAffinity == nil {
+		return nil
+	}
+	for _, a := range p.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		for _, aa := range p.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if equality.Semantic.DeepEqual(a, aa) {
+				return fmt.Errorf("%w: required pod affinity and anti-affinity for %s with the same selector",
+					ErrConflictingConstraints, a.TopologyKey)
+			}
+		}
+	}
+	return nil
+}
+
+// Apply - sets the affinity and topology spread constraints of the policy
+// on the pod spec, replacing the ones set before
+func (p *Policy) Apply(spec *corev1.PodSpec) {
+	p = p.DeepCopy()
+	spec.Affinity = p.Affinity
+	spec.TopologySpreadConstraints = p.TopologySpreadConstraints
+}
+
+// String - returns a short summary of the policy, e.g. for a condition
+// message or an event
+func (p *Policy) String() string {
+	parts := []string{}
+	if a := p.Affinity; a != nil {
+		if a.NodeAffinity != nil {
+			required := 0
+			if a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+				required = len(a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+			}
+			parts = append(parts, fmt.Sprintf("nodeAffinity %d required %d preferred",
+				required, len(a.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution)))
+		}
+		if a.PodAffinity != nil {
+			parts = append(parts, fmt.Sprintf("podAffinity %d required %d preferred",
+				len(a.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution),
+				len(a.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)))
+		}
+		if a.PodAntiAffinity != nil {
+			parts = append(parts, fmt.Sprintf("podAntiAffinity %d required %d preferred",
+				len(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution),
+				len(a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)))
+		}
+	}
+	for _, c := range p.TopologySpreadConstraints {
+		parts = append(parts, fmt.Sprintf("spread %s maxSkew %d %s", c.TopologyKey, c.MaxSkew, c.WhenUnsatisfiable))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEffectivePolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	defaults := Policy{
+		Affinity: DistributePods("service", []string{"nova"}, corev1.LabelHostname),
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			HostSpread("service", []string{"nova"}, 1, corev1.ScheduleAnyway),
+		},
+	}
+	topology := &Policy{
+		Affinity: &corev1.Affinity{NodeAffinity: RequireNodes(map[string]string{"zone": "a"}, nil)},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{TopologyKey: corev1.LabelTopologyZone, MaxSkew: 1, WhenUnsatisfiable: corev1.DoNotSchedule},
+		},
+	}
+	override := &PolicyOverride{
+		AntiAffinity: &AntiAffinityOverride{Action: OverrideRemove},
+		TopologySpreadConstraints: []TopologySpreadOverride{
+			{Constraint: corev1.TopologySpreadConstraint{TopologyKey: corev1.LabelHostname, MaxSkew: 3, WhenUnsatisfiable: corev1.ScheduleAnyway}},
+		},
+	}
+
+	p, err := EffectivePolicy(defaults, topology, override)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(p.Affinity.NodeAffinity).To(Equal(topology.Affinity.NodeAffinity))
+	g.Expect(p.Affinity.PodAntiAffinity).To(BeNil())
+	g.Expect(p.TopologySpreadConstraints).To(HaveLen(2))
+	g.Expect(p.String()).To(Equal("nodeAffinity 1 required 0 preferred, " +
+		"spread kubernetes.io/hostname maxSkew 3 ScheduleAnyway, " +
+		"spread topology.kubernetes.io/zone maxSkew 1 DoNotSchedule"))
+
+	// defaults and topology are not modified
+	g.Expect(defaults.Affinity.PodAntiAffinity).ToNot(BeNil())
+	g.Expect(defaults.TopologySpreadConstraints[0].MaxSkew).To(BeEquivalentTo(1))
+
+	spec := &corev1.PodSpec{}
+	p.Apply(spec)
+	g.Expect(spec.Affinity).To(Equal(p.Affinity))
+	g.Expect(spec.TopologySpreadConstraints).To(Equal(p.TopologySpreadConstraints))
+
+	p, err = EffectivePolicy(defaults, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(p).To(Equal(&defaults))
+
+	g.Expect((&Policy{}).String()).To(Equal("none"))
+}
+
+func TestValidatePolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	term := DistributePods("service", []string{"nova"}, corev1.LabelHostname).
+		PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm
+	p := &Policy{
+		Affinity: &corev1.Affinity{
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+			},
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+			},
+		},
+	}
+	g.Expect(ValidatePolicy(p)).To(MatchError(ErrConflictingConstraints))
+
+	p.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey = corev1.LabelTopologyZone
+	g.Expect(ValidatePolicy(p)).To(Succeed())
+}
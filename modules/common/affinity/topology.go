@@ -0,0 +1,202 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Define static errors
+var (
+	// ErrInvalidTopologySpreadConstraint indicates an invalid maxSkew,
+	// minDomains or whenUnsatisfiable of a topology spread constraint
+	ErrInvalidTopologySpreadConstraint = errors.New("invalid topology spread constraint")
+	// ErrConflictingConstraints indicates scheduling constraints which can
+	// not be satisfied together
+	ErrConflictingConstraints = errors.New("conflicting constraints")
+)
+
+// TopologySpreadOverride - a user provided topology spread constraint, e.g.
+// from a Topology CR or the CR of a service, and how it gets merged into the
+// default constraints
+type TopologySpreadOverride struct {
+	// Action - OverrideReplace (default) replaces the default constraint
+	// with the same topologyKey, OverrideAppend adds the constraint in any
+	// case and OverrideRemove removes the default constraint with the same
+	// topologyKey
+	Action OverrideAction `json:"action,omitempty"`
+	// Constraint - the topology spread constraint
+	Constraint corev1.TopologySpreadConstraint `json:"constraint"`
+}
+
+// DistributePodsTopologySpread - returns a topology spread constraint which
+// spreads the pods of the same selector across the topology domains of the
+// topologyKey, with at most maxSkew pods difference between the domains.
+func DistributePodsTopologySpread(
+	selectorKey string,
+	selectorValues []string,
+	topologyKey string,
+	maxSkew int32,
+	whenUnsatisfiable corev1.UnsatisfiableConstraintAction,
+) corev1.TopologySpreadConstraint {
+	return corev1.TopologySpreadConstraint{
+		MaxSkew:           maxSkew,
+		TopologyKey:       topologyKey,
+		WhenUnsatisfiable: whenUnsatisfiable,
+		LabelSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      selectorKey,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   selectorValues,
+				},
+			},
+		},
+	}
+}
+
+// ZoneSpread - returns a topology spread constraint to spread the pods of
+// the same selector across zones (corev1.LabelTopologyZone)
+func ZoneSpread(
+	selectorKey string,
+	selectorValues []string,
+	maxSkew int32,
+	whenUnsatisfiable corev1.UnsatisfiableConstraintAction,
+) corev1.TopologySpreadConstraint {
+	return DistributePodsTopologySpread(selectorKey, selectorValues, corev1.LabelTopologyZone, maxSkew, whenUnsatisfiable)
+}
+
+// HostSpread - returns a topology spread constraint to spread the pods of
+// the same selector across worker nodes (corev1.LabelHostname)
+func HostSpread(
+	selectorKey string,
+	selectorValues []string,
+	maxSkew int32,
+	whenUnsatisfiable corev1.UnsatisfiableConstraintAction,
+) corev1.TopologySpreadConstraint {
+	return DistributePodsTopologySpread(selectorKey, selectorValues, corev1.LabelHostname, maxSkew, whenUnsatisfiable)
+}
+
+// MergeTopologySpreadConstraints - merges the user provided overrides, e.g.
+// from a Topology CR, into the default constraints of a service. An override
+// replaces the default constraint with the same topologyKey, overrides for
+// other topology keys get appended. Overrides without a LabelSelector inherit
+// the LabelSelector of the default constraint they replace, as users usually
+// don't know the labels of the service pods.
+func MergeTopologySpreadConstraints(
+	defaults []corev1.TopologySpreadConstraint,
+	overrides []corev1.TopologySpreadConstraint,
+) []corev1.TopologySpreadConstraint {
+	merged := []corev1.TopologySpreadConstraint{}
+	for _, d := range defaults {
+		merged = append(merged, *d.DeepCopy())
+	}
+
+	for _, o := range overrides {
+		override := o.DeepCopy()
+		replaced := false
+		for i := range merged {
+			if merged[i].TopologyKey != override.TopologyKey {
+				continue
+			}
+			if override.LabelSelector == nil {
+				override.LabelSelector = merged[i].LabelSelector
+			}
+			merged[i] = *override
+			replaced = true
+			break
+		}
+		if !replaced {
+			merged = append(merged, *override)
+		}
+	}
+
+	return merged
+}
+
+// ApplyTopologySpreadOverrides - returns the default constraints with the
+// overrides applied in order, see TopologySpreadOverride for the actions. As
+// for MergeTopologySpreadConstraints, a replacing override without a
+// LabelSelector inherits the one of the default constraint. The result is
+// validated with ValidateTopologySpreadConstraints.
+func ApplyTopologySpreadOverrides(
+	defaults []corev1.TopologySpreadConstraint,
+	overrides []TopologySpreadOverride,
+) ([]corev1.TopologySpreadConstraint, error) {
+	merged := MergeTopologySpreadConstraints(defaults, nil)
+
+	for _, o := range overrides {
+		switch o.Action {
+		case OverrideReplace, "":
+			merged = MergeTopologySpreadConstraints(merged, []corev1.TopologySpreadConstraint{o.Constraint})
+		case OverrideAppend:
+			merged = append(merged, *o.Constraint.DeepCopy())
+		case OverrideRemove:
+			kept := []corev1.TopologySpreadConstraint{}
+			for _, c := range merged {
+				if c.TopologyKey != o.Constraint.TopologyKey {
+					kept = append(kept, c)
+				}
+			}
+			merged = kept
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidOverrideAction, o.Action)
+		}
+	}
+
+	err := ValidateTopologySpreadConstraints(merged)
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// ValidateTopologySpreadConstraints - returns an error if a constraint has
+// an invalid maxSkew, minDomains or whenUnsatisfiable, or if two constraints
+// for the same topologyKey and LabelSelector spread the pods differently
+func ValidateTopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint) error {
+	for i, c := range constraints {
+		if c.MaxSkew < 1 {
+			return fmt.Errorf("%w: %s maxSkew %d must be greater than 0",
+				ErrInvalidTopologySpreadConstraint, c.TopologyKey, c.MaxSkew)
+		}
+		switch c.WhenUnsatisfiable {
+		case corev1.DoNotSchedule, corev1.ScheduleAnyway:
+		default:
+			return fmt.Errorf("%w: %s whenUnsatisfiable %q",
+				ErrInvalidTopologySpreadConstraint, c.TopologyKey, c.WhenUnsatisfiable)
+		}
+		if c.MinDomains != nil && (*c.MinDomains < 1 || c.WhenUnsatisfiable != corev1.DoNotSchedule) {
+			return fmt.Errorf("%w: %s minDomains requires a value greater than 0 and whenUnsatisfiable %s",
+				ErrInvalidTopologySpreadConstraint, c.TopologyKey, corev1.DoNotSchedule)
+		}
+
+		for _, other := range constraints[i+1:] {
+			if other.TopologyKey == c.TopologyKey &&
+				equality.Semantic.DeepEqual(other.LabelSelector, c.LabelSelector) {
+				return fmt.Errorf("%w: multiple topology spread constraints for %s with the same selector",
+					ErrConflictingConstraints, c.TopologyKey)
+			}
+		}
+	}
+	return nil
+}
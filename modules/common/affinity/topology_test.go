@@ -0,0 +1,141 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTopologySpread(t *testing.T) {
+	t.Run("Zone and host presets", func(t *testing.T) {
+		g := NewWithT(t)
+
+		z := ZoneSpread("service", []string{"nova"}, 1, corev1.DoNotSchedule)
+		g.Expect(z.TopologyKey).To(Equal(corev1.LabelTopologyZone))
+		g.Expect(z.MaxSkew).To(BeEquivalentTo(1))
+		g.Expect(z.WhenUnsatisfiable).To(Equal(corev1.DoNotSchedule))
+		g.Expect(z.LabelSelector.MatchExpressions[0].Values).To(Equal([]string{"nova"}))
+
+		h := HostSpread("service", []string{"nova"}, 2, corev1.ScheduleAnyway)
+		g.Expect(h.TopologyKey).To(Equal(corev1.LabelHostname))
+		g.Expect(h.MaxSkew).To(BeEquivalentTo(2))
+	})
+
+	t.Run("Merge overrides", func(t *testing.T) {
+		g := NewWithT(t)
+
+		defaults := []corev1.TopologySpreadConstraint{
+			ZoneSpread("service", []string{"nova"}, 1, corev1.ScheduleAnyway),
+			HostSpread("service", []string{"nova"}, 1, corev1.ScheduleAnyway),
+		}
+		overrides := []corev1.TopologySpreadConstraint{
+			{
+				TopologyKey:       corev1.LabelTopologyZone,
+				MaxSkew:           2,
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+			},
+			{
+				TopologyKey:       "rack",
+				MaxSkew:           1,
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+				LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+			},
+		}
+
+		merged := MergeTopologySpreadConstraints(defaults, overrides)
+		g.Expect(merged).To(HaveLen(3))
+		g.Expect(merged[0].TopologyKey).To(Equal(corev1.LabelTopologyZone))
+		g.Expect(merged[0].MaxSkew).To(BeEquivalentTo(2))
+		g.Expect(merged[0].WhenUnsatisfiable).To(Equal(corev1.DoNotSchedule))
+		// selector inherited from the default
+		g.Expect(merged[0].LabelSelector).To(Equal(defaults[0].LabelSelector))
+		g.Expect(merged[1]).To(Equal(defaults[1]))
+		g.Expect(merged[2].LabelSelector.MatchLabels).To(HaveKeyWithValue("foo", "bar"))
+
+		// defaults are not modified
+		g.Expect(defaults[0].MaxSkew).To(BeEquivalentTo(1))
+
+		g.Expect(MergeTopologySpreadConstraints(defaults, nil)).To(Equal(defaults))
+	})
+
+	t.Run("Apply overrides", func(t *testing.T) {
+		g := NewWithT(t)
+
+		defaults := []corev1.TopologySpreadConstraint{
+			ZoneSpread("service", []string{"nova"}, 1, corev1.ScheduleAnyway),
+			HostSpread("service", []string{"nova"}, 1, corev1.ScheduleAnyway),
+		}
+		rack := HostSpread("service", []string{"nova"}, 1, corev1.DoNotSchedule)
+		rack.LabelSelector.MatchExpressions[0].Values = []string{"nova", "placement"}
+
+		merged, err := ApplyTopologySpreadOverrides(defaults, []TopologySpreadOverride{
+			{Constraint: corev1.TopologySpreadConstraint{TopologyKey: corev1.LabelTopologyZone, MaxSkew: 2, WhenUnsatisfiable: corev1.DoNotSchedule}},
+			{Action: OverrideAppend, Constraint: rack},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(merged).To(HaveLen(3))
+		g.Expect(merged[0].MaxSkew).To(BeEquivalentTo(2))
+		g.Expect(merged[0].LabelSelector).To(Equal(defaults[0].LabelSelector))
+		g.Expect(merged[1]).To(Equal(defaults[1]))
+		g.Expect(merged[2]).To(Equal(rack))
+
+		merged, err = ApplyTopologySpreadOverrides(defaults, []TopologySpreadOverride{
+			{Action: OverrideRemove, Constraint: corev1.TopologySpreadConstraint{TopologyKey: corev1.LabelHostname}},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(merged).To(Equal(defaults[:1]))
+
+		// appending a second constraint for the same key and selector
+		_, err = ApplyTopologySpreadOverrides(defaults, []TopologySpreadOverride{
+			{Action: OverrideAppend, Constraint: HostSpread("service", []string{"nova"}, 2, corev1.DoNotSchedule)},
+		})
+		g.Expect(err).To(MatchError(ErrConflictingConstraints))
+
+		_, err = ApplyTopologySpreadOverrides(defaults, []TopologySpreadOverride{
+			{Constraint: corev1.TopologySpreadConstraint{TopologyKey: corev1.LabelHostname, WhenUnsatisfiable: corev1.DoNotSchedule}},
+		})
+		g.Expect(err).To(MatchError(ErrInvalidTopologySpreadConstraint))
+
+		_, err = ApplyTopologySpreadOverrides(defaults, []TopologySpreadOverride{{Action: "Foo"}})
+		g.Expect(err).To(MatchError(ErrInvalidOverrideAction))
+	})
+}
+
+func TestTopologySpreadOverrideDeepCopy(t *testing.T) {
+	g := NewWithT(t)
+
+	o := &TopologySpreadOverride{
+		Action:     OverrideAppend,
+		Constraint: ZoneSpread("service", []string{"nova"}, 1, corev1.DoNotSchedule),
+	}
+	out := o.DeepCopy()
+	g.Expect(out).To(Equal(o))
+
+	out.Constraint.LabelSelector.MatchExpressions[0].Values[0] = "glance"
+	g.Expect(o.Constraint.LabelSelector.MatchExpressions[0].Values).To(Equal([]string{"nova"}))
+
+	// as part of a PolicyOverride
+	p := &PolicyOverride{TopologySpreadConstraints: []TopologySpreadOverride{*o}}
+	copied := p.DeepCopy()
+	copied.TopologySpreadConstraints[0].Constraint.MaxSkew = 2
+	g.Expect(p.TopologySpreadConstraints[0].Constraint.MaxSkew).To(BeEquivalentTo(1))
+}
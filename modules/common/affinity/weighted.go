@@ -0,0 +1,144 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrInvalidTerm indicates a preferred term without topology key or label selector
+var ErrInvalidTerm = errors.New("invalid preferred term")
+
+// PreferredTerm - a weighted preference to schedule pods in the same
+// topology domain as (affinity), or not in the same topology domain as
+// (anti-affinity), the pods matching the LabelSelector
+// +kubebuilder:object:generate:=false
+type PreferredTerm struct {
+	// Weight - weight of the term in the range 1-100
+	Weight int32
+	// Anti - true for an anti-affinity term
+	Anti bool
+	// LabelSelector - selects the pods the term relates to
+	LabelSelector *metav1.LabelSelector
+	// TopologyKey - node label defining the topology domain
+	TopologyKey string
+}
+
+// PreferSameTopology - returns a term preferring nodes in the same topology
+// domain as the pods with the label selectorKey in selectorValues, e.g.
+// prefer the same zone as the database
+func PreferSameTopology(
+	selectorKey string,
+	selectorValues []string,
+	topologyKey string,
+	weight int32,
+) PreferredTerm {
+	return PreferredTerm{
+		Weight:        weight,
+		LabelSelector: selectorIn(selectorKey, selectorValues),
+		TopologyKey:   topologyKey,
+	}
+}
+
+// AvoidSameTopology - returns a term preferring nodes not in the same
+// topology domain as the pods with the label selectorKey in selectorValues,
+// e.g. avoid the same host as the other replicas
+func AvoidSameTopology(
+	selectorKey string,
+	selectorValues []string,
+	topologyKey string,
+	weight int32,
+) PreferredTerm {
+	return PreferredTerm{
+		Weight:        weight,
+		Anti:          true,
+		LabelSelector: selectorIn(selectorKey, selectorValues),
+		TopologyKey:   topologyKey,
+	}
+}
+
+// ComposePreferred - returns an affinity holding all the terms as preferred
+// pod affinity or anti-affinity. The terms are ordered by descending weight,
+// then by topology key and label selector, so the result does not depend on
+// the order of the input and does not trigger pod restarts. An error is
+// returned if a weight is out of range or a term is incomplete.
+func ComposePreferred(terms ...PreferredTerm) (*corev1.Affinity, error) {
+	sorted := append([]PreferredTerm{}, terms...)
+	for _, t := range sorted {
+		err := ValidateWeight(t.Weight)
+		if err != nil {
+			return nil, err
+		}
+		if t.TopologyKey == "" || t.LabelSelector == nil {
+			return nil, fmt.Errorf("%w: topology key and label selector are required", ErrInvalidTerm)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Weight != sorted[j].Weight {
+			return sorted[i].Weight > sorted[j].Weight
+		}
+		if sorted[i].TopologyKey != sorted[j].TopologyKey {
+			return sorted[i].TopologyKey < sorted[j].TopologyKey
+		}
+		return metav1.FormatLabelSelector(sorted[i].LabelSelector) < metav1.FormatLabelSelector(sorted[j].LabelSelector)
+	})
+
+	affinity := &corev1.Affinity{}
+	for _, t := range sorted {
+		term := corev1.WeightedPodAffinityTerm{
+			Weight: t.Weight,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: t.LabelSelector.DeepCopy(),
+				TopologyKey:   t.TopologyKey,
+			},
+		}
+		if t.Anti {
+			if affinity.PodAntiAffinity == nil {
+				affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+			}
+			affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+				affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, term)
+			continue
+		}
+		if affinity.PodAffinity == nil {
+			affinity.PodAffinity = &corev1.PodAffinity{}
+		}
+		affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution, term)
+	}
+
+	return affinity, nil
+}
+
+// selectorIn - returns a label selector for selectorKey in selectorValues
+func selectorIn(selectorKey string, selectorValues []string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      selectorKey,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   selectorValues,
+			},
+		},
+	}
+}
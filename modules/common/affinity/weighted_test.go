@@ -0,0 +1,63 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package affinity
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestComposePreferred(t *testing.T) {
+	t.Run("Deterministic ordering", func(t *testing.T) {
+		g := NewWithT(t)
+
+		db := PreferSameTopology("service", []string{"galera"}, corev1.LabelTopologyZone, 50)
+		replicas := AvoidSameTopology("service", []string{"nova"}, corev1.LabelHostname, 100)
+		cache := PreferSameTopology("service", []string{"memcached"}, corev1.LabelTopologyZone, 50)
+
+		a, err := ComposePreferred(db, replicas, cache)
+		g.Expect(err).ToNot(HaveOccurred())
+		b, err := ComposePreferred(cache, replicas, db)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(a).To(Equal(b))
+
+		g.Expect(a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+		g.Expect(a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight).To(BeEquivalentTo(100))
+		terms := a.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+		g.Expect(terms).To(HaveLen(2))
+		g.Expect(terms[0].PodAffinityTerm.LabelSelector.MatchExpressions[0].Values).To(Equal([]string{"galera"}))
+		g.Expect(terms[1].PodAffinityTerm.LabelSelector.MatchExpressions[0].Values).To(Equal([]string{"memcached"}))
+	})
+
+	t.Run("Only anti-affinity", func(t *testing.T) {
+		g := NewWithT(t)
+		a, err := ComposePreferred(AvoidSameTopology("service", []string{"nova"}, corev1.LabelHostname, 100))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(a.PodAffinity).To(BeNil())
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := ComposePreferred(PreferSameTopology("service", []string{"galera"}, corev1.LabelTopologyZone, 0))
+		g.Expect(err).To(MatchError(ErrInvalidWeight))
+		_, err = ComposePreferred(PreferSameTopology("service", []string{"galera"}, "", 10))
+		g.Expect(err).To(MatchError(ErrInvalidTerm))
+	})
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backup provides the labels, hooks and fixups which make the
+// objects managed by the operators restorable with OADP/Velero
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// BackupLabel - label selecting the objects to include in a backup,
+	// e.g. via the labelSelector of the Velero Backup
+	BackupLabel = "openstack.org/backup"
+
+	// ExcludeLabel - label Velero skips the objects with in a backup, e.g.
+	// for objects the operators re-create from the CRs
+	ExcludeLabel = "velero.io/exclude-from-backup"
+
+	// QuiescedReplicasAnnotation - annotation holding the replicas of a
+	// workload scaled down by Quiesce, restored by Resume
+	QuiescedReplicasAnnotation = "openstack.org/quiesced-replicas"
+
+	// PreBackupHookContainerAnnotation - container of a pod the Velero pre
+	// backup hook runs in
+	PreBackupHookContainerAnnotation = "pre.hook.backup.velero.io/container"
+	// PreBackupHookCommandAnnotation - command of the Velero pre backup
+	// hook, e.g. to flush the data of a database to disk
+	PreBackupHookCommandAnnotation = "pre.hook.backup.velero.io/command"
+	// PostBackupHookContainerAnnotation - container of a pod the Velero
+	// post backup hook runs in
+	PostBackupHookContainerAnnotation = "post.hook.backup.velero.io/container"
+	// PostBackupHookCommandAnnotation - command of the Velero post backup
+	// hook, e.g. to unlock the tables of a database
+	PostBackupHookCommandAnnotation = "post.hook.backup.velero.io/command"
+)
+
+// ErrUnsupportedKind - the workload kind can not be quiesced
+var ErrUnsupportedKind = errors.New("unsupported kind")
+
+// SetIncluded - labels obj to be included in backups, or to be excluded
+// from them if include is false
+func SetIncluded(obj metav1.Object, include bool) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if include {
+		labels[BackupLabel] = "true"
+		delete(labels, ExcludeLabel)
+	} else {
+		labels[ExcludeLabel] = "true"
+		delete(labels, BackupLabel)
+	}
+	obj.SetLabels(labels)
+}
+
+// SetBackupHooks - annotates the pod template with the Velero hooks run in
+// container before and after the backup of the pods. An empty command
+// skips the hook.
+func SetBackupHooks(template *corev1.PodTemplateSpec, container string, pre []string, post []string) error {
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	for _, hook := range []struct {
+		containerAnnotation string
+		commandAnnotation   string
+		command             []string
+	}{
+		{PreBackupHookContainerAnnotation, PreBackupHookCommandAnnotation, pre},
+		{PostBackupHookContainerAnnotation, PostBackupHookCommandAnnotation, post},
+	} {
+		if len(hook.command) == 0 {
+			delete(template.Annotations, hook.containerAnnotation)
+			delete(template.Annotations, hook.commandAnnotation)
+			continue
+		}
+		command, err := json.Marshal(hook.command)
+		if err != nil {
+			return err
+		}
+		template.Annotations[hook.containerAnnotation] = container
+		template.Annotations[hook.commandAnnotation] = string(command)
+	}
+	return nil
+}
+
+// replicasOf - returns the replicas field of the Deployment or StatefulSet
+func replicasOf(obj client.Object) (**int32, error) {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return &o.Spec.Replicas, nil
+	case *appsv1.StatefulSet:
+		return &o.Spec.Replicas, nil
+	default:
+		return nil, fmt.Errorf("%w: %T can not be quiesced", ErrUnsupportedKind, obj)
+	}
+}
+
+// Quiesce - scales the Deployment or StatefulSet obj down to zero replicas
+// before a backup, e.g. to get a consistent copy of its volumes. The
+// replicas are recorded in the QuiescedReplicasAnnotation for Resume.
+// Operators must not scale the workload up while it is quiesced, see
+// IsQuiesced. Returns true once all pods are gone.
+func Quiesce(ctx context.Context, h *helper.Helper, obj client.Object) (bool, error) {
+	replicas, err := replicasOf(obj)
+	if err != nil {
+		return false, err
+	}
+
+	if !IsQuiesced(obj) {
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[QuiescedReplicasAnnotation] = strconv.Itoa(int(ptr.Deref(*replicas, 1)))
+		obj.SetAnnotations(annotations)
+		*replicas = ptr.To[int32](0)
+
+		err = h.GetClient().Patch(ctx, obj, patch)
+		if err != nil {
+			return false, err
+		}
+		h.GetLogger().Info("Workload quiesced", "object", obj.GetName())
+	}
+
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return o.Status.Replicas == 0, nil
+	case *appsv1.StatefulSet:
+		return o.Status.Replicas == 0, nil
+	}
+	return false, nil
+}
+
+// IsQuiesced - returns true if obj got scaled down by Quiesce
+func IsQuiesced(obj metav1.Object) bool {
+	_, ok := obj.GetAnnotations()[QuiescedReplicasAnnotation]
+	return ok
+}
+
+// Resume - scales the Deployment or StatefulSet obj quiesced by Quiesce
+// back to its replicas after the backup
+func Resume(ctx context.Context, h *helper.Helper, obj client.Object) error {
+	replicas, err := replicasOf(obj)
+	if err != nil {
+		return err
+	}
+	if !IsQuiesced(obj) {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	previous, err := strconv.ParseInt(annotations[QuiescedReplicasAnnotation], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation on %s: %w", QuiescedReplicasAnnotation, obj.GetName(), err)
+	}
+	delete(annotations, QuiescedReplicasAnnotation)
+	obj.SetAnnotations(annotations)
+	*replicas = ptr.To(int32(previous))
+
+	err = h.GetClient().Patch(ctx, obj, patch)
+	if err != nil {
+		return err
+	}
+	h.GetLogger().Info("Workload resumed", "object", obj.GetName(), "replicas", previous)
+
+	return nil
+}
+
+// FixupOwnerReferences - re-adopts the objects of list, in the namespace of
+// owner and matching the labels, after a restore. Restored objects keep the
+// owner references to the UID the owner had at backup time, which the
+// garbage collector treats as gone. The references to an owner of the same
+// kind and name get updated to the UID of owner. Returns the number of
+// objects updated.
+//
+// Example:
+//
+//	n, err := backup.FixupOwnerReferences(ctx, h, instance, &corev1.SecretList{}, map[string]string{"service": "keystone"})
+func FixupOwnerReferences(
+	ctx context.Context,
+	h *helper.Helper,
+	owner client.Object,
+	list client.ObjectList,
+	labels map[string]string,
+) (int, error) {
+	gvk, err := apiutil.GVKForObject(owner, h.GetScheme())
+	if err != nil {
+		return 0, err
+	}
+
+	err = h.GetClient().List(ctx, list, client.InNamespace(owner.GetNamespace()), client.MatchingLabels(labels))
+	if err != nil {
+		return 0, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		refs := obj.GetOwnerReferences()
+		changed := false
+		for i := range refs {
+			if refs[i].Kind == gvk.Kind && refs[i].Name == owner.GetName() &&
+				refs[i].APIVersion == gvk.GroupVersion().String() && refs[i].UID != owner.GetUID() {
+				refs[i].UID = owner.GetUID()
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		obj.SetOwnerReferences(refs)
+
+		err = h.GetClient().Patch(ctx, obj, patch)
+		if err != nil {
+			return updated, err
+		}
+		h.GetLogger().Info("Owner reference re-adopted", "object", obj.GetName())
+		updated++
+	}
+
+	return updated, nil
+}
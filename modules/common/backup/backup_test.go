@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func setupHelper(owner client.Object, objs ...runtime.Object) (*helper.Helper, client.Client, error) {
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithRuntimeObjects(objs...).
+		Build()
+
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	return h, fakeClient, err
+}
+
+func TestSetIncluded(t *testing.T) {
+	g := NewWithT(t)
+
+	secret := &corev1.Secret{}
+	SetIncluded(secret, true)
+	g.Expect(secret.Labels).To(Equal(map[string]string{BackupLabel: "true"}))
+
+	SetIncluded(secret, false)
+	g.Expect(secret.Labels).To(Equal(map[string]string{ExcludeLabel: "true"}))
+}
+
+func TestSetBackupHooks(t *testing.T) {
+	g := NewWithT(t)
+
+	template := &corev1.PodTemplateSpec{}
+	err := SetBackupHooks(template, "galera", []string{"/bin/sh", "-c", "flush"}, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(template.Annotations).To(Equal(map[string]string{
+		PreBackupHookContainerAnnotation: "galera",
+		PreBackupHookCommandAnnotation:   `["/bin/sh","-c","flush"]`,
+	}))
+
+	err = SetBackupHooks(template, "galera", nil, []string{"unlock"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(template.Annotations).To(Equal(map[string]string{
+		PostBackupHookContainerAnnotation: "galera",
+		PostBackupHookCommandAnnotation:   `["unlock"]`,
+	}))
+}
+
+func TestQuiesceResume(t *testing.T) {
+	g := NewWithT(t)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](3)},
+		Status:     appsv1.DeploymentStatus{Replicas: 3},
+	}
+	h, c, err := setupHelper(deployment, deployment)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	done, err := Quiesce(context.TODO(), h, deployment)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+
+	current := &appsv1.Deployment{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(deployment), current)).To(Succeed())
+	g.Expect(*current.Spec.Replicas).To(Equal(int32(0)))
+	g.Expect(current.Annotations[QuiescedReplicasAnnotation]).To(Equal("3"))
+	g.Expect(IsQuiesced(current)).To(BeTrue())
+
+	// a second call keeps the recorded replicas
+	_, err = Quiesce(context.TODO(), h, current)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(current.Annotations[QuiescedReplicasAnnotation]).To(Equal("3"))
+
+	g.Expect(Resume(context.TODO(), h, current)).To(Succeed())
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(deployment), current)).To(Succeed())
+	g.Expect(*current.Spec.Replicas).To(Equal(int32(3)))
+	g.Expect(IsQuiesced(current)).To(BeFalse())
+
+	_, err = Quiesce(context.TODO(), h, &corev1.Pod{})
+	g.Expect(err).To(MatchError(ErrUnsupportedKind))
+}
+
+func TestFixupOwnerReferences(t *testing.T) {
+	g := NewWithT(t)
+
+	owner := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", UID: "new-uid"},
+	}
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openstack",
+				Labels:    map[string]string{"service": "keystone"},
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "v1", Kind: "ConfigMap", Name: "keystone", UID: "old-uid"},
+					{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"},
+				},
+			},
+		}
+	}
+	stale := secret("stale")
+	current := secret("current")
+	current.OwnerReferences[0].UID = "new-uid"
+
+	h, c, err := setupHelper(owner, owner, stale, current)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	updated, err := FixupOwnerReferences(context.TODO(), h, owner, &corev1.SecretList{}, map[string]string{"service": "keystone"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(updated).To(Equal(1))
+
+	s := &corev1.Secret{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(stale), s)).To(Succeed())
+	g.Expect(s.OwnerReferences[0].UID).To(BeEquivalentTo("new-uid"))
+	g.Expect(s.OwnerReferences[1].UID).To(BeEquivalentTo("other-uid"))
+}
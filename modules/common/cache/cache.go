@@ -0,0 +1,231 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cache provides the oslo.cache and keystonemiddleware config of
+// the OpenStack services for the memcached and redis caches they use
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Define static errors
+var (
+	// ErrNoServers indicates a cache CR which does not publish its servers yet
+	ErrNoServers = errors.New("cache has no servers")
+)
+
+// MemcachedGVK - kind of the Memcached CR of the infra-operator
+var MemcachedGVK = schema.GroupVersionKind{
+	Group:   "memcached.openstack.org",
+	Version: "v1beta1",
+	Kind:    "Memcached",
+}
+
+const (
+	// MemcachedServersEnv - env var with the comma separated memcached servers
+	MemcachedServersEnv = "MEMCACHED_SERVERS"
+	// MemcachedTLSEnv - env var set to true if memcached uses TLS
+	MemcachedTLSEnv = "MEMCACHED_TLS"
+)
+
+// Memcached - the client config of a memcached cache
+type Memcached struct {
+	// Servers - host:port of the memcached pods
+	Servers []string
+	// ServersWithInet - the servers in the inet:/inet6: form of oslo.cache
+	ServersWithInet []string
+	// TLS - the servers use TLS
+	TLS bool
+	// CAFile - CA to verify the servers against, tls.DownstreamTLSCABundlePath if empty
+	CAFile string
+	// SASLUsername - username for SASL authentication, SASL is disabled if empty
+	SASLUsername string
+	// SASLPassword - password for SASL authentication
+	SASLPassword string
+}
+
+// GetMemcached - returns the client config of the Memcached CR name in
+// namespace from its status. ErrNoServers is returned if the CR does not
+// publish its servers yet, e.g. to wait for it to get ready.
+func GetMemcached(ctx context.Context, h *helper.Helper, name string, namespace string) (*Memcached, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(MemcachedGVK)
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	servers, _, err := unstructured.NestedStringSlice(obj.Object, "status", "serverList")
+	if err != nil {
+		return nil, fmt.Errorf("invalid serverList of memcached %s: %w", name, err)
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("%w: memcached %s", ErrNoServers, name)
+	}
+	serversWithInet, _, err := unstructured.NestedStringSlice(obj.Object, "status", "serverListWithInet")
+	if err != nil {
+		return nil, fmt.Errorf("invalid serverListWithInet of memcached %s: %w", name, err)
+	}
+	tls, _, err := unstructured.NestedBool(obj.Object, "status", "tlsSupport")
+	if err != nil {
+		return nil, fmt.Errorf("invalid tlsSupport of memcached %s: %w", name, err)
+	}
+
+	return &Memcached{
+		Servers:         servers,
+		ServersWithInet: serversWithInet,
+		TLS:             tls,
+	}, nil
+}
+
+func (m Memcached) caFile() string {
+	if m.CAFile != "" {
+		return m.CAFile
+	}
+	return tls.DownstreamTLSCABundlePath
+}
+
+func (m Memcached) inetServers() string {
+	if len(m.ServersWithInet) > 0 {
+		return strings.Join(m.ServersWithInet, ",")
+	}
+	return strings.Join(m.Servers, ",")
+}
+
+// OsloCacheConfig - returns the [cache] section of oslo.cache for the
+// memcached servers
+func (m Memcached) OsloCacheConfig() string {
+	b := strings.Builder{}
+	b.WriteString("[cache]\n")
+	b.WriteString("enabled = true\n")
+	b.WriteString("backend = dogpile.cache.pymemcache\n")
+	fmt.Fprintf(&b, "memcache_servers = %s\n", m.inetServers())
+	fmt.Fprintf(&b, "tls_enabled = %s\n", strconv.FormatBool(m.TLS))
+	if m.TLS {
+		fmt.Fprintf(&b, "tls_cafile = %s\n", m.caFile())
+	}
+	if m.SASLUsername != "" {
+		b.WriteString("memcache_sasl_enabled = true\n")
+		fmt.Fprintf(&b, "memcache_username = %s\n", m.SASLUsername)
+		fmt.Fprintf(&b, "memcache_password = %s\n", m.SASLPassword)
+	}
+	return b.String()
+}
+
+// AuthtokenConfig - returns the memcached options of the
+// [keystone_authtoken] section of keystonemiddleware. They get appended to
+// the section rendered by the service templates.
+func (m Memcached) AuthtokenConfig() string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "memcached_servers = %s\n", m.inetServers())
+	fmt.Fprintf(&b, "memcache_tls_enabled = %s\n", strconv.FormatBool(m.TLS))
+	if m.TLS {
+		fmt.Fprintf(&b, "memcache_tls_cafile = %s\n", m.caFile())
+	}
+	if m.SASLUsername != "" {
+		b.WriteString("memcache_sasl_enabled = true\n")
+		fmt.Fprintf(&b, "memcache_username = %s\n", m.SASLUsername)
+		fmt.Fprintf(&b, "memcache_password = %s\n", m.SASLPassword)
+	}
+	return b.String()
+}
+
+// TemplateParameters - returns the memcached parameters for the
+// ConfigOptions of a util.Template
+func (m Memcached) TemplateParameters() map[string]interface{} {
+	return map[string]interface{}{
+		"MemcachedServers":         strings.Join(m.Servers, ","),
+		"MemcachedServersWithInet": m.inetServers(),
+		"MemcachedTLS":             m.TLS,
+		"MemcachedCAFile":          m.caFile(),
+	}
+}
+
+// EnvVars - adds the memcached servers and TLS env vars to envVars, e.g.
+// for the scripts of a container
+func (m Memcached) EnvVars(envVars map[string]env.Setter) {
+	envVars[MemcachedServersEnv] = env.SetValue(strings.Join(m.Servers, ","))
+	envVars[MemcachedTLSEnv] = env.SetValue(strconv.FormatBool(m.TLS))
+}
+
+// Redis - the client config of a redis cache
+type Redis struct {
+	// Server - host:port of the redis service
+	Server string
+	// Sentinels - host:port of the redis sentinels, used instead of Server
+	// if set
+	Sentinels []string
+	// SentinelService - name of the service the sentinels monitor
+	SentinelService string
+	// TLS - the server uses TLS
+	TLS bool
+	// CAFile - CA to verify the server against, tls.DownstreamTLSCABundlePath if empty
+	CAFile string
+}
+
+// URL - returns the redis URL, e.g. for the coordination backend_url of
+// tooz
+func (r Redis) URL() string {
+	scheme := "redis"
+	if r.TLS {
+		scheme = "rediss"
+	}
+	if len(r.Sentinels) > 0 {
+		u := fmt.Sprintf("%s://%s?sentinel=%s", scheme, r.Sentinels[0], r.SentinelService)
+		for _, s := range r.Sentinels[1:] {
+			u += "&sentinel_fallback=" + s
+		}
+		return u
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Server)
+}
+
+// OsloCacheConfig - returns the [cache] section of oslo.cache for the
+// redis server or sentinels
+func (r Redis) OsloCacheConfig() string {
+	b := strings.Builder{}
+	b.WriteString("[cache]\n")
+	b.WriteString("enabled = true\n")
+	if len(r.Sentinels) > 0 {
+		b.WriteString("backend = dogpile.cache.redis_sentinel\n")
+		fmt.Fprintf(&b, "redis_sentinels = %s\n", strings.Join(r.Sentinels, ","))
+		fmt.Fprintf(&b, "redis_sentinel_service_name = %s\n", r.SentinelService)
+	} else {
+		b.WriteString("backend = dogpile.cache.redis\n")
+		fmt.Fprintf(&b, "redis_server = %s\n", r.Server)
+	}
+	fmt.Fprintf(&b, "tls_enabled = %s\n", strconv.FormatBool(r.TLS))
+	if r.TLS {
+		caFile := r.CAFile
+		if caFile == "" {
+			caFile = tls.DownstreamTLSCABundlePath
+		}
+		fmt.Fprintf(&b, "tls_cafile = %s\n", caFile)
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getMemcached(name string, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetGroupVersionKind(MemcachedGVK)
+	obj.SetName(name)
+	obj.SetNamespace("openstack")
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	return obj
+}
+
+func TestGetMemcached(t *testing.T) {
+	g := NewWithT(t)
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		getMemcached("memcached", map[string]interface{}{
+			"serverList":         []interface{}{"memcached-0.memcached:11211", "memcached-1.memcached:11211"},
+			"serverListWithInet": []interface{}{"inet:[memcached-0.memcached]:11211", "inet:[memcached-1.memcached]:11211"},
+			"tlsSupport":         true,
+		}),
+		getMemcached("new", nil),
+	).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	m, err := GetMemcached(context.TODO(), h, "memcached", "openstack")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(m.TLS).To(BeTrue())
+	g.Expect(m.OsloCacheConfig()).To(Equal(`[cache]
+enabled = true
+backend = dogpile.cache.pymemcache
+memcache_servers = inet:[memcached-0.memcached]:11211,inet:[memcached-1.memcached]:11211
+tls_enabled = true
+tls_cafile = /etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem
+`))
+
+	m.SASLUsername = "keystone"
+	m.SASLPassword = "secret"
+	g.Expect(m.AuthtokenConfig()).To(Equal(`memcached_servers = inet:[memcached-0.memcached]:11211,inet:[memcached-1.memcached]:11211
+memcache_tls_enabled = true
+memcache_tls_cafile = /etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem
+memcache_sasl_enabled = true
+memcache_username = keystone
+memcache_password = secret
+`))
+
+	envVars := map[string]env.Setter{}
+	m.EnvVars(envVars)
+	envs := env.MergeEnvs([]corev1.EnvVar{}, envVars)
+	g.Expect(envs).To(ContainElement(corev1.EnvVar{Name: MemcachedServersEnv, Value: "memcached-0.memcached:11211,memcached-1.memcached:11211"}))
+	g.Expect(envs).To(ContainElement(corev1.EnvVar{Name: MemcachedTLSEnv, Value: "true"}))
+	g.Expect(m.TemplateParameters()).To(HaveKeyWithValue("MemcachedTLS", true))
+
+	_, err = GetMemcached(context.TODO(), h, "new", "openstack")
+	g.Expect(err).To(MatchError(ErrNoServers))
+}
+
+func TestRedis(t *testing.T) {
+	g := NewWithT(t)
+
+	r := Redis{Server: "redis.openstack.svc:6379"}
+	g.Expect(r.URL()).To(Equal("redis://redis.openstack.svc:6379"))
+	g.Expect(r.OsloCacheConfig()).To(ContainSubstring("backend = dogpile.cache.redis\nredis_server = redis.openstack.svc:6379\ntls_enabled = false\n"))
+
+	r = Redis{Sentinels: []string{"a:26379", "b:26379"}, SentinelService: "redis", TLS: true}
+	g.Expect(r.URL()).To(Equal("rediss://a:26379?sentinel=redis&sentinel_fallback=b:26379"))
+	g.Expect(r.OsloCacheConfig()).To(ContainSubstring("redis_sentinels = a:26379,b:26379\nredis_sentinel_service_name = redis\ntls_enabled = true\ntls_cafile = "))
+}
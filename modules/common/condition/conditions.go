@@ -93,6 +93,34 @@ const (
 
 	// PDBReadyCondition Status=True condition which indicates if PodDisruptionBudget is configured and operational
 	PDBReadyCondition Type = "PDBReady"
+
+	// PVCExpansionReadyCondition Status=True condition when the requested size of the PersistentVolumeClaims got applied
+	PVCExpansionReadyCondition Type = "PVCExpansionReady"
+
+	// StorageClassReadyCondition Status=True condition when the StorageClass used by the service exists and meets its requirements
+	StorageClassReadyCondition Type = "StorageClassReady"
+
+	// KeyRotationReadyCondition Status=True condition when the fernet or credential keys exist and the workloads use the current keys
+	KeyRotationReadyCondition Type = "KeyRotationReady"
+
+	// PausedCondition Status=True condition when the reconciliation of the CR is paused
+	PausedCondition Type = "Paused"
+
+	// RequirementsReadyCondition Status=True condition when all the prerequisites declared via the preconditions module are met
+	RequirementsReadyCondition Type = "RequirementsReady"
+
+	// UpdateProgressingCondition Status=True condition while a minor update rolls the components to the new version
+	UpdateProgressingCondition Type = "UpdateProgressing"
+
+	// UpdateCompletedCondition Status=True condition when all components run the requested version
+	UpdateCompletedCondition Type = "UpdateCompleted"
+
+	// InSyncCondition Status=True condition when the managed child objects match the state the operator wrote,
+	// Status=False when they got changed out-of-band
+	InSyncCondition Type = "InSync"
+
+	// DependenciesReadyCondition Status=True condition when all the CRs declared via the dependencies module are ready
+	DependenciesReadyCondition Type = "DependenciesReady"
 )
 
 // Common Reasons used by API objects.
@@ -130,6 +158,15 @@ const (
 
 	// DeletedReason (Severity=Info) documents a condition not in Status=True because the underlying object was deleted.
 	DeletedReason = "Deleted"
+
+	// AdmissionDeniedReason (Severity=Warning) documents a condition not in Status=True because the API server admission
+	// rejected the underlying object, e.g. on an exceeded quota or a policy violation. The reconciler retries as
+	// quotas and policies can change.
+	AdmissionDeniedReason = "AdmissionDenied"
+
+	// DriftDetectedReason (Severity=Warning) documents a condition not in Status=True because managed child objects
+	// got changed out-of-band.
+	DriftDetectedReason = "DriftDetected"
 )
 
 // Common Messages used by API objects.
@@ -170,6 +207,9 @@ const (
 	// ServiceConfigReadyErrorMessage
 	ServiceConfigReadyErrorMessage = "Service config create error occurred %s"
 
+	// ServiceConfigReadyInvalidMessage
+	ServiceConfigReadyInvalidMessage = "Service config is invalid: %s"
+
 	//
 	// DBReady condition messages
 	//
@@ -396,6 +436,151 @@ const (
 
 	// PDBReadyErrorMessage
 	PDBReadyErrorMessage = "PodDisruptionBudget error occured %s"
+
+	//
+	// PVCExpansionReady condition messages
+	//
+
+	// PVCExpansionReadyInitMessage
+	PVCExpansionReadyInitMessage = "PVC expansion not started"
+
+	// PVCExpansionReadyMessage
+	PVCExpansionReadyMessage = "PVC expansion completed"
+
+	// PVCExpansionReadyRunningMessage
+	PVCExpansionReadyRunningMessage = "PVC expansion in progress: %s"
+
+	// PVCExpansionReadyErrorMessage
+	PVCExpansionReadyErrorMessage = "PVC expansion error occurred %s"
+
+	//
+	// StorageClassReady condition messages
+	//
+
+	// StorageClassReadyInitMessage
+	StorageClassReadyInitMessage = "StorageClass not validated"
+
+	// StorageClassReadyMessage
+	StorageClassReadyMessage = "StorageClass validated"
+
+	// StorageClassReadyWaitingMessage
+	StorageClassReadyWaitingMessage = "StorageClass missing: %s"
+
+	// StorageClassReadyErrorMessage
+	StorageClassReadyErrorMessage = "StorageClass error occurred %s"
+
+	//
+	// KeyRotationReady condition messages
+	//
+
+	// KeyRotationReadyInitMessage
+	KeyRotationReadyInitMessage = "Key rotation not started"
+
+	// KeyRotationReadyMessage
+	KeyRotationReadyMessage = "Keys ready"
+
+	// KeyRotationReadyRunningMessage
+	KeyRotationReadyRunningMessage = "Key rotation in progress: %s"
+
+	// KeyRotationReadyErrorMessage
+	KeyRotationReadyErrorMessage = "Key rotation error occurred %s"
+
+	//
+	// Paused condition messages
+	//
+
+	// PausedMessage
+	PausedMessage = "Reconciliation paused by annotation %s"
+
+	//
+	// RequirementsReady condition messages
+	//
+	// RequirementsReadyInitMessage
+	RequirementsReadyInitMessage = "Requirements not checked"
+
+	// RequirementsReadyMessage
+	RequirementsReadyMessage = "All requirements met"
+
+	// RequirementsReadyWaitingMessage
+	RequirementsReadyWaitingMessage = "Waiting for requirement %s: %s"
+
+	// RequirementsReadyErrorMessage
+	RequirementsReadyErrorMessage = "Requirement %s error occurred %s"
+
+	//
+	// Lifecycle hook condition messages
+	//
+	// HookReadyInitMessage
+	HookReadyInitMessage = "Hook %s not started"
+
+	// HookReadyMessage
+	HookReadyMessage = "Hook %s completed"
+
+	// HookReadyRunningMessage
+	HookReadyRunningMessage = "Hook %s still running"
+
+	// HookReadyErrorMessage
+	HookReadyErrorMessage = "Hook %s error occurred %s"
+
+	//
+	// Minor update condition messages
+	//
+	// UpdateProgressingMessage
+	UpdateProgressingMessage = "Updating %s to version %s: %s"
+
+	// UpdateCompletedInitMessage
+	UpdateCompletedInitMessage = "Update not started"
+
+	// UpdateCompletedMessage
+	UpdateCompletedMessage = "Version %s deployed"
+
+	// UpdateCompletedRunningMessage
+	UpdateCompletedRunningMessage = "Update to version %s in progress"
+
+	// UpdateCompletedWaitingApprovalMessage
+	UpdateCompletedWaitingApprovalMessage = "Update to version %s waiting for approval via annotation %s"
+
+	// UpdateCompletedErrorMessage
+	UpdateCompletedErrorMessage = "Update to version %s error occurred %s"
+
+	//
+	// InSync condition messages
+	//
+	// InSyncMessage
+	InSyncMessage = "Managed objects in sync"
+
+	// InSyncDriftDetectedMessage
+	InSyncDriftDetectedMessage = "Out-of-band changes detected on %s"
+
+	//
+	// DependenciesReady condition messages
+	//
+	// DependenciesReadyInitMessage
+	DependenciesReadyInitMessage = "Dependencies not checked"
+
+	// DependenciesReadyMessage
+	DependenciesReadyMessage = "All dependencies ready"
+
+	// DependenciesReadyWaitingMessage
+	DependenciesReadyWaitingMessage = "Waiting for dependencies: %s"
+
+	// DependenciesReadyErrorMessage
+	DependenciesReadyErrorMessage = "Dependency %s error occurred %s"
+
+	//
+	// Teardown stage condition messages
+	//
+	// TeardownStageReadyInitMessage
+	TeardownStageReadyInitMessage = "Teardown stage %s not started"
+
+	// TeardownStageReadyMessage
+	TeardownStageReadyMessage = "Teardown stage %s completed"
+
+	// TeardownStageReadyRunningMessage
+	TeardownStageReadyRunningMessage = "Teardown stage %s waiting for %s"
+
+	// TeardownStageReadyErrorMessage
+	TeardownStageReadyErrorMessage = "Teardown stage %s error occurred %s"
 )
 
 // Common Messages used for service accounts, roles, role bindings
@@ -24,6 +24,7 @@ import (
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -77,6 +78,7 @@ func createOrPatchConfigMap(
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), configMap, func() error {
 
 		configMap.Labels = util.MergeStringMaps(configMap.Labels, cm.Labels)
+		h.ApplyPropagatedMetadata(configMap, cm.Labels, cm.Annotations)
 		// add data from templates
 		renderedTemplateData, err := util.GetTemplateData(cm)
 		if err != nil {
@@ -91,14 +93,19 @@ func createOrPatchConfigMap(
 				if err == nil {
 					configMap.Data[k] = vExpanded
 				} else {
-					h.GetLogger().Info(fmt.Sprintf("Skipped customData expansion due to: %s", err))
+					h.GetLogger().Info("Skipped customData expansion", "reason", err.Error())
 					configMap.Data[k] = v
 				}
 			}
 		}
 
+		err = util.ValidateConfigData(cm.Name, configMap.Data, cm.Validators)
+		if err != nil {
+			return err
+		}
+
 		if !cm.SkipSetOwner {
-			err := controllerutil.SetControllerReference(obj, configMap, h.GetScheme())
+			err := object.SetControllerReference(obj, configMap, h.GetScheme())
 			if err != nil {
 				return err
 			}
@@ -138,14 +145,15 @@ func createOrGetCustomConfigMap(
 	foundConfigMap := &corev1.ConfigMap{}
 	err := h.GetClient().Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, foundConfigMap)
 	if err != nil && k8s_errors.IsNotFound(err) {
+		h.ApplyPropagatedMetadata(configMap, cm.Labels, cm.Annotations)
 		if !cm.SkipSetOwner {
-			err := controllerutil.SetControllerReference(obj, configMap, h.GetScheme())
+			err := object.SetControllerReference(obj, configMap, h.GetScheme())
 			if err != nil {
 				return "", err
 			}
 		}
 
-		h.GetLogger().Info(fmt.Sprintf("Creating a new ConfigMap %s in namespace %s", cm.Namespace, cm.Name))
+		h.GetLogger().Info("Creating a new ConfigMap", "object", cm.Name, "objectNamespace", cm.Namespace)
 		err = h.GetClient().Create(ctx, configMap)
 		if err != nil {
 			return "", err
@@ -189,7 +197,7 @@ func EnsureConfigMaps(
 			return err
 		}
 		if op != controllerutil.OperationResultNone {
-			h.GetLogger().Info(fmt.Sprintf("ConfigMap %s successfully reconciled - operation: %s", cm.Name, string(op)))
+			h.GetLogger().Info("ConfigMap reconciled", "object", cm.Name, "operation", op)
 		}
 		if envVars != nil {
 			(*envVars)[cm.Name] = env.SetValue(hash)
@@ -221,10 +229,11 @@ func CreateOrPatchRawConfigMap(
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), configMap, func() error {
 		configMap.Annotations = util.MergeStringMaps(configMap.Annotations, cm.Annotations)
 		configMap.Labels = util.MergeStringMaps(configMap.Labels, cm.Labels)
+		h.ApplyPropagatedMetadata(configMap, cm.Labels, cm.Annotations)
 		configMap.Data = cm.Data
 
 		if !skipSetOwner {
-			err := controllerutil.SetControllerReference(obj, configMap, h.GetScheme())
+			err := object.SetControllerReference(obj, configMap, h.GetScheme())
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,237 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion provides utilities for the conversion webhooks of CRDs
+// with multiple versions, e.g. to migrate the storage version
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DataAnnotation - annotation of a converted object with the source object,
+// to restore the fields its version can not hold when it gets converted
+// back, see MarshalData
+const DataAnnotation = "conversion.openstack.org/data"
+
+// FieldMove - a field which got moved or renamed between two API versions,
+// like a webhook.DeprecatedField maps a deprecated to a new field
+type FieldMove struct {
+	// From - path of the field in the older version, e.g.
+	// []string{"spec", "rabbitMqClusterName"}
+	From []string
+	// To - path of the field in the newer version, e.g.
+	// []string{"spec", "messagingBus", "cluster"}
+	To []string
+}
+
+// FieldMoves - the fields moved or renamed between two API versions
+type FieldMoves []FieldMove
+
+// Up - moves the fields of the unstructured content u from their path in
+// the older to the one in the newer version
+func (m FieldMoves) Up(u map[string]interface{}) error {
+	for _, f := range m {
+		err := moveField(u, f.From, f.To)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down - moves the fields of the unstructured content u from their path in
+// the newer to the one in the older version
+func (m FieldMoves) Down(u map[string]interface{}) error {
+	for i := len(m) - 1; i >= 0; i-- {
+		err := moveField(u, m[i].To, m[i].From)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveField - moves the value at path from to path to, if set. Parents of
+// from left empty by the move get removed, so that an unset optional
+// struct stays unset.
+func moveField(u map[string]interface{}, from []string, to []string) error {
+	v, found, err := unstructured.NestedFieldNoCopy(u, from...)
+	if err != nil {
+		return fmt.Errorf("error reading field %v: %w", from, err)
+	}
+	if !found {
+		return nil
+	}
+
+	unstructured.RemoveNestedField(u, from...)
+	for i := len(from) - 1; i > 0; i-- {
+		parent, found, _ := unstructured.NestedMap(u, from[:i]...)
+		if !found || len(parent) > 0 {
+			break
+		}
+		unstructured.RemoveNestedField(u, from[:i]...)
+	}
+
+	err = unstructured.SetNestedField(u, v, to...)
+	if err != nil {
+		return fmt.Errorf("error setting field %v: %w", to, err)
+	}
+	return nil
+}
+
+// ConvertUp - converts src of the older version to dst of the newer version,
+// for versions which only differ by the moved fields. Fields dst does not
+// know get dropped, see MarshalData to preserve them.
+//
+// Example:
+//
+//	var moves = conversion.FieldMoves{
+//		{From: []string{"spec", "rabbitMqClusterName"}, To: []string{"spec", "messagingBus", "cluster"}},
+//	}
+//
+//	// ConvertTo converts this KeystoneAPI to the Hub version (v1beta2)
+//	func (src *KeystoneAPI) ConvertTo(dstRaw conversion.Hub) error {
+//		return conversion.ConvertUp(src, dstRaw, moves)
+//	}
+func ConvertUp(src runtime.Object, dst runtime.Object, moves FieldMoves) error {
+	return convert(src, dst, moves.Up)
+}
+
+// ConvertDown - converts src of the newer version to dst of the older
+// version, the reverse of ConvertUp
+func ConvertDown(src runtime.Object, dst runtime.Object, moves FieldMoves) error {
+	return convert(src, dst, moves.Down)
+}
+
+// convert - converts src to dst via their unstructured content, applying
+// move in between. The kind and version of dst are kept.
+func convert(src runtime.Object, dst runtime.Object, move func(map[string]interface{}) error) error {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(src)
+	if err != nil {
+		return err
+	}
+	// ToUnstructured might return the content of src itself
+	u = runtime.DeepCopyJSON(u)
+	delete(u, "apiVersion")
+	delete(u, "kind")
+
+	err = move(u)
+	if err != nil {
+		return err
+	}
+
+	gvk := dst.GetObjectKind().GroupVersionKind()
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(u, dst)
+	if err != nil {
+		return err
+	}
+	dst.GetObjectKind().SetGroupVersionKind(gvk)
+
+	return nil
+}
+
+// MarshalData - stores src without its metadata in the DataAnnotation of
+// dst, the object src got converted to. Call it when converting to a
+// version which can not hold all fields of src, so that UnmarshalData can
+// restore them when dst gets converted back, and the round-trip is lossless.
+//
+// Example:
+//
+//	// ConvertFrom converts from the Hub version (v1beta2) to this version
+//	func (dst *KeystoneAPI) ConvertFrom(srcRaw conversion.Hub) error {
+//		src := srcRaw.(*v1beta2.KeystoneAPI)
+//		err := conversion.ConvertDown(src, dst, moves)
+//		if err != nil {
+//			return err
+//		}
+//		return conversion.MarshalData(src, dst)
+//	}
+func MarshalData(src runtime.Object, dst metav1.Object) error {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(src)
+	if err != nil {
+		return err
+	}
+	u = runtime.DeepCopyJSON(u)
+	delete(u, "metadata")
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+
+	annotations := map[string]string{}
+	for k, v := range dst.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[DataAnnotation] = string(data)
+	dst.SetAnnotations(annotations)
+
+	return nil
+}
+
+// UnmarshalData - reads the data MarshalData stored in the DataAnnotation of
+// obj into to and removes the annotation from obj. Call it on the converted
+// object, after its metadata got copied from the source. Returns false if
+// obj has no such annotation, e.g. it was created in the older version.
+//
+// Example:
+//
+//	// ConvertTo converts this KeystoneAPI to the Hub version (v1beta2)
+//	func (src *KeystoneAPI) ConvertTo(dstRaw conversion.Hub) error {
+//		dst := dstRaw.(*v1beta2.KeystoneAPI)
+//		err := conversion.ConvertUp(src, dst, moves)
+//		if err != nil {
+//			return err
+//		}
+//		restored := &v1beta2.KeystoneAPI{}
+//		ok, err := conversion.UnmarshalData(dst, restored)
+//		if err != nil || !ok {
+//			return err
+//		}
+//		dst.Spec.Federation = restored.Spec.Federation
+//		return nil
+//	}
+func UnmarshalData(obj metav1.Object, to interface{}) (bool, error) {
+	data, ok := obj.GetAnnotations()[DataAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	err := json.Unmarshal([]byte(data), to)
+	if err != nil {
+		return false, fmt.Errorf("error reading annotation %s of %s: %w", DataAnnotation, obj.GetName(), err)
+	}
+
+	// copy, the annotations might be shared with the source object
+	annotations := map[string]string{}
+	for k, v := range obj.GetAnnotations() {
+		if k != DataAnnotation {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+
+	return true, nil
+}
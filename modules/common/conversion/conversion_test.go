@@ -0,0 +1,161 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+type oldSpec struct {
+	RabbitMqClusterName string `json:"rabbitMqClusterName,omitempty"`
+	Replicas            *int32 `json:"replicas,omitempty"`
+}
+
+type oldObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              oldSpec `json:"spec,omitempty"`
+}
+
+func (o *oldObject) DeepCopyObject() runtime.Object {
+	c := *o
+	o.ObjectMeta.DeepCopyInto(&c.ObjectMeta)
+	if o.Spec.Replicas != nil {
+		c.Spec.Replicas = ptr.To(*o.Spec.Replicas)
+	}
+	return &c
+}
+
+type messagingBus struct {
+	Cluster string `json:"cluster,omitempty"`
+}
+
+type newSpec struct {
+	MessagingBus *messagingBus `json:"messagingBus,omitempty"`
+	Replicas     *int32        `json:"replicas,omitempty"`
+	Federation   string        `json:"federation,omitempty"`
+}
+
+type newObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              newSpec `json:"spec,omitempty"`
+}
+
+func (o *newObject) DeepCopyObject() runtime.Object {
+	c := *o
+	o.ObjectMeta.DeepCopyInto(&c.ObjectMeta)
+	return &c
+}
+
+var moves = FieldMoves{
+	{From: []string{"spec", "rabbitMqClusterName"}, To: []string{"spec", "messagingBus", "cluster"}},
+}
+
+func TestFieldMoves(t *testing.T) {
+	g := NewWithT(t)
+
+	u := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"rabbitMqClusterName": "rabbitmq",
+			"replicas":            int64(1),
+		},
+	}
+	g.Expect(moves.Up(u)).To(Succeed())
+	g.Expect(u).To(Equal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"messagingBus": map[string]interface{}{"cluster": "rabbitmq"},
+			"replicas":     int64(1),
+		},
+	}))
+
+	// the emptied messagingBus gets removed
+	g.Expect(moves.Down(u)).To(Succeed())
+	g.Expect(u).To(Equal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"rabbitMqClusterName": "rabbitmq",
+			"replicas":            int64(1),
+		},
+	}))
+
+	// unset fields are not moved
+	u = map[string]interface{}{"spec": map[string]interface{}{}}
+	g.Expect(moves.Up(u)).To(Succeed())
+	g.Expect(u).To(Equal(map[string]interface{}{"spec": map[string]interface{}{}}))
+
+	u = map[string]interface{}{"spec": "invalid"}
+	g.Expect(moves.Up(u)).ToNot(Succeed())
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+
+	hub := &newObject{
+		TypeMeta: metav1.TypeMeta{APIVersion: "test.openstack.org/v1beta2", Kind: "Test"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "openstack",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: newSpec{
+			MessagingBus: &messagingBus{Cluster: "rabbitmq"},
+			Replicas:     ptr.To[int32](3),
+			Federation:   "enabled",
+		},
+	}
+
+	// hub to spoke
+	spoke := &oldObject{TypeMeta: metav1.TypeMeta{APIVersion: "test.openstack.org/v1beta1", Kind: "Test"}}
+	g.Expect(ConvertDown(hub, spoke, moves)).To(Succeed())
+	g.Expect(MarshalData(hub, spoke)).To(Succeed())
+	g.Expect(spoke.APIVersion).To(Equal("test.openstack.org/v1beta1"))
+	g.Expect(spoke.Name).To(Equal("test"))
+	g.Expect(spoke.Spec.RabbitMqClusterName).To(Equal("rabbitmq"))
+	g.Expect(*spoke.Spec.Replicas).To(Equal(int32(3)))
+	g.Expect(spoke.Annotations).To(HaveKey(DataAnnotation))
+	g.Expect(hub.Annotations).ToNot(HaveKey(DataAnnotation))
+
+	// and back, restoring the field the spoke can not hold
+	converted := &newObject{TypeMeta: metav1.TypeMeta{APIVersion: "test.openstack.org/v1beta2", Kind: "Test"}}
+	g.Expect(ConvertUp(spoke, converted, moves)).To(Succeed())
+	restored := &newObject{}
+	ok, err := UnmarshalData(converted, restored)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	converted.Spec.Federation = restored.Spec.Federation
+	g.Expect(converted).To(Equal(hub))
+	// the source object keeps its annotation
+	g.Expect(spoke.Annotations).To(HaveKey(DataAnnotation))
+}
+
+func TestUnmarshalData(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &newObject{}
+	ok, err := UnmarshalData(obj, &newObject{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+
+	obj.Annotations = map[string]string{DataAnnotation: "{"}
+	_, err = UnmarshalData(obj, &newObject{})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -52,7 +53,7 @@ func (cj *CronJob) CreateOrPatch(
 
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), cronjob, func() error {
 		cronjob.Spec = cj.cronjob.Spec
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), cronjob, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), cronjob, h.GetScheme())
 		if err != nil {
 			return err
 		}
@@ -61,13 +62,13 @@ func (cj *CronJob) CreateOrPatch(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("CronJob %s not found, reconcile in %s", cj.cronjob.Name, cj.timeout))
+			h.GetLogger().Info("CronJob not found, reconcile later", "object", cj.cronjob.Name, "requeueAfter", cj.timeout)
 			return ctrl.Result{RequeueAfter: cj.timeout}, nil
 		}
 		return ctrl.Result{}, err
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("CronJob %s - %s", cj.cronjob.Name, op))
+		h.GetLogger().Info("CronJob reconciled", "object", cj.cronjob.Name, "operation", op)
 	}
 
 	return ctrl.Result{}, nil
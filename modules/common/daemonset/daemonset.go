@@ -23,6 +23,9 @@ import (
 	"time"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pod"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/scheduling"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	appsv1 "k8s.io/api/apps/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
@@ -43,6 +46,21 @@ func NewDaemonSet(
 	}
 }
 
+// SetScheduling - sets the nodeSelector and tolerations of the merged
+// operator defaults and user override on the pod template of the daemonset.
+// Must be called before CreateOrPatch.
+func (d *DaemonSet) SetScheduling(defaults scheduling.Spec, override scheduling.Spec) {
+	scheduling.Apply(&d.daemonset.Spec.Template.Spec, defaults, override)
+}
+
+// SetAdmissionDryRun - if enabled, CreateOrPatch first submits the DaemonSet
+// with server-side dry-run and returns an object.AdmissionError if the
+// admission rejects it, e.g. on an exceeded quota or a policy violation,
+// before anything gets applied
+func (d *DaemonSet) SetAdmissionDryRun(enabled bool) {
+	d.dryRun = enabled
+}
+
 // CreateOrPatch - creates or patches a DaemonSet, reconciles after Xs if object won't exist.
 func (d *DaemonSet) CreateOrPatch(
 	ctx context.Context,
@@ -55,7 +73,7 @@ func (d *DaemonSet) CreateOrPatch(
 		},
 	}
 
-	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), daemonset, func() error {
+	mutate := func() error {
 		// DaemonSet selector is immutable so we set this value only if
 		// a new object is going to be created
 		if daemonset.CreationTimestamp.IsZero() {
@@ -63,25 +81,34 @@ func (d *DaemonSet) CreateOrPatch(
 		}
 		daemonset.Annotations = util.MergeStringMaps(daemonset.Annotations, d.daemonset.Annotations)
 		daemonset.Labels = util.MergeStringMaps(daemonset.Labels, d.daemonset.Labels)
+		h.ApplyPropagatedMetadata(daemonset, d.daemonset.Labels, d.daemonset.Annotations)
 		daemonset.Spec.Template = d.daemonset.Spec.Template
 		daemonset.Spec.UpdateStrategy = d.daemonset.Spec.UpdateStrategy
 
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), daemonset, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), daemonset, h.GetScheme())
 		if err != nil {
 			return err
 		}
 
 		return nil
-	})
+	}
+	if d.dryRun {
+		err := object.DryRun(ctx, h, daemonset, mutate)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), daemonset, mutate)
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			util.LogForObject(h, fmt.Sprintf("DaemonSet not found, reconcile in %s", d.timeout), daemonset)
+			util.LogForObject(h, "DaemonSet not found, reconcile later", daemonset, "requeueAfter", d.timeout)
 			return ctrl.Result{RequeueAfter: d.timeout}, nil
 		}
 		return ctrl.Result{}, err
 	}
 	if op != controllerutil.OperationResultNone {
-		util.LogForObject(h, fmt.Sprintf("DaemonSet: %s", op), daemonset)
+		util.LogForObject(h, "DaemonSet reconciled", daemonset, "operation", op)
 	}
 
 	// update the daemonset object of the daemonset type
@@ -96,6 +123,21 @@ func (d *DaemonSet) CreateOrPatch(
 	return ctrl.Result{}, nil
 }
 
+// GetReplicaReport - returns the replica counts of the DaemonSet, as of the
+// last CreateOrPatch, and the details of its pods which are not ready
+func (d *DaemonSet) GetReplicaReport(
+	ctx context.Context,
+	h *helper.Helper,
+) (*pod.ReplicaReport, error) {
+	return pod.GetReplicaReport(ctx, h, d.daemonset.Namespace, d.daemonset.Spec.Selector, pod.ReplicaReport{
+		Desired:   d.daemonset.Status.DesiredNumberScheduled,
+		Current:   d.daemonset.Status.CurrentNumberScheduled,
+		Ready:     d.daemonset.Status.NumberReady,
+		Updated:   d.daemonset.Status.UpdatedNumberScheduled,
+		Available: d.daemonset.Status.NumberAvailable,
+	})
+}
+
 // Delete - delete a daemonset.
 func (d *DaemonSet) Delete(
 	ctx context.Context,
@@ -26,4 +26,5 @@ import (
 type DaemonSet struct {
 	daemonset *appsv1.DaemonSet
 	timeout   time.Duration
+	dryRun    bool
 }
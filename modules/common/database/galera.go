@@ -0,0 +1,226 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package database provides the connection config of the OpenStack services
+// for the Galera clusters of the mariadb-operator
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	common_net "github.com/openstack-k8s-operators/lib-common/modules/common/net"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Define static errors
+var (
+	// ErrNotBootstrapped indicates a Galera cluster which is not bootstrapped yet
+	ErrNotBootstrapped = errors.New("galera cluster not bootstrapped")
+	// ErrNoPrimary indicates a Galera cluster without an active pod
+	ErrNoPrimary = errors.New("galera cluster has no primary")
+)
+
+// GaleraGVK - kind of the Galera CR of the mariadb-operator. The
+// mariadb-operator depends on lib-common, so its API cannot be imported.
+var GaleraGVK = schema.GroupVersionKind{
+	Group:   "mariadb.openstack.org",
+	Version: "v1beta1",
+	Kind:    "Galera",
+}
+
+const (
+	// DefaultPort - port of the Galera service
+	DefaultPort = 3306
+	// MyCnfPath - path of the my.cnf the connection URL reads the client
+	// settings from
+	MyCnfPath = "/etc/my.cnf"
+
+	// DatabasePrimaryEnv - env var with the hash of the primary, changes on
+	// failover
+	DatabasePrimaryEnv = "DATABASE_PRIMARY"
+
+	// primaryPodSelector - selector of the Galera service the
+	// mariadb-operator points to the active pod
+	primaryPodSelector = "statefulset.kubernetes.io/pod-name"
+)
+
+// Primary - the current primary of a Galera cluster. The mariadb-operator
+// runs Galera active/passive and points the service of the cluster to the
+// active pod.
+type Primary struct {
+	// Galera - name of the Galera CR
+	Galera string
+	// Pod - the active pod
+	Pod string
+	// Host - hostname of the Galera service, which follows the primary
+	Host string
+	// PodHost - hostname of the active pod
+	PodHost string
+	// Port - port of the service
+	Port int32
+	// TLS - the cluster uses TLS
+	TLS bool
+	// CAFile - CA to verify the servers against, tls.DownstreamTLSCABundlePath if empty
+	CAFile string
+}
+
+// GetPrimary - returns the primary of the Galera CR name in namespace.
+// ErrNotBootstrapped or ErrNoPrimary are returned while the cluster is not
+// ready, e.g. to requeue after requeue.Policy.Next with
+// requeue.ClassDependencyNotReady.
+func GetPrimary(ctx context.Context, h *helper.Helper, name string, namespace string) (*Primary, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(GaleraGVK)
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	bootstrapped, _, err := unstructured.NestedBool(obj.Object, "status", "bootstrapped")
+	if err != nil {
+		return nil, fmt.Errorf("invalid bootstrapped status of galera %s: %w", name, err)
+	}
+	if !bootstrapped {
+		return nil, fmt.Errorf("%w: %s", ErrNotBootstrapped, name)
+	}
+	tlsSecret, _, err := unstructured.NestedString(obj.Object, "spec", "tls", "secretName")
+	if err != nil {
+		return nil, fmt.Errorf("invalid tls of galera %s: %w", name, err)
+	}
+
+	svc := &corev1.Service{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, svc)
+	if err != nil {
+		return nil, err
+	}
+	pod := svc.Spec.Selector[primaryPodSelector]
+	if pod == "" {
+		return nil, fmt.Errorf("%w: %s", ErrNoPrimary, name)
+	}
+
+	port := int32(DefaultPort)
+	if len(svc.Spec.Ports) > 0 {
+		port = svc.Spec.Ports[0].Port
+	}
+
+	return &Primary{
+		Galera:  name,
+		Pod:     pod,
+		Host:    fmt.Sprintf("%s.%s.svc", name, namespace),
+		PodHost: fmt.Sprintf("%s.%s-galera.%s.svc", pod, name, namespace),
+		Port:    port,
+		TLS:     tlsSecret != "",
+	}, nil
+}
+
+func (p Primary) caFile() string {
+	if p.CAFile != "" {
+		return p.CAFile
+	}
+	return tls.DownstreamTLSCABundlePath
+}
+
+// ConnectionURL - returns the SQLAlchemy URL of the database for the
+// account. The client settings, e.g. TLS, are read from the MyCnf.
+func (p Primary) ConnectionURL(account string, password string, database string) string {
+	u := url.URL{
+		Scheme:   "mysql+pymysql",
+		User:     url.UserPassword(account, password),
+		Host:     common_net.FormatHost(p.Host),
+		Path:     "/" + database,
+		RawQuery: "read_default_file=" + MyCnfPath,
+	}
+	if p.Port != DefaultPort && p.Port != 0 {
+		u.Host = net.JoinHostPort(p.Host, strconv.Itoa(int(p.Port)))
+	}
+	return u.String()
+}
+
+// DatabaseConfig - returns the [database] section of oslo.db for the
+// database of the account
+func (p Primary) DatabaseConfig(account string, password string, database string) string {
+	b := strings.Builder{}
+	b.WriteString("[database]\n")
+	fmt.Fprintf(&b, "connection = %s\n", p.ConnectionURL(account, password, database))
+	return b.String()
+}
+
+// MyCnf - returns the my.cnf with the client settings of the ConnectionURL
+func (p Primary) MyCnf() string {
+	b := strings.Builder{}
+	b.WriteString("[client]\n")
+	if p.TLS {
+		b.WriteString("ssl = 1\n")
+		fmt.Fprintf(&b, "ssl-ca = %s\n", p.caFile())
+	}
+	return b.String()
+}
+
+// TemplateParameters - returns the database parameters for the
+// ConfigOptions of a util.Template
+func (p Primary) TemplateParameters() map[string]interface{} {
+	return map[string]interface{}{
+		"DatabaseHostname": p.Host,
+		"DatabasePort":     strconv.Itoa(int(p.Port)),
+		"DatabasePrimary":  p.Pod,
+		"DatabaseTLS":      p.TLS,
+	}
+}
+
+// Hash - returns a hash of the primary. It changes on failover, so that
+// the workloads using it get restarted and reconnect to the new primary
+// instead of keeping the connections of their pools to the old one.
+//
+// Example:
+//
+//	primary, err := database.GetPrimary(ctx, h, "openstack", instance.Namespace)
+//	...
+//	hash, err := primary.Hash()
+//	...
+//	registry.Add("galera-primary", hash)
+func (p Primary) Hash() (string, error) {
+	return util.ObjectHash(struct {
+		Galera string
+		Pod    string
+		Host   string
+		Port   int32
+		TLS    bool
+	}{p.Galera, p.Pod, p.Host, p.Port, p.TLS})
+}
+
+// EnvVars - adds the DatabasePrimaryEnv with the Hash to envVars, e.g. for
+// the pod template of a workload to restart it on failover
+func (p Primary) EnvVars(envVars map[string]env.Setter) error {
+	hash, err := p.Hash()
+	if err != nil {
+		return err
+	}
+	envVars[DatabasePrimaryEnv] = env.SetValue(hash)
+	return nil
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dependencies provides the readiness evaluation of the CRs a CR
+// depends on, e.g. the MariaDBDatabase and the RabbitMqCluster of a
+// KeystoneAPI, and a watch mapper to reconcile the CR on their changes
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/generation"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Dependency - a reference to the CRs of a kind a CR depends on, either
+// by name or by label selector
+type Dependency struct {
+	// GVK - kind of the CRs
+	GVK schema.GroupVersionKind
+	// Namespace - namespace of the CRs, the one of the depending CR if empty
+	Namespace string
+	// Name - name of the CR
+	Name string
+	// Selector - labels of the CRs if Name is empty. All CRs matching it
+	// must be ready, and at least one must exist.
+	Selector map[string]string
+}
+
+// String - returns a short description used in the condition message
+func (d Dependency) String() string {
+	if d.Name != "" {
+		return fmt.Sprintf("%s %s", d.GVK.Kind, d.Name)
+	}
+	return fmt.Sprintf("%s %s", d.GVK.Kind, labels.SelectorFromSet(d.Selector))
+}
+
+// Matches - returns true if obj of kind gvk is referenced by the dependency
+// of a CR in namespace
+func (d Dependency) Matches(gvk schema.GroupVersionKind, obj client.Object, namespace string) bool {
+	if d.GVK.GroupKind() != gvk.GroupKind() {
+		return false
+	}
+	if d.Namespace != "" {
+		namespace = d.Namespace
+	}
+	if obj.GetNamespace() != namespace {
+		return false
+	}
+	if d.Name != "" {
+		return d.Name == obj.GetName()
+	}
+	return labels.SelectorFromSet(d.Selector).Matches(labels.Set(obj.GetLabels()))
+}
+
+// IsReady - returns true if the CR has a Ready condition with status True
+// and, if it publishes its observed generation, its controller observed its
+// current generation. Otherwise a message describing why it is not ready
+// is returned.
+func IsReady(obj *unstructured.Unstructured) (bool, string) {
+	if observed, known := generation.IsObserved(obj); known && !observed {
+		return false, fmt.Sprintf("generation %d not observed yet", obj.GetGeneration())
+	}
+
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, "invalid status conditions"
+	}
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["type"] != string(condition.ReadyCondition) {
+			continue
+		}
+		if cond["status"] == string(corev1.ConditionTrue) {
+			return true, ""
+		}
+		if message, ok := cond["message"].(string); ok && message != "" {
+			return false, "not ready: " + message
+		}
+	}
+	return false, "not ready"
+}
+
+// check - returns true if the CRs of the dependency are ready, otherwise a
+// message describing the ones not ready
+func (d Dependency) check(ctx context.Context, h *helper.Helper, namespace string) (bool, string, error) {
+	if d.Namespace != "" {
+		namespace = d.Namespace
+	}
+
+	if d.Name != "" {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(d.GVK)
+		err := h.GetClient().Get(ctx, client.ObjectKey{Name: d.Name, Namespace: namespace}, obj)
+		if err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				return false, "not found", nil
+			}
+			return false, "", err
+		}
+		ready, message := IsReady(obj)
+		return ready, message, nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(d.GVK.GroupVersion().WithKind(d.GVK.Kind + "List"))
+	err := h.GetClient().List(ctx, list, client.InNamespace(namespace), client.MatchingLabels(d.Selector))
+	if err != nil {
+		return false, "", err
+	}
+	if len(list.Items) == 0 {
+		return false, "none found", nil
+	}
+	notReady := []string{}
+	for i := range list.Items {
+		if ready, message := IsReady(&list.Items[i]); !ready {
+			notReady = append(notReady, fmt.Sprintf("%s %s", list.Items[i].GetName(), message))
+		}
+	}
+	if len(notReady) > 0 {
+		return false, strings.Join(notReady, ", "), nil
+	}
+	return true, "", nil
+}
+
+// Evaluate - checks all dependencies of the CR of the helper and rolls up
+// the result into the DependenciesReadyCondition, which is set to
+// - True if all dependencies are ready, an empty ctrl.Result is returned
+// - False with RequestedReason listing the dependencies not ready, a
+// requeue after requeueTimeout is returned
+// - False with ErrorReason if a dependency could not be checked, the error
+// is returned
+//
+// Example:
+//
+//	ctrlResult, err := dependencies.Evaluate(ctx, h, &instance.Status.Conditions, time.Second*10,
+//		dependencies.Dependency{GVK: mariaDBDatabaseGVK, Name: instance.Spec.DatabaseAccount},
+//		dependencies.Dependency{GVK: transportURLGVK, Selector: map[string]string{"service": "keystone"}},
+//	)
+//	if (ctrlResult != ctrl.Result{}) || err != nil {
+//		return ctrlResult, err
+//	}
+func Evaluate(
+	ctx context.Context,
+	h *helper.Helper,
+	conditions *condition.Conditions,
+	requeueTimeout time.Duration,
+	dependencies ...Dependency,
+) (ctrl.Result, error) {
+	namespace := ""
+	if h.GetBeforeObject() != nil {
+		namespace = h.GetBeforeObject().GetNamespace()
+	}
+
+	notReady := []string{}
+	for _, d := range dependencies {
+		ready, message, err := d.check(ctx, h, namespace)
+		if err != nil {
+			conditions.MarkFalse(
+				condition.DependenciesReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.DependenciesReadyErrorMessage,
+				d.String(),
+				err.Error())
+			return ctrl.Result{}, fmt.Errorf("dependency %s: %w", d, err)
+		}
+		if !ready {
+			notReady = append(notReady, fmt.Sprintf("%s %s", d, message))
+		}
+	}
+
+	if len(notReady) > 0 {
+		h.GetLogger().Info("Dependencies not ready, reconcile later", "dependencies", notReady, "requeueAfter", requeueTimeout)
+		conditions.MarkFalse(
+			condition.DependenciesReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.DependenciesReadyWaitingMessage,
+			strings.Join(notReady, "; "))
+		return ctrl.Result{RequeueAfter: requeueTimeout}, nil
+	}
+
+	conditions.MarkTrue(condition.DependenciesReadyCondition, condition.DependenciesReadyMessage)
+
+	return ctrl.Result{}, nil
+}
+
+// IndexField - name of the field index of the CRs by the dependencies they
+// declare, see Index
+const IndexField = ".spec.dependencies"
+
+// indexKey - returns the index value of the dependencies on the CR name of
+// the kind in namespace, name is empty for dependencies by label selector
+func indexKey(gk schema.GroupKind, namespace string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", gk, namespace, name)
+}
+
+// Index - returns the field index of the CRs of the kind cr by the
+// dependencies dependenciesOf returns for them, which MapFunc lists by. It
+// must be registered with the field indexer of the manager.
+//
+// Example:
+//
+//	err := helper.Indexes{
+//		dependencies.Index(&keystonev1.KeystoneAPI{}, keystoneDependencies),
+//	}.Register(ctx, mgr.GetFieldIndexer())
+func Index(cr client.Object, dependenciesOf func(obj client.Object) []Dependency) helper.Index {
+	return helper.Index{
+		Object: cr,
+		Field:  IndexField,
+		Extract: func(obj client.Object) []string {
+			keys := []string{}
+			for _, d := range dependenciesOf(obj) {
+				namespace := d.Namespace
+				if namespace == "" {
+					namespace = obj.GetNamespace()
+				}
+				keys = append(keys, indexKey(d.GVK.GroupKind(), namespace, d.Name))
+			}
+			return keys
+		},
+	}
+}
+
+// MapFunc - returns a handler.MapFunc for the watches of the dependency
+// kinds. It enqueues the CRs of list, in any namespace, which declare a
+// dependency matching the changed object. dependenciesOf returns the
+// dependencies of a CR of list. The field index returned by Index must be
+// registered.
+//
+// Example:
+//
+//	ctrl.NewControllerManagedBy(mgr).
+//		For(&keystonev1.KeystoneAPI{}).
+//		Watches(&mariadbv1.MariaDBDatabase{},
+//			handler.EnqueueRequestsFromMapFunc(dependencies.MapFunc(mgr.GetClient(), &keystonev1.KeystoneAPIList{}, keystoneDependencies))).
+func MapFunc(
+	c client.Client,
+	list client.ObjectList,
+	dependenciesOf func(obj client.Object) []Dependency,
+) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+		if err != nil {
+			log.Error(err, "Unable to get the kind of the dependency", "object", obj.GetName())
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		seen := map[types.NamespacedName]bool{}
+		// the CRs depending on obj by name, and the ones depending on its
+		// kind in its namespace by label selector
+		for _, key := range []string{
+			indexKey(gvk.GroupKind(), obj.GetNamespace(), obj.GetName()),
+			indexKey(gvk.GroupKind(), obj.GetNamespace(), ""),
+		} {
+			crList := list.DeepCopyObject().(client.ObjectList)
+			err = c.List(ctx, crList, client.MatchingFields{IndexField: key})
+			if err != nil {
+				log.Error(err, "Unable to list the CRs depending on the object", "object", obj.GetName())
+				return nil
+			}
+			items, err := meta.ExtractList(crList)
+			if err != nil {
+				log.Error(err, "Unable to extract the CRs depending on the object", "object", obj.GetName())
+				return nil
+			}
+
+			for _, item := range items {
+				cr, ok := item.(client.Object)
+				if !ok || seen[client.ObjectKeyFromObject(cr)] {
+					continue
+				}
+				for _, d := range dependenciesOf(cr) {
+					if d.Matches(gvk, obj, cr.GetNamespace()) {
+						seen[client.ObjectKeyFromObject(cr)] = true
+						requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+						break
+					}
+				}
+			}
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependencies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Pods with the Ready condition are used as stand-in for CRs
+var podGVK = corev1.SchemeGroupVersion.WithKind("Pod")
+
+func getPod(name string, ready corev1.ConditionStatus, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openstack",
+			Labels:    labels,
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: ready, Message: "containers not ready"},
+			},
+		},
+	}
+}
+
+func setupHelper(objs ...runtime.Object) (*helper.Helper, client.Client, error) {
+	owner := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone",
+			Namespace: "openstack",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithRuntimeObjects(objs...).
+		Build()
+
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	return h, fakeClient, err
+}
+
+func TestEvaluate(t *testing.T) {
+	g := NewWithT(t)
+
+	h, _, err := setupHelper(
+		getPod("mariadb", corev1.ConditionTrue, nil),
+		getPod("rabbitmq-0", corev1.ConditionTrue, map[string]string{"app": "rabbitmq"}),
+		getPod("rabbitmq-1", corev1.ConditionFalse, map[string]string{"app": "rabbitmq"}),
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	conditions := condition.Conditions{}
+	mariadb := Dependency{GVK: podGVK, Name: "mariadb"}
+	rabbitmq := Dependency{GVK: podGVK, Selector: map[string]string{"app": "rabbitmq"}}
+
+	ctrlResult, err := Evaluate(context.TODO(), h, &conditions, 10*time.Second, mariadb)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ctrlResult).To(Equal(ctrl.Result{}))
+	g.Expect(conditions.IsTrue(condition.DependenciesReadyCondition)).To(BeTrue())
+
+	ctrlResult, err = Evaluate(context.TODO(), h, &conditions, 10*time.Second,
+		mariadb, rabbitmq, Dependency{GVK: podGVK, Name: "memcached"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ctrlResult).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Second}))
+	c := conditions.Get(condition.DependenciesReadyCondition)
+	g.Expect(c.Reason).To(Equal(condition.Reason(condition.RequestedReason)))
+	g.Expect(c.Message).To(Equal("Waiting for dependencies: " +
+		"Pod app=rabbitmq rabbitmq-1 not ready: containers not ready; Pod memcached not found"))
+
+	_, err = Evaluate(context.TODO(), h, &conditions, 10*time.Second,
+		Dependency{GVK: podGVK, Selector: map[string]string{"app": "memcached"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(conditions.Get(condition.DependenciesReadyCondition).Message).To(ContainSubstring("app=memcached none found"))
+}
+
+func TestMapFunc(t *testing.T) {
+	g := NewWithT(t)
+
+	// ConfigMaps as stand-in for the depending CRs, their data the
+	// namespace and name of the pod they depend on
+	dependenciesOf := func(obj client.Object) []Dependency {
+		cm := obj.(*corev1.ConfigMap)
+		if cm.Data["selector"] != "" {
+			return []Dependency{{GVK: podGVK, Selector: map[string]string{"app": cm.Data["selector"]}}}
+		}
+		return []Dependency{{GVK: podGVK, Namespace: cm.Data["namespace"], Name: cm.Data["db"]}}
+	}
+	index := Index(&corev1.ConfigMap{}, dependenciesOf)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithIndex(index.Object, index.Field, index.Extract).
+		WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}, Data: map[string]string{"db": "mariadb"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "glance", Namespace: "openstack"}, Data: map[string]string{"db": "other"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "nova", Namespace: "other"}, Data: map[string]string{"db": "mariadb"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cinder", Namespace: "other"}, Data: map[string]string{"db": "mariadb", "namespace": "openstack"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "heat", Namespace: "openstack"}, Data: map[string]string{"selector": "mariadb"}},
+		).
+		Build()
+
+	mapFunc := MapFunc(c, &corev1.ConfigMapList{}, dependenciesOf)
+
+	// by name in the same and in another namespace, and by label selector
+	requests := mapFunc(context.TODO(), getPod("mariadb", corev1.ConditionTrue, map[string]string{"app": "mariadb"}))
+	g.Expect(requests).To(ConsistOf(
+		reconcile.Request{NamespacedName: types.NamespacedName{Name: "keystone", Namespace: "openstack"}},
+		reconcile.Request{NamespacedName: types.NamespacedName{Name: "cinder", Namespace: "other"}},
+		reconcile.Request{NamespacedName: types.NamespacedName{Name: "heat", Namespace: "openstack"}},
+	))
+
+	// other kinds do not match
+	requests = mapFunc(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mariadb", Namespace: "openstack"}})
+	g.Expect(requests).To(BeEmpty())
+}
@@ -23,11 +23,16 @@ import (
 	"time"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pod"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/requeue"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/scheduling"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	appsv1 "k8s.io/api/apps/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -43,6 +48,51 @@ func NewDeployment(
 	}
 }
 
+// SetScheduling - sets the nodeSelector and tolerations of the merged
+// operator defaults and user override on the pod template of the deployment.
+// Must be called before CreateOrPatch.
+func (d *Deployment) SetScheduling(defaults scheduling.Spec, override scheduling.Spec) {
+	scheduling.Apply(&d.deployment.Spec.Template.Spec, defaults, override)
+}
+
+// SetRequeuePolicy - sets the requeue policy which defines the requeue
+// intervals instead of the timeout of the deployment. Once the max attempts
+// of the policy are exceeded while waiting for the deployment, an
+// requeue.ErrMaxAttemptsExceeded error is returned.
+func (d *Deployment) SetRequeuePolicy(p *requeue.Policy) {
+	d.requeuePolicy = p
+}
+
+// SetAdmissionDryRun - if enabled, CreateOrPatch first submits the deployment
+// with server-side dry-run and returns an object.AdmissionError if the
+// admission rejects it, e.g. on an exceeded quota or a policy violation,
+// before anything gets applied
+func (d *Deployment) SetAdmissionDryRun(enabled bool) {
+	d.dryRun = enabled
+}
+
+// SetNormalizeTemplate - if enabled, CreateOrPatch brings the pod template
+// into the form the api server stores it in, see pod.NormalizeTemplate, so
+// that it does not get patched on every reconcile. Enabling it for an
+// existing deployment changes its pod template once, e.g. the order of the
+// env vars and volumes, which triggers a rollout.
+func (d *Deployment) SetNormalizeTemplate(enabled bool) {
+	d.normalize = enabled
+}
+
+// requeueAfter - returns the interval to requeue after for class, the
+// timeout of the deployment if no requeue policy is set
+func (d *Deployment) requeueAfter(class requeue.Class) (time.Duration, error) {
+	if d.requeuePolicy == nil {
+		return d.timeout, nil
+	}
+	return d.requeuePolicy.Next(d.requeueKey(), class)
+}
+
+func (d *Deployment) requeueKey() string {
+	return "Deployment/" + d.deployment.Namespace + "/" + d.deployment.Name
+}
+
 // CreateOrPatch - creates or patches a deployment, reconciles after Xs if object won't exist.
 func (d *Deployment) CreateOrPatch(
 	ctx context.Context,
@@ -55,7 +105,11 @@ func (d *Deployment) CreateOrPatch(
 		},
 	}
 
-	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), deployment, func() error {
+	if d.normalize {
+		pod.NormalizeTemplate(&d.deployment.Spec.Template)
+	}
+
+	mutate := func() error {
 		// Deployment selector is immutable so we set this value only if
 		// a new object is going to be created
 		if deployment.CreationTimestamp.IsZero() {
@@ -63,26 +117,39 @@ func (d *Deployment) CreateOrPatch(
 		}
 		deployment.Annotations = util.MergeStringMaps(deployment.Annotations, d.deployment.Annotations)
 		deployment.Labels = util.MergeStringMaps(deployment.Labels, d.deployment.Labels)
+		h.ApplyPropagatedMetadata(deployment, d.deployment.Labels, d.deployment.Annotations)
 		deployment.Spec.Template = d.deployment.Spec.Template
 		deployment.Spec.Replicas = d.deployment.Spec.Replicas
 		deployment.Spec.Strategy = d.deployment.Spec.Strategy
 
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), deployment, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), deployment, h.GetScheme())
 		if err != nil {
 			return err
 		}
 
 		return nil
-	})
+	}
+	if d.dryRun {
+		err := object.DryRun(ctx, h, deployment, mutate)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), deployment, mutate)
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Deployment %s not found, reconcile in %s", deployment.Name, d.timeout))
-			return ctrl.Result{RequeueAfter: d.timeout}, nil
+			requeueAfter, err := d.requeueAfter(requeue.ClassNotFound)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			h.GetLogger().Info("Deployment not found, reconcile later", "object", deployment.Name, "requeueAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
 		}
 		return ctrl.Result{}, err
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("Deployment %s - %s", deployment.Name, op))
+		h.GetLogger().Info("Deployment reconciled", "object", deployment.Name, "operation", op)
 	}
 
 	// update the deployment object of the deployment type
@@ -94,6 +161,22 @@ func (d *Deployment) CreateOrPatch(
 	return ctrl.Result{}, nil
 }
 
+// GetReplicaReport - returns the replica counts of the Deployment, as of the
+// last CreateOrPatch or WaitForReady, and the details of its pods which are
+// not ready
+func (d *Deployment) GetReplicaReport(
+	ctx context.Context,
+	h *helper.Helper,
+) (*pod.ReplicaReport, error) {
+	return pod.GetReplicaReport(ctx, h, d.deployment.Namespace, d.deployment.Spec.Selector, pod.ReplicaReport{
+		Desired:   ptr.Deref(d.deployment.Spec.Replicas, 1),
+		Current:   d.deployment.Status.Replicas,
+		Ready:     d.deployment.Status.ReadyReplicas,
+		Updated:   d.deployment.Status.UpdatedReplicas,
+		Available: d.deployment.Status.AvailableReplicas,
+	})
+}
+
 // Delete - delete a deployment.
 func (d *Deployment) Delete(
 	ctx context.Context,
@@ -129,6 +212,58 @@ func GetDeploymentWithName(
 	return depl, nil
 }
 
+// WaitForReady - returns a requeue after the timeout of the Deployment
+// while it is not ready. If the rollout made no progress within waitTimeout,
+// measured with the clock of the helper from the last update of the
+// Progressing condition or the creation of the Deployment, an
+// util.ErrWaitTimeout error is returned. Zero waitTimeout waits forever.
+func (d *Deployment) WaitForReady(
+	ctx context.Context,
+	h *helper.Helper,
+	waitTimeout time.Duration,
+) (ctrl.Result, error) {
+	depl, err := GetDeploymentWithName(ctx, h, d.deployment.Name, d.deployment.Namespace)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			requeueAfter, err := d.requeueAfter(requeue.ClassNotFound)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			h.GetLogger().Info("Deployment not found, reconcile later", "object", d.deployment.Name, "requeueAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	d.deployment = depl
+
+	if IsReady(*depl) {
+		if d.requeuePolicy != nil {
+			d.requeuePolicy.Reset(d.requeueKey())
+		}
+		return ctrl.Result{}, nil
+	}
+
+	since := depl.CreationTimestamp.Time
+	for _, c := range depl.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.LastUpdateTime.After(since) {
+			since = c.LastUpdateTime.Time
+		}
+	}
+
+	interval, err := d.requeueAfter(requeue.ClassWaiting)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	requeueAfter, expired := util.RequeueWithDeadline(h.GetClock(), since, waitTimeout, interval)
+	if expired {
+		return ctrl.Result{}, fmt.Errorf("%w: deployment %s made no progress within %s",
+			util.ErrWaitTimeout, depl.Name, waitTimeout)
+	}
+	h.GetLogger().Info("Deployment not ready, reconcile later", "object", depl.Name, "requeueAfter", requeueAfter)
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
 // IsReady - validates when deployment is ready deployed to whats being requested
 // - the requested replicas in the spec matches the ReadyReplicas of the status
 // - the Status.Replicas match Status.ReadyReplicas. if a deployment update is in progress, Replicas > ReadyReplicas
@@ -19,11 +19,15 @@ package deployment
 import (
 	"time"
 
+	"github.com/openstack-k8s-operators/lib-common/modules/common/requeue"
 	appsv1 "k8s.io/api/apps/v1"
 )
 
 // Deployment -
 type Deployment struct {
-	deployment *appsv1.Deployment
-	timeout    time.Duration
+	deployment    *appsv1.Deployment
+	timeout       time.Duration
+	requeuePolicy *requeue.Policy
+	dryRun        bool
+	normalize     bool
 }
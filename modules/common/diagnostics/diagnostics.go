@@ -0,0 +1,351 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diagnostics provides a must-gather style collection of the
+// objects, events, pod logs and conditions of a CR, e.g. to dump context on
+// repeated reconcile failures
+package diagnostics
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// CollectAnnotation - annotation requesting the operator to collect the
+	// diagnostics of the CR, see IsRequested
+	CollectAnnotation = "openstack.org/collect-diagnostics"
+
+	// DefaultLogTailLines - default number of log lines collected per
+	// container
+	DefaultLogTailLines = 200
+	// DefaultLogLimitBytes - default maximum size of the log collected per
+	// container
+	DefaultLogLimitBytes = 256 * 1024
+
+	// RedactedValue - value replacing the data of the collected Secrets
+	RedactedValue = "<redacted>"
+)
+
+// Options - what to collect for a CR
+type Options struct {
+	// Labels - selector of the managed objects and pods of the CR
+	Labels map[string]string
+	// Lists - kinds of the managed objects to collect, e.g.
+	// &appsv1.DeploymentList{}. The data of Secrets is redacted, only
+	// their keys are collected.
+	Lists []client.ObjectList
+	// Conditions - conditions of the CR
+	Conditions condition.Conditions
+	// LogTailLines - number of log lines collected per container,
+	// DefaultLogTailLines if zero
+	LogTailLines int64
+	// LogLimitBytes - maximum size of the log collected per container,
+	// DefaultLogLimitBytes if zero
+	LogLimitBytes int64
+}
+
+// ContainerLog - the recent log of a container
+type ContainerLog struct {
+	Pod       string
+	Container string
+	Log       string
+}
+
+// Bundle - the diagnostics collected for a CR
+type Bundle struct {
+	// CollectedAt - time of the collection
+	CollectedAt time.Time
+	// Object - the CR
+	Object client.Object
+	// Conditions - conditions of the CR, ordered by last transition time
+	Conditions condition.Conditions
+	// Objects - the managed objects of the CR
+	Objects []client.Object
+	// Events - the events of the CR and its managed objects
+	Events []corev1.Event
+	// Logs - recent logs of the containers of the pods of the CR
+	Logs []ContainerLog
+	// Errors - errors hit during the collection, which does not stop on
+	// them to collect as much as possible
+	Errors []string
+}
+
+// IsRequested - returns true if the collection of the diagnostics got
+// requested via the CollectAnnotation on obj
+func IsRequested(obj metav1.Object) bool {
+	_, ok := obj.GetAnnotations()[CollectAnnotation]
+	return ok
+}
+
+// Collect - collects the diagnostics of the CR of the helper. The data of
+// collected Secrets is redacted and the logs are limited to
+// Options.LogTailLines and Options.LogLimitBytes. Failures to
+// collect single items are recorded in the Errors of the bundle, only an
+// error listing the managed objects is returned.
+//
+// Example:
+//
+//	bundle, err := diagnostics.Collect(ctx, h, diagnostics.Options{
+//		Labels:     map[string]string{"service": "keystone"},
+//		Lists:      []client.ObjectList{&appsv1.DeploymentList{}, &corev1.ServiceList{}},
+//		Conditions: instance.Status.Conditions,
+//	})
+func Collect(ctx context.Context, h *helper.Helper, opts Options) (*Bundle, error) {
+	if !h.HasBeforeObject() {
+		return nil, helper.ErrNoBeforeObject
+	}
+	obj := h.GetBefore().DeepCopy()
+	obj.SetGroupVersionKind(h.GetGKV())
+	namespace := obj.GetNamespace()
+
+	b := &Bundle{
+		CollectedAt: h.GetClock().Now(),
+		Object:      obj,
+		Conditions:  opts.Conditions.DeepCopy(),
+	}
+	sort.SliceStable(b.Conditions, func(i, j int) bool {
+		return b.Conditions[i].LastTransitionTime.Before(&b.Conditions[j].LastTransitionTime)
+	})
+
+	uids := map[types.UID]bool{obj.GetUID(): true}
+	for _, l := range opts.Lists {
+		list := l.DeepCopyObject().(client.ObjectList)
+		err := h.GetClient().List(ctx, list, client.InNamespace(namespace), client.MatchingLabels(opts.Labels))
+		if err != nil {
+			return nil, fmt.Errorf("error listing %T: %w", l, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			o, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			// the typed objects returned by List have no kind set
+			if gvk, err := apiutil.GVKForObject(o, h.GetScheme()); err == nil {
+				o.GetObjectKind().SetGroupVersionKind(gvk)
+			}
+			redactSecret(o)
+			b.Objects = append(b.Objects, o)
+			uids[o.GetUID()] = true
+		}
+	}
+
+	if h.GetKClient() == nil {
+		b.Errors = append(b.Errors, "no kubernetes client, events and logs not collected")
+		return b, nil
+	}
+
+	b.collectEvents(ctx, h, namespace, uids)
+	b.collectLogs(ctx, h, namespace, opts)
+
+	h.GetLogger().Info("Diagnostics collected", "objects", len(b.Objects), "events", len(b.Events), "logs", len(b.Logs), "errors", len(b.Errors))
+
+	return b, nil
+}
+
+// redactSecret - replaces the values of the data of o with RedactedValue if
+// it is a Secret, the keys are kept
+func redactSecret(o client.Object) {
+	switch secret := o.(type) {
+	case *corev1.Secret:
+		for k := range secret.Data {
+			secret.Data[k] = []byte(RedactedValue)
+		}
+		for k := range secret.StringData {
+			secret.StringData[k] = RedactedValue
+		}
+	case *unstructured.Unstructured:
+		gvk := secret.GroupVersionKind()
+		if gvk.Group != "" || gvk.Kind != "Secret" {
+			return
+		}
+		for _, field := range []string{"data", "stringData"} {
+			data, found, err := unstructured.NestedMap(secret.Object, field)
+			if err != nil || !found {
+				unstructured.RemoveNestedField(secret.Object, field)
+				continue
+			}
+			for k := range data {
+				data[k] = RedactedValue
+			}
+			_ = unstructured.SetNestedMap(secret.Object, data, field)
+		}
+	default:
+		return
+	}
+	// the last applied configuration of kubectl contains the data as well
+	annotations := o.GetAnnotations()
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		o.SetAnnotations(annotations)
+	}
+}
+
+// collectEvents - collects the events of the objects with the uids, listed
+// per object with a field selector on the involved object
+func (b *Bundle) collectEvents(ctx context.Context, h *helper.Helper, namespace string, uids map[types.UID]bool) {
+	for uid := range uids {
+		events, err := h.GetKClient().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(uid)).String(),
+		})
+		if err != nil {
+			b.Errors = append(b.Errors, fmt.Sprintf("error listing events of %s: %v", uid, err))
+			continue
+		}
+		for _, e := range events.Items {
+			// clients not supporting field selectors return all events
+			if e.InvolvedObject.UID == uid {
+				b.Events = append(b.Events, e)
+			}
+		}
+	}
+	sort.SliceStable(b.Events, func(i, j int) bool {
+		return b.Events[i].LastTimestamp.Before(&b.Events[j].LastTimestamp)
+	})
+}
+
+// collectLogs - collects the recent logs of the containers of the pods
+// matching the labels
+func (b *Bundle) collectLogs(ctx context.Context, h *helper.Helper, namespace string, opts Options) {
+	if len(opts.Labels) == 0 {
+		return
+	}
+	tailLines := opts.LogTailLines
+	if tailLines == 0 {
+		tailLines = DefaultLogTailLines
+	}
+	limitBytes := opts.LogLimitBytes
+	if limitBytes == 0 {
+		limitBytes = DefaultLogLimitBytes
+	}
+
+	pods, err := h.GetKClient().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set(opts.Labels).String(),
+	})
+	if err != nil {
+		b.Errors = append(b.Errors, fmt.Sprintf("error listing pods: %v", err))
+		return
+	}
+
+	for _, pod := range pods.Items {
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, c := range containers {
+			log, err := h.GetKClient().CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container:  c.Name,
+				TailLines:  ptr.To(tailLines),
+				LimitBytes: ptr.To(limitBytes),
+			}).DoRaw(ctx)
+			if err != nil {
+				b.Errors = append(b.Errors, fmt.Sprintf("error getting logs of container %s in pod %s: %v", c.Name, pod.Name, err))
+				continue
+			}
+			b.Logs = append(b.Logs, ContainerLog{Pod: pod.Name, Container: c.Name, Log: string(log)})
+		}
+	}
+}
+
+// WriteTarball - writes the bundle as gzip compressed tarball to w, with
+// the objects, events and conditions as yaml files and the logs as
+// logs/<pod>/<container>.log
+func (b *Bundle) WriteTarball(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	add := func(name string, data []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: b.CollectedAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+	addYAML := func(name string, v interface{}) error {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("error marshalling %s: %w", name, err)
+		}
+		return add(name, data)
+	}
+
+	if b.Object != nil {
+		if err := addYAML(objectPath(b.Object), b.Object); err != nil {
+			return err
+		}
+	}
+	if err := addYAML("conditions.yaml", b.Conditions); err != nil {
+		return err
+	}
+	for _, o := range b.Objects {
+		if err := addYAML(path.Join("objects", objectPath(o)), o); err != nil {
+			return err
+		}
+	}
+	if err := addYAML("events.yaml", b.Events); err != nil {
+		return err
+	}
+	for _, l := range b.Logs {
+		if err := add(path.Join("logs", l.Pod, l.Container+".log"), []byte(l.Log)); err != nil {
+			return err
+		}
+	}
+	if err := addYAML("errors.yaml", b.Errors); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// objectPath - returns <kind>/<name>.yaml, the kind lower case
+func objectPath(o client.Object) string {
+	kind := o.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = "object"
+	}
+	return path.Join(strings.ToLower(kind), o.GetName()+".yaml")
+}
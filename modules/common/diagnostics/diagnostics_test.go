@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCollect(t *testing.T) {
+	g := NewWithT(t)
+
+	labels := map[string]string{"service": "keystone"}
+	// a ConfigMap as stand-in for the CR
+	owner := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "keystone",
+			Namespace:   "openstack",
+			UID:         "keystone-uid",
+			Annotations: map[string]string{CollectAnnotation: ""},
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "keystone-api", Namespace: "openstack", UID: "deployment-uid", Labels: labels},
+	}
+	other := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "glance", Namespace: "openstack", UID: "glance-uid"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keystone-config", Namespace: "openstack", UID: "secret-uid", Labels: labels},
+		Data:       map[string][]byte{"keystone.conf": []byte("password=12345678")},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "keystone-api-0", Namespace: "openstack", Labels: labels},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers:     []corev1.Container{{Name: "keystone-api"}},
+		},
+	}
+	event := func(name string, uid string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "openstack"},
+			InvolvedObject: corev1.ObjectReference{UID: k8stypes.UID(uid)},
+			Reason:         name,
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(deployment, other, secret).Build()
+	kclient := kfake.NewSimpleClientset(pod,
+		event("cr", "keystone-uid"), event("deployment", "deployment-uid"), event("glance", "glance-uid"))
+	h, err := helper.NewHelper(owner, fakeClient, kclient, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(IsRequested(owner)).To(BeTrue())
+
+	conditions := condition.Conditions{
+		{Type: condition.ReadyCondition, LastTransitionTime: metav1.NewTime(time.Unix(20, 0))},
+		{Type: condition.DeploymentReadyCondition, LastTransitionTime: metav1.NewTime(time.Unix(10, 0))},
+	}
+
+	bundle, err := Collect(context.TODO(), h, Options{
+		Labels:     labels,
+		Lists:      []client.ObjectList{&appsv1.DeploymentList{}, &corev1.SecretList{}},
+		Conditions: conditions,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(bundle.Errors).To(BeEmpty())
+	g.Expect(bundle.Object.GetObjectKind().GroupVersionKind().Kind).To(Equal("ConfigMap"))
+	g.Expect(bundle.Conditions[0].Type).To(Equal(condition.DeploymentReadyCondition))
+	g.Expect(bundle.Objects).To(HaveLen(2))
+	g.Expect(bundle.Objects[0].GetName()).To(Equal("keystone-api"))
+	g.Expect(bundle.Objects[1].(*corev1.Secret).Data).To(Equal(map[string][]byte{"keystone.conf": []byte(RedactedValue)}))
+	g.Expect(bundle.Events).To(HaveLen(2))
+	g.Expect(bundle.Logs).To(HaveLen(2))
+
+	eventSelectors := []string{}
+	for _, action := range kclient.Actions() {
+		if action.GetResource().Resource == "events" {
+			eventSelectors = append(eventSelectors, action.(k8stesting.ListAction).GetListRestrictions().Fields.String())
+		}
+	}
+	g.Expect(eventSelectors).To(ConsistOf(
+		"involvedObject.uid=keystone-uid", "involvedObject.uid=deployment-uid", "involvedObject.uid=secret-uid"))
+
+	buf := &bytes.Buffer{}
+	g.Expect(bundle.WriteTarball(buf)).To(Succeed())
+
+	gr, err := gzip.NewReader(buf)
+	g.Expect(err).ToNot(HaveOccurred())
+	tr := tar.NewReader(gr)
+	names := []string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		names = append(names, hdr.Name)
+	}
+	g.Expect(names).To(Equal([]string{
+		"configmap/keystone.yaml",
+		"conditions.yaml",
+		"objects/deployment/keystone-api.yaml",
+		"objects/secret/keystone-config.yaml",
+		"events.yaml",
+		"logs/keystone-api-0/init.log",
+		"logs/keystone-api-0/keystone-api.log",
+		"errors.yaml",
+	}))
+}
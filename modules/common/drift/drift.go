@@ -0,0 +1,220 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift provides the detection, reporting and optional revert of
+// out-of-band changes to the child objects managed by an operator
+package drift
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/metrics"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// ManagedHashAnnotation - annotation holding the hash of the managed
+	// fields of a child object as last written by the operator
+	ManagedHashAnnotation = "openstack.org/managed-hash"
+)
+
+// FieldsFunc - returns the part of the object managed by the operator,
+// e.g. the spec of a Deployment
+type FieldsFunc func(obj client.Object) interface{}
+
+// Resource - a managed child object to check for drift
+type Resource struct {
+	// Object - empty object of the kind with name and namespace set, it
+	// gets filled with the current state
+	Object client.Object
+	// Fields - returns the managed fields of the object
+	Fields FieldsFunc
+	// Revert - optional func which re-applies the desired state, e.g. the
+	// CreateOrPatch of the child object. It gets called if the Detector
+	// remediates drift.
+	Revert func(ctx context.Context, h *helper.Helper) error
+}
+
+// Detector - checks managed child objects for out-of-band changes
+type Detector struct {
+	controller string
+	interval   time.Duration
+	remediate  bool
+	collectors *metrics.Collectors
+}
+
+// NewDetector - returns a Detector which reports the drift of the child
+// objects of the controller in the metrics.DefaultCollectors. The interval
+// is the requeue after which the next check should happen. If remediate is
+// true the out-of-band changes get reverted via Resource.Revert.
+func NewDetector(controller string, interval time.Duration, remediate bool) *Detector {
+	return &Detector{
+		controller: controller,
+		interval:   interval,
+		remediate:  remediate,
+		collectors: metrics.DefaultCollectors,
+	}
+}
+
+// SetCollectors - sets the collectors the drift gets reported in
+func (d *Detector) SetCollectors(c *metrics.Collectors) {
+	d.collectors = c
+}
+
+// GetFieldsHash - returns the hash of the managed fields of obj
+func GetFieldsHash(obj client.Object, fields FieldsFunc) (string, error) {
+	return util.ObjectHash(fields(obj))
+}
+
+// Stamp - sets the ManagedHashAnnotation of obj to the hash of its managed
+// fields. It must be called with the object as returned by the API server
+// after the operator wrote it, e.g. after controllerutil.CreateOrPatch, so
+// that defaulted fields are part of the hash.
+func Stamp(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	fields FieldsFunc,
+) error {
+	hash, err := GetFieldsHash(obj, fields)
+	if err != nil {
+		return err
+	}
+	if obj.GetAnnotations()[ManagedHashAnnotation] == hash {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	obj.SetAnnotations(util.MergeStringMaps(obj.GetAnnotations(), map[string]string{ManagedHashAnnotation: hash}))
+	return h.GetClient().Patch(ctx, obj, patch)
+}
+
+// HasDrifted - returns true if the managed fields of obj changed since it
+// got stamped. Objects without ManagedHashAnnotation are not reported.
+func HasDrifted(obj client.Object, fields FieldsFunc) (bool, error) {
+	stamped, ok := obj.GetAnnotations()[ManagedHashAnnotation]
+	if !ok {
+		return false, nil
+	}
+	hash, err := GetFieldsHash(obj, fields)
+	if err != nil {
+		return false, err
+	}
+	return hash != stamped, nil
+}
+
+// Check - checks the resources for out-of-band changes, reverts them if the
+// Detector remediates drift and reports the drifted resources in the
+// InSyncCondition, False with the drifted resources, True if there is no
+// drift, and the metrics. Missing resources are skipped. The returned result
+// requeues after the check interval.
+func (d *Detector) Check(
+	ctx context.Context,
+	h *helper.Helper,
+	conditions *condition.Conditions,
+	resources ...Resource,
+) (ctrl.Result, error) {
+	drifted := []string{}
+	driftedCount := map[string]int{}
+
+	for _, r := range resources {
+		gvk, err := apiutil.GVKForObject(r.Object, h.GetScheme())
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if _, ok := driftedCount[gvk.Kind]; !ok {
+			driftedCount[gvk.Kind] = 0
+		}
+
+		err = h.GetClient().Get(ctx, client.ObjectKeyFromObject(r.Object), r.Object)
+		if k8s_errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		changed, err := HasDrifted(r.Object, r.Fields)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !changed {
+			continue
+		}
+
+		name := fmt.Sprintf("%s %s", gvk.Kind, r.Object.GetName())
+		if d.remediate && r.Revert != nil {
+			h.GetLogger().Info("Reverting out-of-band changes", "object", name)
+			err = revert(ctx, h, r)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("error reverting out-of-band changes on %s: %w", name, err)
+			}
+			d.collectors.RecordDriftReverted(d.controller, gvk.Kind)
+			continue
+		}
+
+		h.GetLogger().Info("Out-of-band changes detected", "object", name)
+		drifted = append(drifted, name)
+		driftedCount[gvk.Kind]++
+	}
+
+	for kind, count := range driftedCount {
+		d.collectors.SetDriftedCount(d.controller, kind, count)
+	}
+
+	if len(drifted) > 0 {
+		sort.Strings(drifted)
+		conditions.Set(condition.FalseCondition(
+			condition.InSyncCondition,
+			condition.DriftDetectedReason,
+			condition.SeverityWarning,
+			condition.InSyncDriftDetectedMessage,
+			strings.Join(drifted, ", ")))
+	} else {
+		conditions.MarkTrue(condition.InSyncCondition, condition.InSyncMessage)
+	}
+
+	return ctrl.Result{RequeueAfter: d.interval}, nil
+}
+
+// revert - re-applies the desired state of the resource and stamps it
+func revert(
+	ctx context.Context,
+	h *helper.Helper,
+	r Resource,
+) error {
+	err := r.Revert(ctx, h)
+	if err != nil {
+		return err
+	}
+
+	err = h.GetClient().Get(ctx, client.ObjectKeyFromObject(r.Object), r.Object)
+	if err != nil {
+		return err
+	}
+
+	return Stamp(ctx, h, r.Object, r.Fields)
+}
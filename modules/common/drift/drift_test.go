@@ -0,0 +1,126 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func configMapData(obj client.Object) interface{} {
+	return obj.(*corev1.ConfigMap).Data
+}
+
+func gauge(g *WithT, c prometheus.Collector) float64 {
+	m := &dto.Metric{}
+	g.Expect(c.(prometheus.Metric).Write(m)).To(Succeed())
+	if m.Counter != nil {
+		return m.Counter.GetValue()
+	}
+	return m.Gauge.GetValue()
+}
+
+func TestCheck(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone-config",
+			Namespace: "openstack",
+		},
+		Data: map[string]string{"debug": "false"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build()
+	h, err := helper.NewHelper(cm, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	collectors := metrics.NewCollectors()
+	d := NewDetector("keystoneapi", time.Minute, false)
+	d.SetCollectors(collectors)
+
+	resource := func() Resource {
+		return Resource{
+			Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cm.Name, Namespace: cm.Namespace}},
+			Fields: configMapData,
+			Revert: func(ctx context.Context, h *helper.Helper) error {
+				current := &corev1.ConfigMap{}
+				if err := h.GetClient().Get(ctx, client.ObjectKeyFromObject(cm), current); err != nil {
+					return err
+				}
+				current.Data = map[string]string{"debug": "false"}
+				return h.GetClient().Update(ctx, current)
+			},
+		}
+	}
+	conditions := condition.Conditions{}
+
+	// not stamped objects are not reported
+	result, err := d.Check(ctx, h, &conditions, resource())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Minute}))
+	g.Expect(conditions.IsTrue(condition.InSyncCondition)).To(BeTrue())
+
+	g.Expect(Stamp(ctx, h, cm, configMapData)).To(Succeed())
+	g.Expect(cm.Annotations).To(HaveKey(ManagedHashAnnotation))
+
+	_, err = d.Check(ctx, h, &conditions, resource())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(conditions.IsTrue(condition.InSyncCondition)).To(BeTrue())
+
+	// out-of-band edit
+	cm.Data["debug"] = "true"
+	g.Expect(h.GetClient().Update(ctx, cm)).To(Succeed())
+
+	_, err = d.Check(ctx, h, &conditions, resource())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(conditions.IsFalse(condition.InSyncCondition)).To(BeTrue())
+	g.Expect(conditions.Get(condition.InSyncCondition).Reason).To(BeEquivalentTo(condition.DriftDetectedReason))
+	g.Expect(conditions.Get(condition.InSyncCondition).Message).To(
+		Equal("Out-of-band changes detected on ConfigMap keystone-config"))
+	g.Expect(gauge(g, collectors.DriftedResources.WithLabelValues("keystoneapi", "ConfigMap"))).To(Equal(1.0))
+
+	// remediation reverts the edit
+	d = NewDetector("keystoneapi", time.Minute, true)
+	d.SetCollectors(collectors)
+	_, err = d.Check(ctx, h, &conditions, resource())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(conditions.IsTrue(condition.InSyncCondition)).To(BeTrue())
+	g.Expect(gauge(g, collectors.DriftedResources.WithLabelValues("keystoneapi", "ConfigMap"))).To(Equal(0.0))
+	g.Expect(gauge(g, collectors.DriftReverted.WithLabelValues("keystoneapi", "ConfigMap"))).To(Equal(1.0))
+
+	current := &corev1.ConfigMap{}
+	g.Expect(h.GetClient().Get(ctx, client.ObjectKeyFromObject(cm), current)).To(Succeed())
+	g.Expect(current.Data).To(HaveKeyWithValue("debug", "false"))
+	drifted, err := HasDrifted(current, configMapData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(drifted).To(BeFalse())
+}
@@ -20,6 +20,7 @@ package endpoint
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
 	"strings"
 	"time"
@@ -95,6 +96,10 @@ func ExposeEndpoints(
 ) (map[string]string, ctrl.Result, error) {
 	endpointMap := make(map[string]string)
 
+	if !h.HasBeforeObject() {
+		return endpointMap, ctrl.Result{}, helper.ErrNoBeforeObject
+	}
+
 	for endpointType, data := range endpoints {
 
 		endpointName := serviceName + "-" + string(endpointType)
@@ -239,7 +244,7 @@ func ExposeEndpoints(
 
 		// Do not include data.Path in parsing check because %(project_id)s
 		// is invalid without being encoded, but they should not be encoded in the actual endpoint
-		endptURL := fmt.Sprintf("%s://%s:%s", protocol, hostname, port)
+		endptURL := fmt.Sprintf("%s://%s", protocol, net.JoinHostPort(hostname, port))
 		apiEndpoint, err := url.Parse(endptURL)
 		if err != nil {
 			return endpointMap, ctrl.Result{}, err
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+)
+
+// Define static errors
+var (
+	// ErrNoHost indicates that the host source of an endpoint URL template
+	// has no host
+	ErrNoHost = errors.New("no host for endpoint")
+	// ErrInvalidHostSource indicates an unknown host source
+	ErrInvalidHostSource = errors.New("invalid host source")
+)
+
+// HostSource - where the host of an endpoint URL comes from
+type HostSource string
+
+const (
+	// HostSourceRoute - the hostname of the route
+	HostSourceRoute HostSource = "route"
+	// HostSourceService - the hostname of the service
+	HostSourceService HostSource = "service"
+	// HostSourceLoadBalancerIP - the first IP of the LoadBalancer service
+	HostSourceLoadBalancerIP HostSource = "loadBalancerIP"
+)
+
+// Hosts - the hosts an endpoint can be reached on
+type Hosts struct {
+	// Route - hostname of the route
+	Route string
+	// Service - hostname of the service, e.g. keystone-internal.openstack.svc
+	Service string
+	// LoadBalancerIPs - IPs of the LoadBalancer service
+	LoadBalancerIPs []string
+}
+
+// URLTemplate - how to build an endpoint URL
+type URLTemplate struct {
+	// Protocol - scheme of the URL, http if nil
+	Protocol *service.Protocol
+	// Source - where the host comes from
+	Source HostSource
+	// Port - port of the URL, omitted if 0 or the default port of the
+	// protocol
+	Port int32
+	// Path - path appended to the URL as is, e.g. /v3 or
+	// /v2.1/%(project_id)s
+	Path string
+	// Override - user provided URL used instead of the one built from the
+	// template, e.g. the EndpointURL of the service.RoutedOverrideSpec. The
+	// Path gets appended to it as well.
+	Override *string
+}
+
+// URLs - the endpoint URLs of a service to register in keystone
+type URLs struct {
+	Public   string
+	Internal string
+	Admin    string
+}
+
+// Get - returns the URL of the endpoint type
+func (u URLs) Get(endpointType service.Endpoint) string {
+	switch endpointType {
+	case service.EndpointPublic:
+		return u.Public
+	case service.EndpointInternal:
+		return u.Internal
+	case service.EndpointAdmin:
+		return u.Admin
+	}
+	return ""
+}
+
+// Map - returns the URLs which are set by endpoint type, the format used
+// for the endpoints of the keystone registration
+func (u URLs) Map() map[string]string {
+	m := map[string]string{}
+	for _, e := range []service.Endpoint{service.EndpointPublic, service.EndpointInternal, service.EndpointAdmin} {
+		if endpointURL := u.Get(e); endpointURL != "" {
+			m[string(e)] = endpointURL
+		}
+	}
+	return m
+}
+
+// BuildURL - returns the endpoint URL of the template for the hosts. IPv6
+// addresses get enclosed in brackets.
+func BuildURL(t URLTemplate, hosts Hosts) (string, error) {
+	if t.Override != nil && *t.Override != "" {
+		u, err := url.Parse(*t.Override)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(u.String(), "/") + t.Path, nil
+	}
+
+	var host string
+	switch t.Source {
+	case HostSourceRoute:
+		host = hosts.Route
+	case HostSourceService:
+		host = hosts.Service
+	case HostSourceLoadBalancerIP:
+		if len(hosts.LoadBalancerIPs) > 0 {
+			host = hosts.LoadBalancerIPs[0]
+		}
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidHostSource, t.Source)
+	}
+	if host == "" {
+		return "", fmt.Errorf("%w: %s", ErrNoHost, t.Source)
+	}
+
+	protocol := service.ProtocolHTTP
+	if t.Protocol != nil {
+		protocol = *t.Protocol
+	}
+	switch {
+	case t.Port == 0,
+		protocol == service.ProtocolHTTP && t.Port == 80,
+		protocol == service.ProtocolHTTPS && t.Port == 443:
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+	default:
+		host = net.JoinHostPort(host, strconv.Itoa(int(t.Port)))
+	}
+
+	// Do not include the path in parsing check because %(project_id)s
+	// is invalid without being encoded, but they should not be encoded in the actual endpoint
+	u, err := url.Parse(service.EndptProtocol(&protocol) + host)
+	if err != nil {
+		return "", err
+	}
+	return u.String() + t.Path, nil
+}
+
+// BuildURLs - returns the URLs of the endpoint types with a template
+//
+// Example:
+//
+//	urls, err := endpoint.BuildURLs(
+//		map[service.Endpoint]endpoint.URLTemplate{
+//			service.EndpointPublic:   {Source: endpoint.HostSourceRoute, Protocol: &https, Path: "/v3", Override: override.EndpointURL},
+//			service.EndpointInternal: {Source: endpoint.HostSourceService, Port: 5000, Path: "/v3"},
+//		},
+//		map[service.Endpoint]endpoint.Hosts{
+//			service.EndpointPublic:   {Route: route.GetHostname()},
+//			service.EndpointInternal: {Service: svc.GetServiceHostname()},
+//		})
+func BuildURLs(templates map[service.Endpoint]URLTemplate, hosts map[service.Endpoint]Hosts) (URLs, error) {
+	urls := URLs{}
+	for endpointType, t := range templates {
+		u, err := BuildURL(t, hosts[endpointType])
+		if err != nil {
+			return URLs{}, fmt.Errorf("%s endpoint: %w", endpointType, err)
+		}
+		switch endpointType {
+		case service.EndpointPublic:
+			urls.Public = u
+		case service.EndpointInternal:
+			urls.Internal = u
+		case service.EndpointAdmin:
+			urls.Admin = u
+		default:
+			return URLs{}, fmt.Errorf("%w: %s", util.ErrInvalidEndpoint, endpointType)
+		}
+	}
+	return urls, nil
+}
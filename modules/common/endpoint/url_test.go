@@ -0,0 +1,104 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildURL(t *testing.T) {
+	https := service.ProtocolHTTPS
+	hosts := Hosts{
+		Route:           "keystone-public-openstack.apps.example.com",
+		Service:         "keystone-internal.openstack.svc",
+		LoadBalancerIPs: []string{"fd00:bbbb::80", "172.17.0.80"},
+	}
+
+	tests := []struct {
+		name     string
+		template URLTemplate
+		want     string
+	}{
+		{
+			name:     "Route with default https port",
+			template: URLTemplate{Source: HostSourceRoute, Protocol: &https, Port: 443, Path: "/v3"},
+			want:     "https://keystone-public-openstack.apps.example.com/v3",
+		},
+		{
+			name:     "Service with port",
+			template: URLTemplate{Source: HostSourceService, Port: 5000, Path: "/v2.1/%(project_id)s"},
+			want:     "http://keystone-internal.openstack.svc:5000/v2.1/%(project_id)s",
+		},
+		{
+			name:     "IPv6 LoadBalancer IP with port",
+			template: URLTemplate{Source: HostSourceLoadBalancerIP, Port: 5000},
+			want:     "http://[fd00:bbbb::80]:5000",
+		},
+		{
+			name:     "IPv6 LoadBalancer IP without port",
+			template: URLTemplate{Source: HostSourceLoadBalancerIP},
+			want:     "http://[fd00:bbbb::80]",
+		},
+		{
+			name:     "Override",
+			template: URLTemplate{Source: HostSourceRoute, Path: "/v3", Override: ptr.To("https://keystone.example.com/")},
+			want:     "https://keystone.example.com/v3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			u, err := BuildURL(tt.template, hosts)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(u).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestBuildURLs(t *testing.T) {
+	g := NewWithT(t)
+
+	urls, err := BuildURLs(
+		map[service.Endpoint]URLTemplate{
+			service.EndpointPublic:   {Source: HostSourceRoute},
+			service.EndpointInternal: {Source: HostSourceService, Port: 5000},
+		},
+		map[service.Endpoint]Hosts{
+			service.EndpointPublic:   {Route: "keystone.example.com"},
+			service.EndpointInternal: {Service: "keystone-internal.openstack.svc"},
+		})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(urls).To(Equal(URLs{
+		Public:   "http://keystone.example.com",
+		Internal: "http://keystone-internal.openstack.svc:5000",
+	}))
+	g.Expect(urls.Map()).To(Equal(map[string]string{
+		"public":   "http://keystone.example.com",
+		"internal": "http://keystone-internal.openstack.svc:5000",
+	}))
+
+	_, err = BuildURLs(
+		map[service.Endpoint]URLTemplate{service.EndpointPublic: {Source: HostSourceRoute}},
+		map[service.Endpoint]Hosts{})
+	g.Expect(err).To(MatchError(ErrNoHost))
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events provides a rate limited wrapper of the kubernetes event
+// recorder
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultInterval - default minimal interval between two events with the
+	// same reason for the same object
+	DefaultInterval = 5 * time.Minute
+)
+
+type eventKey struct {
+	uid       string
+	namespace string
+	name      string
+	eventType string
+	reason    string
+}
+
+// Recorder - wraps a record.EventRecorder and drops events which have the
+// same object, type and reason as an event emitted less than the interval
+// ago. This prevents event spam from a reconcile loop stuck retrying.
+type Recorder struct {
+	recorder record.EventRecorder
+	interval time.Duration
+	clock    clock.PassiveClock
+
+	lock sync.Mutex
+	last map[eventKey]time.Time
+}
+
+// NewRecorder - returns a Recorder emitting events with the same reason for
+// the same object at most once per interval, DefaultInterval if zero.
+//
+// Example:
+//
+//	recorder := events.NewRecorder(mgr.GetEventRecorderFor("keystone-controller"), 0)
+func NewRecorder(recorder record.EventRecorder, interval time.Duration) *Recorder {
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+	return &Recorder{
+		recorder: recorder,
+		interval: interval,
+		clock:    clock.RealClock{},
+		last:     map[eventKey]time.Time{},
+	}
+}
+
+// SetClock - sets the clock used for the rate limiting
+func (r *Recorder) SetClock(c clock.PassiveClock) {
+	r.clock = c
+}
+
+// allow - returns true if the event is not rate limited and records it
+func (r *Recorder) allow(obj client.Object, eventType string, reason string) bool {
+	key := eventKey{
+		uid:       string(obj.GetUID()),
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+		eventType: eventType,
+		reason:    reason,
+	}
+	now := r.clock.Now()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if last, ok := r.last[key]; ok && now.Sub(last) < r.interval {
+		return false
+	}
+
+	// forget expired entries to not grow forever
+	for k, last := range r.last {
+		if now.Sub(last) >= r.interval {
+			delete(r.last, k)
+		}
+	}
+	r.last[key] = now
+
+	return true
+}
+
+// Event - emits the event unless rate limited. Returns true if the event
+// got emitted.
+func (r *Recorder) Event(obj client.Object, eventType string, reason string, message string) bool {
+	if !r.allow(obj, eventType, reason) {
+		return false
+	}
+	r.recorder.Event(obj, eventType, reason, message)
+	return true
+}
+
+// Eventf - emits the event with a fmt formatted message unless rate
+// limited. Returns true if the event got emitted.
+func (r *Recorder) Eventf(
+	obj client.Object,
+	eventType string,
+	reason string,
+	messageFmt string,
+	args ...interface{},
+) bool {
+	return r.Event(obj, eventType, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// EventTemplate - emits the event with the message rendered from the go
+// template and data, using the functions of util.ExecuteTemplateData,
+// unless rate limited. Returns true if the event got emitted.
+//
+// Example:
+//
+//	_, err := recorder.EventTemplate(instance, corev1.EventTypeNormal, "DatabaseCreated",
+//		"Database {{ .Name }} created on {{ .Host }}", map[string]string{"Name": "keystone", "Host": "openstack"})
+func (r *Recorder) EventTemplate(
+	obj client.Object,
+	eventType string,
+	reason string,
+	messageTemplate string,
+	data interface{},
+) (bool, error) {
+	message, err := util.ExecuteTemplateData(messageTemplate, data)
+	if err != nil {
+		return false, err
+	}
+	return r.Event(obj, eventType, reason, message), nil
+}
+
+// EmitForCondition - emits an event reflecting the condition, unless rate
+// limited: a Warning for a False condition with Warning or Error severity,
+// a Normal event otherwise. The reason is the condition type followed by
+// the condition reason, e.g. "DBReadyError", so that a condition stuck in
+// the same state only produces one event per interval. Unknown conditions
+// are not emitted. Returns true if the event got emitted.
+func (r *Recorder) EmitForCondition(obj client.Object, c *condition.Condition) bool {
+	if c == nil || c.Status == corev1.ConditionUnknown {
+		return false
+	}
+
+	eventType := corev1.EventTypeNormal
+	if c.Status == corev1.ConditionFalse &&
+		(c.Severity == condition.SeverityError || c.Severity == condition.SeverityWarning) {
+		eventType = corev1.EventTypeWarning
+	}
+
+	return r.Event(obj, eventType, string(c.Type)+string(c.Reason), c.Message)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func getObj(name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openstack",
+		},
+	}
+}
+
+func setup() (*Recorder, *record.FakeRecorder, *clocktesting.FakePassiveClock) {
+	fake := record.NewFakeRecorder(10)
+	clk := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := NewRecorder(fake, time.Minute)
+	r.SetClock(clk)
+	return r, fake, clk
+}
+
+func TestEventRateLimit(t *testing.T) {
+	g := NewWithT(t)
+	r, fake, clk := setup()
+	obj := getObj("foo")
+
+	g.Expect(r.Event(obj, corev1.EventTypeWarning, "Failed", "first")).To(BeTrue())
+	g.Expect(fake.Events).To(Receive(Equal("Warning Failed first")))
+
+	// same reason within the interval is dropped
+	g.Expect(r.Eventf(obj, corev1.EventTypeWarning, "Failed", "attempt %d", 2)).To(BeFalse())
+	g.Expect(fake.Events).ToNot(Receive())
+
+	// other reason, type or object is emitted
+	g.Expect(r.Event(obj, corev1.EventTypeWarning, "Other", "other")).To(BeTrue())
+	g.Expect(r.Event(obj, corev1.EventTypeNormal, "Failed", "normal")).To(BeTrue())
+	g.Expect(r.Event(getObj("bar"), corev1.EventTypeWarning, "Failed", "bar")).To(BeTrue())
+	g.Expect(fake.Events).To(HaveLen(3))
+
+	// after the interval the reason is emitted again
+	clk.SetTime(clk.Now().Add(time.Minute))
+	g.Expect(r.Eventf(obj, corev1.EventTypeWarning, "Failed", "attempt %d", 3)).To(BeTrue())
+}
+
+func TestEventTemplate(t *testing.T) {
+	g := NewWithT(t)
+	r, fake, _ := setup()
+
+	emitted, err := r.EventTemplate(getObj("foo"), corev1.EventTypeNormal, "Created",
+		"Database {{ .Name }} created", map[string]string{"Name": "keystone"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(emitted).To(BeTrue())
+	g.Expect(fake.Events).To(Receive(Equal("Normal Created Database keystone created")))
+
+	_, err = r.EventTemplate(getObj("foo"), corev1.EventTypeNormal, "Created",
+		"Database {{ .Missing }} created", map[string]string{"Name": "keystone"})
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestEmitForCondition(t *testing.T) {
+	g := NewWithT(t)
+	r, fake, _ := setup()
+	obj := getObj("foo")
+
+	c := condition.FalseCondition(
+		condition.DBReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		condition.DBReadyErrorMessage,
+		"connection refused")
+	g.Expect(r.EmitForCondition(obj, c)).To(BeTrue())
+	g.Expect(fake.Events).To(Receive(Equal("Warning DBReadyError DB create job error occurred connection refused")))
+
+	// stuck in the same state
+	g.Expect(r.EmitForCondition(obj, c)).To(BeFalse())
+
+	c = condition.FalseCondition(
+		condition.DBReadyCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		condition.DBReadyRunningMessage)
+	g.Expect(r.EmitForCondition(obj, c)).To(BeTrue())
+	g.Expect(fake.Events).To(Receive(HavePrefix("Normal DBReadyRequested")))
+
+	g.Expect(r.EmitForCondition(obj, condition.UnknownCondition(
+		condition.DBReadyCondition, condition.InitReason, condition.InitReason))).To(BeFalse())
+	g.Expect(r.EmitForCondition(obj, nil)).To(BeFalse())
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externaldns provides the public DNS records of the endpoints of
+// the OpenStack APIs for external-dns, either as annotations of the routes
+// and LoadBalancer services or as DNSEndpoint objects
+package externaldns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	ocp_config "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	common_net "github.com/openstack-k8s-operators/lib-common/modules/common/net"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// HostnameAnnotation - annotation with the comma separated host names
+	// external-dns creates the records of a route or service for
+	HostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	// TTLAnnotation - annotation with the TTL of the records in seconds
+	TTLAnnotation = "external-dns.alpha.kubernetes.io/ttl"
+	// TargetAnnotation - annotation with the comma separated targets of the
+	// records, overriding the ones external-dns detects
+	TargetAnnotation = "external-dns.alpha.kubernetes.io/target"
+
+	// RecordTypeA - record of IPv4 targets
+	RecordTypeA = "A"
+	// RecordTypeAAAA - record of IPv6 targets
+	RecordTypeAAAA = "AAAA"
+	// RecordTypeCNAME - record of a host name target
+	RecordTypeCNAME = "CNAME"
+)
+
+// DNSEndpointGVK - the DNSEndpoint kind of the external-dns CRD source
+var DNSEndpointGVK = schema.GroupVersionKind{
+	Group:   "externaldns.k8s.io",
+	Version: "v1alpha1",
+	Kind:    "DNSEndpoint",
+}
+
+// Record - the public DNS records of an endpoint
+type Record struct {
+	// Hostnames - the host names of the endpoint, e.g. keystone.example.com
+	Hostnames []string
+	// Targets - IPs or a single host name the host names resolve to. For
+	// the annotations, the ones external-dns detects from the route or
+	// service if empty.
+	Targets []string
+	// TTL - TTL of the records in seconds, the default of the DNS provider
+	// if zero
+	TTL int64
+}
+
+// Validate - validates the host names are DNS subdomains within one of the
+// zones of the cluster, see GetClusterZones, and the targets are IPs or a
+// single host name. Only the syntax of the host names is validated if zones
+// is empty.
+func (r Record) Validate(basePath *field.Path, zones []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(r.Hostnames) == 0 {
+		allErrs = append(allErrs, field.Required(basePath.Child("hostnames"), "at least one host name is required"))
+	}
+	for i, hostname := range r.Hostnames {
+		path := basePath.Child("hostnames").Index(i)
+		hostname = strings.TrimSuffix(hostname, ".")
+		for _, msg := range validation.IsDNS1123Subdomain(hostname) {
+			allErrs = append(allErrs, field.Invalid(path, r.Hostnames[i], msg))
+		}
+		if len(zones) > 0 && !inZones(hostname, zones) {
+			allErrs = append(allErrs, field.Invalid(path, r.Hostnames[i],
+				fmt.Sprintf("must be within the DNS zones of the cluster: %s", strings.Join(zones, ", "))))
+		}
+	}
+
+	hostnameTargets := 0
+	for i, target := range r.Targets {
+		if _, err := common_net.GetIPFamily(target); err == nil {
+			continue
+		}
+		hostnameTargets++
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(target, ".")) {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("targets").Index(i), target, msg))
+		}
+	}
+	if hostnameTargets > 0 && len(r.Targets) > 1 {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("targets"), r.Targets,
+			"must be IPs or a single host name"))
+	}
+
+	if r.TTL < 0 {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("ttl"), r.TTL, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// inZones - returns true if hostname is one of the zones or a subdomain of
+// one of them
+func inZones(hostname string, zones []string) bool {
+	hostname = strings.ToLower(hostname)
+	for _, zone := range zones {
+		zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+		if hostname == zone || strings.HasSuffix(hostname, "."+zone) {
+			return true
+		}
+	}
+	return false
+}
+
+// Annotations - returns the external-dns annotations of the record, to be
+// added to the route or LoadBalancer service of the endpoint
+//
+// Example:
+//
+//	record := externaldns.Record{Hostnames: []string{instance.Spec.PublicHostname}, TTL: 300}
+//	routeOverride.AddAnnotation(record.Annotations())
+func (r Record) Annotations() map[string]string {
+	annotations := map[string]string{
+		HostnameAnnotation: strings.Join(r.Hostnames, ","),
+	}
+	if len(r.Targets) > 0 {
+		annotations[TargetAnnotation] = strings.Join(r.Targets, ",")
+	}
+	if r.TTL > 0 {
+		annotations[TTLAnnotation] = strconv.FormatInt(r.TTL, 10)
+	}
+	return annotations
+}
+
+// endpoints - returns the endpoints of the DNSEndpoint spec, per host name
+// one per record type of the targets
+func (r Record) endpoints() []interface{} {
+	targets := map[string][]interface{}{}
+	for _, target := range r.Targets {
+		recordType := RecordTypeCNAME
+		if family, err := common_net.GetIPFamily(target); err == nil {
+			recordType = RecordTypeA
+			if family == corev1.IPv6Protocol {
+				recordType = RecordTypeAAAA
+			}
+		}
+		targets[recordType] = append(targets[recordType], target)
+	}
+	recordTypes := []string{}
+	for recordType := range targets {
+		recordTypes = append(recordTypes, recordType)
+	}
+	sort.Strings(recordTypes)
+
+	endpoints := []interface{}{}
+	for _, hostname := range r.Hostnames {
+		for _, recordType := range recordTypes {
+			endpoint := map[string]interface{}{
+				"dnsName":    hostname,
+				"recordType": recordType,
+				"targets":    targets[recordType],
+			}
+			if r.TTL > 0 {
+				endpoint["recordTTL"] = r.TTL
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// EnsureDNSEndpoint - creates or patches the DNSEndpoint name with the
+// records, owned by the CR of the helper. The DNSEndpoint CRD of
+// external-dns must be installed.
+//
+// Example:
+//
+//	record := externaldns.Record{
+//		Hostnames: []string{instance.Spec.PublicHostname},
+//		Targets:   externaldns.GetRouteTargets(rt.GetRoute()),
+//	}
+//	err := externaldns.EnsureDNSEndpoint(ctx, helper, "keystone-public", record)
+func EnsureDNSEndpoint(ctx context.Context, h *helper.Helper, name string, r Record) error {
+	errs := r.Validate(field.NewPath("record"), nil)
+	if len(r.Targets) == 0 {
+		errs = append(errs, field.Required(field.NewPath("record", "targets"), "targets are required"))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid DNSEndpoint %s: %w", name, errs.ToAggregate())
+	}
+	if !h.HasBeforeObject() {
+		return helper.ErrNoBeforeObject
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(DNSEndpointGVK)
+	obj.SetName(name)
+	obj.SetNamespace(h.GetBeforeObject().GetNamespace())
+
+	_, err := object.CreateOrPatch(ctx, h, obj, func() error {
+		err := unstructured.SetNestedSlice(obj.Object, r.endpoints(), "spec", "endpoints")
+		if err != nil {
+			return err
+		}
+		return object.SetControllerReference(h.GetBeforeObject(), obj, h.GetScheme())
+	})
+	if err != nil {
+		return fmt.Errorf("error creating or patching DNSEndpoint %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetClusterZones - returns the DNS zones of the cluster public host names
+// must be within, the base domain of the OpenShift DNS config. None are
+// returned if the cluster has no DNS config, e.g. MicroShift.
+func GetClusterZones(ctx context.Context, h *helper.Helper) ([]string, error) {
+	dnsConfig := &ocp_config.DNS{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: "cluster"}, dnsConfig)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	if dnsConfig.Spec.BaseDomain == "" {
+		return []string{}, nil
+	}
+	return []string{dnsConfig.Spec.BaseDomain}, nil
+}
+
+// GetRouteTargets - returns the canonical host name of the router which
+// admitted the route, the target of its records. A CNAME record has a
+// single target, so if several routers admitted the route the first one
+// in the status which admitted it is used. None is returned if no router
+// admitted the route yet.
+func GetRouteTargets(rt *routev1.Route) []string {
+	for _, ingress := range rt.Status.Ingress {
+		if ingress.RouterCanonicalHostname == "" {
+			continue
+		}
+		for _, c := range ingress.Conditions {
+			if c.Type == routev1.RouteAdmitted && c.Status == corev1.ConditionTrue {
+				return []string{ingress.RouterCanonicalHostname}
+			}
+		}
+	}
+	return []string{}
+}
+
+// GetServiceTargets - returns the load balancer IPs or host names of the
+// LoadBalancer service, the targets of its records
+func GetServiceTargets(svc *corev1.Service) []string {
+	targets := []string{}
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			targets = append(targets, ingress.IP)
+		} else if ingress.Hostname != "" {
+			targets = append(targets, ingress.Hostname)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldns
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	ocp_config "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func setupHelper(g *WithT, funcs interceptor.Funcs, objs ...client.Object) *helper.Helper {
+	g.Expect(ocp_config.AddToScheme(scheme.Scheme)).To(Succeed())
+	cr := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", UID: "1234"}}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(append(objs, cr)...).
+		WithInterceptorFuncs(funcs).
+		Build()
+	h, err := helper.NewHelper(cr, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	return h
+}
+
+func TestValidate(t *testing.T) {
+	zones := []string{"example.com."}
+	tests := []struct {
+		name   string
+		record Record
+		errors []string
+	}{
+		{name: "in zone", record: Record{Hostnames: []string{"keystone.openstack.example.com"}, TTL: 300}},
+		{name: "zone apex", record: Record{Hostnames: []string{"example.com."}}},
+		{name: "IP targets", record: Record{Hostnames: []string{"keystone.example.com"}, Targets: []string{"192.0.2.10", "2001:db8::10"}}},
+		{name: "host name target", record: Record{Hostnames: []string{"keystone.example.com"}, Targets: []string{"router-default.apps.example.com"}}},
+		{name: "no host names", record: Record{}, errors: []string{"spec.dns.hostnames"}},
+		{name: "outside zone", record: Record{Hostnames: []string{"keystone.example.org", "keystone.notexample.com"}},
+			errors: []string{"spec.dns.hostnames[0]", "spec.dns.hostnames[1]"}},
+		{name: "invalid host name", record: Record{Hostnames: []string{"*.example.com"}}, errors: []string{"spec.dns.hostnames[0]"}},
+		{name: "mixed targets", record: Record{Hostnames: []string{"keystone.example.com"}, Targets: []string{"192.0.2.10", "lb.example.com"}},
+			errors: []string{"spec.dns.targets"}},
+		{name: "negative TTL", record: Record{Hostnames: []string{"keystone.example.com"}, TTL: -1}, errors: []string{"spec.dns.ttl"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fields := []string{}
+			for _, e := range tt.record.Validate(field.NewPath("spec", "dns"), zones) {
+				fields = append(fields, e.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.errors))
+		})
+	}
+
+	// without zones only the syntax is validated
+	g := NewWithT(t)
+	g.Expect(Record{Hostnames: []string{"keystone.example.org"}}.Validate(field.NewPath("spec"), nil)).To(BeEmpty())
+}
+
+func TestAnnotations(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Record{Hostnames: []string{"keystone.example.com"}}.Annotations()).To(Equal(map[string]string{
+		HostnameAnnotation: "keystone.example.com",
+	}))
+	g.Expect(Record{
+		Hostnames: []string{"keystone.example.com", "identity.example.com"},
+		Targets:   []string{"192.0.2.10"},
+		TTL:       300,
+	}.Annotations()).To(Equal(map[string]string{
+		HostnameAnnotation: "keystone.example.com,identity.example.com",
+		TargetAnnotation:   "192.0.2.10",
+		TTLAnnotation:      "300",
+	}))
+}
+
+func TestEnsureDNSEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	h := setupHelper(g, interceptor.Funcs{})
+
+	record := Record{
+		Hostnames: []string{"keystone.example.com"},
+		Targets:   []string{"2001:db8::10", "192.0.2.10"},
+		TTL:       300,
+	}
+	g.Expect(EnsureDNSEndpoint(ctx, h, "keystone-public", record)).To(Succeed())
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(DNSEndpointGVK)
+	g.Expect(h.GetClient().Get(ctx, client.ObjectKey{Name: "keystone-public", Namespace: "openstack"}, obj)).To(Succeed())
+	g.Expect(obj.GetOwnerReferences()).To(HaveLen(1))
+	endpoints, _, err := unstructured.NestedSlice(obj.Object, "spec", "endpoints")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(endpoints).To(Equal([]interface{}{
+		map[string]interface{}{
+			"dnsName": "keystone.example.com", "recordType": "A", "recordTTL": int64(300),
+			"targets": []interface{}{"192.0.2.10"},
+		},
+		map[string]interface{}{
+			"dnsName": "keystone.example.com", "recordType": "AAAA", "recordTTL": int64(300),
+			"targets": []interface{}{"2001:db8::10"},
+		},
+	}))
+
+	// patched with the new targets
+	record.Targets = []string{"router-default.apps.example.com"}
+	record.TTL = 0
+	g.Expect(EnsureDNSEndpoint(ctx, h, "keystone-public", record)).To(Succeed())
+	g.Expect(h.GetClient().Get(ctx, client.ObjectKey{Name: "keystone-public", Namespace: "openstack"}, obj)).To(Succeed())
+	endpoints, _, err = unstructured.NestedSlice(obj.Object, "spec", "endpoints")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(endpoints).To(Equal([]interface{}{
+		map[string]interface{}{
+			"dnsName": "keystone.example.com", "recordType": "CNAME",
+			"targets": []interface{}{"router-default.apps.example.com"},
+		},
+	}))
+
+	g.Expect(EnsureDNSEndpoint(ctx, h, "keystone-public", Record{Hostnames: []string{"keystone.example.com"}})).ToNot(Succeed())
+}
+
+func TestGetClusterZones(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	h := setupHelper(g, interceptor.Funcs{}, &ocp_config.DNS{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       ocp_config.DNSSpec{BaseDomain: "ocp.example.com"},
+	})
+	zones, err := GetClusterZones(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(zones).To(Equal([]string{"ocp.example.com"}))
+
+	// MicroShift
+	h = setupHelper(g, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*ocp_config.DNS); ok {
+				return &meta.NoKindMatchError{
+					GroupKind:        schema.GroupKind{Group: "config.openshift.io", Kind: "DNS"},
+					SearchedVersions: []string{"v1"},
+				}
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	})
+	zones, err = GetClusterZones(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(zones).To(BeEmpty())
+}
+
+func TestTargets(t *testing.T) {
+	g := NewWithT(t)
+
+	admitted := []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: corev1.ConditionTrue}}
+	rt := &routev1.Route{Status: routev1.RouteStatus{Ingress: []routev1.RouteIngress{
+		{},
+		{RouterCanonicalHostname: "router-sharded.apps.example.com"},
+		{RouterCanonicalHostname: "router-default.apps.example.com", Conditions: admitted},
+		{RouterCanonicalHostname: "router-internal.apps.example.com", Conditions: admitted},
+	}}}
+	g.Expect(GetRouteTargets(rt)).To(Equal([]string{"router-default.apps.example.com"}))
+
+	rt.Status.Ingress = rt.Status.Ingress[:2]
+	g.Expect(GetRouteTargets(rt)).To(BeEmpty())
+
+	svc := &corev1.Service{}
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "192.0.2.10"}, {Hostname: "lb.example.com"}}
+	g.Expect(GetServiceTargets(svc)).To(Equal([]string{"192.0.2.10", "lb.example.com"}))
+}
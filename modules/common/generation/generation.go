@@ -0,0 +1,82 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package generation provides helpers to publish and check the generation
+// of a CR observed by its controller
+package generation
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObservedGenerationAnnotation - annotation holding the observed generation
+// of CRs whose CRD has no status.observedGeneration field
+const ObservedGenerationAnnotation = "openstack.org/observed-generation"
+
+// SetObserved - sets observedGeneration to the generation of obj, to be
+// called when the controller starts to process the current spec of obj
+//
+// Example:
+//
+//	generation.SetObserved(instance, &instance.Status.ObservedGeneration)
+func SetObserved(obj client.Object, observedGeneration *int64) {
+	*observedGeneration = obj.GetGeneration()
+}
+
+// SetObservedAnnotation - sets the ObservedGenerationAnnotation of obj to
+// its generation, for CRs whose CRD has no status.observedGeneration field
+func SetObservedAnnotation(obj client.Object) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ObservedGenerationAnnotation] = strconv.FormatInt(obj.GetGeneration(), 10)
+	obj.SetAnnotations(annotations)
+}
+
+// GetObserved - returns the observed generation of obj published in
+// status.observedGeneration or the ObservedGenerationAnnotation. Returns
+// false if obj publishes none.
+func GetObserved(obj *unstructured.Unstructured) (int64, bool) {
+	observed, found, err := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if err == nil && found {
+		return observed, true
+	}
+
+	if v, ok := obj.GetAnnotations()[ObservedGenerationAnnotation]; ok {
+		observed, err := strconv.ParseInt(v, 10, 64)
+		if err == nil {
+			return observed, true
+		}
+	}
+
+	return 0, false
+}
+
+// IsObserved - returns true if the controller of obj observed its current
+// generation, i.e. its status reflects the current spec. The second return
+// value is false if obj publishes no observed generation, in which case
+// the status can not be trusted to be current.
+func IsObserved(obj *unstructured.Unstructured) (bool, bool) {
+	observed, ok := GetObserved(obj)
+	if !ok {
+		return false, false
+	}
+	return observed >= obj.GetGeneration(), true
+}
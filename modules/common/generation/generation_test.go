@@ -0,0 +1,55 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generation
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestObserved(t *testing.T) {
+	g := NewWithT(t)
+
+	depl := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Generation: 2}}
+	SetObserved(depl, &depl.Status.ObservedGeneration)
+	g.Expect(depl.Status.ObservedGeneration).To(Equal(int64(2)))
+
+	// status.observedGeneration
+	u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetGeneration(3)
+	observed, known := IsObserved(u)
+	g.Expect(known).To(BeFalse())
+	g.Expect(observed).To(BeFalse())
+
+	g.Expect(unstructured.SetNestedField(u.Object, int64(2), "status", "observedGeneration")).To(Succeed())
+	observed, known = IsObserved(u)
+	g.Expect(known).To(BeTrue())
+	g.Expect(observed).To(BeFalse())
+
+	// annotation of CRs without status.observedGeneration
+	u = &unstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetGeneration(3)
+	SetObservedAnnotation(u)
+	g.Expect(u.GetAnnotations()).To(HaveKeyWithValue(ObservedGenerationAnnotation, "3"))
+	observed, known = IsObserved(u)
+	g.Expect(known).To(BeTrue())
+	g.Expect(observed).To(BeTrue())
+}
@@ -20,6 +20,7 @@ package helper
 import (
 	"context"
 	"encoding/json"
+	"sort"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -36,9 +38,23 @@ import (
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 )
 
+const (
+	// PropagatedLabelsAnnotation - annotation on the children listing the
+	// keys of the labels propagated to them, see ApplyPropagatedMetadata
+	PropagatedLabelsAnnotation = "openstack.org/propagated-labels"
+	// PropagatedAnnotationsAnnotation - annotation on the children listing
+	// the keys of the annotations propagated to them
+	PropagatedAnnotationsAnnotation = "openstack.org/propagated-annotations"
+)
+
+// ErrNoBeforeObject - returned by the functions which need the object the
+// helper was created for, when it was created without one
+var ErrNoBeforeObject = errors.New("helper has no before object")
+
 // Helper is a utility for ensuring the proper patching of objects.
 type Helper struct {
 	client       client.Client
+	reader       client.Reader
 	kclient      kubernetes.Interface
 	gvk          schema.GroupVersionKind
 	scheme       *runtime.Scheme
@@ -47,12 +63,24 @@ type Helper struct {
 	after        *unstructured.Unstructured
 	changes      map[string]bool
 	finalizer    string
+	fieldManager string
+
+	propagatedLabels      map[string]string
+	propagatedAnnotations map[string]string
+
+	clock clock.PassiveClock
 
 	logger logr.Logger
 }
 
-// NewHelper returns an initialized Helper.
+// NewHelper returns an initialized Helper. The kind of obj is added to the
+// key-values of the logger. Its namespace, name and the reconcile ID are
+// not, the logger of the reconcile, log.FromContext(ctx), already has them.
 func NewHelper(obj client.Object, crClient client.Client, kclient kubernetes.Interface, scheme *runtime.Scheme, log logr.Logger) (*Helper, error) {
+	if obj == nil {
+		return nil, ErrNoBeforeObject
+	}
+
 	// Get the GroupVersionKind of the object,
 	// used to validate against later on.
 	gvk, err := apiutil.GVKForObject(obj, crClient.Scheme())
@@ -73,16 +101,60 @@ func NewHelper(obj client.Object, crClient client.Client, kclient kubernetes.Int
 		scheme:       scheme,
 		before:       unstructuredObj,
 		beforeObject: obj.DeepCopyObject().(client.Object),
-		logger:       log,
+		logger:       log.WithValues("kind", gvk.Kind),
 		finalizer:    strings.ToLower("openstack.org/" + gvk.Kind),
 	}, nil
 }
 
+// NewHelperWithoutObject returns a Helper which is not bound to a CR, e.g.
+// for admission webhooks and background workers. The name is added to the
+// logger and used for the finalizer. GetBeforeObject returns nil, the
+// modules which need the CR, e.g. to set it as owner of the objects they
+// create, as well as SetAfter and PatchInstance return ErrNoBeforeObject.
+func NewHelperWithoutObject(name string, crClient client.Client, kclient kubernetes.Interface, scheme *runtime.Scheme, log logr.Logger) *Helper {
+	return &Helper{
+		client:    crClient,
+		kclient:   kclient,
+		scheme:    scheme,
+		logger:    log.WithName(name),
+		finalizer: strings.ToLower("openstack.org/" + name),
+	}
+}
+
+// HasBeforeObject - returns true if the helper was created for an object
+func (h *Helper) HasBeforeObject() bool {
+	return h.beforeObject != nil
+}
+
 // GetClient - returns the client
 func (h *Helper) GetClient() client.Client {
 	return h.client
 }
 
+// SetReader - sets the reader the read-only lookups of the lib-common
+// modules, e.g. of the TLS secrets and the NetworkAttachmentDefinitions,
+// go through instead of the client. Set it to the cache of the manager to
+// serve them from the informers rather than the API server, which matters
+// in large clusters when the client of the helper reads uncached. Objects
+// created in the same reconcile might not be in the cache yet. List calls
+// by field need an index, see Indexes.
+//
+// Example:
+//
+//	h.SetReader(mgr.GetCache())
+func (h *Helper) SetReader(reader client.Reader) {
+	h.reader = reader
+}
+
+// GetReader - returns the reader for read-only lookups, the client if none
+// was set
+func (h *Helper) GetReader() client.Reader {
+	if h.reader == nil {
+		return h.client
+	}
+	return h.reader
+}
+
 // GetKClient - returns the kclient
 func (h *Helper) GetKClient() kubernetes.Interface {
 	return h.kclient
@@ -113,7 +185,8 @@ func (h *Helper) GetChanges() map[string]bool {
 	return h.changes
 }
 
-// GetBeforeObject - returns the object before modification
+// GetBeforeObject - returns the object before modification, nil if the
+// helper was created via NewHelperWithoutObject
 func (h *Helper) GetBeforeObject() client.Object {
 	return h.beforeObject
 }
@@ -123,13 +196,172 @@ func (h *Helper) GetLogger() logr.Logger {
 	return h.logger
 }
 
+// WithLogValues - returns a copy of the helper which adds the key-values
+// to its log lines, e.g. to scope them to a step of the reconcile. The copy
+// shares the before object with h.
+//
+// Example:
+//
+//	dbSyncHelper := h.WithLogValues("step", "db-sync")
+//	ctrlResult, err := dbSyncJob.DoJob(ctx, dbSyncHelper)
+func (h *Helper) WithLogValues(keysAndValues ...interface{}) *Helper {
+	c := *h
+	c.logger = h.logger.WithValues(keysAndValues...)
+	return &c
+}
+
 // GetFinalizer - returns the finalizer
 func (h *Helper) GetFinalizer() string {
 	return h.finalizer
 }
 
+// SetFieldManager - sets the field manager name used by SSAApply. Operators
+// co-managing shared objects must use distinct names.
+func (h *Helper) SetFieldManager(fieldManager string) {
+	h.fieldManager = fieldManager
+}
+
+// GetFieldManager - returns the field manager name, the finalizer if none
+// was set
+func (h *Helper) GetFieldManager() string {
+	if h.fieldManager == "" {
+		return h.finalizer
+	}
+	return h.fieldManager
+}
+
+// SSAApply - applies obj via server-side apply with the field manager of the
+// helper. obj must only hold the fields the operator manages, fields it
+// held in a previous apply and no longer holds get removed. Conflicts with
+// other field managers are forced. On success obj holds the object as
+// returned by the API server. opts are added to the patch, e.g.
+// client.DryRunAll.
+func (h *Helper) SSAApply(ctx context.Context, obj client.Object, opts ...client.PatchOption) error {
+	gvk, err := apiutil.GVKForObject(obj, h.client.Scheme())
+	if err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+
+	opts = append([]client.PatchOption{client.FieldOwner(h.GetFieldManager()), client.ForceOwnership}, opts...)
+	return h.client.Patch(ctx, obj, client.Apply, opts...)
+}
+
+// SetPropagatedMetadata - sets the labels and annotations which get added
+// to the children created via the lib-common modules, e.g. the result of a
+// labels.PropagationPolicy applied to the CR, see ApplyPropagatedMetadata.
+// Labels and annotations set explicitly on a child take precedence.
+func (h *Helper) SetPropagatedMetadata(labels map[string]string, annotations map[string]string) {
+	h.propagatedLabels = labels
+	h.propagatedAnnotations = annotations
+}
+
+// GetPropagatedLabels - returns the labels to add to children
+func (h *Helper) GetPropagatedLabels() map[string]string {
+	return h.propagatedLabels
+}
+
+// GetPropagatedAnnotations - returns the annotations to add to children
+func (h *Helper) GetPropagatedAnnotations() map[string]string {
+	return h.propagatedAnnotations
+}
+
+// ApplyPropagatedMetadata - sets the propagated labels and annotations on
+// the child obj, replacing the values it has, so that changes on the CR
+// reach existing children. The propagated keys are tracked in the
+// PropagatedLabelsAnnotation and PropagatedAnnotationsAnnotation of obj, to
+// remove the ones no longer propagated. The explicitLabels and
+// explicitAnnotations the module sets on obj take precedence.
+//
+// Example:
+//
+//	deployment.Labels = util.MergeStringMaps(deployment.Labels, d.deployment.Labels)
+//	deployment.Annotations = util.MergeStringMaps(deployment.Annotations, d.deployment.Annotations)
+//	h.ApplyPropagatedMetadata(deployment, d.deployment.Labels, d.deployment.Annotations)
+func (h *Helper) ApplyPropagatedMetadata(obj client.Object, explicitLabels map[string]string, explicitAnnotations map[string]string) {
+	current := obj.GetAnnotations()
+	labels, labelKeys := applyPropagated(
+		obj.GetLabels(), h.propagatedLabels, explicitLabels, current[PropagatedLabelsAnnotation])
+	annotations, annotationKeys := applyPropagated(
+		current, h.propagatedAnnotations, explicitAnnotations, current[PropagatedAnnotationsAnnotation])
+
+	for annotation, keys := range map[string]string{
+		PropagatedLabelsAnnotation:      labelKeys,
+		PropagatedAnnotationsAnnotation: annotationKeys,
+	} {
+		if keys == "" {
+			delete(annotations, annotation)
+		} else {
+			annotations[annotation] = keys
+		}
+	}
+
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+}
+
+// applyPropagated - returns a copy of current without the previously
+// propagated keys which are no longer propagated and with the propagated
+// ones, except the explicit keys, and the sorted comma separated list of
+// the propagated keys
+func applyPropagated(
+	current map[string]string,
+	propagated map[string]string,
+	explicit map[string]string,
+	previous string,
+) (map[string]string, string) {
+	result := make(map[string]string, len(current)+len(propagated))
+	for k, v := range current {
+		result[k] = v
+	}
+
+	for _, k := range strings.Split(previous, ",") {
+		if _, ok := propagated[k]; ok {
+			continue
+		}
+		if _, ok := explicit[k]; ok {
+			continue
+		}
+		delete(result, k)
+	}
+
+	keys := []string{}
+	for k, v := range propagated {
+		if _, ok := explicit[k]; ok {
+			continue
+		}
+		result[k] = v
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return result, strings.Join(keys, ",")
+}
+
+// SetClock - sets the clock the wait and timeout logic of the lib-common
+// modules measures elapsed time with. Tests can set a fake clock, e.g.
+// k8s.io/utils/clock/testing.FakePassiveClock, to check the requeue and
+// expiry handling without waiting.
+func (h *Helper) SetClock(c clock.PassiveClock) {
+	h.clock = c
+}
+
+// GetClock - returns the clock, the real clock if none was set
+func (h *Helper) GetClock() clock.PassiveClock {
+	if h.clock == nil {
+		return clock.RealClock{}
+	}
+	return h.clock
+}
+
 // SetAfter - returns the logger
 func (h *Helper) SetAfter(obj client.Object) error {
+	if h.beforeObject == nil {
+		return ErrNoBeforeObject
+	}
+
 	unstructuredObj, err := ToUnstructured(obj)
 	if err != nil {
 		return err
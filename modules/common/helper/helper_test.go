@@ -17,13 +17,23 @@ limitations under the License.
 package helper
 
 import (
+	"context"
 	"testing"
 
+	"github.com/go-logr/logr/funcr"
 	. "github.com/onsi/gomega" // nolint:revive
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestToUnstructured(t *testing.T) {
@@ -85,3 +95,160 @@ func TestToUnstructured(t *testing.T) {
 		g.Expect(obj.GetName()).To(Equal("keystone"))
 	})
 }
+
+func TestNewHelperWithoutObject(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h := NewHelperWithoutObject("keystone-webhook", fakeClient, nil, scheme.Scheme, ctrl.Log)
+
+	g.Expect(h.HasBeforeObject()).To(BeFalse())
+	g.Expect(h.GetBeforeObject()).To(BeNil())
+	g.Expect(h.GetClient()).To(Equal(fakeClient))
+	g.Expect(h.GetScheme()).To(Equal(scheme.Scheme))
+	g.Expect(h.GetFinalizer()).To(Equal("openstack.org/keystone-webhook"))
+
+	obj := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}}
+	g.Expect(h.SetAfter(obj)).To(MatchError(ErrNoBeforeObject))
+	g.Expect(h.PatchInstance(context.TODO(), obj)).To(MatchError(ErrNoBeforeObject))
+
+	_, err := NewHelper(nil, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).To(MatchError(ErrNoBeforeObject))
+}
+
+func TestLogValues(t *testing.T) {
+	g := NewWithT(t)
+
+	lines := []string{}
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{})
+
+	obj := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h, err := NewHelper(obj, fakeClient, nil, scheme.Scheme, logger)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	h.GetLogger().Info("reconcile")
+	h.WithLogValues("step", "db-sync").GetLogger().Info("job")
+	h.GetLogger().Info("done")
+
+	g.Expect(lines).To(HaveLen(3))
+	g.Expect(lines[0]).To(ContainSubstring(`"kind"="Deployment"`))
+	// set by controller-runtime on the logger of the reconcile
+	g.Expect(lines[0]).ToNot(ContainSubstring(`"namespace"`))
+	g.Expect(lines[1]).To(ContainSubstring(`"step"="db-sync"`))
+	g.Expect(lines[2]).ToNot(ContainSubstring(`"step"`))
+}
+
+func TestSSAApply(t *testing.T) {
+	g := NewWithT(t)
+
+	var patchType types.PatchType
+	var fieldManager string
+	var force bool
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchType = patch.Type()
+				po := &client.PatchOptions{}
+				po.ApplyOptions(opts)
+				fieldManager = po.FieldManager
+				force = ptr.Deref(po.Force, false)
+				g.Expect(obj.GetObjectKind().GroupVersionKind().Kind).To(Equal("Service"))
+				return nil
+			},
+		}).
+		Build()
+
+	owner := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}}
+	h, err := NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h.GetFieldManager()).To(Equal("openstack.org/deployment"))
+
+	h.SetFieldManager("keystone-operator")
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", ResourceVersion: "1"}}
+	g.Expect(h.SSAApply(context.TODO(), svc)).To(Succeed())
+	g.Expect(patchType).To(Equal(types.ApplyPatchType))
+	g.Expect(fieldManager).To(Equal("keystone-operator"))
+	g.Expect(force).To(BeTrue())
+	g.Expect(svc.ResourceVersion).To(BeEmpty())
+}
+
+type recordingIndexer struct {
+	fields []string
+}
+
+func (i *recordingIndexer) IndexField(_ context.Context, _ client.Object, field string, _ client.IndexerFunc) error {
+	i.fields = append(i.fields, field)
+	return nil
+}
+
+func TestReader(t *testing.T) {
+	g := NewWithT(t)
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", UID: "owner-uid"}}
+	child := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "keystone-db-sync",
+		Namespace:       "openstack",
+		OwnerReferences: []metav1.OwnerReference{{Name: "keystone", UID: "owner-uid"}},
+	}}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "openstack"}}
+
+	index := OwnerUIDIndex(&corev1.Pod{})
+	apiClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	cache := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+		WithIndex(index.Object, index.Field, index.Extract).
+		WithObjects(child, other).
+		Build()
+
+	h, err := NewHelper(owner, apiClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(h.GetReader()).To(BeIdenticalTo(apiClient))
+
+	h.SetReader(cache)
+	pods := &corev1.PodList{}
+	err = h.GetReader().List(context.TODO(), pods, client.MatchingFields{OwnerUIDField: "owner-uid"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pods.Items).To(HaveLen(1))
+	g.Expect(pods.Items[0].Name).To(Equal("keystone-db-sync"))
+
+	indexer := &recordingIndexer{}
+	err = Indexes{index, {Object: &corev1.Pod{}, Field: ".spec.nodeName"}}.Register(context.TODO(), indexer)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(indexer.fields).To(Equal([]string{OwnerUIDField, ".spec.nodeName"}))
+}
+
+func TestApplyPropagatedMetadata(t *testing.T) {
+	g := NewWithT(t)
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}}
+	h, err := NewHelper(owner, fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	child := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{"team": "old", "app": "keystone"},
+	}}
+	h.SetPropagatedMetadata(
+		map[string]string{"team": "identity", "cost-center": "42", "app": "other"},
+		map[string]string{"example.com/contact": "ops"})
+	h.ApplyPropagatedMetadata(child, map[string]string{"app": "keystone"}, nil)
+	g.Expect(child.Labels).To(Equal(map[string]string{"team": "identity", "cost-center": "42", "app": "keystone"}))
+	g.Expect(child.Annotations).To(Equal(map[string]string{
+		"example.com/contact":           "ops",
+		PropagatedLabelsAnnotation:      "cost-center,team",
+		PropagatedAnnotationsAnnotation: "example.com/contact",
+	}))
+
+	// keys removed from the CR get pruned
+	h.SetPropagatedMetadata(map[string]string{"team": "identity"}, nil)
+	h.ApplyPropagatedMetadata(child, map[string]string{"app": "keystone"}, nil)
+	g.Expect(child.Labels).To(Equal(map[string]string{"team": "identity", "app": "keystone"}))
+	g.Expect(child.Annotations).To(Equal(map[string]string{PropagatedLabelsAnnotation: "team"}))
+
+	h.SetPropagatedMetadata(nil, nil)
+	h.ApplyPropagatedMetadata(child, nil, nil)
+	g.Expect(child.Labels).To(Equal(map[string]string{"app": "keystone"}))
+	g.Expect(child.Annotations).To(BeEmpty())
+}
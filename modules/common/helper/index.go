@@ -0,0 +1,87 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OwnerUIDField - name of the field index of the UIDs of the owners of an
+// object, see OwnerUIDIndex
+const OwnerUIDField = ".metadata.ownerReferences.uid"
+
+// Index - a field index of the cache of the manager, which allows List
+// calls through the reader of the helper to select by the field via
+// client.MatchingFields
+type Index struct {
+	// Object - the indexed kind, e.g. &corev1.Secret{}
+	Object client.Object
+	// Field - name of the index, e.g. ".spec.nodeName"
+	Field string
+	// Extract - returns the values obj gets indexed by
+	Extract client.IndexerFunc
+}
+
+// Indexes - the field indexes a controller lists by
+type Indexes []Index
+
+// Register - registers the indexes with the indexer, e.g. the field
+// indexer of the manager, before the cache is started
+//
+// Example:
+//
+//	var keystoneIndexes = helper.Indexes{
+//		helper.OwnerUIDIndex(&batchv1.Job{}),
+//		{Object: &corev1.Pod{}, Field: ".spec.nodeName", Extract: func(obj client.Object) []string {
+//			return []string{obj.(*corev1.Pod).Spec.NodeName}
+//		}},
+//	}
+//
+//	func (r *KeystoneAPIReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+//		err := keystoneIndexes.Register(ctx, mgr.GetFieldIndexer())
+//		...
+//	}
+func (i Indexes) Register(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, index := range i {
+		err := indexer.IndexField(ctx, index.Object, index.Field, index.Extract)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OwnerUIDIndex - returns the index of the objects of the kind obj by the
+// UIDs of their owners, e.g. to list the children of a CR from the cache:
+//
+//	err := h.GetReader().List(ctx, jobs, client.InNamespace(ns),
+//		client.MatchingFields{helper.OwnerUIDField: string(instance.GetUID())})
+func OwnerUIDIndex(obj client.Object) Index {
+	return Index{
+		Object: obj,
+		Field:  OwnerUIDField,
+		Extract: func(o client.Object) []string {
+			uids := []string{}
+			for _, ref := range o.GetOwnerReferences() {
+				uids = append(uids, string(ref.UID))
+			}
+			return uids
+		},
+	}
+}
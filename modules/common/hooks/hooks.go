@@ -0,0 +1,249 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hooks provides lifecycle hooks to run jobs or functions before the
+// deletion of a CR completes or before a version upgrade proceeds
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/job"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NewRegistry - returns an empty Registry. The timeout is the requeue
+// interval while a hook is running.
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{
+		hooks:   []Hook{},
+		timeout: timeout,
+	}
+}
+
+// Register - adds the hook. Hooks of the same Point run in the order they
+// got registered, a hook only starts after the previous one completed.
+func (r *Registry) Register(hook Hook) error {
+	if hook.Name == "" || (hook.Point != PreDelete && hook.Point != PreUpgrade) {
+		return fmt.Errorf("%w: name %q point %q", ErrInvalidHook, hook.Name, hook.Point)
+	}
+	if (hook.Job == nil) == (hook.Func == nil) {
+		return fmt.Errorf("%w: hook %s must have either a Job or a Func", ErrInvalidHook, hook.Name)
+	}
+	for _, existing := range r.hooks {
+		if existing.Point == hook.Point && existing.Name == hook.Name {
+			return fmt.Errorf("%w: %s %s", ErrDuplicateHook, hook.Point, hook.Name)
+		}
+	}
+	r.hooks = append(r.hooks, hook)
+
+	return nil
+}
+
+// GetHooks - returns the hooks registered for the point
+func (r *Registry) GetHooks(point Point) []Hook {
+	hooks := []Hook{}
+	for _, hook := range r.hooks {
+		if hook.Point == point {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// GetConditionType - returns the condition type of the hook, e.g.
+// PreDeleteHookDBBackupReady
+func GetConditionType(hook Hook) condition.Type {
+	return condition.Type(fmt.Sprintf("%sHook%sReady", hook.Point, hook.Name))
+}
+
+// GetHashKey - returns the key the hash of the completed hook is stored
+// with in the hashes map passed to Run
+func GetHashKey(hook Hook) string {
+	return fmt.Sprintf("%s-%s", strings.ToLower(string(hook.Point)), strings.ToLower(hook.Name))
+}
+
+// InitConditions - returns the init conditions of the hooks registered for
+// the point, to be added to the condition list of the CR
+func (r *Registry) InitConditions(point Point) condition.Conditions {
+	cl := condition.Conditions{}
+	for _, hook := range r.GetHooks(point) {
+		cl = append(cl, *condition.UnknownCondition(
+			GetConditionType(hook),
+			condition.InitReason,
+			condition.HookReadyInitMessage,
+			hook.Name))
+	}
+	return cl
+}
+
+// Run - runs the hooks registered for the point one after the other. A hook
+// is only run if the hash of the version and the hook definition differs
+// from the one stored in hashes, which makes the hooks idempotent as long as
+// the caller persists hashes, e.g. in the CR status. hashes must not be
+// nil. The hash of a completed
+// hook gets set in hashes. A requeue is requested while a hook is running.
+// The progress of each hook is reported in its own condition.
+func (r *Registry) Run(
+	ctx context.Context,
+	h *helper.Helper,
+	point Point,
+	version string,
+	hashes map[string]string,
+	conditions *condition.Conditions,
+) (ctrl.Result, error) {
+	for _, hook := range r.GetHooks(point) {
+		ctrlResult, err := r.runHook(ctx, h, hook, version, hashes)
+		if err != nil {
+			conditions.Set(condition.FalseCondition(
+				GetConditionType(hook),
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.HookReadyErrorMessage,
+				hook.Name,
+				err.Error()))
+			return ctrl.Result{}, fmt.Errorf("%s hook %s: %w", point, hook.Name, err)
+		}
+		if (ctrlResult != ctrl.Result{}) {
+			conditions.Set(condition.FalseCondition(
+				GetConditionType(hook),
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.HookReadyRunningMessage,
+				hook.Name))
+			return ctrlResult, nil
+		}
+		conditions.MarkTrue(GetConditionType(hook), condition.HookReadyMessage, hook.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// RunPreDelete - runs the PreDelete hooks of the deleted obj and removes
+// the HookFinalizer from it when all of them completed. The caller must
+// only remove its own finalizer once RunPreDelete returned an empty result
+// and no error.
+func (r *Registry) RunPreDelete(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	hashes map[string]string,
+	conditions *condition.Conditions,
+) (ctrl.Result, error) {
+	// the UID as version runs the hooks again for a re-created CR
+	ctrlResult, err := r.Run(ctx, h, PreDelete, string(obj.GetUID()), hashes, conditions)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, err
+	}
+
+	return ctrl.Result{}, object.RemoveConsumerFinalizer(ctx, h, obj, HookFinalizer)
+}
+
+// RunPreUpgrade - runs the PreUpgrade hooks for the target version. The
+// caller must only proceed with the upgrade once RunPreUpgrade returned an
+// empty result and no error.
+func (r *Registry) RunPreUpgrade(
+	ctx context.Context,
+	h *helper.Helper,
+	targetVersion string,
+	hashes map[string]string,
+	conditions *condition.Conditions,
+) (ctrl.Result, error) {
+	return r.Run(ctx, h, PreUpgrade, targetVersion, hashes, conditions)
+}
+
+// EnsureFinalizer - adds the HookFinalizer to obj if PreDelete hooks are
+// registered, or removes it if not. Must be called before obj gets deleted,
+// e.g. together with adding the finalizer of the operator.
+func (r *Registry) EnsureFinalizer(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+) error {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	if len(r.GetHooks(PreDelete)) == 0 {
+		if controllerutil.ContainsFinalizer(obj, HookFinalizer) {
+			return object.RemoveConsumerFinalizer(ctx, h, obj, HookFinalizer)
+		}
+		return nil
+	}
+	return object.AddConsumerFinalizer(ctx, h, obj, HookFinalizer)
+}
+
+// runHook - runs a single hook if its hash changed, returns an empty result
+// when the hook completed
+func (r *Registry) runHook(
+	ctx context.Context,
+	h *helper.Helper,
+	hook Hook,
+	version string,
+	hashes map[string]string,
+) (ctrl.Result, error) {
+	var definition interface{} = hook.Input
+	if hook.Job != nil {
+		// same as the job module only the pod spec defines what to run
+		definition = hook.Job.Spec.Template.Spec
+	}
+	hash, err := util.ObjectHash(struct {
+		Version    string
+		Definition interface{}
+	}{version, definition})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error calculating hook hash: %w", err)
+	}
+
+	key := GetHashKey(hook)
+	if hashes[key] == hash {
+		return ctrl.Result{}, nil
+	}
+
+	if hook.Func != nil {
+		done, err := hook.Func(ctx, h)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !done {
+			h.GetLogger().Info("Hook running, reconcile later", "point", hook.Point, "hook", hook.Name, "requeueAfter", r.timeout)
+			return ctrl.Result{RequeueAfter: r.timeout}, nil
+		}
+	} else {
+		// an empty before hash makes DoJob create or wait for the job
+		hookJob := job.NewJob(hook.Job, hook.Name, false, r.timeout, "")
+		ctrlResult, err := hookJob.DoJob(ctx, h)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, nil
+		}
+	}
+
+	h.GetLogger().Info("Hook completed", "point", hook.Point, "hook", hook.Name)
+	hashes[key] = hash
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var errHook = errors.New("hook failed")
+
+func setupHelper(g *WithT) (*helper.Helper, *corev1.ConfigMap) {
+	cr := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone",
+			Namespace: "openstack",
+			UID:       "1234",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cr).Build()
+
+	h, err := helper.NewHelper(cr, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	return h, cr
+}
+
+func TestRegister(t *testing.T) {
+	g := NewWithT(t)
+	r := NewRegistry(time.Second)
+
+	noop := func(_ context.Context, _ *helper.Helper) (bool, error) { return true, nil }
+
+	g.Expect(r.Register(Hook{Name: "DBBackup", Point: PreDelete, Func: noop})).To(Succeed())
+	g.Expect(r.Register(Hook{Name: "DBBackup", Point: PreUpgrade, Func: noop})).To(Succeed())
+	g.Expect(r.Register(Hook{Name: "DBBackup", Point: PreDelete, Func: noop})).To(MatchError(ErrDuplicateHook))
+	g.Expect(r.Register(Hook{Name: "NoAction", Point: PreDelete})).To(MatchError(ErrInvalidHook))
+	g.Expect(r.Register(Hook{Name: "Both", Point: PreDelete, Func: noop, Job: &batchv1.Job{}})).To(MatchError(ErrInvalidHook))
+	g.Expect(r.Register(Hook{Name: "NoPoint", Func: noop})).To(MatchError(ErrInvalidHook))
+
+	g.Expect(r.GetHooks(PreDelete)).To(HaveLen(1))
+	g.Expect(r.InitConditions(PreUpgrade)).To(HaveLen(1))
+	g.Expect(r.InitConditions(PreUpgrade)[0].Type).To(Equal(condition.Type("PreUpgradeHookDBBackupReady")))
+}
+
+func TestRunFunc(t *testing.T) {
+	g := NewWithT(t)
+	h, _ := setupHelper(g)
+
+	calls := 0
+	done := false
+	var hookErr error
+	r := NewRegistry(time.Second)
+	g.Expect(r.Register(Hook{
+		Name:  "Migration",
+		Point: PreUpgrade,
+		Func: func(_ context.Context, _ *helper.Helper) (bool, error) {
+			calls++
+			return done, hookErr
+		},
+	})).To(Succeed())
+
+	hashes := map[string]string{}
+	conditions := condition.Conditions{}
+	conditions.Init(&condition.Conditions{})
+	conditionType := condition.Type("PreUpgradeHookMigrationReady")
+
+	hookErr = errHook
+	_, err := r.RunPreUpgrade(context.TODO(), h, "1.1", hashes, &conditions)
+	g.Expect(err).To(MatchError(errHook))
+	g.Expect(conditions.Get(conditionType).Reason).To(Equal(condition.Reason(condition.ErrorReason)))
+
+	hookErr = nil
+	result, err := r.RunPreUpgrade(context.TODO(), h, "1.1", hashes, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+	g.Expect(conditions.Get(conditionType).Reason).To(Equal(condition.Reason(condition.RequestedReason)))
+	g.Expect(hashes).To(BeEmpty())
+
+	done = true
+	result, err = r.RunPreUpgrade(context.TODO(), h, "1.1", hashes, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(conditions.IsTrue(conditionType)).To(BeTrue())
+	g.Expect(hashes).To(HaveKey("preupgrade-migration"))
+	g.Expect(calls).To(Equal(3))
+
+	// the same version does not run the hook again
+	_, err = r.RunPreUpgrade(context.TODO(), h, "1.1", hashes, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(calls).To(Equal(3))
+
+	// a new version does
+	_, err = r.RunPreUpgrade(context.TODO(), h, "1.2", hashes, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(calls).To(Equal(4))
+}
+
+func TestRunPreDeleteJob(t *testing.T) {
+	g := NewWithT(t)
+	h, cr := setupHelper(g)
+	ctx := context.TODO()
+
+	backup := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone-db-backup",
+			Namespace: "openstack",
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "backup", Image: "mariadb"}},
+				},
+			},
+		},
+	}
+	r := NewRegistry(time.Second)
+	g.Expect(r.Register(Hook{Name: "DBBackup", Point: PreDelete, Job: backup})).To(Succeed())
+
+	g.Expect(r.EnsureFinalizer(ctx, h, cr)).To(Succeed())
+	g.Expect(cr.Finalizers).To(ContainElement(HookFinalizer))
+
+	hashes := map[string]string{}
+	conditions := condition.Conditions{}
+
+	// the job gets created
+	result, err := r.RunPreDelete(ctx, h, cr, hashes, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+	g.Expect(cr.Finalizers).To(ContainElement(HookFinalizer))
+
+	job := &batchv1.Job{}
+	g.Expect(h.GetClient().Get(ctx, client.ObjectKeyFromObject(backup), job)).To(Succeed())
+	job.Status.Succeeded = 1
+	g.Expect(h.GetClient().Status().Update(ctx, job)).To(Succeed())
+
+	result, err = r.RunPreDelete(ctx, h, cr, hashes, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(conditions.IsTrue("PreDeleteHookDBBackupReady")).To(BeTrue())
+	g.Expect(hashes).To(HaveKey("predelete-dbbackup"))
+
+	updated := &corev1.ConfigMap{}
+	g.Expect(h.GetClient().Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, updated)).To(Succeed())
+	g.Expect(updated.Finalizers).ToNot(ContainElement(HookFinalizer))
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// Point - lifecycle point a hook runs at
+type Point string
+
+const (
+	// PreDelete - hooks run when the CR got deleted, before its finalizer
+	// gets removed, e.g. to backup the database
+	PreDelete Point = "PreDelete"
+	// PreUpgrade - hooks run before the deployment of a new version
+	// proceeds, e.g. online data migrations
+	PreUpgrade Point = "PreUpgrade"
+
+	// HookFinalizer - finalizer which blocks the deletion of the CR until
+	// its PreDelete hooks completed
+	HookFinalizer = "openstack.org/pre-delete-hooks"
+)
+
+// Func - function run as hook. It returns true when the hook completed,
+// false to get called again after the requeue timeout.
+type Func func(ctx context.Context, h *helper.Helper) (bool, error)
+
+// Hook - a job or function to run at a lifecycle point. Exactly one of Job
+// and Func must be set.
+type Hook struct {
+	// Name - name of the hook, unique per Point. It is used in the condition
+	// type of the hook and should be CamelCase, e.g. DBBackup.
+	Name string
+	// Point - lifecycle point the hook runs at
+	Point Point
+	// Job - job to run. The job name should include the version for
+	// PreUpgrade hooks, as a finished job with the same pod template counts
+	// as completed.
+	Job *batchv1.Job
+	// Func - function to run
+	Func Func
+	// Input - data the result of a Func hook depends on. A change of its
+	// hash re-runs the hook.
+	Input interface{}
+}
+
+// Registry - ordered set of lifecycle hooks
+type Registry struct {
+	hooks   []Hook
+	timeout time.Duration
+}
+
+// Define static errors
+var (
+	// ErrInvalidHook indicates that the hook definition is incomplete
+	ErrInvalidHook = errors.New("invalid hook")
+	// ErrDuplicateHook indicates that a hook with the same name is already registered for the point
+	ErrDuplicateHook = errors.New("duplicate hook")
+)
@@ -0,0 +1,309 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpd provides the rendering of the Apache httpd vhost and
+// mod_wsgi config of the OpenStack API services
+package httpd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+)
+
+const (
+	// DefaultTimeout - default request timeout of a vhost in seconds, the
+	// httpd default
+	DefaultTimeout = 60
+	// DefaultBindAddress - default address a vhost listens on, all
+	// addresses
+	DefaultBindAddress = "*"
+	// DefaultUser - default user of the WSGI daemon processes
+	DefaultUser = "apache"
+	// DefaultLog - default target of the error and access log, collected
+	// from the container output
+	DefaultLog = "/dev/stdout"
+)
+
+// Define static errors
+var (
+	// ErrInvalidConfig indicates a vhost config httpd can not use
+	ErrInvalidConfig = errors.New("invalid httpd config")
+)
+
+// TLS - the TLS material of a vhost
+type TLS struct {
+	// CertFile - path of the cert
+	CertFile string
+	// KeyFile - path of the key
+	KeyFile string
+	// CAFile - path of the CA cert to validate client certs, optional
+	CAFile string
+}
+
+// TLSFromService - returns the TLS material of the vhost at the paths the
+// tls module mounts the cert secret of s to, see tls.Service
+// CreateVolumeMounts
+func TLSFromService(s *tls.Service, serviceID string) *TLS {
+	t := &TLS{
+		CertFile: s.CertMountPath(serviceID),
+		KeyFile:  s.KeyMountPath(serviceID),
+	}
+	if s.CaMount != nil {
+		t.CAFile = *s.CaMount
+	}
+	return t
+}
+
+// WSGI - the mod_wsgi daemon serving the application of a vhost
+type WSGI struct {
+	// ProcessGroup - name of the daemon process group, e.g. keystone-public
+	ProcessGroup string
+	// Script - path of the WSGI script, e.g. /usr/bin/keystone-wsgi-public
+	Script string
+	// Path - URL path the application is served at, / if empty
+	Path string
+	// User - user of the daemon processes, DefaultUser if empty
+	User string
+	// Group - group of the daemon processes, User if empty
+	Group string
+	// Processes - number of daemon processes, 1 if zero
+	Processes int
+	// Threads - number of threads per process, 1 if zero
+	Threads int
+	// PassAuthorization - pass the Authorization header to the application
+	PassAuthorization bool
+}
+
+// VHost - a vhost of the service
+type VHost struct {
+	// ServerName - the host name of the vhost, e.g. the one of the endpoint
+	// keystone-public.openstack.svc
+	ServerName string
+	// BindAddress - address to listen on, DefaultBindAddress if empty
+	BindAddress string
+	// Port - port to listen on
+	Port int32
+	// Timeout - request timeout in seconds, DefaultTimeout if zero
+	Timeout int
+	// DocumentRoot - the document root, optional
+	DocumentRoot string
+	// TLS - the TLS material, plain HTTP if nil
+	TLS *TLS
+	// WSGI - the WSGI application, optional
+	WSGI *WSGI
+	// CustomDirectives - additional directives of the vhost, e.g.
+	// "LimitRequestBody 1073741824" or a <Location> block
+	CustomDirectives []string
+}
+
+// Config - the vhosts of a service, rendered into a file of the conf.d
+// directory of httpd, including the Listen directives of the vhosts
+type Config struct {
+	VHosts []VHost
+}
+
+// address - returns the address:port of the vhost, IPv6 addresses in
+// brackets
+func (v VHost) address() string {
+	addr := v.BindAddress
+	if addr == "" {
+		addr = DefaultBindAddress
+	}
+	if ip := net.ParseIP(addr); ip != nil && ip.To4() == nil {
+		addr = "[" + addr + "]"
+	}
+	return addr + ":" + strconv.Itoa(int(v.Port))
+}
+
+// Validate - checks the config for the fields httpd requires
+func (c Config) Validate() error {
+	if len(c.VHosts) == 0 {
+		return fmt.Errorf("%w: no vhost", ErrInvalidConfig)
+	}
+	for i, v := range c.VHosts {
+		if v.ServerName == "" {
+			return fmt.Errorf("%w: vhosts[%d]: serverName is required", ErrInvalidConfig, i)
+		}
+		if v.Port < 1 || v.Port > 65535 {
+			return fmt.Errorf("%w: vhosts[%d]: invalid port %d", ErrInvalidConfig, i, v.Port)
+		}
+		if v.BindAddress != "" && v.BindAddress != DefaultBindAddress && net.ParseIP(v.BindAddress) == nil {
+			return fmt.Errorf("%w: vhosts[%d]: invalid bind address %s", ErrInvalidConfig, i, v.BindAddress)
+		}
+		if v.TLS != nil && (v.TLS.CertFile == "" || v.TLS.KeyFile == "") {
+			return fmt.Errorf("%w: vhosts[%d]: TLS cert and key are required", ErrInvalidConfig, i)
+		}
+		if v.WSGI != nil && (v.WSGI.ProcessGroup == "" || v.WSGI.Script == "") {
+			return fmt.Errorf("%w: vhosts[%d]: WSGI process group and script are required", ErrInvalidConfig, i)
+		}
+		for _, d := range v.CustomDirectives {
+			if strings.Contains(strings.ToLower(d), "</virtualhost") {
+				return fmt.Errorf("%w: vhosts[%d]: custom directive %q closes the vhost", ErrInvalidConfig, i, d)
+			}
+		}
+	}
+	return nil
+}
+
+// vhostTemplate - the conf.d file of the vhosts
+var vhostTemplate = template.Must(template.New("vhost").Parse(
+	`{{ range .Listen -}}
+Listen {{ . }}
+{{ end }}
+{{- range .VHosts }}
+<VirtualHost {{ .Address }}>
+  ServerName {{ .ServerName }}
+  TimeOut {{ .Timeout }}
+{{- if .DocumentRoot }}
+
+  DocumentRoot "{{ .DocumentRoot }}"
+  <Directory "{{ .DocumentRoot }}">
+    Options -Indexes +FollowSymLinks +MultiViews
+    AllowOverride None
+    Require all granted
+  </Directory>
+{{- end }}
+
+  ErrorLog {{ .Log }}
+  ServerSignature Off
+  CustomLog {{ .Log }} combined
+{{- with .TLS }}
+
+  SetEnvIf X-Forwarded-Proto https HTTPS=1
+  SSLEngine on
+  SSLCertificateFile "{{ .CertFile }}"
+  SSLCertificateKeyFile "{{ .KeyFile }}"
+{{- if .CAFile }}
+  SSLCACertificateFile "{{ .CAFile }}"
+{{- end }}
+{{- end }}
+{{- with .WSGI }}
+
+  WSGIApplicationGroup %{GLOBAL}
+  WSGIDaemonProcess {{ .ProcessGroup }} display-name={{ .ProcessGroup }} group={{ .Group }} processes={{ .Processes }} threads={{ .Threads }} user={{ .User }}
+  WSGIProcessGroup {{ .ProcessGroup }}
+  WSGIScriptAlias {{ .Path }} "{{ .Script }}"
+{{- if .PassAuthorization }}
+  WSGIPassAuthorization On
+{{- end }}
+{{- end }}
+{{- if .CustomDirectives }}
+{{ range .CustomDirectives }}
+  {{ . }}
+{{- end }}
+{{- end }}
+</VirtualHost>
+{{ end -}}
+`))
+
+// renderVHost - a VHost with the defaults applied, for the template
+type renderVHost struct {
+	VHost
+	Address string
+	Log     string
+}
+
+// Render - validates the config and returns it as httpd config
+func (c Config) Render() (string, error) {
+	err := c.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	data := struct {
+		Listen []string
+		VHosts []renderVHost
+	}{}
+	for _, v := range c.VHosts {
+		if v.Timeout == 0 {
+			v.Timeout = DefaultTimeout
+		}
+		if v.WSGI != nil {
+			wsgi := *v.WSGI
+			if wsgi.Path == "" {
+				wsgi.Path = "/"
+			}
+			if wsgi.User == "" {
+				wsgi.User = DefaultUser
+			}
+			if wsgi.Group == "" {
+				wsgi.Group = wsgi.User
+			}
+			wsgi.Processes = max(wsgi.Processes, 1)
+			wsgi.Threads = max(wsgi.Threads, 1)
+			v.WSGI = &wsgi
+		}
+		r := renderVHost{VHost: v, Address: v.address(), Log: DefaultLog}
+		data.VHosts = append(data.VHosts, r)
+
+		// vhosts can share an address, e.g. with different server names
+		if !util.StringInSlice(r.Address, data.Listen) {
+			data.Listen = append(data.Listen, r.Address)
+		}
+	}
+
+	var out bytes.Buffer
+	err = vhostTemplate.Execute(&out, data)
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// AddToTemplate - renders the config and adds it as key to the CustomData
+// of the config secret template t, e.g. before passing it to
+// secret.EnsureSecrets
+//
+// Example:
+//
+//	vhosts := httpd.Config{}
+//	for endpt, name := range endpointNames {
+//		vhost := httpd.VHost{
+//			ServerName: fmt.Sprintf("%s-%s.%s.svc", keystone.ServiceName, endpt, instance.Namespace),
+//			Port:       keystone.KeystonePublicPort,
+//			WSGI: &httpd.WSGI{
+//				ProcessGroup:      name,
+//				Script:            "/usr/bin/keystone-wsgi-public",
+//				User:              "keystone",
+//				Processes:         3,
+//				PassAuthorization: true,
+//			},
+//		}
+//		if instance.Spec.TLS.API.Enabled(endpt) {
+//			vhost.TLS = httpd.TLSFromService(&tls.Service{}, endpt.String())
+//		}
+//		vhosts.VHosts = append(vhosts.VHosts, vhost)
+//	}
+//	err := vhosts.AddToTemplate(&cms[0], "10-keystone_wsgi.conf")
+func (c Config) AddToTemplate(t *util.Template, key string) error {
+	data, err := c.Render()
+	if err != nil {
+		return err
+	}
+	if t.CustomData == nil {
+		t.CustomData = map[string]string{}
+	}
+	t.CustomData[key] = data
+	return nil
+}
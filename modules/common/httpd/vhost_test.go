@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpd
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	"k8s.io/utils/ptr"
+)
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+
+	c := Config{VHosts: []VHost{
+		{
+			ServerName: "keystone-public.openstack.svc",
+			Port:       5000,
+			TLS:        TLSFromService(&tls.Service{CaMount: ptr.To("/etc/pki/ca.crt")}, "public"),
+			WSGI: &WSGI{
+				ProcessGroup:      "public",
+				Script:            "/usr/bin/keystone-wsgi-public",
+				User:              "keystone",
+				Processes:         3,
+				PassAuthorization: true,
+			},
+			CustomDirectives: []string{"LimitRequestBody 1024"},
+		},
+		{
+			ServerName:   "keystone-internal.openstack.svc",
+			BindAddress:  "fd00::1",
+			Port:         5000,
+			Timeout:      120,
+			DocumentRoot: "/var/www/cgi-bin/keystone",
+		},
+	}}
+
+	out, err := c.Render()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(out).To(Equal(`Listen *:5000
+Listen [fd00::1]:5000
+
+<VirtualHost *:5000>
+  ServerName keystone-public.openstack.svc
+  TimeOut 60
+
+  ErrorLog /dev/stdout
+  ServerSignature Off
+  CustomLog /dev/stdout combined
+
+  SetEnvIf X-Forwarded-Proto https HTTPS=1
+  SSLEngine on
+  SSLCertificateFile "/var/lib/config-data/tls/certs/public.crt"
+  SSLCertificateKeyFile "/var/lib/config-data/tls/private/public.key"
+  SSLCACertificateFile "/etc/pki/ca.crt"
+
+  WSGIApplicationGroup %{GLOBAL}
+  WSGIDaemonProcess public display-name=public group=keystone processes=3 threads=1 user=keystone
+  WSGIProcessGroup public
+  WSGIScriptAlias / "/usr/bin/keystone-wsgi-public"
+  WSGIPassAuthorization On
+
+  LimitRequestBody 1024
+</VirtualHost>
+
+<VirtualHost [fd00::1]:5000>
+  ServerName keystone-internal.openstack.svc
+  TimeOut 120
+
+  DocumentRoot "/var/www/cgi-bin/keystone"
+  <Directory "/var/www/cgi-bin/keystone">
+    Options -Indexes +FollowSymLinks +MultiViews
+    AllowOverride None
+    Require all granted
+  </Directory>
+
+  ErrorLog /dev/stdout
+  ServerSignature Off
+  CustomLog /dev/stdout combined
+</VirtualHost>
+`))
+	// the defaults are not applied to the config itself
+	g.Expect(c.VHosts[0].WSGI.Path).To(BeEmpty())
+
+	tmpl := &util.Template{}
+	g.Expect(c.AddToTemplate(tmpl, "10-keystone_wsgi.conf")).To(Succeed())
+	g.Expect(tmpl.CustomData).To(HaveKeyWithValue("10-keystone_wsgi.conf", out))
+}
+
+func TestValidate(t *testing.T) {
+	valid := VHost{ServerName: "keystone-public.openstack.svc", Port: 5000}
+
+	tests := []struct {
+		name  string
+		vhost func(v VHost) VHost
+	}{
+		{name: "no server name", vhost: func(v VHost) VHost { v.ServerName = ""; return v }},
+		{name: "invalid port", vhost: func(v VHost) VHost { v.Port = 0; return v }},
+		{name: "invalid bind address", vhost: func(v VHost) VHost { v.BindAddress = "localhost"; return v }},
+		{name: "TLS without key", vhost: func(v VHost) VHost { v.TLS = &TLS{CertFile: "/tls.crt"}; return v }},
+		{name: "WSGI without script", vhost: func(v VHost) VHost { v.WSGI = &WSGI{ProcessGroup: "public"}; return v }},
+		{name: "closing directive", vhost: func(v VHost) VHost {
+			v.CustomDirectives = []string{"</VirtualHost>\n<VirtualHost *:80>"}
+			return v
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(Config{VHosts: []VHost{valid}}.Validate()).To(Succeed())
+			_, err := Config{VHosts: []VHost{tt.vhost(valid)}}.Render()
+			g.Expect(err).To(MatchError(ErrInvalidConfig))
+		})
+	}
+
+	g := NewWithT(t)
+	g.Expect(Config{}.Validate()).To(MatchError(ErrInvalidConfig))
+}
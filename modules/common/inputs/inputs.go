@@ -0,0 +1,182 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inputs provides the registry of the hashes of the inputs of a
+// reconcile, e.g. secrets, configmaps, certs and transport URLs, and the
+// detection of their changes between reconciles
+package inputs
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	common "github.com/openstack-k8s-operators/lib-common/modules/common"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/configmap"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/messaging"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+)
+
+// HashPrefix - prefix of the keys of the hashes of the single inputs in the
+// status hash map
+const HashPrefix = common.InputHashName + "-"
+
+// Registry - the hashes of the named inputs of a reconcile
+type Registry struct {
+	hashes map[string]string
+}
+
+// NewRegistry - returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{hashes: map[string]string{}}
+}
+
+// Add - registers the input name with its hash, e.g. the one returned by
+// tls.ValidateCACertSecret or secret.EnsureSecrets
+func (r *Registry) Add(name string, hash string) {
+	r.hashes[name] = hash
+}
+
+// AddObject - registers the input name with the hash of obj
+func (r *Registry) AddObject(name string, obj interface{}) error {
+	hash, err := util.ObjectHash(obj)
+	if err != nil {
+		return err
+	}
+	r.Add(name, hash)
+	return nil
+}
+
+// AddSecret - registers the secret name in namespace with the hash of its
+// data. The error of the get is returned, e.g. to check it with
+// k8s_errors.IsNotFound.
+func (r *Registry) AddSecret(ctx context.Context, h *helper.Helper, name string, namespace string) error {
+	_, hash, err := secret.GetSecret(ctx, h, name, namespace)
+	if err != nil {
+		return err
+	}
+	r.Add(name, hash)
+	return nil
+}
+
+// AddConfigMap - registers the configmap name in namespace with the hash of
+// its data
+func (r *Registry) AddConfigMap(ctx context.Context, h *helper.Helper, name string, namespace string) error {
+	_, hash, err := configmap.GetConfigMapAndHashWithName(ctx, h, name, namespace)
+	if err != nil {
+		return err
+	}
+	r.Add(name, hash)
+	return nil
+}
+
+// AddTransportURL - registers the transport URL as input name. Only changes
+// of the URL itself, not of the format of the secret, are detected.
+func (r *Registry) AddTransportURL(name string, t *messaging.TransportURL) error {
+	hash, err := t.Hash()
+	if err != nil {
+		return err
+	}
+	r.Add(name, hash)
+	return nil
+}
+
+// Names - returns the sorted names of the registered inputs
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.hashes))
+	for name := range r.hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnvVars - returns the hashes as env vars, e.g. to add them to the pods
+func (r *Registry) EnvVars() map[string]env.Setter {
+	envVars := map[string]env.Setter{}
+	for name, hash := range r.hashes {
+		envVars[name] = env.SetValue(hash)
+	}
+	return envVars
+}
+
+// Hash - returns the hash of all inputs. It is the same as the one of
+// util.HashOfInputHashes for env vars with the same names and hashes.
+func (r *Registry) Hash() (string, error) {
+	return util.HashOfInputHashes(r.EnvVars())
+}
+
+// Changed - returns the sorted names of the inputs which got added, removed
+// or changed since the hashes got stored in hashMap with SetHashes
+func (r *Registry) Changed(hashMap map[string]string) []string {
+	changed := []string{}
+	for name, hash := range r.hashes {
+		if hashMap[HashPrefix+name] != hash {
+			changed = append(changed, name)
+		}
+	}
+	for key := range hashMap {
+		name, ok := strings.CutPrefix(key, HashPrefix)
+		if !ok {
+			continue
+		}
+		if _, registered := r.hashes[name]; !registered {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// SetHashes - stores the hash of all inputs as common.InputHashName and the
+// hashes of the single inputs with the HashPrefix in hashMap, e.g. the Hash
+// of the status of the CR. The hashes of inputs no longer registered get
+// removed. Returns the hash map and true if the hash of all inputs changed.
+//
+// Example:
+//
+//	registry := inputs.NewRegistry()
+//	err := registry.AddSecret(ctx, h, instance.Spec.Secret, instance.Namespace)
+//	...
+//	if changed := registry.Changed(instance.Status.Hash); len(changed) > 0 {
+//		h.GetLogger().Info("Inputs changed", "inputs", changed)
+//	}
+//	instance.Status.Hash, _, err = registry.SetHashes(instance.Status.Hash)
+func (r *Registry) SetHashes(hashMap map[string]string) (map[string]string, bool, error) {
+	hash, err := r.Hash()
+	if err != nil {
+		return hashMap, false, err
+	}
+	hashMap, changed := util.SetHash(hashMap, common.InputHashName, hash)
+
+	for key := range hashMap {
+		name, ok := strings.CutPrefix(key, HashPrefix)
+		if !ok {
+			continue
+		}
+		if _, registered := r.hashes[name]; !registered {
+			delete(hashMap, key)
+		}
+	}
+	for name, h := range r.hashes {
+		hashMap[HashPrefix+name] = h
+	}
+
+	return hashMap, changed, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metadata provides a three-way merge of the labels and annotations
+// of the objects the modules create or patch
+package metadata
+
+import (
+	"encoding/json"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ManagedKeysAnnotation - annotation which records the label and annotation
+// keys set by the last merge, to remove them once no longer desired
+const ManagedKeysAnnotation = "openstack.org/managed-metadata"
+
+// managedKeys - content of the ManagedKeysAnnotation
+type managedKeys struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// Merge - merges the desired labels and annotations into obj. The desired
+// values take precedence over the current ones. Keys set by a previous
+// Merge which are no longer desired get removed, keys set by others, e.g.
+// an admin, are kept.
+func Merge(obj metav1.Object, labels map[string]string, annotations map[string]string) {
+	previous := managedKeys{}
+	if v, ok := obj.GetAnnotations()[ManagedKeysAnnotation]; ok {
+		// an invalid value means nothing to remove
+		_ = json.Unmarshal([]byte(v), &previous)
+	}
+
+	obj.SetLabels(merge(obj.GetLabels(), labels, previous.Labels))
+
+	newAnnotations := merge(obj.GetAnnotations(), annotations, previous.Annotations)
+	current := managedKeys{
+		Labels:      sortedKeys(labels),
+		Annotations: sortedKeys(annotations),
+	}
+	if len(current.Labels) == 0 && len(current.Annotations) == 0 {
+		delete(newAnnotations, ManagedKeysAnnotation)
+	} else {
+		if newAnnotations == nil {
+			newAnnotations = map[string]string{}
+		}
+		// marshalling a struct of string slices can not fail
+		v, _ := json.Marshal(current)
+		newAnnotations[ManagedKeysAnnotation] = string(v)
+	}
+	if len(newAnnotations) == 0 {
+		newAnnotations = nil
+	}
+	obj.SetAnnotations(newAnnotations)
+}
+
+// merge - returns current without the previous keys not in desired, with
+// desired applied on top
+func merge(current map[string]string, desired map[string]string, previous []string) map[string]string {
+	result := map[string]string{}
+	for k, v := range current {
+		result[k] = v
+	}
+	for _, k := range previous {
+		if _, ok := desired[k]; !ok {
+			delete(result, k)
+		}
+	}
+	for k, v := range desired {
+		result[k] = v
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
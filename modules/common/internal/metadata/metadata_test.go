@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMerge(t *testing.T) {
+	g := NewWithT(t)
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone-role",
+			Namespace: "openstack",
+			Labels:    map[string]string{"admin": "true"},
+		},
+	}
+
+	Merge(role,
+		map[string]string{"service": "keystone", "owner": "keystone"},
+		map[string]string{"openstack.org/reason": "initial"})
+	g.Expect(role.Labels).To(Equal(map[string]string{"admin": "true", "service": "keystone", "owner": "keystone"}))
+	g.Expect(role.Annotations).To(HaveKeyWithValue("openstack.org/reason", "initial"))
+	g.Expect(role.Annotations).ToNot(HaveKey("admin"))
+	g.Expect(role.Annotations).To(HaveKeyWithValue(ManagedKeysAnnotation,
+		`{"labels":["owner","service"],"annotations":["openstack.org/reason"]}`))
+
+	// owner no longer desired, service changed
+	role.Labels["service"] = "changed"
+	Merge(role,
+		map[string]string{"service": "keystone"},
+		map[string]string{"openstack.org/reason": "update"})
+	g.Expect(role.Labels).To(Equal(map[string]string{"admin": "true", "service": "keystone"}))
+	g.Expect(role.Annotations).To(HaveKeyWithValue("openstack.org/reason", "update"))
+
+	// nothing desired anymore
+	Merge(role, nil, nil)
+	g.Expect(role.Labels).To(Equal(map[string]string{"admin": "true"}))
+	g.Expect(role.Annotations).To(BeNil())
+}
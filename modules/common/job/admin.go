@@ -0,0 +1,274 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// AdminTaskContainerName - name of the container running the script of
+	// an AdminTask
+	AdminTaskContainerName = "openstackclient"
+	// DefaultCloudsConfigMap - configmap with the clouds.yaml of the admin
+	// user created by the openstack-operator
+	DefaultCloudsConfigMap = "openstack-config"
+	// DefaultCloudsSecret - secret with the secure.yaml of the admin user
+	// created by the openstack-operator
+	DefaultCloudsSecret = "openstack-config-secret"
+	// DefaultCloud - the cloud of the clouds.yaml used by the tasks
+	DefaultCloud = "default"
+
+	cloudsConfigDir   = "/home/cloud-admin/.config/openstack/"
+	cloudsVolumeName  = "openstack-config"
+	secureVolumeName  = "openstack-config-secret"
+	tmpVolumeName     = "admin-task-tmp"
+	tmpDir            = "/var/lib/admin-task/"
+	jobNameLabel      = "batch.kubernetes.io/job-name"
+	adminTaskJobType  = "admin-task"
+	defaultTaskUserID = 42401
+
+	// adminTaskCommand - runs the script, passed as $0, with its stderr
+	// redirected to a file. The pod logs, read as output of the task, only
+	// contain its stdout, warnings of the clients on stderr would corrupt
+	// e.g. JSON output. On failure the stderr is written to the logs.
+	adminTaskCommand = `/bin/bash -c "$0" 2>` + tmpDir + `stderr; rc=$?; ` +
+		`if [ $rc -ne 0 ]; then cat ` + tmpDir + `stderr >&2; fi; exit $rc`
+)
+
+// AdminTask - a one-off OpenStack CLI task, e.g. the creation of the
+// default flavors, run in a job with the admin clouds.yaml
+type AdminTask struct {
+	// Name - name of the job
+	Name string
+	// Namespace - namespace of the job
+	Namespace string
+	// Image - the openstackclient image
+	Image string
+	// Script - bash script run in the container. What it writes to stdout
+	// is the output of the task, e.g. of openstack flavor list -f json. Its
+	// stderr is not part of the output.
+	Script string
+	// Cloud - cloud of the clouds.yaml, DefaultCloud if empty
+	Cloud string
+	// CloudsConfigMap - configmap with the clouds.yaml, DefaultCloudsConfigMap if empty
+	CloudsConfigMap string
+	// CloudsSecret - secret with the secure.yaml, DefaultCloudsSecret if empty
+	CloudsSecret string
+	// CABundleSecretName - secret with the CA bundle of the endpoints, if any
+	CABundleSecretName string
+	// NetworkAttachments - networks the task needs, e.g. to reach internal
+	// endpoints
+	NetworkAttachments []networkv1.NetworkAttachmentDefinition
+	// ServiceAccount - service account of the job pod
+	ServiceAccount string
+	// Labels - labels of the job and its pod
+	Labels map[string]string
+}
+
+// Job - returns the job running the task. It can be adjusted, e.g. with a
+// node selector, before it is passed to RunAdminTask.
+func (t AdminTask) Job() (*batchv1.Job, error) {
+	cloud := t.Cloud
+	if cloud == "" {
+		cloud = DefaultCloud
+	}
+	cloudsConfigMap := t.CloudsConfigMap
+	if cloudsConfigMap == "" {
+		cloudsConfigMap = DefaultCloudsConfigMap
+	}
+	cloudsSecret := t.CloudsSecret
+	if cloudsSecret == "" {
+		cloudsSecret = DefaultCloudsSecret
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: cloudsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: cloudsConfigMap},
+				},
+			},
+		},
+		{
+			Name: secureVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: cloudsSecret},
+			},
+		},
+		{
+			Name: tmpVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{Name: cloudsVolumeName, MountPath: cloudsConfigDir + "clouds.yaml", SubPath: "clouds.yaml", ReadOnly: true},
+		{Name: secureVolumeName, MountPath: cloudsConfigDir + "secure.yaml", SubPath: "secure.yaml", ReadOnly: true},
+		{Name: tmpVolumeName, MountPath: tmpDir},
+	}
+	if t.CABundleSecretName != "" {
+		ca := tls.Ca{CaBundleSecretName: t.CABundleSecretName}
+		volumes = append(volumes, ca.CreateVolume())
+		mounts = append(mounts, ca.CreateVolumeMounts(nil)...)
+	}
+
+	annotations := map[string]string{}
+	if len(t.NetworkAttachments) > 0 {
+		var err error
+		annotations, err = networkattachment.EnsureNetworksAnnotation(t.NetworkAttachments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create network annotation for task %s: %w", t.Name, err)
+		}
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.Name,
+			Namespace: t.Namespace,
+			Labels:    t.Labels,
+		},
+		Spec: batchv1.JobSpec{
+			// tasks like the creation of flavors are not necessarily
+			// idempotent, failures get reported instead of retried
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      t.Labels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: t.ServiceAccount,
+					Volumes:            volumes,
+					Containers: []corev1.Container{
+						{
+							Name:    AdminTaskContainerName,
+							Image:   t.Image,
+							Command: []string{"/bin/bash", "-c", adminTaskCommand, t.Script},
+							Env: []corev1.EnvVar{
+								{Name: "OS_CLOUD", Value: cloud},
+							},
+							VolumeMounts: mounts,
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser:                ptr.To[int64](defaultTaskUserID),
+								RunAsNonRoot:             ptr.To(true),
+								AllowPrivilegeEscalation: ptr.To(false),
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{"ALL"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// AdminTaskResult - the result of RunAdminTask
+type AdminTaskResult struct {
+	// Done - the task of the current hash succeeded
+	Done bool
+	// Hash - the hash of the job, to be stored as beforeHash
+	Hash string
+	// Output - stdout of the task, without its stderr. Only set in the reconcile which observed
+	// the success of the job, the job gets deleted by its TTL afterwards.
+	Output string
+}
+
+// Decode - unmarshals the JSON output of the task into v
+func (r AdminTaskResult) Decode(v interface{}) error {
+	return json.Unmarshal([]byte(r.Output), v)
+}
+
+// RunAdminTask - runs the job of an AdminTask if its hash differs from
+// beforeHash and returns its output once it succeeded. The result hash
+// must be stored, e.g. in the status hash map, and passed as beforeHash to
+// not re-run the task.
+//
+// Example:
+//
+//	job, err := job.AdminTask{Name: "nova-default-flavors", Namespace: instance.Namespace, Image: image,
+//		Script: "openstack flavor create --ram 512 --disk 1 --vcpus 1 m1.tiny -f json"}.Job()
+//	...
+//	ctrlResult, result, err := job.RunAdminTask(ctx, h, job, time.Second*5, instance.Status.Hash["flavors"])
+//	if (ctrlResult != ctrl.Result{}) || err != nil {
+//		return ctrlResult, err
+//	}
+//	if result.Output != "" {
+//		flavor := map[string]interface{}{}
+//		err = result.Decode(&flavor)
+//		...
+//	}
+//	instance.Status.Hash["flavors"] = result.Hash
+func RunAdminTask(
+	ctx context.Context,
+	h *helper.Helper,
+	job *batchv1.Job,
+	timeout time.Duration,
+	beforeHash string,
+) (ctrl.Result, AdminTaskResult, error) {
+	j := NewJob(job, adminTaskJobType, false, timeout, beforeHash)
+	ctrlResult, err := j.DoJob(ctx, h)
+	if err != nil || (ctrlResult != ctrl.Result{}) {
+		return ctrlResult, AdminTaskResult{}, err
+	}
+	result := AdminTaskResult{Done: true, Hash: j.GetHash()}
+	if !j.HasChanged() {
+		return ctrl.Result{}, result, nil
+	}
+
+	pods, err := h.GetKClient().CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: jobNameLabel + "=" + job.Name,
+	})
+	if err != nil {
+		return ctrl.Result{}, AdminTaskResult{}, fmt.Errorf("error listing pods of job %s: %w", job.Name, err)
+	}
+	for _, p := range pods.Items {
+		if p.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		logs, err := h.GetKClient().CoreV1().Pods(job.Namespace).GetLogs(p.Name, &corev1.PodLogOptions{
+			Container: AdminTaskContainerName,
+		}).DoRaw(ctx)
+		if err != nil {
+			return ctrl.Result{}, AdminTaskResult{}, fmt.Errorf("error getting logs of job %s: %w", job.Name, err)
+		}
+		// the logs only contain the stdout of the script, see adminTaskCommand
+		result.Output = string(logs)
+		break
+	}
+	h.GetLogger().Info("Admin task done", "job", job.Name, "outputBytes", len(result.Output))
+
+	return ctrl.Result{}, result, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAdminTaskJob(t *testing.T) {
+	g := NewWithT(t)
+
+	task := AdminTask{
+		Name:               "nova-flavors",
+		Namespace:          "openstack",
+		Image:              "openstackclient:latest",
+		Script:             "openstack flavor list -f json",
+		CABundleSecretName: "combined-ca-bundle",
+	}
+	job, err := task.Job()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	spec := job.Spec.Template.Spec
+	g.Expect(spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+	g.Expect(*job.Spec.BackoffLimit).To(Equal(int32(0)))
+	g.Expect(spec.Volumes).To(HaveLen(4))
+	g.Expect(spec.Volumes[0].ConfigMap.Name).To(Equal(DefaultCloudsConfigMap))
+	g.Expect(spec.Volumes[1].Secret.SecretName).To(Equal(DefaultCloudsSecret))
+
+	c := spec.Containers[0]
+	g.Expect(c.Name).To(Equal(AdminTaskContainerName))
+	g.Expect(c.Command).To(Equal([]string{"/bin/bash", "-c", adminTaskCommand, "openstack flavor list -f json"}))
+	g.Expect(c.Env).To(ContainElement(corev1.EnvVar{Name: "OS_CLOUD", Value: DefaultCloud}))
+	g.Expect(c.VolumeMounts[0].MountPath).To(Equal("/home/cloud-admin/.config/openstack/clouds.yaml"))
+	g.Expect(len(c.VolumeMounts)).To(BeNumerically(">", 2))
+}
+
+func TestAdminTaskResultDecode(t *testing.T) {
+	g := NewWithT(t)
+
+	flavors := []map[string]interface{}{}
+	err := AdminTaskResult{Output: `[{"Name": "m1.tiny"}]`}.Decode(&flavors)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(flavors[0]["Name"]).To(Equal("m1.tiny"))
+
+	err = AdminTaskResult{Output: "not json"}.Decode(&flavors)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -28,6 +28,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/requeue"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/scheduling"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,6 +61,14 @@ func NewJob(
 	return j
 }
 
+// SetScheduling - sets the nodeSelector and tolerations of the merged
+// operator defaults and user override on the pod template of the job.
+// Must be called before DoJob. As the pod spec is part of the job hash, a
+// change of the scheduling settings re-runs the job.
+func (j *Job) SetScheduling(defaults scheduling.Spec, override scheduling.Spec) {
+	scheduling.Apply(&j.expectedJob.Spec.Template.Spec, defaults, override)
+}
+
 // createJob - creates job, reconciles after Xs if object won't exist.
 func (j *Job) createJob(
 	ctx context.Context,
@@ -68,7 +79,7 @@ func (j *Job) createJob(
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), job, func() error {
 		job.Spec = j.expectedJob.Spec
 		job.Annotations = util.MergeStringMaps(job.Annotations, map[string]string{hashAnnotationName: j.hash})
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), job, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), job, h.GetScheme())
 		if err != nil {
 			return err
 		}
@@ -77,16 +88,20 @@ func (j *Job) createJob(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Job %s not found, reconcile in %s", job.Name, j.timeout))
-			return ctrl.Result{RequeueAfter: j.timeout}, nil
+			requeueAfter, err := j.requeueAfter(requeue.ClassNotFound)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			h.GetLogger().Info("Job not found, reconcile later", "object", job.Name, "requeueAfter", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
 		}
 		h.GetLogger().Error(err, "Job CreateOrPatch failed", "job", job.Name)
 		return ctrl.Result{}, err
 	}
 	j.actualJob = job
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("Job %s %s - %s", j.jobType, job.Name, op))
-		return ctrl.Result{RequeueAfter: j.timeout}, nil
+		h.GetLogger().Info("Job reconciled", "object", job.Name, "jobType", j.jobType, "operation", op)
+		return j.requeue(requeue.ClassWaiting)
 	}
 
 	return ctrl.Result{}, nil
@@ -198,6 +213,65 @@ func (j *Job) updateTTL(ctx context.Context, h *helper.Helper) (ctrl.Result, err
 	return ctrl.Result{}, nil
 }
 
+// SetWaitTimeout - sets how long to wait for the job to complete after it
+// started. Once exceeded DoJob returns an util.ErrWaitTimeout error. The
+// elapsed time is measured with the clock of the helper. Zero, the default,
+// waits forever.
+func (j *Job) SetWaitTimeout(waitTimeout time.Duration) {
+	j.waitTimeout = waitTimeout
+}
+
+// SetRequeuePolicy - sets the requeue policy which defines the requeue
+// intervals instead of the timeout of the job. Once the max attempts of the
+// policy are exceeded while waiting for the job, DoJob returns an
+// requeue.ErrMaxAttemptsExceeded error.
+func (j *Job) SetRequeuePolicy(p *requeue.Policy) {
+	j.requeuePolicy = p
+}
+
+// requeueAfter - returns the interval to requeue after for class, the
+// timeout of the job if no requeue policy is set
+func (j *Job) requeueAfter(class requeue.Class) (time.Duration, error) {
+	if j.requeuePolicy == nil {
+		return j.timeout, nil
+	}
+	return j.requeuePolicy.Next(j.requeueKey(), class)
+}
+
+// requeue - returns the requeue after the interval for class
+func (j *Job) requeue(class requeue.Class) (ctrl.Result, error) {
+	requeueAfter, err := j.requeueAfter(class)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (j *Job) requeueKey() string {
+	return "Job/" + j.expectedJob.Namespace + "/" + j.expectedJob.Name
+}
+
+// requeueOrExpire - returns the requeue while waiting for the running job,
+// or an error if it runs longer than the wait timeout
+func (j *Job) requeueOrExpire(h *helper.Helper) (ctrl.Result, error) {
+	since := j.actualJob.CreationTimestamp.Time
+	if j.actualJob.Status.StartTime != nil {
+		since = j.actualJob.Status.StartTime.Time
+	}
+
+	interval, err := j.requeueAfter(requeue.ClassWaiting)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	requeueAfter, expired := util.RequeueWithDeadline(h.GetClock(), since, j.waitTimeout, interval)
+	if expired {
+		return ctrl.Result{}, fmt.Errorf("%w: job %s did not complete within %s",
+			util.ErrWaitTimeout, j.actualJob.Name, j.waitTimeout)
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
 // HasChanged func
 func (j *Job) HasChanged() bool {
 	return j.changed
@@ -259,7 +333,7 @@ func (j *Job) waitOnJob(
 					"waiting for the previous job to finish before re-run.")
 		}
 		h.GetLogger().Info("Job Status Active... requeuing")
-		return ctrl.Result{RequeueAfter: j.timeout}, nil
+		return j.requeueOrExpire(h)
 	} else if j.actualJob.Status.Succeeded > 0 {
 		if existingJobHash != j.hash {
 			h.GetLogger().Info(
@@ -269,9 +343,12 @@ func (j *Job) waitOnJob(
 			if err != nil {
 				return ctrl.Result{}, err
 			}
-			return ctrl.Result{RequeueAfter: j.timeout}, nil
+			return j.requeue(requeue.ClassWaiting)
 		}
 		h.GetLogger().Info("Job Status Successful")
+		if j.requeuePolicy != nil {
+			j.requeuePolicy.Reset(j.requeueKey())
+		}
 		return ctrl.Result{}, nil
 	} else if j.actualJob.Status.Failed > 0 {
 		if existingJobHash != j.hash {
@@ -282,7 +359,7 @@ func (j *Job) waitOnJob(
 			if err != nil {
 				return ctrl.Result{}, err
 			}
-			return ctrl.Result{RequeueAfter: j.timeout}, nil
+			return j.requeue(requeue.ClassWaiting)
 		}
 		h.GetLogger().Info("Job Status Failed")
 		errMsg := fmt.Sprintf("Job Attempt #%d Failed. Check job logs", j.GetTotalFailedAttempts())
@@ -297,7 +374,7 @@ func (j *Job) waitOnJob(
 				"waiting for the previous job to finish before re-run.")
 	}
 	h.GetLogger().Info("Job Status incomplete... requeuing")
-	return ctrl.Result{RequeueAfter: j.timeout}, nil
+	return j.requeueOrExpire(h)
 }
 
 // GetJobWithName func
@@ -19,6 +19,7 @@ package job
 import (
 	"time"
 
+	"github.com/openstack-k8s-operators/lib-common/modules/common/requeue"
 	batchv1 "k8s.io/api/batch/v1"
 )
 
@@ -29,12 +30,14 @@ const (
 
 // Job -
 type Job struct {
-	expectedJob *batchv1.Job
-	actualJob   *batchv1.Job
-	jobType     string
-	preserve    bool
-	timeout     time.Duration
-	beforeHash  string
-	hash        string
-	changed     bool
+	expectedJob   *batchv1.Job
+	actualJob     *batchv1.Job
+	jobType       string
+	preserve      bool
+	timeout       time.Duration
+	waitTimeout   time.Duration
+	requeuePolicy *requeue.Policy
+	beforeHash    string
+	hash          string
+	changed       bool
 }
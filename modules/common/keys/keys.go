@@ -0,0 +1,187 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package keys provides the management of fernet and credential key
+// repositories, like keystone-manage fernet_setup and fernet_rotate
+// maintain them, stored in a secret
+package keys
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FernetKeysPrefix - prefix of the secret data keys of the fernet token
+	// keys, e.g. FernetKeys0 for the staged key
+	FernetKeysPrefix = "FernetKeys"
+	// CredentialKeysPrefix - prefix of the secret data keys of the
+	// credential encryption keys
+	CredentialKeysPrefix = "CredentialKeys"
+	// DefaultMaxActiveKeys - default number of keys kept, the staged, the
+	// primary and one secondary key, like the keystone max_active_keys
+	DefaultMaxActiveKeys = 3
+	// StagedKey - index of the staged key, the next primary key
+	StagedKey = 0
+
+	// keySize - size of a fernet key, the signing and the encryption key
+	keySize = 32
+)
+
+// Define static errors
+var (
+	// ErrInvalidKeys indicates a key repository without the staged or a
+	// primary key, or with a malformed key
+	ErrInvalidKeys = errors.New("invalid key repository")
+	// ErrInvalidMaxActiveKeys indicates less than the staged and the primary
+	// key to be kept
+	ErrInvalidMaxActiveKeys = errors.New("max active keys must be at least 2")
+)
+
+// Keys - the keys of a repository by index. StagedKey is the staged key,
+// which every node knows before it becomes the primary key on the next
+// rotation, the highest index is the primary key which encrypts, the
+// others are secondary keys which only decrypt.
+type Keys map[int]string
+
+// GenerateKey - returns a new random fernet key, the URL safe base64
+// encoding of 32 bytes
+func GenerateKey() (string, error) {
+	b := make([]byte, keySize)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// NewKeys - returns a new repository with the staged and the primary key,
+// like keystone-manage fernet_setup
+func NewKeys() (Keys, error) {
+	keys := Keys{}
+	for _, i := range []int{StagedKey, 1} {
+		key, err := GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// Indices - returns the indices of the keys in ascending order
+func (k Keys) Indices() []int {
+	indices := make([]int, 0, len(k))
+	for i := range k {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// Primary - returns the index of the primary key, the highest index
+func (k Keys) Primary() int {
+	indices := k.Indices()
+	return indices[len(indices)-1]
+}
+
+// Validate - checks that the repository has the staged and a primary key
+// and that all keys are fernet keys
+func (k Keys) Validate() error {
+	if _, ok := k[StagedKey]; !ok {
+		return fmt.Errorf("%w: no staged key", ErrInvalidKeys)
+	}
+	if len(k) < 2 {
+		return fmt.Errorf("%w: no primary key", ErrInvalidKeys)
+	}
+	for _, i := range k.Indices() {
+		if i < 0 {
+			return fmt.Errorf("%w: negative index %d", ErrInvalidKeys, i)
+		}
+		b, err := base64.URLEncoding.DecodeString(k[i])
+		if err != nil || len(b) != keySize {
+			return fmt.Errorf("%w: key %d is not a fernet key", ErrInvalidKeys, i)
+		}
+	}
+	return nil
+}
+
+// Rotate - returns the keys after a rotation, like keystone-manage
+// fernet_rotate: the staged key becomes the primary key with the next
+// index, a new staged key gets generated and the oldest secondary keys get
+// removed to keep maxActiveKeys keys. k is not changed.
+func (k Keys) Rotate(maxActiveKeys int) (Keys, error) {
+	if maxActiveKeys < 2 {
+		return nil, ErrInvalidMaxActiveKeys
+	}
+	err := k.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	staged, err := GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	rotated := Keys{}
+	for i, key := range k {
+		rotated[i] = key
+	}
+	rotated[k.Primary()+1] = k[StagedKey]
+	rotated[StagedKey] = staged
+
+	// the indices are in ascending order, the oldest secondary keys first
+	for _, i := range rotated.Indices()[1:] {
+		if len(rotated) <= maxActiveKeys {
+			break
+		}
+		delete(rotated, i)
+	}
+
+	return rotated, nil
+}
+
+// ParseKeys - returns the keys of the secret data with the keys prefix,
+// e.g. FernetKeysPrefix. Other data keys are ignored.
+func ParseKeys(prefix string, data map[string][]byte) (Keys, error) {
+	keys := Keys{}
+	for k, v := range data {
+		index, found := strings.CutPrefix(k, prefix)
+		if !found {
+			continue
+		}
+		i, err := strconv.Atoi(index)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid key %s", ErrInvalidKeys, k)
+		}
+		keys[i] = string(v)
+	}
+	return keys, keys.Validate()
+}
+
+// Data - returns the keys as secret data with the keys prefix
+func (k Keys) Data(prefix string) map[string][]byte {
+	data := map[string][]byte{}
+	for i, key := range k {
+		data[prefix+strconv.Itoa(i)] = []byte(key)
+	}
+	return data
+}
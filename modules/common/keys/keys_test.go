@@ -0,0 +1,188 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keys
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRotate(t *testing.T) {
+	g := NewWithT(t)
+
+	keys, err := NewKeys()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(keys.Indices()).To(Equal([]int{0, 1}))
+	g.Expect(keys.Validate()).To(Succeed())
+
+	rotated, err := keys.Rotate(3)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rotated.Indices()).To(Equal([]int{0, 1, 2}))
+	// the staged key got promoted to primary
+	g.Expect(rotated[2]).To(Equal(keys[0]))
+	g.Expect(rotated[1]).To(Equal(keys[1]))
+	g.Expect(rotated[0]).ToNot(Equal(keys[0]))
+	// keys did not change
+	g.Expect(keys.Indices()).To(Equal([]int{0, 1}))
+
+	// the oldest secondary key gets removed
+	again, err := rotated.Rotate(3)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(again.Indices()).To(Equal([]int{0, 2, 3}))
+	g.Expect(again[3]).To(Equal(rotated[0]))
+	g.Expect(again.Primary()).To(Equal(3))
+
+	_, err = rotated.Rotate(1)
+	g.Expect(err).To(MatchError(ErrInvalidMaxActiveKeys))
+}
+
+func TestParseKeys(t *testing.T) {
+	g := NewWithT(t)
+
+	keys, err := NewKeys()
+	g.Expect(err).ToNot(HaveOccurred())
+	data := keys.Data(FernetKeysPrefix)
+	g.Expect(data).To(HaveKey("FernetKeys0"))
+	g.Expect(data).To(HaveKey("FernetKeys1"))
+	data["other"] = []byte("ignored")
+
+	parsed, err := ParseKeys(FernetKeysPrefix, data)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(parsed).To(Equal(keys))
+
+	_, err = ParseKeys(CredentialKeysPrefix, data)
+	g.Expect(err).To(MatchError(ErrInvalidKeys))
+
+	data["FernetKeys2"] = []byte("not a key")
+	_, err = ParseKeys(FernetKeysPrefix, data)
+	g.Expect(err).To(MatchError(ErrInvalidKeys))
+	g.Expect(err.Error()).To(ContainSubstring("key 2"))
+
+	delete(data, "FernetKeys2")
+	data["FernetKeysX"] = data["FernetKeys1"]
+	_, err = ParseKeys(FernetKeysPrefix, data)
+	g.Expect(err).To(MatchError(ErrInvalidKeys))
+
+	_, err = ParseKeys(FernetKeysPrefix, map[string][]byte{"FernetKeys0": data["FernetKeys0"]})
+	g.Expect(err).To(MatchError(ErrInvalidKeys))
+}
+
+func TestRepositoryEnsure(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", UID: "uid-1"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	clock := clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	h.SetClock(clock)
+
+	conditions := condition.Conditions{}
+	repo := NewRepository("keystone-fernet-keys", "openstack", FernetKeysPrefix, time.Hour)
+
+	// the keys get created
+	hash, result, err := repo.Ensure(ctx, h, owner, &conditions, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hash).ToNot(BeEmpty())
+	g.Expect(result.RequeueAfter).To(Equal(time.Hour))
+	g.Expect(conditions.IsTrue(condition.KeyRotationReadyCondition)).To(BeTrue())
+
+	s := &corev1.Secret{}
+	g.Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "keystone-fernet-keys", Namespace: "openstack"}, s)).To(Succeed())
+	g.Expect(s.OwnerReferences).To(HaveLen(1))
+	g.Expect(s.Annotations).To(HaveKeyWithValue(RotatedAtAnnotation, "2026-01-01T00:00:00Z"))
+	keys, err := ParseKeys(FernetKeysPrefix, s.Data)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(keys.Indices()).To(Equal([]int{0, 1}))
+
+	volume := repo.Volume("fernet-keys")
+	g.Expect(volume.Secret.SecretName).To(Equal("keystone-fernet-keys"))
+	g.Expect(volume.Secret.Items).To(Equal([]corev1.KeyToPath{
+		{Key: "FernetKeys0", Path: "0"},
+		{Key: "FernetKeys1", Path: "1"},
+	}))
+
+	// the workloads did not roll out the keys yet
+	_, result, err = repo.Ensure(ctx, h, owner, &conditions, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(rolloutRequeue))
+	g.Expect(conditions.Get(condition.KeyRotationReadyCondition).Reason).To(BeEquivalentTo(condition.RequestedReason))
+
+	// rolled out, the rotation is not due yet
+	clock.SetTime(clock.Now().Add(20 * time.Minute))
+	_, result, err = repo.Ensure(ctx, h, owner, &conditions, hash)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(40 * time.Minute))
+	g.Expect(conditions.IsTrue(condition.KeyRotationReadyCondition)).To(BeTrue())
+
+	// the rotation is due
+	clock.SetTime(clock.Now().Add(time.Hour))
+	rotatedHash, result, err := repo.Ensure(ctx, h, owner, &conditions, hash)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rotatedHash).ToNot(Equal(hash))
+	g.Expect(result.RequeueAfter).To(Equal(rolloutRequeue))
+	g.Expect(conditions.Get(condition.KeyRotationReadyCondition).Message).To(ContainSubstring("keys rotated"))
+
+	g.Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "keystone-fernet-keys", Namespace: "openstack"}, s)).To(Succeed())
+	rotated, err := ParseKeys(FernetKeysPrefix, s.Data)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rotated.Indices()).To(Equal([]int{0, 1, 2}))
+	g.Expect(rotated[2]).To(Equal(keys[0]))
+	g.Expect(s.Annotations).To(HaveKeyWithValue(RotatedAtAnnotation, "2026-01-01T01:20:00Z"))
+	g.Expect(repo.Volume("fernet-keys").Secret.Items).To(HaveLen(3))
+
+	// no further rotation until the rotated keys got rolled out, even if due
+	clock.SetTime(clock.Now().Add(2 * time.Hour))
+	sameHash, _, err := repo.Ensure(ctx, h, owner, &conditions, hash)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sameHash).To(Equal(rotatedHash))
+	g.Expect(conditions.IsTrue(condition.KeyRotationReadyCondition)).To(BeFalse())
+}
+
+func TestRepositoryEnsureInvalidKeys(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", UID: "uid-1"}}
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keystone-credential-keys", Namespace: "openstack"},
+		Data:       map[string][]byte{"CredentialKeys0": []byte("invalid")},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(s).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	conditions := condition.Conditions{}
+	repo := NewRepository("keystone-credential-keys", "openstack", CredentialKeysPrefix, time.Hour)
+	_, _, err = repo.Ensure(ctx, h, owner, &conditions, "")
+	g.Expect(err).To(MatchError(ErrInvalidKeys))
+	g.Expect(conditions.Get(condition.KeyRotationReadyCondition).Reason).To(BeEquivalentTo(condition.ErrorReason))
+}
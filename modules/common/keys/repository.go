@@ -0,0 +1,303 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keys
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RotatedAtAnnotation - annotation of the keys secret with the time of
+	// the last rotation
+	RotatedAtAnnotation = "keystone.openstack.org/rotatedat"
+
+	// rolloutRequeue - requeue interval while waiting for the workloads to
+	// use the current keys
+	rolloutRequeue = 10 * time.Second
+)
+
+// Repository - a key repository stored in a secret. The secret is owned by
+// the repository, the fernet and the credential keys need a secret each.
+type Repository struct {
+	name             string
+	namespace        string
+	prefix           string
+	maxActiveKeys    int
+	rotationInterval time.Duration
+	conditionType    condition.Type
+	labels           map[string]string
+	secret           *corev1.Secret
+}
+
+// NewRepository returns an initialized Repository for the keys with the
+// prefix, e.g. FernetKeysPrefix, in the secret name. The keys get rotated
+// every rotationInterval, never if zero.
+func NewRepository(
+	name string,
+	namespace string,
+	prefix string,
+	rotationInterval time.Duration,
+) *Repository {
+	return &Repository{
+		name:             name,
+		namespace:        namespace,
+		prefix:           prefix,
+		maxActiveKeys:    DefaultMaxActiveKeys,
+		rotationInterval: rotationInterval,
+		conditionType:    condition.KeyRotationReadyCondition,
+	}
+}
+
+// SetMaxActiveKeys - sets the number of keys kept on rotation, the staged,
+// the primary and the secondary keys. DefaultMaxActiveKeys if not set.
+func (r *Repository) SetMaxActiveKeys(maxActiveKeys int) {
+	r.maxActiveKeys = maxActiveKeys
+}
+
+// SetConditionType - sets the condition the progress gets reported in, to
+// manage multiple repositories, e.g. the fernet and the credential keys.
+// KeyRotationReadyCondition if not set.
+func (r *Repository) SetConditionType(t condition.Type) {
+	r.conditionType = t
+}
+
+// SetLabels - sets the labels of the keys secret
+func (r *Repository) SetLabels(labels map[string]string) {
+	r.labels = labels
+}
+
+// GetSecret - returns the keys secret of the last Ensure
+func (r *Repository) GetSecret() *corev1.Secret {
+	return r.secret
+}
+
+// Ensure - creates the keys secret with new keys if it does not exist and
+// rotates the keys when the rotation interval passed. Returns the hash of
+// the secret, to be added to the config hash of the workloads mounting the
+// keys, so that they roll out the keys after a rotation.
+//
+// rolledOutHash must be the hash of the keys all workloads run with, e.g.
+// the one the ready deployment got applied with, and empty if they are not
+// ready. A due rotation is held back until the workloads run with the
+// current keys, as every node must know the staged key before the next
+// rotation promotes it to the primary key, else tokens issued with it get
+// rejected. The progress is reported in the KeyRotationReadyCondition, a
+// requeue is requested for the next rotation or while the rollout is
+// pending.
+//
+// Example:
+//
+//	fernetKeys := keys.NewRepository("keystone-fernet-keys", instance.Namespace, keys.FernetKeysPrefix, rotationInterval)
+//	rolledOutHash := ""
+//	if deployment.IsReady(depl.GetDeployment()) {
+//		// stored when the deployment got applied with the keys
+//		rolledOutHash = instance.Status.Hash["fernet-keys"]
+//	}
+//	hash, ctrlResult, err := fernetKeys.Ensure(ctx, h, instance, &instance.Status.Conditions, rolledOutHash)
+//	if err != nil {
+//		return ctrl.Result{}, err
+//	}
+//	envVars["fernet-keys"] = env.SetValue(hash)
+//	...
+//	volumes = append(volumes, fernetKeys.Volume("fernet-keys"))
+func (r *Repository) Ensure(
+	ctx context.Context,
+	h *helper.Helper,
+	owner client.Object,
+	conditions *condition.Conditions,
+	rolledOutHash string,
+) (string, ctrl.Result, error) {
+	hash, result, progress, err := r.ensure(ctx, h, owner, rolledOutHash)
+	switch {
+	case err != nil:
+		conditions.Set(condition.FalseCondition(
+			r.conditionType,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.KeyRotationReadyErrorMessage,
+			err.Error()))
+	case progress != "":
+		conditions.Set(condition.FalseCondition(
+			r.conditionType,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.KeyRotationReadyRunningMessage,
+			progress))
+	default:
+		conditions.MarkTrue(r.conditionType, condition.KeyRotationReadyMessage)
+	}
+	return hash, result, err
+}
+
+// ensure - creates or rotates the keys, returns the progress while the
+// rotation is not completed
+func (r *Repository) ensure(
+	ctx context.Context,
+	h *helper.Helper,
+	owner client.Object,
+	rolledOutHash string,
+) (string, ctrl.Result, string, error) {
+	if r.maxActiveKeys < 2 {
+		return "", ctrl.Result{}, "", ErrInvalidMaxActiveKeys
+	}
+	now := h.GetClock().Now().UTC()
+
+	s, hash, err := secret.GetSecret(ctx, h, r.name, r.namespace)
+	if k8s_errors.IsNotFound(err) {
+		keys, err := NewKeys()
+		if err != nil {
+			return "", ctrl.Result{}, "", err
+		}
+		hash, err := r.store(ctx, h, owner, nil, keys, now)
+		if err != nil {
+			return "", ctrl.Result{}, "", err
+		}
+		h.GetLogger().Info("Keys created", "secret", r.name)
+		// nothing got encrypted with other keys yet, no need to wait for
+		// the rollout
+		return hash, r.requeueForRotation(h, now), "", nil
+	}
+	if err != nil {
+		return "", ctrl.Result{}, "", err
+	}
+	r.secret = s
+
+	keys, err := ParseKeys(r.prefix, s.Data)
+	if err != nil {
+		return "", ctrl.Result{}, "", fmt.Errorf("secret %s: %w", r.name, err)
+	}
+
+	if rolledOutHash != hash {
+		return hash, ctrl.Result{RequeueAfter: rolloutRequeue}, "waiting for the workloads to use the current keys", nil
+	}
+
+	// a secret without a valid rotation time, e.g. created by an older
+	// version, gets rotated
+	rotatedAt, _ := time.Parse(time.RFC3339, s.Annotations[RotatedAtAnnotation])
+	if r.rotationInterval == 0 || now.Before(rotatedAt.Add(r.rotationInterval)) {
+		return hash, r.requeueForRotation(h, rotatedAt), "", nil
+	}
+
+	rotated, err := keys.Rotate(r.maxActiveKeys)
+	if err != nil {
+		return "", ctrl.Result{}, "", err
+	}
+	hash, err = r.store(ctx, h, owner, s, rotated, now)
+	if err != nil {
+		return "", ctrl.Result{}, "", err
+	}
+	h.GetLogger().Info("Keys rotated", "secret", r.name, "primary", rotated.Primary())
+
+	return hash, ctrl.Result{RequeueAfter: rolloutRequeue}, "keys rotated, waiting for the workloads to use them", nil
+}
+
+// requeueForRotation - returns the result to reconcile when the next
+// rotation after the one at rotatedAt is due
+func (r *Repository) requeueForRotation(h *helper.Helper, rotatedAt time.Time) ctrl.Result {
+	if r.rotationInterval == 0 {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: max(rotatedAt.Add(r.rotationInterval).Sub(h.GetClock().Now()), time.Second)}
+}
+
+// store - creates the secret with the keys if current is nil, else
+// replaces the keys of current. The patch is conditional on the resource
+// version of current, so that the keys never get rotated twice based on a
+// stale read.
+func (r *Repository) store(
+	ctx context.Context,
+	h *helper.Helper,
+	owner client.Object,
+	current *corev1.Secret,
+	keys Keys,
+	rotatedAt time.Time,
+) (string, error) {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.name,
+			Namespace: r.namespace,
+		},
+	}
+	if current != nil {
+		s = current.DeepCopy()
+	}
+
+	rotated := map[string]string{RotatedAtAnnotation: rotatedAt.Format(time.RFC3339)}
+	s.Labels = util.MergeStringMaps(r.labels, s.Labels)
+	s.Annotations = util.MergeStringMaps(rotated, s.Annotations)
+	h.ApplyPropagatedMetadata(s, r.labels, rotated)
+	s.Data = keys.Data(r.prefix)
+	err := object.SetControllerReference(owner, s, h.GetScheme())
+	if err != nil {
+		return "", err
+	}
+
+	if current == nil {
+		err = h.GetClient().Create(ctx, s)
+	} else {
+		err = h.GetClient().Patch(ctx, s, client.MergeFromWithOptions(current, client.MergeFromWithOptimisticLock{}))
+	}
+	if err != nil {
+		return "", fmt.Errorf("error storing keys in secret %s: %w", r.name, err)
+	}
+	r.secret = s
+
+	return secret.Hash(s)
+}
+
+// Volume - returns the volume of the keys secret of the last Ensure,
+// mounting the keys with their index as file name, like keystone expects
+// them in its key repository, e.g. /etc/keystone/fernet-keys. The items
+// change with every rotation, so the workloads roll out the rotated keys.
+func (r *Repository) Volume(name string) corev1.Volume {
+	items := []corev1.KeyToPath{}
+	if r.secret != nil {
+		keys, err := ParseKeys(r.prefix, r.secret.Data)
+		if err == nil {
+			for _, i := range keys.Indices() {
+				items = append(items, corev1.KeyToPath{
+					Key:  r.prefix + strconv.Itoa(i),
+					Path: strconv.Itoa(i),
+				})
+			}
+		}
+	}
+
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: r.name,
+				Items:      items,
+			},
+		},
+	}
+}
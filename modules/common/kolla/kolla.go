@@ -0,0 +1,168 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kolla provides the rendering of the config.json kolla_start uses
+// to copy the config files into place and start the service in the
+// OpenStack service containers
+package kolla
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ConfigPath - path kolla_start reads the config.json from
+	ConfigPath = "/var/lib/kolla/config_files/config.json"
+
+	// ConfigStrategyEnv - env var selecting when kolla_start copies the
+	// config files
+	ConfigStrategyEnv = "KOLLA_CONFIG_STRATEGY"
+	// ConfigStrategyCopyAlways - copy the config files on every start
+	ConfigStrategyCopyAlways = "COPY_ALWAYS"
+)
+
+// Define static errors
+var (
+	// ErrInvalidConfig indicates a config.json kolla_start can not use
+	ErrInvalidConfig = errors.New("invalid kolla config")
+)
+
+// ConfigFile - a file or directory kolla_start copies into place
+type ConfigFile struct {
+	// Source - path of the file in the container, e.g. of the mounted
+	// config secret. Can be a glob.
+	Source string `json:"source"`
+	// Dest - destination path, a directory if it ends with a /
+	Dest string `json:"dest"`
+	// Owner - owner of the copy, e.g. keystone or keystone:keystone
+	Owner string `json:"owner,omitempty"`
+	// Perm - octal permissions of the copy, e.g. 0600
+	Perm string `json:"perm,omitempty"`
+	// Optional - do not fail if the source does not exist
+	Optional bool `json:"optional,omitempty"`
+	// Merge - merge a source directory into an existing dest
+	Merge bool `json:"merge,omitempty"`
+}
+
+// Permission - ownership and permissions kolla_start sets before starting
+// the service
+type Permission struct {
+	// Path - path of the file or directory
+	Path string `json:"path"`
+	// Owner - owner to set, e.g. keystone:keystone
+	Owner string `json:"owner"`
+	// Perm - octal permissions to set
+	Perm string `json:"perm,omitempty"`
+	// Recurse - apply to the content of the directory as well
+	Recurse bool `json:"recurse,omitempty"`
+}
+
+// Config - the kolla config.json of a container
+type Config struct {
+	// Command - the command starting the service
+	Command string `json:"command"`
+	// ConfigFiles - the files copied into place
+	ConfigFiles []ConfigFile `json:"config_files,omitempty"`
+	// Permissions - the permissions to set
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// Validate - checks the config for the fields kolla_start requires
+func (c Config) Validate() error {
+	if c.Command == "" {
+		return fmt.Errorf("%w: command is required", ErrInvalidConfig)
+	}
+	for i, f := range c.ConfigFiles {
+		if f.Source == "" || f.Dest == "" {
+			return fmt.Errorf("%w: config_files[%d]: source and dest are required", ErrInvalidConfig, i)
+		}
+		if err := validatePerm(f.Perm); err != nil {
+			return fmt.Errorf("%w: config_files[%d]: %w", ErrInvalidConfig, i, err)
+		}
+	}
+	for i, p := range c.Permissions {
+		if p.Path == "" || p.Owner == "" {
+			return fmt.Errorf("%w: permissions[%d]: path and owner are required", ErrInvalidConfig, i)
+		}
+		if err := validatePerm(p.Perm); err != nil {
+			return fmt.Errorf("%w: permissions[%d]: %w", ErrInvalidConfig, i, err)
+		}
+	}
+	return nil
+}
+
+func validatePerm(perm string) error {
+	if perm == "" {
+		return nil
+	}
+	if _, err := strconv.ParseUint(perm, 8, 32); err != nil {
+		return fmt.Errorf("perm %q is not octal", perm)
+	}
+	return nil
+}
+
+// Render - validates the config and returns it as config.json
+func (c Config) Render() (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// AddToTemplate - renders the config and adds it as key to the CustomData
+// of the config secret template t, e.g. before passing it to
+// secret.EnsureSecrets
+//
+// Example:
+//
+//	err := kolla.Config{
+//		Command: "/usr/sbin/httpd -DFOREGROUND",
+//		ConfigFiles: []kolla.ConfigFile{
+//			{Source: "/var/lib/config-data/merged/keystone.conf", Dest: "/etc/keystone/keystone.conf", Owner: "keystone", Perm: "0600"},
+//		},
+//	}.AddToTemplate(&cms[0], "keystone-api-config.json")
+func (c Config) AddToTemplate(t *util.Template, key string) error {
+	data, err := c.Render()
+	if err != nil {
+		return err
+	}
+	if t.CustomData == nil {
+		t.CustomData = map[string]string{}
+	}
+	t.CustomData[key] = data
+	return nil
+}
+
+// VolumeMount - returns the mount of key of the config secret volume as
+// the config.json of kolla_start
+func VolumeMount(volume string, key string) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      volume,
+		MountPath: ConfigPath,
+		SubPath:   key,
+		ReadOnly:  true,
+	}
+}
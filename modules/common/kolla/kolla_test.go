@@ -0,0 +1,66 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kolla
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+)
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+
+	config := Config{
+		Command: "/usr/sbin/httpd -DFOREGROUND",
+		ConfigFiles: []ConfigFile{
+			{Source: "/var/lib/config-data/merged/keystone.conf", Dest: "/etc/keystone/keystone.conf", Owner: "keystone", Perm: "0600"},
+			{Source: "/var/lib/config-data/tls/certs/*", Dest: "/etc/pki/tls/certs/", Optional: true},
+		},
+		Permissions: []Permission{
+			{Path: "/var/log/keystone", Owner: "keystone:keystone", Recurse: true},
+		},
+	}
+
+	data, err := config.Render()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data).To(MatchJSON(`{
+		"command": "/usr/sbin/httpd -DFOREGROUND",
+		"config_files": [
+			{"source": "/var/lib/config-data/merged/keystone.conf", "dest": "/etc/keystone/keystone.conf", "owner": "keystone", "perm": "0600"},
+			{"source": "/var/lib/config-data/tls/certs/*", "dest": "/etc/pki/tls/certs/", "optional": true}
+		],
+		"permissions": [
+			{"path": "/var/log/keystone", "owner": "keystone:keystone", "recurse": true}
+		]
+	}`))
+
+	tmpl := util.Template{Name: "keystone-config-data"}
+	g.Expect(config.AddToTemplate(&tmpl, "keystone-api-config.json")).To(Succeed())
+	g.Expect(tmpl.CustomData).To(HaveKeyWithValue("keystone-api-config.json", data))
+}
+
+func TestValidate(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Config{}.Validate()).To(MatchError(ErrInvalidConfig))
+	g.Expect(Config{Command: "a", ConfigFiles: []ConfigFile{{Source: "a"}}}.Validate()).To(MatchError(ContainSubstring("config_files[0]")))
+	g.Expect(Config{Command: "a", ConfigFiles: []ConfigFile{{Source: "a", Dest: "b", Perm: "0999"}}}.Validate()).To(MatchError(ContainSubstring("not octal")))
+	g.Expect(Config{Command: "a", Permissions: []Permission{{Path: "a"}}}.Validate()).To(MatchError(ContainSubstring("permissions[0]")))
+	g.Expect(Config{Command: "a", Permissions: []Permission{{Path: "a", Owner: "b", Perm: "0755"}}}.Validate()).To(Succeed())
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Owner labels the lib-common create helpers set on the objects they create
+// for a CR, independent of the service. Unlike the owner references they
+// can be used in label selectors, e.g. to list all children of a CR.
+const (
+	OwnerKindLabel = "openstack.org/owner-kind"
+	OwnerNameLabel = "openstack.org/owner-name"
+	OwnerUIDLabel  = "openstack.org/owner-uid"
+)
+
+// GetOwnerLabels - returns the owner labels of the children of owner of
+// kind. The name label is omitted if the name is no valid label value, e.g.
+// longer than 63 characters.
+func GetOwnerLabels(kind string, owner metav1.Object) map[string]string {
+	ownerLabels := map[string]string{
+		OwnerKindLabel: kind,
+		OwnerUIDLabel:  string(owner.GetUID()),
+	}
+	if len(validation.IsValidLabelValue(owner.GetName())) == 0 {
+		ownerLabels[OwnerNameLabel] = owner.GetName()
+	}
+
+	return ownerLabels
+}
+
+// GetOwnerSelector - returns the labels selecting the children of owner.
+// Only the UID gets selected, as unlike the name it is not reused if the
+// owner gets recreated.
+//
+// Example:
+//
+//	err := h.GetClient().List(ctx, jobs, client.InNamespace(instance.Namespace),
+//		client.MatchingLabels(labels.GetOwnerSelector(instance)))
+func GetOwnerSelector(owner metav1.Object) map[string]string {
+	return map[string]string{
+		OwnerUIDLabel: string(owner.GetUID()),
+	}
+}
+
+// SetOwnerLabels - adds the owner labels of owner to obj, the kind of owner
+// is looked up in the scheme
+func SetOwnerLabels(owner client.Object, obj metav1.Object, scheme *runtime.Scheme) error {
+	gvk, err := apiutil.GVKForObject(owner, scheme)
+	if err != nil {
+		return err
+	}
+
+	// the owner labels take precedence over the ones of obj
+	obj.SetLabels(util.MergeStringMaps(GetOwnerLabels(gvk.Kind, owner), obj.GetLabels()))
+
+	return nil
+}
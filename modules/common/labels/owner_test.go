@@ -0,0 +1,52 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestOwnerLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", UID: "uid-1"}}
+	child := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:   "keystone-config",
+		Labels: map[string]string{"service": "keystone", OwnerUIDLabel: "stale"},
+	}}
+
+	g.Expect(SetOwnerLabels(owner, child, scheme.Scheme)).To(Succeed())
+	g.Expect(child.Labels).To(Equal(map[string]string{
+		"service":      "keystone",
+		OwnerKindLabel: "ConfigMap",
+		OwnerNameLabel: "keystone",
+		OwnerUIDLabel:  "uid-1",
+	}))
+	g.Expect(GetOwnerSelector(owner)).To(Equal(map[string]string{OwnerUIDLabel: "uid-1"}))
+	g.Expect(ValidateLabels(child.Labels)).To(Succeed())
+
+	// names longer than label values can be are left out
+	owner.Name = strings.Repeat("a", 64)
+	g.Expect(GetOwnerLabels("ConfigMap", owner)).ToNot(HaveKey(OwnerNameLabel))
+}
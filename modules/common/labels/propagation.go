@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultPropagationDenylist - keys which never get propagated from the CR
+// to its children, even if allowed by the PropagationPolicy
+var DefaultPropagationDenylist = []string{
+	"kubectl.kubernetes.io/*",
+	"openstack.org/*",
+}
+
+// PropagationPolicy - selects the labels and annotations of a CR which get
+// propagated to the children created for it. Entries are either exact keys,
+// or prefixes ending with "*", e.g. "example.com/*". Nothing gets propagated
+// unless allowed, the excludes take precedence over the allow lists.
+type PropagationPolicy struct {
+	// Labels - allowlist of label keys
+	Labels []string
+	// ExcludeLabels - denylist of label keys
+	ExcludeLabels []string
+	// Annotations - allowlist of annotation keys
+	Annotations []string
+	// ExcludeAnnotations - denylist of annotation keys
+	ExcludeAnnotations []string
+}
+
+// GetPropagatedLabels - returns the labels of the object which are allowed
+// to be propagated to its children
+func (p *PropagationPolicy) GetPropagatedLabels(obj metav1.Object) map[string]string {
+	return filterKeys(obj.GetLabels(), p.Labels, p.ExcludeLabels)
+}
+
+// GetPropagatedAnnotations - returns the annotations of the object which
+// are allowed to be propagated to its children
+func (p *PropagationPolicy) GetPropagatedAnnotations(obj metav1.Object) map[string]string {
+	return filterKeys(obj.GetAnnotations(), p.Annotations, p.ExcludeAnnotations)
+}
+
+// filterKeys - returns the entries of m matching allow and not matching deny
+// or the DefaultPropagationDenylist, nil if none
+func filterKeys(m map[string]string, allow []string, deny []string) map[string]string {
+	var filtered map[string]string
+	for k, v := range m {
+		if !matchesAny(k, allow) || matchesAny(k, deny) || matchesAny(k, DefaultPropagationDenylist) {
+			continue
+		}
+		if filtered == nil {
+			filtered = map[string]string{}
+		}
+		filtered[k] = v
+	}
+	return filtered
+}
+
+// matchesAny - returns true if key matches one of the patterns
+func matchesAny(key string, patterns []string) bool {
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+			continue
+		}
+		if key == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPropagationPolicy(t *testing.T) {
+	obj := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"cost-center":           "1234",
+				"backup.example.com/on": "true",
+				"backup.example.com/x":  "skip",
+				"service":               "nova",
+			},
+			Annotations: map[string]string{
+				"backup.example.com/schedule":                      "daily",
+				"openstack.org/reconcile-trigger":                  "1",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+	}
+
+	t.Run("Allow and exclude", func(t *testing.T) {
+		g := NewWithT(t)
+		p := PropagationPolicy{
+			Labels:        []string{"cost-center", "backup.example.com/*"},
+			ExcludeLabels: []string{"backup.example.com/x"},
+			Annotations:   []string{"*"},
+		}
+
+		g.Expect(p.GetPropagatedLabels(obj)).To(Equal(map[string]string{
+			"cost-center":           "1234",
+			"backup.example.com/on": "true",
+		}))
+		// the default denylist always applies
+		g.Expect(p.GetPropagatedAnnotations(obj)).To(Equal(map[string]string{
+			"backup.example.com/schedule": "daily",
+		}))
+	})
+
+	t.Run("Nothing allowed", func(t *testing.T) {
+		g := NewWithT(t)
+		p := PropagationPolicy{}
+		g.Expect(p.GetPropagatedLabels(obj)).To(BeNil())
+		g.Expect(p.GetPropagatedAnnotations(obj)).To(BeNil())
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Define static errors
+var (
+	// ErrEmptyLabelSelector indicates a label selector which would match everything
+	ErrEmptyLabelSelector = errors.New("label selector is empty")
+	// ErrInvalidLabelSelector indicates a label selector which can not be parsed
+	ErrInvalidLabelSelector = errors.New("invalid label selector")
+	// ErrInvalidLabel indicates a label with an invalid key or value
+	ErrInvalidLabel = errors.New("invalid label")
+)
+
+// ValidateLabelSelector - returns an error if the selector can not be
+// parsed, or if it is empty. An empty selector matches all objects in the
+// namespace, which is never what a service wants to select.
+func ValidateLabelSelector(selector *metav1.LabelSelector) error {
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return ErrEmptyLabelSelector
+	}
+
+	_, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLabelSelector, err)
+	}
+
+	return nil
+}
+
+// ValidateLabels - returns an error listing all label keys and values
+// which are not valid kubernetes labels
+func ValidateLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	msgs := []string{}
+	for _, k := range keys {
+		for _, msg := range validation.IsQualifiedName(k) {
+			msgs = append(msgs, fmt.Sprintf("key %s: %s", k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(labels[k]) {
+			msgs = append(msgs, fmt.Sprintf("value %s of key %s: %s", labels[k], k, msg))
+		}
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidLabel, strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
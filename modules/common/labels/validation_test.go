@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateLabelSelector(t *testing.T) {
+	t.Run("Valid selector", func(t *testing.T) {
+		g := NewWithT(t)
+		s := GetAppLabelSelector("nova")
+		g.Expect(ValidateLabelSelector(&s)).To(Succeed())
+	})
+
+	t.Run("Empty selector", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(ValidateLabelSelector(nil)).To(MatchError(ErrEmptyLabelSelector))
+		g.Expect(ValidateLabelSelector(&metav1.LabelSelector{})).To(MatchError(ErrEmptyLabelSelector))
+	})
+
+	t.Run("Invalid selector", func(t *testing.T) {
+		g := NewWithT(t)
+		err := ValidateLabelSelector(&metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "service", Operator: metav1.LabelSelectorOpIn},
+			},
+		})
+		g.Expect(err).To(MatchError(ErrInvalidLabelSelector))
+
+		err = ValidateLabelSelector(&metav1.LabelSelector{
+			MatchLabels: map[string]string{"service": "nova api"},
+		})
+		g.Expect(err).To(MatchError(ErrInvalidLabelSelector))
+	})
+}
+
+func TestValidateLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ValidateLabels(map[string]string{"service": "nova", "foo.openstack.org/uid": ""})).To(Succeed())
+
+	err := ValidateLabels(map[string]string{"service": "nova api", "bad key": "x"})
+	g.Expect(err).To(MatchError(ErrInvalidLabel))
+	g.Expect(err.Error()).To(ContainSubstring("key bad key"))
+	g.Expect(err.Error()).To(ContainSubstring("value nova api of key service"))
+}
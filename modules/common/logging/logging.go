@@ -0,0 +1,272 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/podspec"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// RsyslogConfigKey - key of the rsyslog config in the config secret of
+	// the service
+	RsyslogConfigKey = "rsyslog-forward.conf"
+	// RsyslogConfigPath - path the rsyslog sidecar reads its config from
+	RsyslogConfigPath = "/etc/rsyslog-forward.conf"
+)
+
+// ErrInvalidSource indicates a Source the log sidecar can not be wired for
+var ErrInvalidSource = errors.New("invalid log source")
+
+// Source - the log files of a service
+// +kubebuilder:object:generate:=false
+type Source struct {
+	// Name - name of the service, e.g. glance-api. The sidecar is named
+	// <Name>-log.
+	Name string
+	// Image - image of the sidecar, usually the one of the service, which
+	// has tail and rsyslog
+	Image string
+	// LogDir - directory the service writes the log files to, e.g.
+	// /var/log/glance
+	LogDir string
+	// Files - names of the log files in LogDir, e.g. glance-api.log
+	Files []string
+	// ConfigSecret - name of the config secret of the service, the rsyslog
+	// config gets added to it, see AddToTemplate
+	ConfigSecret string
+	// SecurityContext - security context of the sidecar, e.g. the one of
+	// the service container
+	SecurityContext *corev1.SecurityContext
+}
+
+// containerName - returns the name of the sidecar
+func (src Source) containerName() string {
+	return src.Name + "-log"
+}
+
+// paths - returns the paths of the log files
+func (src Source) paths() []string {
+	paths := []string{}
+	for _, f := range src.Files {
+		paths = append(paths, filepath.Join(src.LogDir, f))
+	}
+	return paths
+}
+
+// validate - checks the source has the fields the wiring requires
+func (src Source) validate() error {
+	if src.Name == "" || src.Image == "" || src.LogDir == "" || len(src.Files) == 0 {
+		return fmt.Errorf("%w: name, image, log dir and files are required", ErrInvalidSource)
+	}
+	return nil
+}
+
+// LogVolume - returns the volume the service writes its log files to and
+// the sidecar reads them from
+func (src Source) LogVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: src.Name + "-logs",
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+// LogVolumeMount - returns the mount of the log volume at the log dir, to
+// be added to the service container
+func (src Source) LogVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      src.Name + "-logs",
+		MountPath: src.LogDir,
+	}
+}
+
+// Enabled - returns true if the logs get forwarded
+func (s Spec) Enabled() bool {
+	return s.Mode != "" && s.Mode != ModeNone
+}
+
+// protocol - returns the protocol, tcp if not set
+func (s Spec) protocol() Protocol {
+	if s.Protocol == "" {
+		return ProtocolTCP
+	}
+	return s.Protocol
+}
+
+// Validate - validates the spec, for the webhook of the service CR
+func (s Spec) Validate(basePath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch s.Mode {
+	case "", ModeNone, ModeSidecar:
+	case ModeRsyslog:
+		host, port, err := net.SplitHostPort(s.Target)
+		if err == nil && host == "" {
+			err = errors.New("missing host")
+		}
+		if err == nil {
+			if p, perr := strconv.Atoi(port); perr != nil || p < 1 || p > 65535 {
+				err = fmt.Errorf("invalid port %s", port)
+			}
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("target"), s.Target,
+				fmt.Sprintf("must be host:port of the syslog server: %s", err)))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(basePath.Child("mode"), s.Mode,
+			[]Mode{ModeNone, ModeSidecar, ModeRsyslog}))
+	}
+
+	switch s.protocol() {
+	case ProtocolTCP, ProtocolUDP:
+	default:
+		allErrs = append(allErrs, field.NotSupported(basePath.Child("protocol"), s.Protocol,
+			[]Protocol{ProtocolTCP, ProtocolUDP}))
+	}
+
+	return allErrs
+}
+
+// RsyslogConfig - returns the rsyslog config forwarding the log files of
+// src to the target
+func (s Spec) RsyslogConfig(src Source) (string, error) {
+	host, port, err := net.SplitHostPort(s.Target)
+	if err != nil {
+		return "", fmt.Errorf("invalid log forwarding target %s: %w", s.Target, err)
+	}
+
+	var b strings.Builder
+	b.WriteString("global(workDirectory=\"/tmp\")\n")
+	b.WriteString("module(load=\"imfile\" mode=\"inotify\")\n")
+	for _, p := range src.paths() {
+		tag := strings.TrimSuffix(filepath.Base(p), filepath.Ext(p))
+		fmt.Fprintf(&b, "input(type=\"imfile\" File=%q Tag=%q)\n", p, tag+":")
+	}
+	fmt.Fprintf(&b, "action(type=\"omfwd\" Target=%q Port=%q Protocol=%q)\n", host, port, s.protocol())
+
+	return b.String(), nil
+}
+
+// AddToTemplate - adds the rsyslog config as RsyslogConfigKey to the
+// CustomData of the config secret template t in the rsyslog mode, nothing
+// is added in the other modes
+func (s Spec) AddToTemplate(t *util.Template, src Source) error {
+	if s.Mode != ModeRsyslog {
+		return nil
+	}
+
+	data, err := s.RsyslogConfig(src)
+	if err != nil {
+		return err
+	}
+	if t.CustomData == nil {
+		t.CustomData = map[string]string{}
+	}
+	t.CustomData[RsyslogConfigKey] = data
+	return nil
+}
+
+// Injections - returns the log sidecar of the mode for the pod spec of the
+// service, none if the logs are not forwarded. The service container must
+// mount the LogVolumeMount of src.
+//
+// Example:
+//
+//	logSource := logging.Source{
+//		Name:         "glance-api",
+//		Image:        instance.Spec.ContainerImage,
+//		LogDir:       "/var/log/glance",
+//		Files:        []string{"glance-api.log"},
+//		ConfigSecret: configSecretName,
+//	}
+//	err := instance.Spec.Logging.AddToTemplate(&cms[0], logSource)
+//	...
+//	apiContainer.VolumeMounts = append(apiContainer.VolumeMounts, logSource.LogVolumeMount())
+//	logInjections, err := instance.Spec.Logging.Injections(logSource)
+//	...
+//	template, err := podspec.Spec{
+//		Base:       apiContainer,
+//		Injections: append(injections, logInjections...),
+//	}.Build()
+func (s Spec) Injections(src Source) ([]podspec.Injection, error) {
+	if !s.Enabled() {
+		return nil, nil
+	}
+	err := src.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	container := corev1.Container{
+		Name:            src.containerName(),
+		Image:           src.Image,
+		SecurityContext: src.SecurityContext,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      src.Name + "-logs",
+				MountPath: src.LogDir,
+				ReadOnly:  true,
+			},
+		},
+	}
+	if s.Resources != nil {
+		container.Resources = *s.Resources.DeepCopy()
+	}
+	volumes := []corev1.Volume{src.LogVolume()}
+
+	switch s.Mode {
+	case ModeSidecar:
+		container.Command = append([]string{"/usr/bin/tail", "-n+1", "-F"}, src.paths()...)
+	case ModeRsyslog:
+		if src.ConfigSecret == "" {
+			return nil, fmt.Errorf("%w: config secret is required for %s", ErrInvalidSource, ModeRsyslog)
+		}
+		configVolume := src.containerName() + "-config"
+		container.Command = []string{"/usr/sbin/rsyslogd", "-n", "-f", RsyslogConfigPath, "-i", "/tmp/rsyslogd.pid"}
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      configVolume,
+			MountPath: RsyslogConfigPath,
+			SubPath:   RsyslogConfigKey,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: configVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: src.ConfigSecret,
+					Items:      []corev1.KeyToPath{{Key: RsyslogConfigKey, Path: RsyslogConfigKey}},
+				},
+			},
+		})
+	default:
+		return nil, fmt.Errorf("%w: unsupported mode %s", ErrInvalidSource, s.Mode)
+	}
+
+	return []podspec.Injection{{Container: container, Volumes: volumes}}, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/podspec"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var source = Source{
+	Name:         "glance-api",
+	Image:        "glance:latest",
+	LogDir:       "/var/log/glance",
+	Files:        []string{"glance-api.log"},
+	ConfigSecret: "glance-config-data",
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   Spec
+		errors []string
+	}{
+		{name: "default", spec: Spec{}},
+		{name: "sidecar", spec: Spec{Mode: ModeSidecar}},
+		{name: "rsyslog", spec: Spec{Mode: ModeRsyslog, Target: "syslog.logging.svc:514", Protocol: ProtocolUDP}},
+		{name: "rsyslog IPv6", spec: Spec{Mode: ModeRsyslog, Target: "[fd00::1]:514"}},
+		{name: "rsyslog without target", spec: Spec{Mode: ModeRsyslog}, errors: []string{"spec.logging.target"}},
+		{name: "rsyslog without port", spec: Spec{Mode: ModeRsyslog, Target: "syslog"}, errors: []string{"spec.logging.target"}},
+		{name: "rsyslog invalid port", spec: Spec{Mode: ModeRsyslog, Target: "syslog:0"}, errors: []string{"spec.logging.target"}},
+		{name: "invalid mode", spec: Spec{Mode: "fluentd"}, errors: []string{"spec.logging.mode"}},
+		{name: "invalid protocol", spec: Spec{Mode: ModeSidecar, Protocol: "relp"}, errors: []string{"spec.logging.protocol"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fields := []string{}
+			for _, e := range tt.spec.Validate(field.NewPath("spec", "logging")) {
+				fields = append(fields, e.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.errors))
+		})
+	}
+}
+
+func TestInjectionsNone(t *testing.T) {
+	g := NewWithT(t)
+
+	for _, s := range []Spec{{}, {Mode: ModeNone}} {
+		injections, err := s.Injections(source)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(injections).To(BeEmpty())
+
+		tmpl := &util.Template{}
+		g.Expect(s.AddToTemplate(tmpl, source)).To(Succeed())
+		g.Expect(tmpl.CustomData).To(BeEmpty())
+	}
+}
+
+func TestInjectionsSidecar(t *testing.T) {
+	g := NewWithT(t)
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Mi")},
+	}
+	s := Spec{Mode: ModeSidecar, Resources: &resources}
+	injections, err := s.Injections(source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(injections).To(HaveLen(1))
+
+	c := injections[0].Container
+	g.Expect(c.Name).To(Equal("glance-api-log"))
+	g.Expect(c.Image).To(Equal("glance:latest"))
+	g.Expect(c.Command).To(Equal([]string{"/usr/bin/tail", "-n+1", "-F", "/var/log/glance/glance-api.log"}))
+	g.Expect(c.Resources).To(Equal(resources))
+	g.Expect(c.VolumeMounts).To(Equal([]corev1.VolumeMount{
+		{Name: "glance-api-logs", MountPath: "/var/log/glance", ReadOnly: true},
+	}))
+	g.Expect(injections[0].Volumes).To(Equal([]corev1.Volume{source.LogVolume()}))
+
+	// wired into the pod spec with the service container
+	api := corev1.Container{Name: "glance-api", VolumeMounts: []corev1.VolumeMount{source.LogVolumeMount()}}
+	template, err := podspec.Spec{Base: api, Injections: injections}.Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(template.Spec.Containers).To(HaveLen(2))
+	g.Expect(template.Spec.Volumes).To(HaveLen(1))
+
+	_, err = s.Injections(Source{Name: "glance-api"})
+	g.Expect(err).To(MatchError(ErrInvalidSource))
+}
+
+func TestInjectionsRsyslog(t *testing.T) {
+	g := NewWithT(t)
+
+	s := Spec{Mode: ModeRsyslog, Target: "syslog.logging.svc:514"}
+	tmpl := &util.Template{}
+	g.Expect(s.AddToTemplate(tmpl, source)).To(Succeed())
+	g.Expect(tmpl.CustomData).To(HaveKeyWithValue(RsyslogConfigKey, `global(workDirectory="/tmp")
+module(load="imfile" mode="inotify")
+input(type="imfile" File="/var/log/glance/glance-api.log" Tag="glance-api:")
+action(type="omfwd" Target="syslog.logging.svc" Port="514" Protocol="tcp")
+`))
+
+	injections, err := s.Injections(source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(injections).To(HaveLen(1))
+	c := injections[0].Container
+	g.Expect(c.Command).To(ContainElement(RsyslogConfigPath))
+	g.Expect(c.Resources).To(Equal(corev1.ResourceRequirements{}))
+	g.Expect(c.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+		Name:      "glance-api-log-config",
+		MountPath: RsyslogConfigPath,
+		SubPath:   RsyslogConfigKey,
+		ReadOnly:  true,
+	}))
+	g.Expect(injections[0].Volumes).To(HaveLen(2))
+	g.Expect(injections[0].Volumes[1].Secret.SecretName).To(Equal("glance-config-data"))
+
+	noSecret := source
+	noSecret.ConfigSecret = ""
+	_, err = s.Injections(noSecret)
+	g.Expect(err).To(MatchError(ErrInvalidSource))
+
+	g.Expect(Spec{Mode: ModeRsyslog, Target: "syslog"}.AddToTemplate(tmpl, source)).ToNot(Succeed())
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:object:generate:=true
+
+// Package logging provides the wiring of log forwarding into the pods of a
+// service, either a sidecar tailing the log files to its output, or an
+// rsyslog sidecar forwarding them to a central syslog server
+package logging
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Mode - how the logs of the service get forwarded
+type Mode string
+
+const (
+	// ModeNone - the logs are not forwarded
+	ModeNone Mode = "none"
+	// ModeSidecar - a sidecar tails the log files to its output, to be
+	// collected with the container logs
+	ModeSidecar Mode = "sidecar"
+	// ModeRsyslog - an rsyslog sidecar forwards the log files to Target
+	ModeRsyslog Mode = "rsyslog"
+)
+
+// Protocol - the protocol rsyslog forwards with
+type Protocol string
+
+const (
+	// ProtocolTCP - forward via TCP
+	ProtocolTCP Protocol = "tcp"
+	// ProtocolUDP - forward via UDP
+	ProtocolUDP Protocol = "udp"
+)
+
+// Spec - the log forwarding of a service, to be embedded in the spec of a
+// service CR
+type Spec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=none;sidecar;rsyslog
+	// +kubebuilder:default:=none
+	// Mode - how the logs of the service get forwarded, none, sidecar to
+	// tail the log files to the container logs, or rsyslog to forward them
+	// to a syslog server
+	Mode Mode `json:"mode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Target - the syslog server the rsyslog mode forwards to, as host:port
+	Target string `json:"target,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=tcp;udp
+	// +kubebuilder:default:=tcp
+	// Protocol - the protocol the rsyslog mode forwards with
+	Protocol Protocol `json:"protocol,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Resources - the resources of the log sidecar, the defaults of the
+	// service for sidecars if not set
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
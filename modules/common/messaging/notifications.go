@@ -0,0 +1,130 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+const (
+	// NotificationsDriverMessaging - oslo.messaging notification driver
+	// sending to the notifications bus
+	NotificationsDriverMessaging = "messagingv2"
+	// NotificationsDriverNoop - oslo.messaging notification driver dropping
+	// the notifications
+	NotificationsDriverNoop = "noop"
+	// DefaultNotificationsTopic - topic the notifications get sent to
+	DefaultNotificationsTopic = "notifications"
+)
+
+// Notifications - the notifications bus of a service, separate from the
+// RPC bus of its TransportURL
+type Notifications struct {
+	// TransportURL - the transport of the notifications bus, notifications
+	// are disabled if nil
+	TransportURL *TransportURL
+	// Driver - the notification driver, NotificationsDriverMessaging if empty
+	Driver string
+	// Topics - the topics, DefaultNotificationsTopic if empty
+	Topics []string
+}
+
+// Enabled - returns true if the notifications get sent
+func (n Notifications) Enabled() bool {
+	return n.TransportURL != nil
+}
+
+func (n Notifications) driver() string {
+	if !n.Enabled() {
+		return NotificationsDriverNoop
+	}
+	if n.Driver != "" {
+		return n.Driver
+	}
+	return NotificationsDriverMessaging
+}
+
+func (n Notifications) topics() string {
+	if len(n.Topics) == 0 {
+		return DefaultNotificationsTopic
+	}
+	return strings.Join(n.Topics, ",")
+}
+
+// OsloMessagingNotificationsConfig - returns the
+// [oslo_messaging_notifications] section. With notifications disabled the
+// noop driver is set, so services do not fall back to the RPC bus.
+func (n Notifications) OsloMessagingNotificationsConfig() string {
+	b := strings.Builder{}
+	b.WriteString("[oslo_messaging_notifications]\n")
+	fmt.Fprintf(&b, "driver = %s\n", n.driver())
+	if n.Enabled() {
+		fmt.Fprintf(&b, "transport_url = %s\n", n.TransportURL.String())
+		fmt.Fprintf(&b, "topics = %s\n", n.topics())
+	}
+	return b.String()
+}
+
+// TemplateParameters - returns the notification parameters for the
+// ConfigOptions of a util.Template
+func (n Notifications) TemplateParameters() map[string]interface{} {
+	params := map[string]interface{}{
+		"NotificationsEnabled": n.Enabled(),
+		"NotificationsDriver":  n.driver(),
+		"NotificationsURL":     "",
+		"NotificationsTopics":  n.topics(),
+	}
+	if n.Enabled() {
+		params["NotificationsURL"] = n.TransportURL.String()
+	}
+	return params
+}
+
+// Hash - returns a hash of the notifications settings, e.g. for an env var
+// of the pods to restart them on changes
+func (n Notifications) Hash() (string, error) {
+	if !n.Enabled() {
+		return "", nil
+	}
+	return n.TransportURL.Hash()
+}
+
+// GetNotifications - returns the notifications bus of the TransportURL
+// secret name in namespace. Notifications are disabled if name is empty,
+// e.g. if the notificationsBus of the CR is not set.
+//
+// Example:
+//
+//	notifications, err := messaging.GetNotifications(ctx, h, instance.Status.NotificationsURLSecret, instance.Namespace)
+//	if err != nil {
+//		return ctrl.Result{}, err
+//	}
+//	maps.Copy(templateParameters, notifications.TemplateParameters())
+func GetNotifications(ctx context.Context, h *helper.Helper, name string, namespace string) (Notifications, error) {
+	if name == "" {
+		return Notifications{}, nil
+	}
+	t, err := GetTransportURL(ctx, h, name, namespace)
+	if err != nil {
+		return Notifications{}, err
+	}
+	return Notifications{TransportURL: t}, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics provides standard prometheus collectors for the reconcile
+// outcomes and managed resources of the OpenStack operators
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	"github.com/prometheus/client_golang/prometheus"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// Namespace - prefix of all the metric names
+	Namespace = "openstack_operator"
+
+	// ResultSuccess - reconcile finished without requeue
+	ResultSuccess = "success"
+	// ResultRequeue - reconcile finished and requested a requeue
+	ResultRequeue = "requeue"
+	// ResultError - reconcile returned an error
+	ResultError = "error"
+
+	// ErrorClassNotFound - an object was not found
+	ErrorClassNotFound = "not_found"
+	// ErrorClassConflict - an update conflicted or the object already exists
+	ErrorClassConflict = "conflict"
+	// ErrorClassTimeout - waiting for a resource or an API call timed out
+	ErrorClassTimeout = "timeout"
+	// ErrorClassForbidden - the API denied access
+	ErrorClassForbidden = "forbidden"
+	// ErrorClassInvalid - the API rejected an invalid object
+	ErrorClassInvalid = "invalid"
+	// ErrorClassOther - any other error
+	ErrorClassOther = "other"
+)
+
+// Collectors - the standard collectors of an operator
+type Collectors struct {
+	// ReconcileDuration - reconcile duration per controller and result
+	ReconcileDuration *prometheus.HistogramVec
+	// ReconcileTotal - reconciles per controller and result
+	ReconcileTotal *prometheus.CounterVec
+	// ReconcileErrors - reconcile errors per controller and error class
+	ReconcileErrors *prometheus.CounterVec
+	// Resources - number of managed resources per controller and kind
+	Resources *prometheus.GaugeVec
+	// DriftedResources - number of managed resources with out-of-band
+	// changes per controller and kind
+	DriftedResources *prometheus.GaugeVec
+	// DriftReverted - reverted out-of-band changes per controller and kind
+	DriftReverted *prometheus.CounterVec
+}
+
+// NewCollectors - returns new, not registered, Collectors
+func NewCollectors() *Collectors {
+	return &Collectors{
+		ReconcileDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "reconcile_duration_seconds",
+			Help:      "Duration of the reconciles per controller and result",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"controller", "result"}),
+		ReconcileTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "reconcile_total",
+			Help:      "Number of reconciles per controller and result",
+		}, []string{"controller", "result"}),
+		ReconcileErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "reconcile_errors_total",
+			Help:      "Number of reconcile errors per controller and error class",
+		}, []string{"controller", "error_class"}),
+		Resources: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "resources",
+			Help:      "Number of resources managed per controller and kind",
+		}, []string{"controller", "kind"}),
+		DriftedResources: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "drifted_resources",
+			Help:      "Number of managed resources with out-of-band changes per controller and kind",
+		}, []string{"controller", "kind"}),
+		DriftReverted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "drift_reverted_total",
+			Help:      "Number of reverted out-of-band changes per controller and kind",
+		}, []string{"controller", "kind"}),
+	}
+}
+
+// Register - registers the collectors with the registerer
+func (c *Collectors) Register(r prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{
+		c.ReconcileDuration,
+		c.ReconcileTotal,
+		c.ReconcileErrors,
+		c.Resources,
+		c.DriftedResources,
+		c.DriftReverted,
+	} {
+		if err := r.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordReconcile - records the duration, result and error class of a
+// reconcile which started at start
+func (c *Collectors) RecordReconcile(
+	controller string,
+	start time.Time,
+	result ctrl.Result,
+	err error,
+) {
+	res := GetResult(result, err)
+	c.ReconcileDuration.WithLabelValues(controller, res).Observe(time.Since(start).Seconds())
+	c.ReconcileTotal.WithLabelValues(controller, res).Inc()
+	if err != nil {
+		c.ReconcileErrors.WithLabelValues(controller, GetErrorClass(err)).Inc()
+	}
+}
+
+// SetResourceCount - sets the number of resources of the kind managed by
+// the controller
+func (c *Collectors) SetResourceCount(controller string, kind string, count int) {
+	c.Resources.WithLabelValues(controller, kind).Set(float64(count))
+}
+
+// SetDriftedCount - sets the number of resources of the kind managed by
+// the controller which have out-of-band changes
+func (c *Collectors) SetDriftedCount(controller string, kind string, count int) {
+	c.DriftedResources.WithLabelValues(controller, kind).Set(float64(count))
+}
+
+// RecordDriftReverted - records that out-of-band changes of a resource of
+// the kind got reverted
+func (c *Collectors) RecordDriftReverted(controller string, kind string) {
+	c.DriftReverted.WithLabelValues(controller, kind).Inc()
+}
+
+// DefaultCollectors - the collectors the package level functions record
+// in, see Register
+var DefaultCollectors = NewCollectors()
+
+// Register - registers the DefaultCollectors with the registerer. The
+// operator calls it once from main, usually with the controller-runtime
+// metrics registry, which is served on the metrics endpoint of the manager.
+//
+// Example:
+//
+//	if err := metrics.Register(ctrlmetrics.Registry); err != nil {
+//		setupLog.Error(err, "unable to register metrics")
+//		os.Exit(1)
+//	}
+func Register(r prometheus.Registerer) error {
+	return DefaultCollectors.Register(r)
+}
+
+// RecordReconcile - records the outcome of a reconcile in the
+// DefaultCollectors. Call it deferred at the start of Reconcile.
+//
+// Example:
+//
+//	func (r *SomeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+//	    start := time.Now()
+//	    defer func() {
+//	        metrics.RecordReconcile("keystoneapi", start, result, _err)
+//	    }()
+//	    ...
+//	}
+func RecordReconcile(controller string, start time.Time, result ctrl.Result, err error) {
+	DefaultCollectors.RecordReconcile(controller, start, result, err)
+}
+
+// SetResourceCount - sets the number of resources of the kind managed by
+// the controller in the DefaultCollectors
+func SetResourceCount(controller string, kind string, count int) {
+	DefaultCollectors.SetResourceCount(controller, kind, count)
+}
+
+// GetResult - returns the result label of a reconcile outcome
+func GetResult(result ctrl.Result, err error) string {
+	if err != nil {
+		return ResultError
+	}
+	if result.RequeueAfter > 0 || result.Requeue {
+		return ResultRequeue
+	}
+	return ResultSuccess
+}
+
+// GetErrorClass - returns the error class label of a reconcile error
+func GetErrorClass(err error) string {
+	switch {
+	case k8s_errors.IsNotFound(err):
+		return ErrorClassNotFound
+	case k8s_errors.IsConflict(err), k8s_errors.IsAlreadyExists(err):
+		return ErrorClassConflict
+	case k8s_errors.IsTimeout(err), k8s_errors.IsServerTimeout(err),
+		errors.Is(err, context.DeadlineExceeded), errors.Is(err, util.ErrWaitTimeout):
+		return ErrorClassTimeout
+	case k8s_errors.IsForbidden(err), k8s_errors.IsUnauthorized(err):
+		return ErrorClassForbidden
+	case k8s_errors.IsInvalid(err), k8s_errors.IsBadRequest(err):
+		return ErrorClassInvalid
+	}
+	return ErrorClassOther
+}
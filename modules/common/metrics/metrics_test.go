@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func value(g *WithT, c prometheus.Collector) float64 {
+	m := &dto.Metric{}
+	g.Expect(c.(prometheus.Metric).Write(m)).To(Succeed())
+	if m.Counter != nil {
+		return m.Counter.GetValue()
+	}
+	if m.Histogram != nil {
+		return float64(m.Histogram.GetSampleCount())
+	}
+	return m.Gauge.GetValue()
+}
+
+func TestRecordReconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	c := NewCollectors()
+	g.Expect(c.Register(prometheus.NewRegistry())).To(Succeed())
+
+	start := time.Now()
+	c.RecordReconcile("keystoneapi", start, ctrl.Result{}, nil)
+	c.RecordReconcile("keystoneapi", start, ctrl.Result{RequeueAfter: time.Second}, nil)
+	c.RecordReconcile("keystoneapi", start, ctrl.Result{}, k8s_errors.NewConflict(
+		schema.GroupResource{Resource: "keystoneapis"}, "keystone", errors.New("modified")))
+	c.RecordReconcile("keystoneapi", start, ctrl.Result{}, fmt.Errorf("%w: job", util.ErrWaitTimeout))
+
+	g.Expect(value(g, c.ReconcileTotal.WithLabelValues("keystoneapi", ResultSuccess))).To(Equal(1.0))
+	g.Expect(value(g, c.ReconcileTotal.WithLabelValues("keystoneapi", ResultRequeue))).To(Equal(1.0))
+	g.Expect(value(g, c.ReconcileTotal.WithLabelValues("keystoneapi", ResultError))).To(Equal(2.0))
+	g.Expect(value(g, c.ReconcileErrors.WithLabelValues("keystoneapi", ErrorClassConflict))).To(Equal(1.0))
+	g.Expect(value(g, c.ReconcileErrors.WithLabelValues("keystoneapi", ErrorClassTimeout))).To(Equal(1.0))
+
+	observer, err := c.ReconcileDuration.GetMetricWithLabelValues("keystoneapi", ResultError)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(value(g, observer.(prometheus.Collector))).To(Equal(2.0))
+
+	c.SetResourceCount("keystoneapi", "Deployment", 3)
+	g.Expect(value(g, c.Resources.WithLabelValues("keystoneapi", "Deployment"))).To(Equal(3.0))
+
+	c.SetDriftedCount("keystoneapi", "Deployment", 1)
+	c.RecordDriftReverted("keystoneapi", "Deployment")
+	g.Expect(value(g, c.DriftedResources.WithLabelValues("keystoneapi", "Deployment"))).To(Equal(1.0))
+	g.Expect(value(g, c.DriftReverted.WithLabelValues("keystoneapi", "Deployment"))).To(Equal(1.0))
+}
+
+func TestRegisterTwice(t *testing.T) {
+	g := NewWithT(t)
+
+	r := prometheus.NewRegistry()
+	g.Expect(NewCollectors().Register(r)).To(Succeed())
+	g.Expect(NewCollectors().Register(r)).ToNot(Succeed())
+
+	r = prometheus.NewRegistry()
+	g.Expect(Register(r)).To(Succeed())
+	g.Expect(Register(r)).ToNot(Succeed())
+}
+
+func TestGetErrorClass(t *testing.T) {
+	gr := schema.GroupResource{Resource: "secrets"}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "not found", err: k8s_errors.NewNotFound(gr, "foo"), want: ErrorClassNotFound},
+		{name: "already exists", err: k8s_errors.NewAlreadyExists(gr, "foo"), want: ErrorClassConflict},
+		{name: "forbidden", err: k8s_errors.NewForbidden(gr, "foo", errors.New("denied")), want: ErrorClassForbidden},
+		{name: "invalid", err: k8s_errors.NewBadRequest("bad"), want: ErrorClassInvalid},
+		{name: "context deadline", err: fmt.Errorf("get: %w", context.DeadlineExceeded), want: ErrorClassTimeout},
+		{name: "other", err: errors.New("boom"), want: ErrorClassOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(GetErrorClass(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
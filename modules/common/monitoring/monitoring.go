@@ -0,0 +1,249 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package monitoring provides utilities for managing the prometheus-operator
+// ServiceMonitors and PodMonitors of services exposing metrics
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NewServiceMonitor - returns a Monitor for a ServiceMonitor scraping the
+// endpoints of the services in the namespace matching the selector
+func NewServiceMonitor(
+	name string,
+	namespace string,
+	labels map[string]string,
+	selector map[string]string,
+	endpoints []Endpoint,
+	timeout time.Duration,
+) (*Monitor, error) {
+	eps := []endpointSpec{}
+	for _, e := range endpoints {
+		serverName := e.ServerName
+		if serverName == "" {
+			// the service name is the name of the monitor
+			serverName = fmt.Sprintf("%s.%s.svc", name, namespace)
+		}
+		eps = append(eps, getEndpointSpec(name, e, serverName))
+	}
+
+	return newMonitor(ServiceMonitorGVK, name, namespace, labels, selector, "endpoints", eps, timeout)
+}
+
+// NewPodMonitor - returns a Monitor for a PodMonitor scraping the endpoints
+// of the pods in the namespace matching the selector
+func NewPodMonitor(
+	name string,
+	namespace string,
+	labels map[string]string,
+	selector map[string]string,
+	endpoints []Endpoint,
+	timeout time.Duration,
+) (*Monitor, error) {
+	eps := []endpointSpec{}
+	for _, e := range endpoints {
+		eps = append(eps, getEndpointSpec(name, e, e.ServerName))
+	}
+
+	return newMonitor(PodMonitorGVK, name, namespace, labels, selector, "podMetricsEndpoints", eps, timeout)
+}
+
+// newMonitor - returns the monitor of the kind with the endpoints set in
+// the endpointsField of the spec
+func newMonitor(
+	gvk schema.GroupVersionKind,
+	name string,
+	namespace string,
+	labels map[string]string,
+	selector map[string]string,
+	endpointsField string,
+	endpoints []endpointSpec,
+	timeout time.Duration,
+) (*Monitor, error) {
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": selector,
+		},
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []string{namespace},
+		},
+		endpointsField: endpoints,
+	}
+	unstructuredSpec, err := toUnstructured(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error converting %s %s spec: %w", gvk.Kind, name, err)
+	}
+
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(gvk)
+	monitor.SetName(name)
+	monitor.SetNamespace(namespace)
+	monitor.SetLabels(labels)
+	monitor.Object["spec"] = unstructuredSpec
+
+	return &Monitor{
+		monitor: monitor,
+		timeout: timeout,
+	}, nil
+}
+
+// getEndpointSpec - returns the prometheus-operator endpoint with the
+// standard relabelings of the service
+func getEndpointSpec(serviceName string, e Endpoint, serverName string) endpointSpec {
+	ep := endpointSpec{
+		Port:     e.Port,
+		Path:     e.Path,
+		Interval: e.Interval,
+		Scheme:   "http",
+		Relabelings: []relabelConfig{
+			{
+				TargetLabel: ServiceLabel,
+				Replacement: ptr.To(serviceName),
+				Action:      "replace",
+			},
+			{
+				SourceLabels: []string{"__meta_kubernetes_pod_node_name"},
+				TargetLabel:  NodeLabel,
+				Action:       "replace",
+			},
+		},
+	}
+	if ep.Path == "" {
+		ep.Path = DefaultMetricsPath
+	}
+	if e.TLS != nil && e.TLS.CaBundleSecretName != "" {
+		ep.Scheme = "https"
+		ep.TLSConfig = &tlsConfig{ServerName: serverName}
+		ep.TLSConfig.CA.Secret = secretKeySelector{
+			Name: e.TLS.CaBundleSecretName,
+			Key:  tls.CABundleKey,
+		}
+	}
+
+	return ep
+}
+
+// toUnstructured - converts v into its unstructured representation
+func toUnstructured(v map[string]interface{}) (map[string]interface{}, error) {
+	spec := struct {
+		Spec map[string]interface{} `json:"spec"`
+	}{v}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return nil, err
+	}
+	return u["spec"].(map[string]interface{}), nil
+}
+
+// IsAvailable - returns true if the API of the kind is served, e.g. the
+// prometheus-operator CRDs are installed
+func IsAvailable(h *helper.Helper, gvk schema.GroupVersionKind) (bool, error) {
+	_, err := h.GetClient().RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateOrPatch - creates or patches the monitor. If the monitoring CRDs
+// are not installed the monitor is skipped and false is returned, so that
+// services can be deployed on clusters without monitoring stack.
+func (m *Monitor) CreateOrPatch(
+	ctx context.Context,
+	h *helper.Helper,
+) (bool, ctrl.Result, error) {
+	gvk := m.monitor.GroupVersionKind()
+	available, err := IsAvailable(h, gvk)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if !available {
+		h.GetLogger().Info(gvk.Kind+" API not available, skipping", "object", m.monitor.GetName())
+		return false, ctrl.Result{}, nil
+	}
+
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(gvk)
+	monitor.SetName(m.monitor.GetName())
+	monitor.SetNamespace(m.monitor.GetNamespace())
+
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), monitor, func() error {
+		monitor.SetLabels(util.MergeStringMaps(monitor.GetLabels(), m.monitor.GetLabels()))
+		monitor.SetAnnotations(util.MergeStringMaps(monitor.GetAnnotations(), m.monitor.GetAnnotations()))
+		h.ApplyPropagatedMetadata(monitor, m.monitor.GetLabels(), m.monitor.GetAnnotations())
+		monitor.Object["spec"] = runtime.DeepCopyJSONValue(m.monitor.Object["spec"])
+
+		err := object.SetControllerReference(h.GetBeforeObject(), monitor, h.GetScheme())
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			h.GetLogger().Info(gvk.Kind+" not found, reconcile later", "object", monitor.GetName(), "requeueAfter", m.timeout)
+			return true, ctrl.Result{RequeueAfter: m.timeout}, nil
+		}
+		return true, ctrl.Result{}, err
+	}
+	if op != controllerutil.OperationResultNone {
+		h.GetLogger().Info(gvk.Kind+" reconciled", "object", monitor.GetName(), "operation", op)
+	}
+
+	m.monitor = monitor
+
+	return true, ctrl.Result{}, nil
+}
+
+// Delete - delete the monitor. It is not an error to call this on an
+// already deleted monitor or if the monitoring CRDs are not installed.
+func (m *Monitor) Delete(
+	ctx context.Context,
+	h *helper.Helper,
+) error {
+	err := h.GetClient().Delete(ctx, m.monitor)
+	if err != nil && !k8s_errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("error deleting %s %s: %w", m.monitor.GetKind(), m.monitor.GetName(), err)
+	}
+
+	return nil
+}
+
+// GetMonitor - get the unstructured monitor object.
+func (m *Monitor) GetMonitor() *unstructured.Unstructured {
+	return m.monitor.DeepCopy()
+}
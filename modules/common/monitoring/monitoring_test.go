@@ -0,0 +1,132 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func setupHelper(g *WithT, withMonitoring bool) *helper.Helper {
+	cr := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone",
+			Namespace: "openstack",
+			UID:       "1234",
+		},
+	}
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{ServiceMonitorGVK.GroupVersion()})
+	if withMonitoring {
+		mapper.Add(ServiceMonitorGVK, meta.RESTScopeNamespace)
+		mapper.Add(PodMonitorGVK, meta.RESTScopeNamespace)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper).Build()
+	h, err := helper.NewHelper(cr, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	return h
+}
+
+func TestNewServiceMonitor(t *testing.T) {
+	g := NewWithT(t)
+
+	m, err := NewServiceMonitor(
+		"keystone-internal",
+		"openstack",
+		map[string]string{"service": "keystone"},
+		map[string]string{"service": "keystone"},
+		[]Endpoint{
+			{Port: "metrics", Interval: "30s", TLS: &tls.Ca{CaBundleSecretName: tls.CABundleSecret}},
+			{Port: "exporter", Path: "/exporter"},
+		},
+		time.Second,
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	monitor := m.GetMonitor()
+	g.Expect(monitor.GroupVersionKind()).To(Equal(ServiceMonitorGVK))
+
+	endpoints, found, err := unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(endpoints).To(HaveLen(2))
+
+	secured := endpoints[0].(map[string]interface{})
+	g.Expect(secured).To(HaveKeyWithValue("scheme", "https"))
+	g.Expect(secured).To(HaveKeyWithValue("path", DefaultMetricsPath))
+	g.Expect(secured).To(HaveKeyWithValue("interval", "30s"))
+	serverName, _, _ := unstructured.NestedString(secured, "tlsConfig", "serverName")
+	g.Expect(serverName).To(Equal("keystone-internal.openstack.svc"))
+	caSecret, _, _ := unstructured.NestedString(secured, "tlsConfig", "ca", "secret", "name")
+	g.Expect(caSecret).To(Equal(tls.CABundleSecret))
+	relabelings, _, _ := unstructured.NestedSlice(secured, "relabelings")
+	g.Expect(relabelings[0]).To(HaveKeyWithValue("targetLabel", ServiceLabel))
+	g.Expect(relabelings[0]).To(HaveKeyWithValue("replacement", "keystone-internal"))
+
+	plain := endpoints[1].(map[string]interface{})
+	g.Expect(plain).To(HaveKeyWithValue("scheme", "http"))
+	g.Expect(plain).To(HaveKeyWithValue("path", "/exporter"))
+	g.Expect(plain).ToNot(HaveKey("tlsConfig"))
+
+	namespaces, _, _ := unstructured.NestedStringSlice(monitor.Object, "spec", "namespaceSelector", "matchNames")
+	g.Expect(namespaces).To(Equal([]string{"openstack"}))
+}
+
+func TestCreateOrPatch(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	m, err := NewPodMonitor("ovn-controller", "openstack", nil,
+		map[string]string{"service": "ovn-controller"}, []Endpoint{{Port: "metrics"}}, time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// skipped without the monitoring CRDs
+	h := setupHelper(g, false)
+	created, result, err := m.CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(created).To(BeFalse())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(m.Delete(ctx, h)).To(Succeed())
+
+	h = setupHelper(g, true)
+	created, _, err = m.CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(created).To(BeTrue())
+
+	monitor := &unstructured.Unstructured{}
+	monitor.SetGroupVersionKind(PodMonitorGVK)
+	g.Expect(h.GetClient().Get(ctx, client.ObjectKey{Name: "ovn-controller", Namespace: "openstack"}, monitor)).To(Succeed())
+	g.Expect(monitor.GetOwnerReferences()).To(HaveLen(1))
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "podMetricsEndpoints")
+	g.Expect(endpoints).To(HaveLen(1))
+
+	g.Expect(m.Delete(ctx, h)).To(Succeed())
+	g.Expect(m.Delete(ctx, h)).To(Succeed())
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoring
+
+import (
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// ServiceMonitorGVK - GroupVersionKind of the prometheus-operator ServiceMonitor
+	ServiceMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+	// PodMonitorGVK - GroupVersionKind of the prometheus-operator PodMonitor
+	PodMonitorGVK = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+)
+
+const (
+	// DefaultMetricsPath - default path of the metrics endpoint
+	DefaultMetricsPath = "/metrics"
+
+	// ServiceLabel - label added to all scraped metrics holding the name of
+	// the OpenStack service
+	ServiceLabel = "openstack_service"
+	// NodeLabel - label added to all scraped metrics holding the node name
+	// of the pod
+	NodeLabel = "node"
+)
+
+// Endpoint - a metrics endpoint of a service or pod
+type Endpoint struct {
+	// Port - name of the service port for a ServiceMonitor, name of the
+	// container port for a PodMonitor
+	Port string
+	// Path - metrics path, defaults to DefaultMetricsPath
+	Path string
+	// Interval - scrape interval, e.g. 30s, defaults to the prometheus
+	// global scrape interval
+	Interval string
+	// TLS - if set the endpoint gets scraped via https and the server cert
+	// validated with the CA bundle
+	TLS *tls.Ca
+	// ServerName - name to validate the server cert with, defaults to the
+	// service hostname for a ServiceMonitor
+	ServerName string
+}
+
+// Monitor - a ServiceMonitor or PodMonitor. The monitoring CRDs are an
+// optional dependency, the prometheus-operator API is not vendored and the
+// monitors are handled as unstructured objects.
+type Monitor struct {
+	monitor *unstructured.Unstructured
+	timeout time.Duration
+}
+
+// relabelConfig - prometheus-operator RelabelConfig
+type relabelConfig struct {
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	TargetLabel  string   `json:"targetLabel,omitempty"`
+	Replacement  *string  `json:"replacement,omitempty"`
+	Action       string   `json:"action,omitempty"`
+}
+
+// secretKeySelector - prometheus-operator SecretOrConfigMap secret
+type secretKeySelector struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// tlsConfig - prometheus-operator SafeTLSConfig
+type tlsConfig struct {
+	CA struct {
+		Secret secretKeySelector `json:"secret"`
+	} `json:"ca"`
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// endpointSpec - prometheus-operator Endpoint and PodMetricsEndpoint
+type endpointSpec struct {
+	Port        string          `json:"port"`
+	Path        string          `json:"path,omitempty"`
+	Interval    string          `json:"interval,omitempty"`
+	Scheme      string          `json:"scheme,omitempty"`
+	TLSConfig   *tlsConfig      `json:"tlsConfig,omitempty"`
+	Relabelings []relabelConfig `json:"relabelings,omitempty"`
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// BindAddressIPv4 - the address to listen on all IPv4 addresses
+	BindAddressIPv4 = "0.0.0.0"
+	// BindAddressIPv6 - the address to listen on all IPv6 addresses, on
+	// Linux also on the IPv4 ones unless the socket is IPV6_V6ONLY
+	BindAddressIPv6 = "::"
+	// LoopbackIPv4 - the IPv4 loopback address
+	LoopbackIPv4 = "127.0.0.1"
+	// LoopbackIPv6 - the IPv6 loopback address
+	LoopbackIPv6 = "::1"
+)
+
+// Define static errors
+var (
+	// ErrInvalidIP indicates an address which is not an IP
+	ErrInvalidIP = errors.New("invalid IP address")
+	// ErrUnknownIPFamily indicates the IP family of the cluster could not
+	// be determined
+	ErrUnknownIPFamily = errors.New("unknown IP family")
+)
+
+// kubernetesService - the service of the API server, its cluster IP is of
+// the primary IP family of the cluster
+var kubernetesService = types.NamespacedName{Namespace: "default", Name: "kubernetes"}
+
+// FormatHost - returns host for the host part of a URL, IPv6 addresses in
+// brackets. Host names, IPv4 and already bracketed addresses are returned
+// as is. Use net.JoinHostPort instead if there is a port, host must not
+// have one.
+//
+// Example:
+//
+//	u := fmt.Sprintf("http://%s/v3", net.FormatHost(ip))
+func FormatHost(host string) string {
+	if strings.HasPrefix(host, "[") {
+		return host
+	}
+	// like net.JoinHostPort, any colon means an IPv6 address, including
+	// the IPv4-mapped ones
+	if strings.Contains(host, ":") {
+		return "[" + host + "]"
+	}
+	return host
+}
+
+// IsIPv6 - returns true if addr is an IPv6 address, a zone or brackets
+// are allowed
+func IsIPv6(addr string) bool {
+	addr = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	if i := strings.LastIndex(addr, "%"); i > 0 {
+		addr = addr[:i]
+	}
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.To4() == nil
+}
+
+// GetIPFamily - returns the IP family of addr
+func GetIPFamily(addr string) (corev1.IPFamily, error) {
+	if IsIPv6(addr) {
+		return corev1.IPv6Protocol, nil
+	}
+	if net.ParseIP(addr) != nil {
+		return corev1.IPv4Protocol, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrInvalidIP, addr)
+}
+
+// BindAddress - returns the address a service of the IP family listens on
+// all addresses with, for rendered configs
+func BindAddress(family corev1.IPFamily) string {
+	if family == corev1.IPv6Protocol {
+		return BindAddressIPv6
+	}
+	return BindAddressIPv4
+}
+
+// LoopbackAddress - returns the loopback address of the IP family, e.g.
+// for the probes or a local memcached of a service
+func LoopbackAddress(family corev1.IPFamily) string {
+	if family == corev1.IPv6Protocol {
+		return LoopbackIPv6
+	}
+	return LoopbackIPv4
+}
+
+// GetClusterIPFamily - returns the primary IP family of the cluster, the one
+// of the kubernetes service of the API server. Services get their cluster
+// IP in this family unless they request a different one.
+//
+// Example:
+//
+//	family, err := net.GetClusterIPFamily(ctx, helper)
+//	if err != nil {
+//		return ctrl.Result{}, err
+//	}
+//	templateParameters["BindIP"] = net.BindAddress(family)
+func GetClusterIPFamily(ctx context.Context, h *helper.Helper) (corev1.IPFamily, error) {
+	svc := &corev1.Service{}
+	err := h.GetReader().Get(ctx, kubernetesService, svc)
+	if err != nil {
+		return "", err
+	}
+	if len(svc.Spec.IPFamilies) > 0 {
+		return svc.Spec.IPFamilies[0], nil
+	}
+	family, err := GetIPFamily(svc.Spec.ClusterIP)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnknownIPFamily, err)
+	}
+	return family, nil
+}
+
+// ValidateIPFamily - validates the addresses are IPs of the IP family, e.g.
+// for the webhook to reject IPv4 addresses provided for an IPv6 only cluster.
+// Empty addresses are ignored.
+func ValidateIPFamily(basePath *field.Path, addrs []string, family corev1.IPFamily) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, addr := range addrs {
+		if addr == "" {
+			continue
+		}
+		path := basePath.Index(i)
+		f, err := GetIPFamily(addr)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, addr, "must be a valid IP address"))
+			continue
+		}
+		if f != family {
+			allErrs = append(allErrs, field.Invalid(path, addr,
+				fmt.Sprintf("must be an %s address, the IP family of the cluster", family)))
+		}
+	}
+	return allErrs
+}
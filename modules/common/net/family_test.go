@@ -0,0 +1,144 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFormatHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "keystone-public.openstack.svc", want: "keystone-public.openstack.svc"},
+		{host: "10.0.0.1", want: "10.0.0.1"},
+		{host: "fd00:bbbb::1", want: "[fd00:bbbb::1]"},
+		{host: "[fd00:bbbb::1]", want: "[fd00:bbbb::1]"},
+		{host: "fe80::1%eth0", want: "[fe80::1%eth0]"},
+		{host: "::ffff:10.0.0.1", want: "[::ffff:10.0.0.1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(FormatHost(tt.host)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetIPFamily(t *testing.T) {
+	g := NewWithT(t)
+
+	family, err := GetIPFamily("10.0.0.1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(family).To(Equal(corev1.IPv4Protocol))
+
+	family, err = GetIPFamily("fd00:bbbb::1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(family).To(Equal(corev1.IPv6Protocol))
+
+	_, err = GetIPFamily("keystone-public.openstack.svc")
+	g.Expect(err).To(MatchError(ErrInvalidIP))
+
+	g.Expect(BindAddress(corev1.IPv4Protocol)).To(Equal("0.0.0.0"))
+	g.Expect(BindAddress(corev1.IPv6Protocol)).To(Equal("::"))
+	g.Expect(LoopbackAddress(corev1.IPv4Protocol)).To(Equal("127.0.0.1"))
+	g.Expect(LoopbackAddress(corev1.IPv6Protocol)).To(Equal("::1"))
+}
+
+func TestValidateIPFamily(t *testing.T) {
+	g := NewWithT(t)
+
+	path := field.NewPath("spec", "externalIPs")
+	g.Expect(ValidateIPFamily(path, []string{"fd00:bbbb::1", ""}, corev1.IPv6Protocol)).To(BeEmpty())
+
+	errs := ValidateIPFamily(path, []string{"fd00:bbbb::1", "10.0.0.1", "foo"}, corev1.IPv6Protocol)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Field).To(Equal("spec.externalIPs[1]"))
+	g.Expect(errs[1].Field).To(Equal("spec.externalIPs[2]"))
+}
+
+func TestGetClusterIPFamily(t *testing.T) {
+	tests := []struct {
+		name string
+		spec corev1.ServiceSpec
+		want corev1.IPFamily
+	}{
+		{
+			name: "IPv4",
+			spec: corev1.ServiceSpec{ClusterIP: "10.0.0.1", IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol}},
+			want: corev1.IPv4Protocol,
+		},
+		{
+			name: "IPv6",
+			spec: corev1.ServiceSpec{ClusterIP: "fd00:10:96::1", IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol}},
+			want: corev1.IPv6Protocol,
+		},
+		{
+			name: "dual stack IPv6 primary",
+			spec: corev1.ServiceSpec{
+				ClusterIP:  "fd00:10:96::1",
+				IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			},
+			want: corev1.IPv6Protocol,
+		},
+		{
+			name: "without IP families",
+			spec: corev1.ServiceSpec{ClusterIP: "fd00:10:96::1"},
+			want: corev1.IPv6Protocol,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			family, err := GetClusterIPFamily(context.TODO(), getHelper(g, svc))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(family).To(Equal(tt.want))
+		})
+	}
+
+	g := NewWithT(t)
+	_, err := GetClusterIPFamily(context.TODO(), getHelper(g, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+	}))
+	g.Expect(err).To(MatchError(ErrUnknownIPFamily))
+}
+
+func getHelper(g *WithT, objs ...client.Object) *helper.Helper {
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	return h
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ProbeFailure - the kind of failure of a Probe
+type ProbeFailure string
+
+const (
+	// ProbeFailureNone - the target is reachable
+	ProbeFailureNone ProbeFailure = ""
+	// ProbeFailureInvalidTarget - the target is not a URL or host:port
+	ProbeFailureInvalidTarget ProbeFailure = "InvalidTarget"
+	// ProbeFailureDNS - the host name could not be resolved
+	ProbeFailureDNS ProbeFailure = "DNSFailure"
+	// ProbeFailureConnection - the connection got refused or reset
+	ProbeFailureConnection ProbeFailure = "ConnectionFailure"
+	// ProbeFailureTimeout - no response within the timeout
+	ProbeFailureTimeout ProbeFailure = "Timeout"
+	// ProbeFailureTLS - the TLS handshake or the verification of the
+	// certificate failed
+	ProbeFailureTLS ProbeFailure = "TLSFailure"
+	// ProbeFailureHTTPStatus - the HTTP response has a status not accepted
+	ProbeFailureHTTPStatus ProbeFailure = "HTTPStatus"
+
+	// DefaultProbeTimeout - timeout of a Probe if not set
+	DefaultProbeTimeout = 5 * time.Second
+)
+
+// ProbeOptions - options of a Probe
+type ProbeOptions struct {
+	// Timeout - timeout of the whole probe, DefaultProbeTimeout if 0
+	Timeout time.Duration
+	// CACert - PEM encoded CA certificates to verify the server against,
+	// the system ones if empty
+	CACert []byte
+	// TLS - use TLS for host:port targets. URL targets use TLS for the
+	// https scheme and schemes ending with s, e.g. amqps.
+	TLS bool
+	// ServerName - name to verify the certificate against, the host of the
+	// target if empty
+	ServerName string
+	// AcceptStatus - accepted HTTP status codes, all below 400 if empty
+	AcceptStatus []int
+}
+
+// ProbeResult - the result of a Probe
+type ProbeResult struct {
+	// Failure - the kind of failure, ProbeFailureNone if reachable
+	Failure ProbeFailure
+	// StatusCode - the status code of the HTTP response, if any
+	StatusCode int
+	// Message - a description of the failure
+	Message string
+}
+
+// Reachable - returns true if the probe succeeded
+func (r ProbeResult) Reachable() bool {
+	return r.Failure == ProbeFailureNone
+}
+
+// String - returns the result in a form suitable for a condition message
+func (r ProbeResult) String() string {
+	if r.Reachable() {
+		return "reachable"
+	}
+	return fmt.Sprintf("%s: %s", r.Failure, r.Message)
+}
+
+// Probe - checks target is reachable. HTTP(S) URLs get a GET request,
+// other URLs, e.g. amqp://user@rabbitmq:5672, and host:port targets get
+// connected to, with a TLS handshake if requested.
+//
+// Example:
+//
+//	result := net.Probe(ctx, instance.Spec.TransportURL, net.ProbeOptions{CACert: caBundle})
+//	if !result.Reachable() {
+//		return false, fmt.Sprintf("rabbitmq %s", result), nil
+//	}
+func Probe(ctx context.Context, target string, opts ProbeOptions) ProbeResult {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultProbeTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	useTLS := opts.TLS
+	hostPort := target
+	if strings.Contains(target, "://") {
+		u, err := url.Parse(target)
+		if err != nil || u.Host == "" {
+			return ProbeResult{Failure: ProbeFailureInvalidTarget, Message: fmt.Sprintf("invalid URL %q", redactUserinfo(target))}
+		}
+		if u.Scheme == "http" || u.Scheme == "https" {
+			return probeHTTP(ctx, u, opts)
+		}
+		useTLS = strings.HasSuffix(u.Scheme, "s")
+		hostPort = u.Host
+	}
+
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return ProbeResult{Failure: ProbeFailureInvalidTarget, Message: err.Error()}
+	}
+
+	if !useTLS {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", hostPort)
+		if err != nil {
+			return failure(err)
+		}
+		conn.Close()
+		return ProbeResult{}
+	}
+
+	tlsConfig, err := opts.tlsConfig(host)
+	if err != nil {
+		return ProbeResult{Failure: ProbeFailureTLS, Message: err.Error()}
+	}
+	conn, err := (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", hostPort)
+	if err != nil {
+		return failure(err)
+	}
+	conn.Close()
+	return ProbeResult{}
+}
+
+func probeHTTP(ctx context.Context, u *url.URL, opts ProbeOptions) ProbeResult {
+	tlsConfig, err := opts.tlsConfig(u.Hostname())
+	if err != nil {
+		return ProbeResult{Failure: ProbeFailureTLS, Message: err.Error()}
+	}
+	// a new transport per probe, its connections must not outlive it
+	transport := &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{
+		Transport: transport,
+		// the status of a redirect is the answer of the probed endpoint
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return ProbeResult{Failure: ProbeFailureInvalidTarget, Message: err.Error()}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return failure(err)
+	}
+	resp.Body.Close()
+
+	if !opts.accepts(resp.StatusCode) {
+		return ProbeResult{
+			Failure:    ProbeFailureHTTPStatus,
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("unexpected status %s", resp.Status),
+		}
+	}
+	return ProbeResult{StatusCode: resp.StatusCode}
+}
+
+// redactUserinfo - replaces the userinfo of the hosts of target, e.g. the
+// credentials of a transport URL, so that it can be put into messages
+func redactUserinfo(target string) string {
+	scheme, rest, ok := strings.Cut(target, "://")
+	if !ok {
+		return target
+	}
+	authority, path := rest, ""
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		authority, path = rest[:i], rest[i:]
+	}
+	hosts := strings.Split(authority, ",")
+	for i, host := range hosts {
+		if j := strings.LastIndex(host, "@"); j >= 0 {
+			hosts[i] = "xxxxx@" + host[j+1:]
+		}
+	}
+	return scheme + "://" + strings.Join(hosts, ",") + path
+}
+
+func (o ProbeOptions) tlsConfig(host string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: host,
+	}
+	if o.ServerName != "" {
+		config.ServerName = o.ServerName
+	}
+	if len(o.CACert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(o.CACert) {
+			return nil, errors.New("no valid CA certificate in PEM data")
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+func (o ProbeOptions) accepts(status int) bool {
+	if len(o.AcceptStatus) == 0 {
+		return status < 400
+	}
+	for _, s := range o.AcceptStatus {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// failure - returns the result of the error of a connection
+func failure(err error) ProbeResult {
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var recordErr tls.RecordHeaderError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &dnsErr):
+		return ProbeResult{Failure: ProbeFailureDNS, Message: dnsErr.Error()}
+	case errors.As(err, &certErr), errors.As(err, &unknownAuthorityErr),
+		errors.As(err, &hostnameErr), errors.As(err, &invalidErr), errors.As(err, &recordErr):
+		return ProbeResult{Failure: ProbeFailureTLS, Message: err.Error()}
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ProbeResult{Failure: ProbeFailureTimeout, Message: err.Error()}
+	default:
+		return ProbeResult{Failure: ProbeFailureConnection, Message: err.Error()}
+	}
+}
@@ -41,7 +41,7 @@ func GetNADWithName(
 
 	nad := &networkv1.NetworkAttachmentDefinition{}
 
-	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, nad)
+	err := h.GetReader().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, nad)
 	if err != nil {
 		err = fmt.Errorf("error getting network-attachment-definition %s/%s - %w", name, namespace, err)
 
@@ -105,7 +105,7 @@ func GetNetworkStatusFromAnnotation(annotations map[string]string) ([]networkv1.
 // per network IPs the ready count of the deployment. Return true if count matches with the list of IPs per network.
 func VerifyNetworkStatusFromAnnotation(
 	ctx context.Context,
-	helper *helper.Helper,
+	h *helper.Helper,
 	networkAttachments []string,
 	serviceLabels map[string]string,
 	readyCount int32,
@@ -114,7 +114,10 @@ func VerifyNetworkStatusFromAnnotation(
 	networkReady := true
 	networkAttachmentStatus := map[string][]string{}
 	if len(networkAttachments) > 0 {
-		podList, err := pod.GetPodListWithLabel(ctx, helper, helper.GetBeforeObject().GetNamespace(), serviceLabels)
+		if !h.HasBeforeObject() {
+			return false, networkAttachmentStatus, helper.ErrNoBeforeObject
+		}
+		podList, err := pod.GetPodListWithLabel(ctx, h, h.GetBeforeObject().GetNamespace(), serviceLabels)
 		if err != nil {
 			return false, networkAttachmentStatus, err
 		}
@@ -130,7 +133,7 @@ func VerifyNetworkStatusFromAnnotation(
 		}
 
 		for _, netAtt := range networkAttachments {
-			netAtt = helper.GetBeforeObject().GetNamespace() + "/" + netAtt
+			netAtt = h.GetBeforeObject().GetNamespace() + "/" + netAtt
 
 			if net, ok := networkAttachmentStatus[netAtt]; !ok || len(net) < int(readyCount) {
 				networkReady = false
@@ -0,0 +1,255 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package networkpolicy provides utilities for managing Kubernetes
+// NetworkPolicies of a service
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NewNetworkPolicy returns an initialized NetworkPolicy.
+func NewNetworkPolicy(
+	networkPolicy *networkingv1.NetworkPolicy,
+	timeout time.Duration,
+) *NetworkPolicy {
+	return &NetworkPolicy{
+		networkPolicy: networkPolicy,
+		timeout:       timeout,
+	}
+}
+
+// DefaultDeny - returns a NetworkPolicy which denies all ingress traffic to
+// the pods matching the podSelector. Traffic which should be allowed has to
+// be permitted by additional policies, e.g. from AllowServicePorts.
+func DefaultDeny(
+	name string,
+	namespace string,
+	podSelector map[string]string,
+	labels map[string]string,
+) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: podSelector,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{},
+		},
+	}
+}
+
+// AllowServicePorts - returns a NetworkPolicy which allows ingress traffic
+// from the peers to the target ports of the service, for the pods selected
+// by the service. No peers allows the traffic from all sources.
+func AllowServicePorts(
+	name string,
+	svc *corev1.Service,
+	from []networkingv1.NetworkPolicyPeer,
+	labels map[string]string,
+) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: svc.Namespace,
+			Labels:    labels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: svc.Spec.Selector,
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: GetServicePorts(svc),
+					From:  from,
+				},
+			},
+		},
+	}
+}
+
+// GetServicePorts - returns the NetworkPolicy ports of the target ports of
+// the service. As policies apply to the pods, the target port is used, or
+// the service port if no target port is set.
+func GetServicePorts(svc *corev1.Service) []networkingv1.NetworkPolicyPort {
+	ports := []networkingv1.NetworkPolicyPort{}
+	for _, p := range svc.Spec.Ports {
+		port := p.TargetPort
+		if port.Type == intstr.Int && port.IntVal == 0 {
+			port = intstr.FromInt32(p.Port)
+		}
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		})
+	}
+
+	return ports
+}
+
+// NamespacePeer - returns a peer matching all pods of the namespace
+func NamespacePeer(namespace string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{NamespaceNameLabel: namespace},
+		},
+	}
+}
+
+// PodPeer - returns a peer matching the pods with the labels in the
+// namespace of the policy, e.g. the labels.GetLabels of a service
+func PodPeer(podLabels map[string]string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: podLabels,
+		},
+	}
+}
+
+// CreateOrPatch - creates or patches a NetworkPolicy, reconciles after Xs if object won't exist.
+func (n *NetworkPolicy) CreateOrPatch(
+	ctx context.Context,
+	h *helper.Helper,
+) (ctrl.Result, error) {
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      n.networkPolicy.Name,
+			Namespace: n.networkPolicy.Namespace,
+		},
+	}
+
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), np, func() error {
+		np.Labels = util.MergeStringMaps(np.Labels, n.networkPolicy.Labels)
+		np.Annotations = util.MergeStringMaps(np.Annotations, n.networkPolicy.Annotations)
+		h.ApplyPropagatedMetadata(np, n.networkPolicy.Labels, n.networkPolicy.Annotations)
+		np.Spec = n.networkPolicy.Spec
+
+		err := object.SetControllerReference(h.GetBeforeObject(), np, h.GetScheme())
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			h.GetLogger().Info("NetworkPolicy not found, reconcile later", "object", np.Name, "requeueAfter", n.timeout)
+			return ctrl.Result{RequeueAfter: n.timeout}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if op != controllerutil.OperationResultNone {
+		h.GetLogger().Info("NetworkPolicy reconciled", "object", np.Name, "operation", op)
+	}
+
+	n.networkPolicy = np
+
+	return ctrl.Result{}, nil
+}
+
+// Delete - delete a NetworkPolicy.
+func (n *NetworkPolicy) Delete(
+	ctx context.Context,
+	h *helper.Helper,
+) error {
+	err := h.GetClient().Delete(ctx, n.networkPolicy)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting NetworkPolicy %s: %w", n.networkPolicy.Name, err)
+	}
+
+	return nil
+}
+
+// GetNetworkPolicy - get the NetworkPolicy object.
+func (n *NetworkPolicy) GetNetworkPolicy() networkingv1.NetworkPolicy {
+	return *n.networkPolicy
+}
+
+// GetNetworkPolicyWithName func
+func GetNetworkPolicyWithName(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	namespace string,
+) (*networkingv1.NetworkPolicy, error) {
+	np := &networkingv1.NetworkPolicy{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, np)
+	if err != nil {
+		return np, err
+	}
+
+	return np, nil
+}
+
+// PruneNetworkPolicies - deletes the NetworkPolicies in the namespace
+// matching the label selector which are not in keep, e.g. the policies of
+// a service port which got removed
+func PruneNetworkPolicies(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	labelSelectorMap map[string]string,
+	keep []string,
+) error {
+	policies := &networkingv1.NetworkPolicyList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels(labelSelectorMap),
+	}
+	if err := h.GetClient().List(ctx, policies, listOpts...); err != nil {
+		return fmt.Errorf("error listing NetworkPolicies for labels %v: %w", labelSelectorMap, err)
+	}
+
+	for _, np := range policies.Items {
+		if slices.Contains(keep, np.Name) {
+			continue
+		}
+		err := h.GetClient().Delete(ctx, &np)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return fmt.Errorf("error deleting NetworkPolicy %s: %w", np.Name, err)
+		}
+		h.GetLogger().Info("NetworkPolicy pruned", "object", np.Name)
+	}
+
+	return nil
+}
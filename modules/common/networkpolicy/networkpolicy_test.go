@@ -0,0 +1,115 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	serviceLabels = map[string]string{"service": "keystone"}
+	policyLabels  = map[string]string{"keystone.openstack.org/name": "keystone"}
+)
+
+func getService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone-internal",
+			Namespace: "openstack",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: serviceLabels,
+			Ports: []corev1.ServicePort{
+				{Name: "keystone-internal", Port: 5000},
+				{Name: "metrics", Port: 80, TargetPort: intstr.FromString("metrics"), Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+}
+
+func TestAllowServicePorts(t *testing.T) {
+	g := NewWithT(t)
+
+	np := AllowServicePorts("keystone-internal", getService(), []networkingv1.NetworkPolicyPeer{NamespacePeer("openstack")}, policyLabels)
+
+	g.Expect(np.Namespace).To(Equal("openstack"))
+	g.Expect(np.Spec.PodSelector.MatchLabels).To(Equal(serviceLabels))
+	g.Expect(np.Spec.Ingress).To(HaveLen(1))
+	g.Expect(np.Spec.Ingress[0].From[0].NamespaceSelector.MatchLabels).To(
+		HaveKeyWithValue(NamespaceNameLabel, "openstack"))
+	g.Expect(np.Spec.Ingress[0].Ports).To(Equal([]networkingv1.NetworkPolicyPort{
+		{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(5000))},
+		{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromString("metrics"))},
+	}))
+
+	deny := DefaultDeny("keystone-deny", "openstack", serviceLabels, policyLabels)
+	g.Expect(deny.Spec.PolicyTypes).To(Equal([]networkingv1.PolicyType{networkingv1.PolicyTypeIngress}))
+	g.Expect(deny.Spec.Ingress).To(BeEmpty())
+}
+
+func TestCreateOrPatchAndPrune(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	cr := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone",
+			Namespace: "openstack",
+			UID:       "1234",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h, err := helper.NewHelper(cr, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for _, np := range []*networkingv1.NetworkPolicy{
+		DefaultDeny("keystone-deny", "openstack", serviceLabels, policyLabels),
+		AllowServicePorts("keystone-internal", getService(), nil, policyLabels),
+		AllowServicePorts("keystone-public", getService(), nil, policyLabels),
+	} {
+		n := NewNetworkPolicy(np, time.Second)
+		result, err := n.CreateOrPatch(ctx, h)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(n.GetNetworkPolicy().OwnerReferences).To(HaveLen(1))
+	}
+
+	g.Expect(PruneNetworkPolicies(ctx, h, "openstack", policyLabels, []string{"keystone-deny", "keystone-internal"})).To(Succeed())
+
+	_, err = GetNetworkPolicyWithName(ctx, h, "keystone-public", "openstack")
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+	np, err := GetNetworkPolicyWithName(ctx, h, "keystone-internal", "openstack")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(NewNetworkPolicy(np, time.Second).Delete(ctx, h)).To(Succeed())
+	_, err = GetNetworkPolicyWithName(ctx, h, "keystone-internal", "openstack")
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicy
+
+import (
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const (
+	// NamespaceNameLabel - label the API server sets on every namespace
+	// with the name of the namespace
+	NamespaceNameLabel = "kubernetes.io/metadata.name"
+)
+
+// NetworkPolicy -
+type NetworkPolicy struct {
+	networkPolicy *networkingv1.NetworkPolicy
+	timeout       time.Duration
+}
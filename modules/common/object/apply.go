@@ -0,0 +1,156 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Apply - an object ApplyAll creates or patches and the function setting
+// its desired state
+type Apply struct {
+	// Object - the object, with name and namespace set
+	Object client.Object
+	// Mutate - sets the desired state, see CreateOrPatch
+	Mutate controllerutil.MutateFn
+}
+
+// ApplyError - the errors of the objects ApplyAll failed to create or
+// patch, by Kind/namespace/name of the object
+type ApplyError struct {
+	Errors map[string]error
+}
+
+// Error - returns the failed objects with their errors, ordered by object
+func (e *ApplyError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	msgs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", key, e.Errors[key]))
+	}
+	return fmt.Sprintf("failed to apply %d objects: %s", len(keys), strings.Join(msgs, "; "))
+}
+
+// Unwrap - returns the errors of the objects, for errors.Is and errors.As
+func (e *ApplyError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// ApplyResult - the objects ApplyAll created or patched
+type ApplyResult struct {
+	// Succeeded - Kind/namespace/name of the objects applied, ordered
+	Succeeded []string
+	// Reports - the PatchReport by Kind/namespace/name of the objects applied
+	Reports map[string]*PatchReport
+}
+
+// ApplyAll - creates or patches the objects via CreateOrPatch, up to
+// concurrency of them in parallel. The Mutate functions run concurrently,
+// they must not modify shared state. All objects get applied, the errors of
+// the failed ones are returned together as *ApplyError, the result holds
+// the ones which succeeded.
+//
+// Example:
+//
+//	objs := []object.Apply{}
+//	for _, cell := range instance.Spec.Cells {
+//		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: cell.Name + "-config", Namespace: instance.Namespace}}
+//		objs = append(objs, object.Apply{Object: cm, Mutate: func() error {
+//			cm.Data = cellConfig(cell)
+//			return controllerutil.SetControllerReference(instance, cm, h.GetScheme())
+//		}})
+//	}
+//	result, err := object.ApplyAll(ctx, h, objs, 8)
+func ApplyAll(
+	ctx context.Context,
+	h *helper.Helper,
+	objs []Apply,
+	concurrency int,
+) (*ApplyResult, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	result := &ApplyResult{
+		Succeeded: []string{},
+		Reports:   map[string]*PatchReport{},
+	}
+	errs := map[string]error{}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, o := range objs {
+		key := objectKey(h, o.Object)
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(o Apply) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			report, err := CreateOrPatch(ctx, h, o.Object, o.Mutate)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs[key] = err
+				return
+			}
+			result.Succeeded = append(result.Succeeded, key)
+			result.Reports[key] = report
+		}(o)
+	}
+	wg.Wait()
+
+	sort.Strings(result.Succeeded)
+	if len(errs) > 0 {
+		return result, &ApplyError{Errors: errs}
+	}
+
+	return result, nil
+}
+
+// objectKey - returns Kind/namespace/name of obj, Kind/name if it is
+// cluster scoped
+func objectKey(h *helper.Helper, obj client.Object) string {
+	key := client.ObjectKeyFromObject(obj).String()
+	gvk, err := apiutil.GVKForObject(obj, h.GetScheme())
+	if err != nil {
+		return key
+	}
+	return gvk.Kind + "/" + key
+}
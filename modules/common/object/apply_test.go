@@ -0,0 +1,78 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var errInvalidCell = errors.New("invalid cell")
+
+func TestApplyAll(t *testing.T) {
+	g := NewWithT(t)
+
+	owner := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openstack"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	objs := []Apply{}
+	for i := 0; i < 10; i++ {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cell%d", i), Namespace: "openstack"}}
+		cell := i
+		objs = append(objs, Apply{Object: cm, Mutate: func() error {
+			if cell == 3 {
+				return errInvalidCell
+			}
+			cm.Data = map[string]string{"cell": fmt.Sprint(cell)}
+			return nil
+		}})
+	}
+
+	// the same name in another namespace is a separate object
+	other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cell0", Namespace: "other"}}
+	objs = append(objs, Apply{Object: other, Mutate: func() error { return nil }})
+
+	result, err := ApplyAll(context.TODO(), h, objs, 4)
+	g.Expect(err).To(MatchError(errInvalidCell))
+	applyErr := &ApplyError{}
+	g.Expect(errors.As(err, &applyErr)).To(BeTrue())
+	g.Expect(applyErr.Errors).To(HaveKey("ConfigMap/openstack/cell3"))
+	g.Expect(err.Error()).To(Equal("failed to apply 1 objects: ConfigMap/openstack/cell3: invalid cell"))
+
+	g.Expect(result.Succeeded).To(HaveLen(10))
+	g.Expect(result.Succeeded).To(ContainElement("ConfigMap/other/cell0"))
+	g.Expect(result.Succeeded[0]).To(Equal("ConfigMap/openstack/cell0"))
+	g.Expect(result.Reports["ConfigMap/openstack/cell0"].Operation).To(Equal(controllerutil.OperationResultCreated))
+
+	cms := &corev1.ConfigMapList{}
+	g.Expect(fakeClient.List(context.TODO(), cms)).To(Succeed())
+	g.Expect(cms.Items).To(HaveLen(10))
+}
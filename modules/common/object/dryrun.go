@@ -0,0 +1,161 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ErrAdmissionDenied - the API server admission rejected an object on a
+// dry-run, see DryRun
+var ErrAdmissionDenied = errors.New("admission denied")
+
+// AdmissionReason - why the admission rejected an object
+type AdmissionReason string
+
+const (
+	// AdmissionReasonQuota - a ResourceQuota of the namespace got exceeded
+	AdmissionReasonQuota AdmissionReason = "QuotaExceeded"
+	// AdmissionReasonPolicy - a validating webhook, e.g. of Gatekeeper or
+	// Kyverno, or a ValidatingAdmissionPolicy denied the object
+	AdmissionReasonPolicy AdmissionReason = "PolicyDenied"
+	// AdmissionReasonForbidden - any other forbidden error, e.g. a pod
+	// security violation
+	AdmissionReasonForbidden AdmissionReason = "Forbidden"
+)
+
+// AdmissionError - the rejection of an object by the API server admission
+type AdmissionError struct {
+	// Object - kind and name of the object, e.g. Deployment/keystone
+	Object string
+	// Reason - why the object got rejected
+	Reason AdmissionReason
+	// Err - the error returned by the API server
+	Err error
+}
+
+// Error - returns the object, the reason and the message of the API server
+func (e *AdmissionError) Error() string {
+	return fmt.Sprintf("%s: %s %s: %s", ErrAdmissionDenied, e.Object, e.Reason, e.Err)
+}
+
+// Unwrap - returns ErrAdmissionDenied and the error of the API server, for
+// errors.Is and errors.As
+func (e *AdmissionError) Unwrap() []error {
+	return []error{ErrAdmissionDenied, e.Err}
+}
+
+// DryRun - submits obj as CreateOrPatch would, with server-side dry-run, so
+// that admission failures, e.g. exceeded quotas or policy engines like
+// Gatekeeper or Kyverno denying the object, are found before anything gets
+// applied. mutate must be the mutate function passed to CreateOrPatch
+// afterwards, obj is reset to its state before the call. Returns an
+// AdmissionError if the admission rejected obj.
+//
+// Example:
+//
+//	mutate := func() error { ... }
+//	err := object.DryRun(ctx, h, deployment, mutate)
+//	if err != nil {
+//		return ctrl.Result{}, err
+//	}
+//	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), deployment, mutate)
+func DryRun(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	mutate controllerutil.MutateFn,
+) error {
+	orig := obj.DeepCopyObject().(client.Object)
+	defer reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(orig).Elem())
+
+	err := h.GetClient().Get(ctx, client.ObjectKeyFromObject(obj), obj)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return err
+	}
+
+	if k8s_errors.IsNotFound(err) {
+		err = mutate()
+		if err != nil {
+			return err
+		}
+		err = h.GetClient().Create(ctx, obj, client.DryRunAll)
+	} else {
+		before := obj.DeepCopyObject().(client.Object)
+		err = mutate()
+		if err != nil {
+			return err
+		}
+		err = h.GetClient().Patch(ctx, obj, client.MergeFrom(before), client.DryRunAll)
+	}
+
+	return admissionError(h, obj, err)
+}
+
+// DryRunApply - like DryRun, for objects applied via helper.SSAApply
+func DryRunApply(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+) error {
+	err := h.SSAApply(ctx, obj.DeepCopyObject().(client.Object), client.DryRunAll)
+
+	return admissionError(h, obj, err)
+}
+
+// admissionError - returns an AdmissionError if err is a rejection by the
+// admission, err otherwise. Only forbidden errors are rejections by the
+// admission, an invalid object is returned as is.
+func admissionError(h *helper.Helper, obj client.Object, err error) error {
+	var apiStatus k8s_errors.APIStatus
+	if err == nil || !errors.As(err, &apiStatus) || !k8s_errors.IsForbidden(err) {
+		return err
+	}
+	status := apiStatus.Status()
+
+	reason := AdmissionReasonForbidden
+	switch {
+	case status.Reason != metav1.StatusReasonForbidden ||
+		(status.Details != nil && len(status.Details.Causes) > 0):
+		// the API server returns the status of a denying webhook as is,
+		// without a reason unless the webhook set one, and the messages of
+		// a denying ValidatingAdmissionPolicy as causes
+		reason = AdmissionReasonPolicy
+	case strings.Contains(status.Message, "exceeded quota"):
+		// the ResourceQuota admission has no cause of its own
+		reason = AdmissionReasonQuota
+	}
+
+	name := obj.GetName()
+	if gvk, gvkErr := apiutil.GVKForObject(obj, h.GetScheme()); gvkErr == nil {
+		name = gvk.Kind + "/" + name
+	}
+	h.GetLogger().Info("Object rejected by admission on dry-run", "object", name, "reason", reason)
+
+	return &AdmissionError{Object: name, Reason: reason, Err: err}
+}
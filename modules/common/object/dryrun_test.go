@@ -0,0 +1,155 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var (
+	errConnection = errors.New("connection refused")
+	errInvalid    = k8s_errors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "foo", field.ErrorList{
+		field.Required(field.NewPath("data"), ""),
+	})
+)
+
+func TestDryRun(t *testing.T) {
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+	tests := []struct {
+		name      string
+		createErr error
+		reason    AdmissionReason
+		wantErr   error
+	}{
+		{
+			name: "Admitted",
+		},
+		{
+			name:      "Quota",
+			createErr: k8s_errors.NewForbidden(configMaps, "foo", errors.New("exceeded quota: compute-resources")),
+			reason:    AdmissionReasonQuota,
+		},
+		{
+			name: "Webhook",
+			createErr: &k8s_errors.StatusError{ErrStatus: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusForbidden,
+				Message: `admission webhook "validation.gatekeeper.sh" denied the request: [required-labels] missing label owner`,
+			}},
+			reason: AdmissionReasonPolicy,
+		},
+		{
+			name: "ValidatingAdmissionPolicy",
+			createErr: &k8s_errors.StatusError{ErrStatus: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusForbidden,
+				Reason:  metav1.StatusReasonForbidden,
+				Message: `configmaps "foo" is forbidden: ValidatingAdmissionPolicy 'require-owner' denied request`,
+				Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{Message: "missing label owner"}}},
+			}},
+			reason: AdmissionReasonPolicy,
+		},
+		{
+			name: "Pod security",
+			createErr: k8s_errors.NewForbidden(configMaps, "foo",
+				errors.New(`violates PodSecurity "restricted:latest": privileged`)),
+			reason: AdmissionReasonForbidden,
+		},
+		{
+			name:      "Invalid",
+			createErr: errInvalid,
+			wantErr:   errInvalid,
+		},
+		{
+			name:      "Not an admission error",
+			createErr: errConnection,
+			wantErr:   errConnection,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+
+			dryRuns := 0
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+						createOpts := &client.CreateOptions{}
+						createOpts.ApplyOptions(opts)
+						if len(createOpts.DryRun) > 0 {
+							dryRuns++
+							if tt.createErr != nil {
+								return tt.createErr
+							}
+						}
+						return c.Create(ctx, obj, opts...)
+					},
+				}).Build()
+			owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "openstack"}}
+			h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "openstack"}}
+			mutate := func() error {
+				cm.Data = map[string]string{"foo": "bar"}
+				return nil
+			}
+
+			err = DryRun(ctx, h, cm, mutate)
+			g.Expect(dryRuns).To(Equal(1))
+			// obj is reset for the CreateOrPatch which follows
+			g.Expect(cm.Data).To(BeNil())
+			g.Expect(cm.ResourceVersion).To(BeEmpty())
+			// nothing got created
+			g.Expect(k8s_errors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(cm), &corev1.ConfigMap{}))).To(BeTrue())
+
+			switch {
+			case tt.reason != "":
+				g.Expect(err).To(MatchError(ErrAdmissionDenied))
+				admissionErr := &AdmissionError{}
+				g.Expect(errors.As(err, &admissionErr)).To(BeTrue())
+				g.Expect(admissionErr.Reason).To(Equal(tt.reason))
+				g.Expect(admissionErr.Object).To(Equal("ConfigMap/foo"))
+				g.Expect(k8s_errors.IsForbidden(err)).To(BeTrue())
+			case tt.wantErr != nil:
+				g.Expect(err).To(MatchError(tt.wantErr))
+				g.Expect(err).ToNot(MatchError(ErrAdmissionDenied))
+			default:
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
@@ -109,7 +109,7 @@ func EnsureOwnerRef(
 			return fmt.Errorf("error metadata update failed: %w", err)
 		}
 
-		h.GetLogger().Info(fmt.Sprintf("Owner reference patched - diff %+v", patchDiff["metadata"]))
+		h.GetLogger().Info("Owner reference patched", "object", object.GetName(), "diff", patchDiff["metadata"])
 	}
 
 	return nil
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// SetControllerReference - sets owner as the controller of obj, like
+// controllerutil.SetControllerReference, and adds the owner labels of owner
+// to obj, see labels.SetOwnerLabels. Used by the create helpers, so that
+// the children of a CR can be found with ListOwned. Returns
+// helper.ErrNoBeforeObject if owner is nil, e.g. the before object of a
+// helper created via helper.NewHelperWithoutObject.
+func SetControllerReference(owner client.Object, obj client.Object, scheme *runtime.Scheme) error {
+	if owner == nil {
+		return helper.ErrNoBeforeObject
+	}
+
+	err := labels.SetOwnerLabels(owner, obj, scheme)
+	if err != nil {
+		return err
+	}
+
+	return controllerutil.SetControllerReference(owner, obj, scheme)
+}
+
+// ListOwned - lists the objects of the kind of list in the namespace of
+// owner which carry the owner labels of owner
+//
+// Example:
+//
+//	jobs := &batchv1.JobList{}
+//	err := object.ListOwned(ctx, h, instance, jobs)
+func ListOwned(
+	ctx context.Context,
+	h *helper.Helper,
+	owner client.Object,
+	list client.ObjectList,
+	opts ...client.ListOption,
+) error {
+	listOpts := []client.ListOption{
+		client.InNamespace(owner.GetNamespace()),
+		client.MatchingLabels(labels.GetOwnerSelector(owner)),
+	}
+
+	return h.GetReader().List(ctx, list, append(listOpts, opts...)...)
+}
+
+// GetOwner - returns the kind, name and UID of the owner from the owner
+// labels of obj, ok is false if obj has no owner labels
+func GetOwner(obj client.Object) (kind string, name string, uid string, ok bool) {
+	objLabels := obj.GetLabels()
+	uid, ok = objLabels[labels.OwnerUIDLabel]
+
+	return objLabels[labels.OwnerKindLabel], objLabels[labels.OwnerNameLabel], uid, ok
+}
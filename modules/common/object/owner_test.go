@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestListOwned(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", UID: "uid-1"}}
+	other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "glance", Namespace: "openstack", UID: "uid-2"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for _, s := range []struct {
+		name  string
+		owner *corev1.ConfigMap
+	}{{"keystone-a", owner}, {"keystone-b", owner}, {"glance-a", other}} {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: "openstack"}}
+		g.Expect(SetControllerReference(s.owner, secret, scheme.Scheme)).To(Succeed())
+		g.Expect(secret.OwnerReferences).To(HaveLen(1))
+		g.Expect(fakeClient.Create(ctx, secret)).To(Succeed())
+	}
+
+	secrets := &corev1.SecretList{}
+	g.Expect(ListOwned(ctx, h, owner, secrets)).To(Succeed())
+	names := []string{}
+	for _, s := range secrets.Items {
+		names = append(names, s.Name)
+	}
+	g.Expect(names).To(ConsistOf("keystone-a", "keystone-b"))
+
+	kind, name, uid, ok := GetOwner(&secrets.Items[0])
+	g.Expect(ok).To(BeTrue())
+	g.Expect([]string{kind, name, uid}).To(Equal([]string{"ConfigMap", "keystone", "uid-1"}))
+
+	_, _, _, ok = GetOwner(owner)
+	g.Expect(ok).To(BeFalse())
+
+	// helper created without a before object
+	noOwner := helper.NewHelperWithoutObject("keystone-webhook", fakeClient, nil, scheme.Scheme, ctrl.Log)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "keystone-c", Namespace: "openstack"}}
+	g.Expect(SetControllerReference(noOwner.GetBeforeObject(), secret, scheme.Scheme)).To(MatchError(helper.ErrNoBeforeObject))
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// FieldChange - a field changed by CreateOrPatch
+type FieldChange struct {
+	// Path - path of the field, e.g. spec.replicas. Lists are reported as a
+	// whole.
+	Path []string
+	// PreviousManagers - the field managers which owned the field before
+	// the change, e.g. kubectl-edit if a user changed the field
+	PreviousManagers []string
+}
+
+// String - returns the path and the previous managers of the change
+func (c FieldChange) String() string {
+	if len(c.PreviousManagers) == 0 {
+		return strings.Join(c.Path, ".")
+	}
+	return fmt.Sprintf("%s (owned by %s)", strings.Join(c.Path, "."), strings.Join(c.PreviousManagers, ", "))
+}
+
+// PatchReport - the result of CreateOrPatch
+type PatchReport struct {
+	// Operation - the operation done on the object
+	Operation controllerutil.OperationResult
+	// Changes - the fields changed by the mutate function of an existing
+	// object, ordered by path
+	Changes []FieldChange
+}
+
+// String - returns a short summary of the report
+func (r *PatchReport) String() string {
+	changes := make([]string, 0, len(r.Changes))
+	for _, c := range r.Changes {
+		changes = append(changes, c.String())
+	}
+	return fmt.Sprintf("%s: %s", r.Operation, strings.Join(changes, ", "))
+}
+
+// CreateOrPatch - wraps controllerutil.CreateOrPatch and returns a report
+// of the fields changed by mutate on an existing object together with the
+// field managers which owned them before, which are logged at debug level.
+// This helps to find out which other actor, e.g. a user or another
+// operator, keeps changing the fields the operator manages.
+//
+// Example:
+//
+//	report, err := object.CreateOrPatch(ctx, h, deployment, func() error {
+//		deployment.Spec = desired.Spec
+//		return controllerutil.SetControllerReference(h.GetBeforeObject(), deployment, h.GetScheme())
+//	})
+func CreateOrPatch(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	mutate controllerutil.MutateFn,
+) (*PatchReport, error) {
+	var before, after runtime.Object
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), obj, func() error {
+		if obj.GetResourceVersion() != "" {
+			before = obj.DeepCopyObject()
+		}
+		err := mutate()
+		if err != nil {
+			return err
+		}
+		after = obj.DeepCopyObject()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PatchReport{Operation: op}
+	if op == controllerutil.OperationResultNone || before == nil {
+		return report, nil
+	}
+
+	report.Changes, err = changedFields(before, after)
+	if err != nil {
+		return nil, err
+	}
+	h.GetLogger().V(1).Info("Object patched", "object", obj.GetName(), "operation", op, "changes", report.String())
+
+	return report, nil
+}
+
+// changedFields - returns the fields which differ between before and after
+// with the managers of the fields in before
+func changedFields(before runtime.Object, after runtime.Object) ([]FieldChange, error) {
+	b, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := runtime.DefaultUnstructuredConverter.ToUnstructured(after)
+	if err != nil {
+		return nil, err
+	}
+
+	managedFields, err := parseManagedFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := [][]string{}
+	diffPaths(nil, b, a, &paths)
+	sort.Slice(paths, func(i, j int) bool {
+		return strings.Join(paths[i], ".") < strings.Join(paths[j], ".")
+	})
+
+	changes := []FieldChange{}
+	for _, p := range paths {
+		if len(p) > 0 && p[0] == "metadata" && len(p) > 1 &&
+			(p[1] == "managedFields" || p[1] == "resourceVersion") {
+			continue
+		}
+		c := FieldChange{Path: p}
+		for _, m := range managedFields {
+			if fieldSetContains(m.fields, p) {
+				c.PreviousManagers = append(c.PreviousManagers, m.manager)
+			}
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// diffPaths - appends the paths of the leaves which differ between b and a
+func diffPaths(path []string, b interface{}, a interface{}, paths *[][]string) {
+	bMap, bOk := b.(map[string]interface{})
+	aMap, aOk := a.(map[string]interface{})
+	if !bOk || !aOk {
+		if !reflect.DeepEqual(b, a) {
+			*paths = append(*paths, append([]string{}, path...))
+		}
+		return
+	}
+
+	keys := map[string]bool{}
+	for k := range bMap {
+		keys[k] = true
+	}
+	for k := range aMap {
+		keys[k] = true
+	}
+	for k := range keys {
+		diffPaths(append(path, k), bMap[k], aMap[k], paths)
+	}
+}
+
+type managedFieldSet struct {
+	manager string
+	fields  map[string]interface{}
+}
+
+// parseManagedFields - returns the field sets of the managedFields of obj
+func parseManagedFields(obj runtime.Object) ([]managedFieldSet, error) {
+	accessor, ok := obj.(metav1.Object)
+	if !ok {
+		return nil, nil
+	}
+
+	sets := []managedFieldSet{}
+	for _, m := range accessor.GetManagedFields() {
+		if m.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]interface{}{}
+		err := json.Unmarshal(m.FieldsV1.Raw, &fields)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing managed fields of %s: %w", m.Manager, err)
+		}
+		sets = append(sets, managedFieldSet{manager: m.Manager, fields: fields})
+	}
+	return sets, nil
+}
+
+// fieldSetContains - returns true if the FieldsV1 field set contains the
+// path or a part of it, e.g. an element of a list
+func fieldSetContains(fields map[string]interface{}, path []string) bool {
+	current := fields
+	for _, p := range path {
+		next, ok := current["f:"+p].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		current = next
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestCreateOrPatch(t *testing.T) {
+	g := NewWithT(t)
+
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone-config",
+			Namespace: "openstack",
+			Labels:    map[string]string{"service": "keystone"},
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    "keystone-operator",
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:debug":{}},"f:metadata":{"f:labels":{"f:service":{}}}}`)},
+				},
+				{
+					Manager:    "kubectl-edit",
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:workers":{}}}`)},
+				},
+			},
+		},
+		Data: map[string]string{"debug": "false", "workers": "8"},
+	}
+	owner := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openstack"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(existing).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone-config", Namespace: "openstack"}}
+	report, err := CreateOrPatch(context.TODO(), h, cm, func() error {
+		cm.Data = map[string]string{"debug": "false", "workers": "4", "new": "true"}
+		return nil
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Operation).To(Equal(controllerutil.OperationResultUpdated))
+	g.Expect(report.Changes).To(Equal([]FieldChange{
+		{Path: []string{"data", "new"}},
+		{Path: []string{"data", "workers"}, PreviousManagers: []string{"kubectl-edit"}},
+	}))
+	g.Expect(report.String()).To(Equal("updated: data.new, data.workers (owned by kubectl-edit)"))
+
+	// unchanged
+	report, err = CreateOrPatch(context.TODO(), h, cm, func() error { return nil })
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Operation).To(Equal(controllerutil.OperationResultNone))
+	g.Expect(report.Changes).To(BeEmpty())
+
+	// created
+	cm = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "glance-config", Namespace: "openstack"}}
+	report, err = CreateOrPatch(context.TODO(), h, cm, func() error {
+		cm.Data = map[string]string{"debug": "true"}
+		return nil
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Operation).To(Equal(controllerutil.OperationResultCreated))
+	g.Expect(report.Changes).To(BeEmpty())
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WatchNamespaceEnv - env var with the comma separated namespaces the
+// operator watches, all namespaces if empty. OLM sets it from the
+// OperatorGroup of the operator.
+const WatchNamespaceEnv = "WATCH_NAMESPACE"
+
+// NamespaceScope - the namespaces the operator watches, all namespaces if
+// empty
+type NamespaceScope []string
+
+// GetWatchNamespaces - returns the namespaces of the WatchNamespaceEnv env var
+func GetWatchNamespaces() NamespaceScope {
+	return newNamespaceScope(strings.Split(os.Getenv(WatchNamespaceEnv), ","))
+}
+
+// NamespacesFromList - returns the namespaces of the objects of the kind of
+// list, e.g. of the OpenStackControlPlane CRs to watch the namespaces the
+// control planes got created in. As the cache is not running yet when the
+// manager gets configured, c should be a client without cache.
+//
+// Example:
+//
+//	c, err := client.New(cfg, client.Options{Scheme: scheme})
+//	...
+//	scope, err := operator.NamespacesFromList(ctx, c, &corev1beta1.OpenStackControlPlaneList{})
+func NamespacesFromList(
+	ctx context.Context,
+	c client.Reader,
+	list client.ObjectList,
+	opts ...client.ListOption,
+) (NamespaceScope, error) {
+	err := c.List(ctx, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := []string{}
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok {
+			namespaces = append(namespaces, obj.GetNamespace())
+		}
+	}
+	return newNamespaceScope(namespaces), nil
+}
+
+// newNamespaceScope - returns the sorted, unique and non empty namespaces
+func newNamespaceScope(namespaces []string) NamespaceScope {
+	scope := NamespaceScope{}
+	for _, ns := range namespaces {
+		ns = strings.TrimSpace(ns)
+		if ns != "" && !slices.Contains(scope, ns) {
+			scope = append(scope, ns)
+		}
+	}
+	sort.Strings(scope)
+	return scope
+}
+
+// All - returns true if all namespaces get watched
+func (s NamespaceScope) All() bool {
+	return len(s) == 0
+}
+
+// Contains - returns true if the namespace gets watched. Cluster scoped
+// objects, with an empty namespace, are always watched.
+func (s NamespaceScope) Contains(namespace string) bool {
+	return s.All() || namespace == "" || slices.Contains(s, namespace)
+}
+
+// SetCacheOptions - restricts the cache of the manager to the namespaces of
+// the scope, so that the operator only needs list and watch permissions in
+// them. Nothing is changed if all namespaces get watched.
+//
+// Example:
+//
+//	options := ctrl.Options{...}
+//	operator.GetWatchNamespaces().SetCacheOptions(&options, setupLog)
+func (s NamespaceScope) SetCacheOptions(options *ctrl.Options, setupLog logr.Logger) {
+	if s.All() {
+		return
+	}
+
+	options.Cache.DefaultNamespaces = map[string]cache.Config{}
+	for _, ns := range s {
+		options.Cache.DefaultNamespaces[ns] = cache.Config{}
+	}
+	setupLog.Info("manager cache restricted to namespaces", "namespaces", []string(s))
+}
+
+// Reader - returns a reader which reads the objects in the namespaces of the
+// scope, and cluster scoped ones, from cached and the others from
+// uncached. Set as reader of the helper, the lib-common modules can read
+// objects in other namespaces, e.g. a shared CA bundle, which the
+// restricted cache can not serve.
+//
+// Example:
+//
+//	h.SetReader(scope.Reader(mgr.GetClient(), mgr.GetAPIReader()))
+func (s NamespaceScope) Reader(cached client.Reader, uncached client.Reader) client.Reader {
+	return &scopedReader{scope: s, cached: cached, uncached: uncached}
+}
+
+// scopedReader - routes the reads by namespace, see NamespaceScope.Reader
+type scopedReader struct {
+	scope    NamespaceScope
+	cached   client.Reader
+	uncached client.Reader
+}
+
+// Get - implements client.Reader
+func (r *scopedReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if r.scope.Contains(key.Namespace) {
+		return r.cached.Get(ctx, key, obj, opts...)
+	}
+	return r.uncached.Get(ctx, key, obj, opts...)
+}
+
+// List - implements client.Reader, lists across all namespaces are served
+// from the cache of the namespaces of the scope
+func (r *scopedReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if r.scope.Contains(listOpts.Namespace) {
+		return r.cached.List(ctx, list, opts...)
+	}
+	return r.uncached.List(ctx, list, opts...)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetWatchNamespaces(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Setenv(WatchNamespaceEnv, "")
+	scope := GetWatchNamespaces()
+	g.Expect(scope.All()).To(BeTrue())
+	g.Expect(scope.Contains("openstack")).To(BeTrue())
+	options := ctrl.Options{}
+	scope.SetCacheOptions(&options, logr.Discard())
+	g.Expect(options.Cache.DefaultNamespaces).To(BeNil())
+
+	t.Setenv(WatchNamespaceEnv, "openstack2, openstack,,openstack")
+	scope = GetWatchNamespaces()
+	g.Expect(scope).To(Equal(NamespaceScope{"openstack", "openstack2"}))
+	g.Expect(scope.Contains("openstack2")).To(BeTrue())
+	g.Expect(scope.Contains("")).To(BeTrue())
+	g.Expect(scope.Contains("other")).To(BeFalse())
+	scope.SetCacheOptions(&options, logr.Discard())
+	g.Expect(options.Cache.DefaultNamespaces).To(HaveLen(2))
+	g.Expect(options.Cache.DefaultNamespaces).To(HaveKey("openstack2"))
+}
+
+func TestNamespacesFromList(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "tenant-b"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "tenant-a"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "tenant-a"}},
+	).Build()
+
+	scope, err := NamespacesFromList(context.TODO(), c, &corev1.ConfigMapList{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(scope).To(Equal(NamespaceScope{"tenant-a", "tenant-b"}))
+}
+
+func TestScopedReader(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	cached := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cached", Namespace: "openstack"}},
+	).Build()
+	uncached := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle", Namespace: "shared"}},
+	).Build()
+	r := NamespaceScope{"openstack"}.Reader(cached, uncached)
+
+	g.Expect(r.Get(ctx, types.NamespacedName{Name: "cached", Namespace: "openstack"}, &corev1.Secret{})).To(Succeed())
+	g.Expect(r.Get(ctx, types.NamespacedName{Name: "ca-bundle", Namespace: "shared"}, &corev1.Secret{})).To(Succeed())
+
+	secrets := &corev1.SecretList{}
+	g.Expect(r.List(ctx, secrets, client.InNamespace("shared"))).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(1))
+	g.Expect(secrets.Items[0].Name).To(Equal("ca-bundle"))
+
+	g.Expect(r.List(ctx, secrets)).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(1))
+	g.Expect(secrets.Items[0].Name).To(Equal("cached"))
+}
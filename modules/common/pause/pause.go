@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pause provides utilities to pause the reconciliation of a CR via an annotation
+package pause
+
+import (
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// PauseAnnotation - annotation an admin sets to "true" on a CR to pause
+	// its reconciliation, e.g. during a maintenance window
+	PauseAnnotation = "openstack.org/reconcile-paused"
+)
+
+// IsPaused - returns true if the object has the PauseAnnotation set to "true"
+func IsPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[PauseAnnotation] == "true"
+}
+
+// NotPausedPredicate - returns a predicate which filters the events of
+// paused objects. The update which pauses the object passes, so that the
+// controller can set the PausedCondition, as does the update removing the
+// annotation, which resumes the reconciliation. Delete events always pass
+// to not block the finalizer handling of paused objects.
+//
+// NOTE: the predicate only filters the events of the objects of the watch it
+// is added to, usually For(). Events of owned or otherwise watched objects
+// still enqueue a paused CR, so the reconcile must check the pause itself
+// with SetPausedCondition or IsPaused before changing anything.
+//
+// example usage:
+//
+//	ctrl.NewControllerManagedBy(mgr).
+//		For(&keystonev1.KeystoneAPI{}, builder.WithPredicates(pause.NotPausedPredicate())).
+func NotPausedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return !IsPaused(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !IsPaused(e.ObjectOld) || !IsPaused(e.ObjectNew)
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return !IsPaused(e.Object)
+		},
+	}
+}
+
+// SetPausedCondition - sets the PausedCondition if the object is paused, or
+// removes it if not. Returns true if the object is paused and the reconcile
+// should return early. The other conditions, including Ready, keep the
+// state of the last reconcile. It must be called at the start of every
+// reconcile of a pausable CR, NotPausedPredicate alone does not prevent the
+// reconcile of a paused CR.
+//
+// example usage:
+//
+//	if pause.SetPausedCondition(instance, &instance.Status.Conditions) {
+//		return ctrl.Result{}, nil
+//	}
+func SetPausedCondition(obj metav1.Object, conditions *condition.Conditions) bool {
+	if IsPaused(obj) {
+		conditions.MarkTrue(condition.PausedCondition, condition.PausedMessage, PauseAnnotation)
+		return true
+	}
+	conditions.Remove(condition.PausedCondition)
+	return false
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func getObj(paused bool) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	if paused {
+		cm.Annotations = map[string]string{PauseAnnotation: "true"}
+	}
+	return cm
+}
+
+func TestNotPausedPredicate(t *testing.T) {
+	g := NewWithT(t)
+	p := NotPausedPredicate()
+
+	g.Expect(p.Create(event.CreateEvent{Object: getObj(false)})).To(BeTrue())
+	g.Expect(p.Create(event.CreateEvent{Object: getObj(true)})).To(BeFalse())
+	g.Expect(p.Generic(event.GenericEvent{Object: getObj(true)})).To(BeFalse())
+	g.Expect(p.Delete(event.DeleteEvent{Object: getObj(true)})).To(BeTrue())
+
+	// pausing and resuming pass, changes while paused are filtered
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: getObj(false), ObjectNew: getObj(true)})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: getObj(true), ObjectNew: getObj(false)})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: getObj(true), ObjectNew: getObj(true)})).To(BeFalse())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: getObj(false), ObjectNew: getObj(false)})).To(BeTrue())
+}
+
+func TestSetPausedCondition(t *testing.T) {
+	g := NewWithT(t)
+	conditions := condition.Conditions{}
+
+	g.Expect(SetPausedCondition(getObj(true), &conditions)).To(BeTrue())
+	g.Expect(conditions.IsTrue(condition.PausedCondition)).To(BeTrue())
+	g.Expect(conditions.Get(condition.PausedCondition).Message).To(ContainSubstring(PauseAnnotation))
+
+	g.Expect(SetPausedCondition(getObj(false), &conditions)).To(BeFalse())
+	g.Expect(conditions.Has(condition.PausedCondition)).To(BeFalse())
+}
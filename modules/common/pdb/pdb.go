@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	policyv1 "k8s.io/api/policy/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
@@ -92,7 +93,7 @@ func (p *PDB) CreateOrPatch(
 		pdb.Annotations = util.MergeStringMaps(pdb.Annotations, p.pdb.Annotations)
 		pdb.Spec = p.pdb.Spec
 
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), pdb, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), pdb, h.GetScheme())
 		if err != nil {
 			return err
 		}
@@ -101,13 +102,13 @@ func (p *PDB) CreateOrPatch(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("PodDisruptionBudget %s not found, reconcile in %s", pdb.Name, p.timeout))
+			h.GetLogger().Info("PodDisruptionBudget not found, reconcile later", "object", pdb.Name, "requeueAfter", p.timeout)
 			return ctrl.Result{RequeueAfter: p.timeout}, nil
 		}
 		return ctrl.Result{}, err
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("PodDisruptionBudget %s - %s", pdb.Name, op))
+		h.GetLogger().Info("PodDisruptionBudget reconciled", "object", pdb.Name, "operation", op)
 	}
 
 	// update the pdb object of the pdb type
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DebugAllowedAnnotation - annotation a pod must have with the value
+	// "true" to allow ephemeral debug containers to be attached to it
+	DebugAllowedAnnotation = "openstack.org/debug-allowed"
+)
+
+// Define static errors
+var (
+	// ErrDebugNotAllowed indicates that the pod did not opt-in for debug containers
+	ErrDebugNotAllowed = errors.New("debug containers not allowed")
+	// ErrDebugContainerNotFound indicates that the ephemeral container does not exist
+	ErrDebugContainerNotFound = errors.New("debug container not found")
+)
+
+// DebugContainer - ephemeral container to collect diagnostics from a running pod
+type DebugContainer struct {
+	// Name - name of the ephemeral container, must be unique within the pod
+	Name string
+	// Image - image providing the debug tools
+	Image string
+	// Command - command to run, its output can be retrieved via
+	// GetDebugContainerOutput
+	Command []string
+	// TargetContainer - if set, the debug container shares the process
+	// namespace of this container
+	TargetContainer string
+}
+
+// IsDebugAllowed - returns true if the pod opted in for debug containers
+func IsDebugAllowed(pod *corev1.Pod) bool {
+	return pod.Annotations[DebugAllowedAnnotation] == "true"
+}
+
+// AddDebugContainer - attaches the ephemeral debug container to the running
+// pod. The pod has to opt-in via the DebugAllowedAnnotation, otherwise
+// ErrDebugNotAllowed is returned. Ephemeral containers can not be removed or
+// changed, if a container with the same name already exists it is left
+// untouched.
+func AddDebugContainer(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	podName string,
+	dc DebugContainer,
+) error {
+	pod, err := h.GetKClient().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting pod %s: %w", podName, err)
+	}
+
+	if !IsDebugAllowed(pod) {
+		return fmt.Errorf("%w: pod %s does not have annotation %s=true", ErrDebugNotAllowed, podName, DebugAllowedAnnotation)
+	}
+
+	for _, c := range pod.Spec.EphemeralContainers {
+		if c.Name == dc.Name {
+			return nil
+		}
+	}
+
+	if dc.TargetContainer != "" {
+		found := false
+		for _, c := range pod.Spec.Containers {
+			if c.Name == dc.TargetContainer {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: %s in pod %s", ErrContainerNotFound, dc.TargetContainer, podName)
+		}
+	}
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     dc.Name,
+			Image:                    dc.Image,
+			Command:                  dc.Command,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+		TargetContainerName: dc.TargetContainer,
+	})
+
+	_, err = h.GetKClient().CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("error adding debug container %s to pod %s: %w", dc.Name, podName, err)
+	}
+	h.GetLogger().Info("Debug container added", "object", podName, "container", dc.Name)
+
+	return nil
+}
+
+// GetDebugContainerOutput - returns the output of the debug container. done
+// is false as long as the container did not terminate, the output is partial
+// in that case.
+func GetDebugContainerOutput(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	podName string,
+	name string,
+) (output string, done bool, err error) {
+	pod, err := h.GetKClient().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return "", false, fmt.Errorf("error getting pod %s: %w", podName, err)
+	}
+
+	var status *corev1.ContainerStatus
+	for i := range pod.Status.EphemeralContainerStatuses {
+		if pod.Status.EphemeralContainerStatuses[i].Name == name {
+			status = &pod.Status.EphemeralContainerStatuses[i]
+			break
+		}
+	}
+	if status == nil {
+		for _, c := range pod.Spec.EphemeralContainers {
+			if c.Name == name {
+				// not yet started
+				return "", false, nil
+			}
+		}
+		return "", false, fmt.Errorf("%w: %s in pod %s", ErrDebugContainerNotFound, name, podName)
+	}
+	if status.State.Waiting != nil {
+		return "", false, nil
+	}
+
+	logs, err := h.GetKClient().CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: name,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("error getting logs of debug container %s in pod %s: %w", name, podName, err)
+	}
+
+	return string(logs), status.State.Terminated != nil, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddDebugContainer(t *testing.T) {
+	g := NewWithT(t)
+
+	denied := getPod("keystone-0", "node-1", nil)
+	denied.Spec.Containers = []corev1.Container{{Name: "keystone"}}
+	allowed := getPod("keystone-1", "node-1", nil)
+	allowed.Spec.Containers = []corev1.Container{{Name: "keystone"}}
+	allowed.Annotations = map[string]string{DebugAllowedAnnotation: "true"}
+
+	h, kclient, err := setupKHelper(nil, denied, allowed)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	dc := DebugContainer{
+		Name:            "debug",
+		Image:           "debug-tools:latest",
+		Command:         []string{"ss", "-tlnp"},
+		TargetContainer: "keystone",
+	}
+
+	err = AddDebugContainer(context.TODO(), h, "openstack", "keystone-0", dc)
+	g.Expect(err).To(MatchError(ErrDebugNotAllowed))
+
+	err = AddDebugContainer(context.TODO(), h, "openstack", "keystone-1", DebugContainer{
+		Name: "debug", TargetContainer: "missing",
+	})
+	g.Expect(err).To(MatchError(ErrContainerNotFound))
+
+	err = AddDebugContainer(context.TODO(), h, "openstack", "keystone-1", dc)
+	g.Expect(err).ToNot(HaveOccurred())
+	// adding it again is a noop
+	err = AddDebugContainer(context.TODO(), h, "openstack", "keystone-1", dc)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	pod, err := kclient.CoreV1().Pods("openstack").Get(context.TODO(), "keystone-1", metav1.GetOptions{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pod.Spec.EphemeralContainers).To(HaveLen(1))
+	g.Expect(pod.Spec.EphemeralContainers[0].TargetContainerName).To(Equal("keystone"))
+	g.Expect(pod.Spec.EphemeralContainers[0].Command).To(Equal(dc.Command))
+
+	// container not started yet
+	_, done, err := GetDebugContainerOutput(context.TODO(), h, "openstack", "keystone-1", "debug")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+
+	_, _, err = GetDebugContainerOutput(context.TODO(), h, "openstack", "keystone-1", "missing")
+	g.Expect(err).To(MatchError(ErrDebugContainerNotFound))
+
+	pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name:  "debug",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+		},
+	}
+	_, err = kclient.CoreV1().Pods("openstack").UpdateStatus(context.TODO(), pod, metav1.UpdateOptions{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	output, done, err := GetDebugContainerOutput(context.TODO(), h, "openstack", "keystone-1", "debug")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+	// the fake clientset always returns "fake logs"
+	g.Expect(output).To(Equal("fake logs"))
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DrainResult - progress of a DrainPodsForNode call
+type DrainResult struct {
+	// Evicted - pods evicted in this call
+	Evicted []string
+	// Blocked - pods whose eviction got refused, e.g. by a PodDisruptionBudget
+	Blocked []string
+	// Terminating - pods which are already terminating
+	Terminating []string
+}
+
+// Done - returns true if no pods are left on the node
+func (r *DrainResult) Done() bool {
+	return len(r.Evicted) == 0 && len(r.Blocked) == 0 && len(r.Terminating) == 0
+}
+
+// String - returns the progress in a form which can be used in a condition message
+func (r *DrainResult) String() string {
+	if r.Done() {
+		return "all pods drained"
+	}
+	msgs := []string{}
+	if len(r.Evicted) > 0 {
+		msgs = append(msgs, fmt.Sprintf("evicted: %s", strings.Join(r.Evicted, ",")))
+	}
+	if len(r.Terminating) > 0 {
+		msgs = append(msgs, fmt.Sprintf("terminating: %s", strings.Join(r.Terminating, ",")))
+	}
+	if len(r.Blocked) > 0 {
+		msgs = append(msgs, fmt.Sprintf("eviction blocked: %s", strings.Join(r.Blocked, ",")))
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// EvictPod - evicts the pod using the Eviction subresource, which honors the
+// PodDisruptionBudgets of the pod. Returns false, and no error, if the eviction
+// got refused because it would violate a PodDisruptionBudget, the caller
+// should retry later. A pod which does not exist is reported as evicted.
+func EvictPod(
+	ctx context.Context,
+	h *helper.Helper,
+	pod *corev1.Pod,
+	gracePeriodSeconds *int64,
+) (bool, error) {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	if gracePeriodSeconds != nil {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds}
+	}
+
+	err := h.GetKClient().CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return true, nil
+		}
+		// 429 TooManyRequests is returned if a PDB does not allow the disruption
+		if k8s_errors.IsTooManyRequests(err) {
+			h.GetLogger().Info("Pod eviction blocked", "object", pod.Name, "reason", err.Error())
+			return false, nil
+		}
+		return false, fmt.Errorf("error evicting pod %s: %w", pod.Name, err)
+	}
+
+	h.GetLogger().Info("Pod evicted", "object", pod.Name)
+	return true, nil
+}
+
+// DrainPodsForNode - evicts the pods in the namespace matching the labels
+// which run on the node. It does not wait for the pods to terminate, call it
+// again until DrainResult.Done() reports all pods are gone from the node.
+func DrainPodsForNode(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	nodeName string,
+	labelSelectorMap map[string]string,
+	gracePeriodSeconds *int64,
+) (*DrainResult, error) {
+	podList, err := GetPodListWithLabel(ctx, h, namespace, labelSelectorMap)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	result := &DrainResult{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		// pods which already finished don't block the drain
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if pod.DeletionTimestamp != nil {
+			result.Terminating = append(result.Terminating, pod.Name)
+			continue
+		}
+
+		evicted, err := EvictPod(ctx, h, pod, gracePeriodSeconds)
+		if err != nil {
+			return result, err
+		}
+		if evicted {
+			result.Evicted = append(result.Evicted, pod.Name)
+		} else {
+			result.Blocked = append(result.Blocked, pod.Name)
+		}
+	}
+
+	return result, nil
+}
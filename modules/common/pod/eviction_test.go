@@ -0,0 +1,116 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getPod(name string, node string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openstack",
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+}
+
+func setupKHelper(blocked map[string]bool, objs ...runtime.Object) (*helper.Helper, *kfake.Clientset, error) {
+	kclient := kfake.NewSimpleClientset(objs...)
+	kclient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction)
+		if blocked[eviction.Name] {
+			return true, nil, k8s_errors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+		}
+		return true, nil, nil
+	})
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "openstack",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	h, err := helper.NewHelper(ns, fakeClient, kclient, scheme.Scheme, ctrl.Log)
+	return h, kclient, err
+}
+
+func TestDrainPodsForNode(t *testing.T) {
+	g := NewWithT(t)
+	labels := map[string]string{"service": "galera"}
+
+	terminating := getPod("galera-2", "node-1", labels)
+	now := metav1.Now()
+	terminating.DeletionTimestamp = &now
+	terminating.Finalizers = []string{"foo"}
+
+	h, kclient, err := setupKHelper(
+		map[string]bool{"galera-1": true},
+		getPod("galera-0", "node-1", labels),
+		getPod("galera-1", "node-1", labels),
+		terminating,
+		getPod("galera-3", "node-2", labels),
+		getPod("other-0", "node-1", map[string]string{"service": "other"}),
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	result, err := DrainPodsForNode(context.TODO(), h, "openstack", "node-1", labels, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Evicted).To(Equal([]string{"galera-0"}))
+	g.Expect(result.Blocked).To(Equal([]string{"galera-1"}))
+	g.Expect(result.Terminating).To(Equal([]string{"galera-2"}))
+	g.Expect(result.Done()).To(BeFalse())
+	g.Expect(result.String()).To(Equal("evicted: galera-0, terminating: galera-2, eviction blocked: galera-1"))
+
+	evictions := 0
+	for _, a := range kclient.Actions() {
+		if a.GetSubresource() == "eviction" {
+			evictions++
+		}
+	}
+	g.Expect(evictions).To(Equal(2))
+
+	result, err = DrainPodsForNode(context.TODO(), h, "openstack", "node-3", labels, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Done()).To(BeTrue())
+}
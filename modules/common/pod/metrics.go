@@ -0,0 +1,221 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodMetricsGroupVersionKind - GVK of the PodMetrics served by the metrics API
+var PodMetricsGroupVersionKind = schema.GroupVersionKind{
+	Group:   "metrics.k8s.io",
+	Version: "v1beta1",
+	Kind:    "PodMetrics",
+}
+
+// ErrMetricsNotAvailable indicates that the metrics API is not served in the cluster
+var ErrMetricsNotAvailable = errors.New("metrics API not available")
+
+// ContainerUsage - resource usage and limits of a container
+type ContainerUsage struct {
+	// Name - name of the container
+	Name string
+	// CPU - current cpu usage
+	CPU resource.Quantity
+	// Memory - current memory usage
+	Memory resource.Quantity
+	// CPULimit - cpu limit of the container, zero if not set
+	CPULimit resource.Quantity
+	// MemoryLimit - memory limit of the container, zero if not set
+	MemoryLimit resource.Quantity
+}
+
+// CPUUtilization - returns the cpu usage in percent of the limit, 0 if the
+// container has no cpu limit
+func (c ContainerUsage) CPUUtilization() int64 {
+	return utilization(c.CPU, c.CPULimit)
+}
+
+// MemoryUtilization - returns the memory usage in percent of the limit, 0
+// if the container has no memory limit
+func (c ContainerUsage) MemoryUtilization() int64 {
+	return utilization(c.Memory, c.MemoryLimit)
+}
+
+// PodUsage - resource usage of a pod
+type PodUsage struct {
+	// Name - name of the pod
+	Name string
+	// Window - the time window the usage got sampled in, e.g. 30s
+	Window string
+	// Containers - usage per container
+	Containers []ContainerUsage
+}
+
+// CPU - returns the summed up cpu usage of all containers
+func (p PodUsage) CPU() resource.Quantity {
+	total := resource.Quantity{}
+	for _, c := range p.Containers {
+		total.Add(c.CPU)
+	}
+	return total
+}
+
+// Memory - returns the summed up memory usage of all containers
+func (p PodUsage) Memory() resource.Quantity {
+	total := resource.Quantity{}
+	for _, c := range p.Containers {
+		total.Add(c.Memory)
+	}
+	return total
+}
+
+// NearLimits - returns "<pod>/<container> <resource> <percent>%" for all
+// containers whose cpu or memory usage is at least threshold percent of
+// their limit. Containers without limits are never near their limits.
+func (p PodUsage) NearLimits(threshold int64) []string {
+	near := []string{}
+	for _, c := range p.Containers {
+		if u := c.CPUUtilization(); !c.CPULimit.IsZero() && u >= threshold {
+			near = append(near, fmt.Sprintf("%s/%s cpu %d%%", p.Name, c.Name, u))
+		}
+		if u := c.MemoryUtilization(); !c.MemoryLimit.IsZero() && u >= threshold {
+			near = append(near, fmt.Sprintf("%s/%s memory %d%%", p.Name, c.Name, u))
+		}
+	}
+	return near
+}
+
+// GetPodUsageWithLabel - returns the resource usage of the pods in the
+// namespace matching the label selector, sorted by pod name. The usage is
+// read from the metrics.k8s.io API and enriched with the container limits
+// of the pods. ErrMetricsNotAvailable is returned if the metrics API is not
+// served. Pods which got no metrics yet, e.g. just started, are not
+// returned.
+func GetPodUsageWithLabel(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	labelSelectorMap map[string]string,
+) ([]PodUsage, error) {
+	metricsList := &unstructured.UnstructuredList{}
+	metricsList.SetGroupVersionKind(PodMetricsGroupVersionKind.GroupVersion().WithKind("PodMetricsList"))
+
+	err := h.GetClient().List(ctx, metricsList,
+		client.InNamespace(namespace),
+		client.MatchingLabels(labelSelectorMap),
+	)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("%w: %w", ErrMetricsNotAvailable, err)
+		}
+		return nil, fmt.Errorf("error listing pod metrics for labels: %v - %w", labelSelectorMap, err)
+	}
+
+	podList, err := GetPodListWithLabel(ctx, h, namespace, labelSelectorMap)
+	if err != nil {
+		return nil, err
+	}
+	pods := map[string]*corev1.Pod{}
+	for i := range podList.Items {
+		pods[podList.Items[i].Name] = &podList.Items[i]
+	}
+
+	usage := []PodUsage{}
+	for i := range metricsList.Items {
+		p, err := getPodUsage(&metricsList.Items[i], pods[metricsList.Items[i].GetName()])
+		if err != nil {
+			return nil, err
+		}
+		usage = append(usage, p)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+
+	return usage, nil
+}
+
+// getPodUsage - converts the PodMetrics into a PodUsage and adds the
+// container limits from the pod, if any
+func getPodUsage(m *unstructured.Unstructured, pod *corev1.Pod) (PodUsage, error) {
+	p := PodUsage{
+		Name: m.GetName(),
+	}
+	p.Window, _, _ = unstructured.NestedString(m.Object, "window")
+
+	limits := map[string]corev1.ResourceList{}
+	if pod != nil {
+		for _, c := range pod.Spec.Containers {
+			limits[c.Name] = c.Resources.Limits
+		}
+	}
+
+	containers, _, err := unstructured.NestedSlice(m.Object, "containers")
+	if err != nil {
+		return p, fmt.Errorf("error parsing metrics of pod %s: %w", p.Name, err)
+	}
+	for _, obj := range containers {
+		container, ok := obj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := ContainerUsage{}
+		c.Name, _, _ = unstructured.NestedString(container, "name")
+
+		usage, _, _ := unstructured.NestedStringMap(container, "usage")
+		if c.CPU, err = parseQuantity(usage[string(corev1.ResourceCPU)]); err != nil {
+			return p, fmt.Errorf("error parsing cpu usage of %s/%s: %w", p.Name, c.Name, err)
+		}
+		if c.Memory, err = parseQuantity(usage[string(corev1.ResourceMemory)]); err != nil {
+			return p, fmt.Errorf("error parsing memory usage of %s/%s: %w", p.Name, c.Name, err)
+		}
+		c.CPULimit = limits[c.Name][corev1.ResourceCPU]
+		c.MemoryLimit = limits[c.Name][corev1.ResourceMemory]
+
+		p.Containers = append(p.Containers, c)
+	}
+	sort.Slice(p.Containers, func(i, j int) bool { return p.Containers[i].Name < p.Containers[j].Name })
+
+	return p, nil
+}
+
+// parseQuantity - parses the quantity, an empty string is a zero quantity
+func parseQuantity(s string) (resource.Quantity, error) {
+	if s == "" {
+		return resource.Quantity{}, nil
+	}
+	return resource.ParseQuantity(s)
+}
+
+// utilization - returns usage in percent of limit, 0 if limit is zero
+func utilization(usage resource.Quantity, limit resource.Quantity) int64 {
+	if limit.IsZero() {
+		return 0
+	}
+	return usage.MilliValue() * 100 / limit.MilliValue()
+}
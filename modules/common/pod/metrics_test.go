@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func getPodMetrics(name string, containers ...map[string]interface{}) *unstructured.Unstructured {
+	m := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	m.SetGroupVersionKind(PodMetricsGroupVersionKind)
+	m.SetName(name)
+	m.SetNamespace("openstack")
+	m.Object["window"] = "30s"
+	items := []interface{}{}
+	for _, c := range containers {
+		items = append(items, c)
+	}
+	m.Object["containers"] = items
+	return m
+}
+
+func getContainerMetrics(name string, cpu string, memory string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name,
+		"usage": map[string]interface{}{
+			"cpu":    cpu,
+			"memory": memory,
+		},
+	}
+}
+
+func TestGetPodUsage(t *testing.T) {
+	g := NewWithT(t)
+
+	pod := getPod("nova-api-0", "node-1", nil)
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name: "nova-api",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+		{Name: "nova-api-log"},
+	}
+
+	m := getPodMetrics("nova-api-0",
+		getContainerMetrics("nova-api-log", "1m", "10Mi"),
+		getContainerMetrics("nova-api", "100m", "950Mi"),
+	)
+
+	usage, err := getPodUsage(m, pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(usage.Name).To(Equal("nova-api-0"))
+	g.Expect(usage.Window).To(Equal("30s"))
+	g.Expect(usage.Containers).To(HaveLen(2))
+	g.Expect(usage.Containers[0].Name).To(Equal("nova-api"))
+	g.Expect(usage.Containers[0].CPUUtilization()).To(BeEquivalentTo(20))
+	g.Expect(usage.Containers[0].MemoryUtilization()).To(BeEquivalentTo(92))
+	g.Expect(usage.Containers[1].MemoryUtilization()).To(BeEquivalentTo(0))
+
+	cpu := usage.CPU()
+	g.Expect(cpu.MilliValue()).To(BeEquivalentTo(101))
+	mem := usage.Memory()
+	g.Expect(mem.Cmp(resource.MustParse("960Mi"))).To(Equal(0))
+	g.Expect(usage.NearLimits(90)).To(Equal([]string{"nova-api-0/nova-api memory 92%"}))
+	g.Expect(usage.NearLimits(95)).To(BeEmpty())
+
+	// no pod, no limits
+	usage, err = getPodUsage(m, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(usage.NearLimits(0)).To(BeEmpty())
+
+	_, err = getPodUsage(getPodMetrics("bad", getContainerMetrics("c", "x1", "")), nil)
+	g.Expect(err).To(HaveOccurred())
+}
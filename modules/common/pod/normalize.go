@@ -0,0 +1,171 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// defaultTerminationGracePeriodSeconds - api server default of the pod
+	// spec
+	defaultTerminationGracePeriodSeconds = int64(30)
+	// defaultVolumeMode - api server default of the file mode of secret,
+	// configmap, projected and downward api volumes
+	defaultVolumeMode = int32(0o644)
+)
+
+// NormalizeTemplate - brings the pod template into the form the api server
+// stores it in, so that a semantically identical template does not differ
+// from the stored one, which would patch the Deployment/StatefulSet on
+// every reconcile, or result in a different hash. It
+// - sets the server defaults which are not set, e.g. the imagePullPolicy,
+// terminationMessagePath and the probe thresholds
+// - sorts the volumes by name and the volume mounts by mount path, parent
+// directories get mounted first
+// - sorts the env vars by name, unless a value references another var via
+// $(VAR), which depends on the order
+//
+// Must be called on the desired template before it gets hashed or set on
+// the object. The deployment and statefulset modules call it if enabled via
+// SetNormalizeTemplate. Normalizing the template of an existing object
+// changes it once, which triggers a rollout.
+func NormalizeTemplate(template *corev1.PodTemplateSpec) {
+	spec := &template.Spec
+
+	if spec.RestartPolicy == "" {
+		spec.RestartPolicy = corev1.RestartPolicyAlways
+	}
+	if spec.DNSPolicy == "" {
+		spec.DNSPolicy = corev1.DNSClusterFirst
+	}
+	if spec.SchedulerName == "" {
+		spec.SchedulerName = corev1.DefaultSchedulerName
+	}
+	if spec.TerminationGracePeriodSeconds == nil {
+		spec.TerminationGracePeriodSeconds = ptr.To(defaultTerminationGracePeriodSeconds)
+	}
+	if spec.SecurityContext == nil {
+		spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+
+	for i := range spec.InitContainers {
+		normalizeContainer(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		normalizeContainer(&spec.Containers[i])
+	}
+
+	for i := range spec.Volumes {
+		normalizeVolume(&spec.Volumes[i])
+	}
+	sort.SliceStable(spec.Volumes, func(i, j int) bool {
+		return spec.Volumes[i].Name < spec.Volumes[j].Name
+	})
+}
+
+func normalizeContainer(c *corev1.Container) {
+	if c.ImagePullPolicy == "" {
+		c.ImagePullPolicy = defaultPullPolicy(c.Image)
+	}
+	if c.TerminationMessagePath == "" {
+		c.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	}
+	if c.TerminationMessagePolicy == "" {
+		c.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	}
+	for i := range c.Ports {
+		if c.Ports[i].Protocol == "" {
+			c.Ports[i].Protocol = corev1.ProtocolTCP
+		}
+	}
+	normalizeProbe(c.LivenessProbe)
+	normalizeProbe(c.ReadinessProbe)
+	normalizeProbe(c.StartupProbe)
+
+	sortEnv := true
+	for i := range c.Env {
+		if c.Env[i].ValueFrom != nil && c.Env[i].ValueFrom.FieldRef != nil && c.Env[i].ValueFrom.FieldRef.APIVersion == "" {
+			c.Env[i].ValueFrom.FieldRef.APIVersion = "v1"
+		}
+		if strings.Contains(c.Env[i].Value, "$(") {
+			sortEnv = false
+		}
+	}
+	if sortEnv {
+		sort.SliceStable(c.Env, func(i, j int) bool {
+			return c.Env[i].Name < c.Env[j].Name
+		})
+	}
+
+	sort.SliceStable(c.VolumeMounts, func(i, j int) bool {
+		return c.VolumeMounts[i].MountPath < c.VolumeMounts[j].MountPath
+	})
+}
+
+// defaultPullPolicy - the api server default of the imagePullPolicy, Always
+// for the latest or no tag, otherwise IfNotPresent
+func defaultPullPolicy(image string) corev1.PullPolicy {
+	if strings.Contains(image, "@") {
+		return corev1.PullIfNotPresent
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if idx := strings.LastIndex(name, ":"); idx < 0 || name[idx+1:] == "latest" {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
+
+func normalizeProbe(p *corev1.Probe) {
+	if p == nil {
+		return
+	}
+	if p.TimeoutSeconds == 0 {
+		p.TimeoutSeconds = 1
+	}
+	if p.PeriodSeconds == 0 {
+		p.PeriodSeconds = 10
+	}
+	if p.SuccessThreshold == 0 {
+		p.SuccessThreshold = 1
+	}
+	if p.FailureThreshold == 0 {
+		p.FailureThreshold = 3
+	}
+	if p.HTTPGet != nil && p.HTTPGet.Scheme == "" {
+		p.HTTPGet.Scheme = corev1.URISchemeHTTP
+	}
+}
+
+func normalizeVolume(v *corev1.Volume) {
+	switch {
+	case v.Secret != nil && v.Secret.DefaultMode == nil:
+		v.Secret.DefaultMode = ptr.To(defaultVolumeMode)
+	case v.ConfigMap != nil && v.ConfigMap.DefaultMode == nil:
+		v.ConfigMap.DefaultMode = ptr.To(defaultVolumeMode)
+	case v.Projected != nil && v.Projected.DefaultMode == nil:
+		v.Projected.DefaultMode = ptr.To(defaultVolumeMode)
+	case v.DownwardAPI != nil && v.DownwardAPI.DefaultMode == nil:
+		v.DownwardAPI.DefaultMode = ptr.To(defaultVolumeMode)
+	case v.HostPath != nil && v.HostPath.Type == nil:
+		v.HostPath.Type = ptr.To(corev1.HostPathUnset)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestNormalizeTemplate(t *testing.T) {
+	g := NewWithT(t)
+
+	template := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "api",
+					Image: "quay.io/podified/keystone:current",
+					Env: []corev1.EnvVar{
+						{Name: "KOLLA_CONFIG_STRATEGY", Value: "COPY_ALWAYS"},
+						{Name: "CONFIG_HASH", Value: "abc"},
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "config", MountPath: "/var/lib/config-data/merged"},
+						{Name: "scripts", MountPath: "/usr/local/bin"},
+						{Name: "data", MountPath: "/var/lib/config-data"},
+					},
+					Ports:          []corev1.ContainerPort{{ContainerPort: 5000}},
+					ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/v3"}}},
+				},
+				{
+					Name:  "sidecar",
+					Image: "localhost:5000/sidecar",
+					Env: []corev1.EnvVar{
+						{Name: "HOST", Value: "keystone"},
+						{Name: "URL", Value: "http://$(HOST)"},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "scripts", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "scripts"}}},
+				{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{DefaultMode: ptr.To[int32](0o755)}}},
+			},
+		},
+	}
+	NormalizeTemplate(template)
+
+	spec := template.Spec
+	g.Expect(spec.RestartPolicy).To(Equal(corev1.RestartPolicyAlways))
+	g.Expect(spec.DNSPolicy).To(Equal(corev1.DNSClusterFirst))
+	g.Expect(spec.SchedulerName).To(Equal(corev1.DefaultSchedulerName))
+	g.Expect(*spec.TerminationGracePeriodSeconds).To(BeEquivalentTo(30))
+	g.Expect(spec.SecurityContext).ToNot(BeNil())
+
+	api := spec.Containers[0]
+	g.Expect(api.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+	g.Expect(api.TerminationMessagePath).To(Equal(corev1.TerminationMessagePathDefault))
+	g.Expect(api.TerminationMessagePolicy).To(Equal(corev1.TerminationMessageReadFile))
+	g.Expect(api.Ports[0].Protocol).To(Equal(corev1.ProtocolTCP))
+	g.Expect(api.ReadinessProbe.PeriodSeconds).To(BeEquivalentTo(10))
+	g.Expect(api.ReadinessProbe.HTTPGet.Scheme).To(Equal(corev1.URISchemeHTTP))
+	g.Expect(api.Env[0].Name).To(Equal("CONFIG_HASH"))
+	g.Expect(api.VolumeMounts[0].MountPath).To(Equal("/usr/local/bin"))
+	g.Expect(api.VolumeMounts[1].MountPath).To(Equal("/var/lib/config-data"))
+	g.Expect(api.VolumeMounts[2].MountPath).To(Equal("/var/lib/config-data/merged"))
+
+	// the registry port is no tag
+	sidecar := spec.Containers[1]
+	g.Expect(sidecar.ImagePullPolicy).To(Equal(corev1.PullAlways))
+	// order kept for the $(HOST) reference
+	g.Expect(sidecar.Env[0].Name).To(Equal("HOST"))
+
+	g.Expect(spec.Volumes[0].Name).To(Equal("config"))
+	g.Expect(*spec.Volumes[0].ConfigMap.DefaultMode).To(BeEquivalentTo(0o755))
+	g.Expect(*spec.Volumes[1].Secret.DefaultMode).To(BeEquivalentTo(0o644))
+
+	// idempotent
+	normalized := template.DeepCopy()
+	NormalizeTemplate(normalized)
+	g.Expect(normalized).To(Equal(template))
+}
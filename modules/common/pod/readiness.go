@@ -0,0 +1,277 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ContainerNotReady - details of a container which is not ready
+type ContainerNotReady struct {
+	// Name - name of the container
+	Name string `json:"name"`
+	// Init - true if it is an init container
+	Init bool `json:"init,omitempty"`
+	// Reason - waiting or termination reason, e.g. CrashLoopBackOff
+	Reason string `json:"reason,omitempty"`
+	// Message - waiting or termination message
+	Message string `json:"message,omitempty"`
+	// RestartCount - number of restarts of the container
+	RestartCount int32 `json:"restartCount,omitempty"`
+	// LastTerminationMessage - reason and message of the last termination
+	LastTerminationMessage string `json:"lastTerminationMessage,omitempty"`
+}
+
+// String - returns a short description of the container state
+func (c ContainerNotReady) String() string {
+	s := c.Name
+	if c.Init {
+		s = fmt.Sprintf("init:%s", c.Name)
+	}
+	if c.Reason != "" {
+		s = fmt.Sprintf("%s %s", s, c.Reason)
+	}
+	if c.RestartCount > 0 {
+		s = fmt.Sprintf("%s (restarts: %d)", s, c.RestartCount)
+	}
+	if c.LastTerminationMessage != "" {
+		s = fmt.Sprintf("%s last termination: %s", s, c.LastTerminationMessage)
+	} else if c.Message != "" {
+		s = fmt.Sprintf("%s: %s", s, c.Message)
+	}
+	return s
+}
+
+// PodNotReady - details of a pod which is not ready
+type PodNotReady struct {
+	// Name - name of the pod
+	Name string `json:"name"`
+	// Phase - phase of the pod
+	Phase corev1.PodPhase `json:"phase,omitempty"`
+	// Reason - reason of the pod status or of the PodScheduled condition
+	// if the pod can not be scheduled
+	Reason string `json:"reason,omitempty"`
+	// Message - message matching the Reason
+	Message string `json:"message,omitempty"`
+	// Containers - containers of the pod which are not ready
+	Containers []ContainerNotReady `json:"containers,omitempty"`
+}
+
+// String - returns a short description of the pod state
+func (p PodNotReady) String() string {
+	details := []string{}
+	if p.Reason != "" {
+		reason := p.Reason
+		if p.Message != "" {
+			reason = fmt.Sprintf("%s: %s", p.Reason, p.Message)
+		}
+		details = append(details, reason)
+	}
+	for _, c := range p.Containers {
+		details = append(details, c.String())
+	}
+	if len(details) == 0 {
+		details = append(details, string(p.Phase))
+	}
+	return fmt.Sprintf("%s [%s]", p.Name, strings.Join(details, "; "))
+}
+
+// ReadinessResult - readiness of the pods matching a label selector
+type ReadinessResult struct {
+	// Expected - number of pods expected to be ready
+	Expected int
+	// Ready - names of the ready pods
+	Ready []string
+	// NotReady - details of the pods which are not ready
+	NotReady []PodNotReady
+	// Failed - details of the failed pods, e.g. evicted ones, which stay
+	// until they get garbage collected. They do not block the readiness.
+	Failed []PodNotReady
+}
+
+// IsReady - returns true if at least the expected number of pods is ready
+// and no other pod matching the selector is not ready
+func (r *ReadinessResult) IsReady() bool {
+	return len(r.Ready) >= r.Expected && len(r.NotReady) == 0
+}
+
+// String - returns the readiness in a form which can be used in a condition
+// message
+func (r *ReadinessResult) String() string {
+	msg := fmt.Sprintf("%d/%d pods ready", len(r.Ready), r.Expected)
+	if len(r.NotReady) == 0 {
+		return msg
+	}
+	notReady := []string{}
+	for _, p := range r.NotReady {
+		notReady = append(notReady, p.String())
+	}
+	msg = fmt.Sprintf("%s, not ready: %s", msg, strings.Join(notReady, ", "))
+	if len(r.Failed) > 0 {
+		failed := []string{}
+		for _, p := range r.Failed {
+			failed = append(failed, p.String())
+		}
+		msg = fmt.Sprintf("%s, failed: %s", msg, strings.Join(failed, ", "))
+	}
+	return msg
+}
+
+// WaitForPodsReady - checks that count pods matching the label selector are
+// ready. If not, the details of the pods and containers which are not ready
+// are returned in the ReadinessResult and a requeue after timeout is
+// requested. The ReadinessResult.String() output is intended to be used in
+// the message of a condition.
+func WaitForPodsReady(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	labelSelectorMap map[string]string,
+	count int,
+	timeout time.Duration,
+) (ctrl.Result, *ReadinessResult, error) {
+	podList, err := GetPodListWithLabel(ctx, h, namespace, labelSelectorMap)
+	if err != nil {
+		return ctrl.Result{}, nil, err
+	}
+
+	result := GetPodsReadiness(podList.Items, count)
+	if !result.IsReady() {
+		h.GetLogger().Info("Pods not ready, reconcile later", "labels", labelSelectorMap, "status", result, "requeueAfter", timeout)
+		return ctrl.Result{RequeueAfter: timeout}, result, nil
+	}
+
+	return ctrl.Result{}, result, nil
+}
+
+// GetPodsReadiness - returns the readiness of the pods. Pods which are
+// terminating or completed are ignored, failed pods, e.g. evicted ones, are
+// returned as Failed and do not block the readiness.
+func GetPodsReadiness(pods []corev1.Pod, count int) *ReadinessResult {
+	result := &ReadinessResult{
+		Expected: count,
+		Ready:    []string{},
+		NotReady: []PodNotReady{},
+		Failed:   []PodNotReady{},
+	}
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodFailed {
+			result.Failed = append(result.Failed, getPodNotReady(&pod))
+			continue
+		}
+		if IsPodReady(&pod) {
+			result.Ready = append(result.Ready, pod.Name)
+			continue
+		}
+		result.NotReady = append(result.NotReady, getPodNotReady(&pod))
+	}
+	sort.Strings(result.Ready)
+	sort.Slice(result.NotReady, func(i, j int) bool {
+		return result.NotReady[i].Name < result.NotReady[j].Name
+	})
+	sort.Slice(result.Failed, func(i, j int) bool {
+		return result.Failed[i].Name < result.Failed[j].Name
+	})
+
+	return result
+}
+
+// IsPodReady - returns true if the Ready condition of the pod is true
+func IsPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getPodNotReady - collects the details why the pod is not ready
+func getPodNotReady(pod *corev1.Pod) PodNotReady {
+	p := PodNotReady{
+		Name:    pod.Name,
+		Phase:   pod.Status.Phase,
+		Reason:  pod.Status.Reason,
+		Message: pod.Status.Message,
+	}
+
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			p.Reason = c.Reason
+			p.Message = c.Message
+		}
+	}
+
+	for _, cs := range pod.Status.InitContainerStatuses {
+		// completed init containers are not ready, but did their job
+		if cs.Ready || (cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0) {
+			continue
+		}
+		p.Containers = append(p.Containers, getContainerNotReady(cs, true))
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Ready {
+			continue
+		}
+		p.Containers = append(p.Containers, getContainerNotReady(cs, false))
+	}
+
+	return p
+}
+
+// getContainerNotReady - collects the details why the container is not ready
+func getContainerNotReady(cs corev1.ContainerStatus, init bool) ContainerNotReady {
+	c := ContainerNotReady{
+		Name:         cs.Name,
+		Init:         init,
+		RestartCount: cs.RestartCount,
+	}
+
+	switch {
+	case cs.State.Waiting != nil:
+		c.Reason = cs.State.Waiting.Reason
+		c.Message = cs.State.Waiting.Message
+	case cs.State.Terminated != nil:
+		c.Reason = cs.State.Terminated.Reason
+		c.Message = cs.State.Terminated.Message
+	case cs.State.Running != nil:
+		c.Reason = "NotReady"
+	}
+
+	if t := cs.LastTerminationState.Terminated; t != nil {
+		c.LastTerminationMessage = fmt.Sprintf("%s (exit code %d)", t.Reason, t.ExitCode)
+		if t.Message != "" {
+			c.LastTerminationMessage = fmt.Sprintf("%s: %s", c.LastTerminationMessage, strings.TrimSpace(t.Message))
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func getReadyPod(name string, labels map[string]string) *corev1.Pod {
+	pod := getPod(name, "node-1", labels)
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "galera", Ready: true},
+	}
+	return pod
+}
+
+func TestWaitForPodsReady(t *testing.T) {
+	g := NewWithT(t)
+	labels := map[string]string{"service": "galera"}
+
+	crashing := getPod("galera-1", "node-1", labels)
+	crashing.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+	}
+	crashing.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name:  "init",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}},
+		},
+	}
+	crashing.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name:         "galera",
+			RestartCount: 3,
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 40s"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", Message: "wsrep failed\n"},
+			},
+		},
+	}
+
+	pending := getPod("galera-2", "", labels)
+	pending.Status.Phase = corev1.PodPending
+	pending.Status.Conditions = []corev1.PodCondition{
+		{
+			Type:    corev1.PodScheduled,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Unschedulable",
+			Message: "0/3 nodes are available",
+		},
+	}
+
+	h, _, err := setupKHelper(nil, getReadyPod("galera-0", labels), crashing, pending)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	res, result, err := WaitForPodsReady(context.TODO(), h, "openstack", labels, 3, time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+	g.Expect(result.IsReady()).To(BeFalse())
+	g.Expect(result.Ready).To(Equal([]string{"galera-0"}))
+	g.Expect(result.NotReady).To(HaveLen(2))
+	g.Expect(result.NotReady[0].Containers).To(HaveLen(1))
+	g.Expect(result.NotReady[0].Containers[0].RestartCount).To(BeEquivalentTo(3))
+	g.Expect(result.String()).To(Equal(
+		"1/3 pods ready, not ready: " +
+			"galera-1 [galera CrashLoopBackOff (restarts: 3) last termination: Error (exit code 1): wsrep failed], " +
+			"galera-2 [Unschedulable: 0/3 nodes are available]"))
+
+	// an evicted pod, which stays until it gets garbage collected, does
+	// not block the readiness of the recovered replicas
+	evicted := getPod("galera-1-evicted", "node-2", labels)
+	evicted.Status.Phase = corev1.PodFailed
+	evicted.Status.Reason = "Evicted"
+	evicted.Status.Message = "The node was low on resource: memory."
+	h, _, err = setupKHelper(nil, getReadyPod("galera-0", labels), getReadyPod("galera-1", labels), evicted)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	res, result, err = WaitForPodsReady(context.TODO(), h, "openstack", labels, 2, time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(Equal(ctrl.Result{}))
+	g.Expect(result.IsReady()).To(BeTrue())
+	g.Expect(result.NotReady).To(BeEmpty())
+	g.Expect(result.Failed).To(ConsistOf(HaveField("Reason", "Evicted")))
+	g.Expect(result.String()).To(Equal("2/2 pods ready"))
+
+	// and is reported next to the pods which are not ready
+	h, _, err = setupKHelper(nil, getReadyPod("galera-0", labels), crashing, evicted)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, result, err = WaitForPodsReady(context.TODO(), h, "openstack", labels, 2, time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.IsReady()).To(BeFalse())
+	g.Expect(result.String()).To(HaveSuffix(
+		", failed: galera-1-evicted [Evicted: The node was low on resource: memory.]"))
+}
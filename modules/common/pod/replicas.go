@@ -0,0 +1,122 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReplicaReport - the replica counts of a Deployment, StatefulSet or
+// DaemonSet and the details of its pods which are not ready, in a form
+// operators can copy into the status of their CR
+type ReplicaReport struct {
+	// Desired - number of pods requested
+	Desired int32 `json:"desired"`
+	// Current - number of pods created
+	Current int32 `json:"current"`
+	// Ready - number of pods ready
+	Ready int32 `json:"ready"`
+	// Updated - number of pods running the current pod template
+	Updated int32 `json:"updated"`
+	// Available - number of pods ready for at least minReadySeconds
+	Available int32 `json:"available"`
+	// NotReady - the pods which are not ready
+	NotReady []PodNotReady `json:"notReady,omitempty"`
+}
+
+// IsReady - returns true if all desired pods are updated and ready
+func (r *ReplicaReport) IsReady() bool {
+	return r.Ready == r.Desired && r.Updated == r.Desired && len(r.NotReady) == 0
+}
+
+// String - returns the report in a form which can be used in a condition
+// message
+func (r *ReplicaReport) String() string {
+	msg := fmt.Sprintf("%d/%d ready, %d updated, %d available", r.Ready, r.Desired, r.Updated, r.Available)
+	for i, p := range r.NotReady {
+		if i == 0 {
+			msg += ", not ready: "
+		} else {
+			msg += ", "
+		}
+		msg += p.String()
+	}
+	return msg
+}
+
+// GetReplicaReport - returns the report with the counts and the pods of
+// the workload which match the selector and are not ready. Used by the
+// GetReplicaReport of the workload modules.
+func GetReplicaReport(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	selector *metav1.LabelSelector,
+	counts ReplicaReport,
+) (*ReplicaReport, error) {
+	report := counts.DeepCopy()
+	report.NotReady = nil
+
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := h.GetKClient().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: s.String()})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods for selector %s: %w", s, err)
+	}
+	report.NotReady = GetPodsReadiness(pods.Items, int(counts.Desired)).NotReady
+	if len(report.NotReady) == 0 {
+		report.NotReady = nil
+	}
+
+	return report, nil
+}
+
+// DeepCopyInto - copies the report into out
+func (r *ReplicaReport) DeepCopyInto(out *ReplicaReport) {
+	*out = *r
+	if r.NotReady != nil {
+		out.NotReady = make([]PodNotReady, len(r.NotReady))
+		for i := range r.NotReady {
+			r.NotReady[i].DeepCopyInto(&out.NotReady[i])
+		}
+	}
+}
+
+// DeepCopy - returns a deep copy of the report
+func (r *ReplicaReport) DeepCopy() *ReplicaReport {
+	if r == nil {
+		return nil
+	}
+	out := &ReplicaReport{}
+	r.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto - copies the pod details into out
+func (p *PodNotReady) DeepCopyInto(out *PodNotReady) {
+	*out = *p
+	if p.Containers != nil {
+		out.Containers = make([]ContainerNotReady, len(p.Containers))
+		copy(out.Containers, p.Containers)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetReplicaReport(t *testing.T) {
+	g := NewWithT(t)
+	labels := map[string]string{"service": "galera"}
+
+	pending := getPod("galera-1", "", labels)
+	pending.Status.Phase = corev1.PodPending
+	pending.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable", Message: "0/3 nodes are available"},
+	}
+
+	h, _, err := setupKHelper(nil,
+		getReadyPod("galera-0", labels),
+		pending,
+		getPod("other", "node-1", map[string]string{"service": "other"}),
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	report, err := GetReplicaReport(context.TODO(), h, "openstack", &metav1.LabelSelector{MatchLabels: labels},
+		ReplicaReport{Desired: 2, Current: 2, Ready: 1, Updated: 2, Available: 1})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.IsReady()).To(BeFalse())
+	g.Expect(report.NotReady).To(HaveLen(1))
+	g.Expect(report.NotReady[0].Name).To(Equal("galera-1"))
+	g.Expect(report.String()).To(Equal("1/2 ready, 2 updated, 1 available, " +
+		"not ready: galera-1 [Unschedulable: 0/3 nodes are available]"))
+
+	// the deep copy does not share the pod details
+	copied := report.DeepCopy()
+	copied.NotReady[0].Name = "changed"
+	g.Expect(report.NotReady[0].Name).To(Equal("galera-1"))
+
+	// counts complete, but the pod is not ready
+	report, err = GetReplicaReport(context.TODO(), h, "openstack", &metav1.LabelSelector{MatchLabels: map[string]string{"service": "other"}},
+		ReplicaReport{Desired: 1, Current: 1, Ready: 1, Updated: 1, Available: 1})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.IsReady()).To(BeFalse())
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// SecurityContextOptions - the opt-outs of a service from the restricted
+// pod security standard presets. The zero value gives contexts passing the
+// "restricted" pod security admission level.
+type SecurityContextOptions struct {
+	// RunAsUser - uid to run the containers as, the one of the image if nil
+	RunAsUser *int64
+	// RunAsGroup - gid to run the containers as
+	RunAsGroup *int64
+	// FSGroup - group owning the volumes of the pod
+	FSGroup *int64
+	// Capabilities - capabilities to add to the containers. Only
+	// NET_BIND_SERVICE is allowed by the restricted level.
+	Capabilities []corev1.Capability
+	// RunAsRoot - do not require to run as non root, e.g. for services
+	// managing the network of the node. Fails the restricted level.
+	RunAsRoot bool
+	// AllowPrivilegeEscalation - allow e.g. sudo in the containers. Fails
+	// the restricted level.
+	AllowPrivilegeEscalation bool
+	// ReadOnlyRootFilesystem - mount the root filesystem of the containers
+	// read only
+	ReadOnlyRootFilesystem bool
+}
+
+// RestrictedPodSecurityContext - returns the pod security context of the
+// restricted level with the opt-outs of opts
+func RestrictedPodSecurityContext(opts SecurityContextOptions) *corev1.PodSecurityContext {
+	return &corev1.PodSecurityContext{
+		RunAsNonRoot: ptr.To(!opts.RunAsRoot),
+		RunAsUser:    opts.RunAsUser,
+		RunAsGroup:   opts.RunAsGroup,
+		FSGroup:      opts.FSGroup,
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// RestrictedSecurityContext - returns the container security context of the
+// restricted level with the opt-outs of opts
+func RestrictedSecurityContext(opts SecurityContextOptions) *corev1.SecurityContext {
+	sc := &corev1.SecurityContext{
+		RunAsNonRoot:             ptr.To(!opts.RunAsRoot),
+		RunAsUser:                opts.RunAsUser,
+		RunAsGroup:               opts.RunAsGroup,
+		AllowPrivilegeEscalation: ptr.To(opts.AllowPrivilegeEscalation),
+		ReadOnlyRootFilesystem:   ptr.To(opts.ReadOnlyRootFilesystem),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+	if len(opts.Capabilities) > 0 {
+		sc.Capabilities.Add = append([]corev1.Capability{}, opts.Capabilities...)
+	}
+	return sc
+}
+
+// RestrictedViolations - returns the reasons the pod spec does not pass the
+// restricted pod security admission level, e.g. to report opt-outs in a
+// condition. Only the checks covered by the presets are done.
+func RestrictedViolations(spec *corev1.PodSpec) []string {
+	violations := []string{}
+
+	podNonRoot := false
+	podSeccomp := false
+	if spec.SecurityContext != nil {
+		podNonRoot = ptr.Deref(spec.SecurityContext.RunAsNonRoot, false)
+		podSeccomp = spec.SecurityContext.SeccompProfile != nil &&
+			spec.SecurityContext.SeccompProfile.Type != corev1.SeccompProfileTypeUnconfined
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		if !ptr.Deref(sc.RunAsNonRoot, podNonRoot) {
+			violations = append(violations, fmt.Sprintf("container %s must set runAsNonRoot", c.Name))
+		}
+		if ptr.Deref(sc.AllowPrivilegeEscalation, true) {
+			violations = append(violations, fmt.Sprintf("container %s must not allow privilege escalation", c.Name))
+		}
+		if ptr.Deref(sc.Privileged, false) {
+			violations = append(violations, fmt.Sprintf("container %s must not be privileged", c.Name))
+		}
+		seccomp := podSeccomp
+		if sc.SeccompProfile != nil {
+			seccomp = sc.SeccompProfile.Type != corev1.SeccompProfileTypeUnconfined
+		}
+		if !seccomp {
+			violations = append(violations, fmt.Sprintf("container %s must set a seccomp profile", c.Name))
+		}
+		if !dropsAll(sc.Capabilities) {
+			violations = append(violations, fmt.Sprintf("container %s must drop ALL capabilities", c.Name))
+		}
+		if sc.Capabilities != nil {
+			for _, add := range sc.Capabilities.Add {
+				if add != "NET_BIND_SERVICE" {
+					violations = append(violations, fmt.Sprintf("container %s must not add capability %s", c.Name, add))
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+func dropsAll(c *corev1.Capabilities) bool {
+	if c == nil {
+		return false
+	}
+	for _, drop := range c.Drop {
+		if drop == "ALL" {
+			return true
+		}
+	}
+	return false
+}
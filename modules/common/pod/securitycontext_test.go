@@ -0,0 +1,62 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRestrictedSecurityContext(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := &corev1.PodSpec{
+		SecurityContext: RestrictedPodSecurityContext(SecurityContextOptions{FSGroup: ptr.To[int64](42451)}),
+		Containers: []corev1.Container{
+			{Name: "keystone-api", SecurityContext: RestrictedSecurityContext(SecurityContextOptions{
+				RunAsUser:    ptr.To[int64](42425),
+				Capabilities: []corev1.Capability{"NET_BIND_SERVICE"},
+			})},
+		},
+	}
+	g.Expect(RestrictedViolations(spec)).To(BeEmpty())
+	g.Expect(*spec.SecurityContext.FSGroup).To(Equal(int64(42451)))
+	g.Expect(spec.Containers[0].SecurityContext.Capabilities.Add).To(Equal([]corev1.Capability{"NET_BIND_SERVICE"}))
+
+	// the seccomp profile of the pod applies to containers without
+	spec.Containers[0].SecurityContext.SeccompProfile = nil
+	g.Expect(RestrictedViolations(spec)).To(BeEmpty())
+
+	spec.InitContainers = []corev1.Container{
+		{Name: "init", SecurityContext: RestrictedSecurityContext(SecurityContextOptions{
+			RunAsRoot:                true,
+			AllowPrivilegeEscalation: true,
+			Capabilities:             []corev1.Capability{"NET_ADMIN"},
+		})},
+		{Name: "plain"},
+	}
+	g.Expect(RestrictedViolations(spec)).To(Equal([]string{
+		"container init must set runAsNonRoot",
+		"container init must not allow privilege escalation",
+		"container init must not add capability NET_ADMIN",
+		"container plain must not allow privilege escalation",
+		"container plain must drop ALL capabilities",
+	}))
+}
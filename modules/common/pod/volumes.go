@@ -0,0 +1,166 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Define static errors
+var (
+	// ErrVolumeConflict indicates that a volume with the same name but a different source exists
+	ErrVolumeConflict = errors.New("volume conflict")
+	// ErrMountPathConflict indicates that a mount path is already used by a different volume
+	ErrMountPathConflict = errors.New("mount path conflict")
+	// ErrContainerNotFound indicates that a selected container does not exist in the pod spec
+	ErrContainerNotFound = errors.New("container not found")
+)
+
+// VolumeSet - volumes and the mounts of those volumes, e.g. the config,
+// TLS or extraMounts volumes of a service
+type VolumeSet struct {
+	Volumes []corev1.Volume
+	Mounts  []corev1.VolumeMount
+}
+
+// ContainerSelector - selects the containers and initContainers of a pod
+// spec the mounts of a VolumeSet get injected into
+type ContainerSelector struct {
+	// Containers - names of the containers, ignored if AllContainers is set
+	Containers []string
+	// InitContainers - names of the initContainers, ignored if AllInitContainers is set
+	InitContainers []string
+	// AllContainers - select all containers
+	AllContainers bool
+	// AllInitContainers - select all initContainers
+	AllInitContainers bool
+}
+
+// AllContainers - selects all containers and initContainers
+var AllContainers = ContainerSelector{AllContainers: true, AllInitContainers: true}
+
+// Append - appends the volumes and mounts of other to the VolumeSet
+func (v *VolumeSet) Append(other VolumeSet) {
+	v.Volumes = append(v.Volumes, other.Volumes...)
+	v.Mounts = append(v.Mounts, other.Mounts...)
+}
+
+// InjectVolumes - adds the volumes of the VolumeSet to the pod spec and
+// the mounts to the selected containers. It is idempotent, volumes and
+// mounts which are already present are not added again. The pod spec is
+// not modified and an error is returned if
+// - a volume with the same name but a different source exists
+// - a mount path of a selected container is used by a different volume
+// - a selected container does not exist
+func InjectVolumes(
+	spec *corev1.PodSpec,
+	set VolumeSet,
+	selector ContainerSelector,
+) error {
+	volumes, err := mergeVolumes(spec.Volumes, set.Volumes)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range selector.Containers {
+		if !selector.AllContainers && !hasContainer(spec.Containers, name) {
+			return fmt.Errorf("%w: %s", ErrContainerNotFound, name)
+		}
+	}
+	for _, name := range selector.InitContainers {
+		if !selector.AllInitContainers && !hasContainer(spec.InitContainers, name) {
+			return fmt.Errorf("%w: initContainer %s", ErrContainerNotFound, name)
+		}
+	}
+
+	containers := slices.Clone(spec.Containers)
+	for i := range containers {
+		if !selector.AllContainers && !slices.Contains(selector.Containers, containers[i].Name) {
+			continue
+		}
+		containers[i].VolumeMounts, err = mergeMounts(containers[i].Name, containers[i].VolumeMounts, set.Mounts)
+		if err != nil {
+			return err
+		}
+	}
+
+	initContainers := slices.Clone(spec.InitContainers)
+	for i := range initContainers {
+		if !selector.AllInitContainers && !slices.Contains(selector.InitContainers, initContainers[i].Name) {
+			continue
+		}
+		initContainers[i].VolumeMounts, err = mergeMounts(initContainers[i].Name, initContainers[i].VolumeMounts, set.Mounts)
+		if err != nil {
+			return err
+		}
+	}
+
+	spec.Volumes = volumes
+	spec.Containers = containers
+	spec.InitContainers = initContainers
+
+	return nil
+}
+
+// mergeVolumes - returns existing with the desired volumes added which are
+// not yet present
+func mergeVolumes(existing []corev1.Volume, desired []corev1.Volume) ([]corev1.Volume, error) {
+	merged := slices.Clone(existing)
+	for _, d := range desired {
+		idx := slices.IndexFunc(merged, func(v corev1.Volume) bool { return v.Name == d.Name })
+		if idx < 0 {
+			merged = append(merged, d)
+			continue
+		}
+		if !equality.Semantic.DeepEqual(merged[idx].VolumeSource, d.VolumeSource) {
+			return nil, fmt.Errorf("%w: volume %s exists with a different source", ErrVolumeConflict, d.Name)
+		}
+	}
+
+	return merged, nil
+}
+
+// mergeMounts - returns existing with the desired mounts added which are
+// not yet present
+func mergeMounts(container string, existing []corev1.VolumeMount, desired []corev1.VolumeMount) ([]corev1.VolumeMount, error) {
+	merged := slices.Clone(existing)
+	for _, d := range desired {
+		idx := slices.IndexFunc(merged, func(m corev1.VolumeMount) bool {
+			return path.Clean(m.MountPath) == path.Clean(d.MountPath)
+		})
+		if idx < 0 {
+			merged = append(merged, d)
+			continue
+		}
+		if merged[idx].Name != d.Name || merged[idx].SubPath != d.SubPath {
+			return nil, fmt.Errorf("%w: container %s mount path %s is used by volume %s",
+				ErrMountPathConflict, container, d.MountPath, merged[idx].Name)
+		}
+	}
+
+	return merged, nil
+}
+
+func hasContainer(containers []corev1.Container, name string) bool {
+	return slices.ContainsFunc(containers, func(c corev1.Container) bool { return c.Name == name })
+}
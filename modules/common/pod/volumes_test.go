@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func getPodSpec() *corev1.PodSpec {
+	return &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init"}},
+		Containers: []corev1.Container{
+			{Name: "api"},
+			{Name: "httpd"},
+		},
+	}
+}
+
+func getConfigVolumeSet() VolumeSet {
+	return VolumeSet{
+		Volumes: []corev1.Volume{
+			{
+				Name: "config-data",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "config"},
+				},
+			},
+		},
+		Mounts: []corev1.VolumeMount{
+			{
+				Name:      "config-data",
+				MountPath: "/var/lib/config-data/default",
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+func TestInjectVolumes(t *testing.T) {
+	t.Run("Inject into selected containers", func(t *testing.T) {
+		g := NewWithT(t)
+		spec := getPodSpec()
+
+		err := InjectVolumes(spec, getConfigVolumeSet(), ContainerSelector{
+			Containers:     []string{"api"},
+			InitContainers: []string{"init"},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(spec.Volumes).To(HaveLen(1))
+		g.Expect(spec.Containers[0].VolumeMounts).To(HaveLen(1))
+		g.Expect(spec.Containers[1].VolumeMounts).To(BeEmpty())
+		g.Expect(spec.InitContainers[0].VolumeMounts).To(HaveLen(1))
+
+		// injecting again does not duplicate volumes or mounts
+		err = InjectVolumes(spec, getConfigVolumeSet(), AllContainers)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(spec.Volumes).To(HaveLen(1))
+		g.Expect(spec.Containers[0].VolumeMounts).To(HaveLen(1))
+		g.Expect(spec.Containers[1].VolumeMounts).To(HaveLen(1))
+		g.Expect(spec.InitContainers[0].VolumeMounts).To(HaveLen(1))
+	})
+
+	t.Run("Volume conflict", func(t *testing.T) {
+		g := NewWithT(t)
+		spec := getPodSpec()
+		g.Expect(InjectVolumes(spec, getConfigVolumeSet(), AllContainers)).To(Succeed())
+
+		set := getConfigVolumeSet()
+		set.Volumes[0].Secret.SecretName = "other"
+		err := InjectVolumes(spec, set, AllContainers)
+		g.Expect(err).To(MatchError(ErrVolumeConflict))
+	})
+
+	t.Run("Mount path conflict leaves the spec unchanged", func(t *testing.T) {
+		g := NewWithT(t)
+		spec := getPodSpec()
+		g.Expect(InjectVolumes(spec, getConfigVolumeSet(), AllContainers)).To(Succeed())
+
+		set := VolumeSet{}
+		set.Append(VolumeSet{
+			Volumes: []corev1.Volume{
+				{
+					Name:         "scratch",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				},
+			},
+			Mounts: []corev1.VolumeMount{
+				{
+					Name:      "scratch",
+					MountPath: "/var/lib/config-data/default/",
+				},
+			},
+		})
+		err := InjectVolumes(spec, set, AllContainers)
+		g.Expect(err).To(MatchError(ErrMountPathConflict))
+		g.Expect(spec.Volumes).To(HaveLen(1))
+	})
+
+	t.Run("Unknown container", func(t *testing.T) {
+		g := NewWithT(t)
+		spec := getPodSpec()
+
+		err := InjectVolumes(spec, getConfigVolumeSet(), ContainerSelector{Containers: []string{"foo"}})
+		g.Expect(err).To(MatchError(ErrContainerNotFound))
+		g.Expect(spec.Volumes).To(BeEmpty())
+	})
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package podspec provides the assembly of the pod template of a service
+// from its main container and optional sidecars and init containers, e.g.
+// log rotation, a TLS proxy or the IPA client
+package podspec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pod"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Define static errors
+var (
+	// ErrDuplicateContainer indicates two containers with the same name
+	ErrDuplicateContainer = errors.New("duplicate container")
+	// ErrOrderCycle indicates ordering rules which contradict each other
+	ErrOrderCycle = errors.New("container ordering cycle")
+)
+
+const (
+	// DefaultContainerAnnotation - annotation selecting the container kubectl
+	// logs and exec use by default
+	DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+)
+
+// Injection - a sidecar or init container added to the pods of a service
+type Injection struct {
+	// Container - the container
+	Container corev1.Container
+	// Init - add the container as init container
+	Init bool
+	// Before - names of the containers of the same kind (init or not) this
+	// container must be ordered before. For init containers the order is
+	// the execution order. Names of containers which are not part of the
+	// pod are ignored, as the injections are optional.
+	Before []string
+	// After - names of the containers of the same kind this container must
+	// be ordered after
+	After []string
+	// Volumes - volumes the container mounts, added to the pod
+	Volumes []corev1.Volume
+}
+
+// Spec - the pod template of a service with its containers
+type Spec struct {
+	// Template - the pod template, e.g. with the labels, service account
+	// and volumes. Containers and init containers of it are kept, the base
+	// container is added first.
+	Template corev1.PodTemplateSpec
+	// Base - the main container of the service, it is the first container
+	// and the default container of kubectl
+	Base corev1.Container
+	// Injections - the sidecars and init containers, in the order they get
+	// added if no ordering rules apply
+	Injections []Injection
+	// DefaultResources - the resources of injected containers which do not
+	// set any
+	DefaultResources corev1.ResourceRequirements
+}
+
+// Build - returns the pod template with the base container and the
+// injections added in the order of their rules
+//
+// Example:
+//
+//	template, err := podspec.Spec{
+//		Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: labels}},
+//		Base:     apiContainer,
+//		Injections: []podspec.Injection{
+//			{Container: logRotateContainer, Volumes: logVolumes},
+//			{Container: tlsProxyContainer, Before: []string{"keystone-log"}},
+//		},
+//		DefaultResources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")}},
+//	}.Build()
+func (s Spec) Build() (*corev1.PodTemplateSpec, error) {
+	template := s.Template.DeepCopy()
+
+	containers := []orderedContainer{{container: *s.Base.DeepCopy()}}
+	for _, c := range template.Spec.Containers {
+		containers = append(containers, orderedContainer{container: c})
+	}
+	initContainers := []orderedContainer{}
+	for _, c := range template.Spec.InitContainers {
+		initContainers = append(initContainers, orderedContainer{container: c})
+	}
+
+	for _, inj := range s.Injections {
+		c := *inj.Container.DeepCopy()
+		if len(c.Resources.Requests) == 0 && len(c.Resources.Limits) == 0 {
+			c.Resources = *s.DefaultResources.DeepCopy()
+		}
+		oc := orderedContainer{container: c, before: inj.Before, after: inj.After}
+		if inj.Init {
+			initContainers = append(initContainers, oc)
+		} else {
+			containers = append(containers, oc)
+		}
+
+		err := pod.InjectVolumes(&template.Spec, pod.VolumeSet{Volumes: inj.Volumes}, pod.ContainerSelector{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	template.Spec.Containers, err = order(containers)
+	if err != nil {
+		return nil, err
+	}
+	template.Spec.InitContainers, err = order(initContainers)
+	if err != nil {
+		return nil, err
+	}
+	if len(template.Spec.InitContainers) == 0 {
+		template.Spec.InitContainers = nil
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[DefaultContainerAnnotation] = s.Base.Name
+
+	return template, nil
+}
+
+type orderedContainer struct {
+	container corev1.Container
+	before    []string
+	after     []string
+}
+
+// order - returns the containers sorted by their before and after rules,
+// keeping the given order where no rule applies
+func order(containers []orderedContainer) ([]corev1.Container, error) {
+	index := map[string]int{}
+	for i, c := range containers {
+		if _, ok := index[c.container.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateContainer, c.container.Name)
+		}
+		index[c.container.Name] = i
+	}
+
+	// edges from a container to the ones which must come after it
+	next := make([][]int, len(containers))
+	inDegree := make([]int, len(containers))
+	addEdge := func(from int, to int) {
+		next[from] = append(next[from], to)
+		inDegree[to]++
+	}
+	for i, c := range containers {
+		for _, name := range c.before {
+			if j, ok := index[name]; ok {
+				addEdge(i, j)
+			}
+		}
+		for _, name := range c.after {
+			if j, ok := index[name]; ok {
+				addEdge(j, i)
+			}
+		}
+	}
+
+	// Kahn's algorithm, always picking the first ready container to keep
+	// the given order
+	result := []corev1.Container{}
+	done := make([]bool, len(containers))
+	for len(result) < len(containers) {
+		picked := -1
+		for i := range containers {
+			if !done[i] && inDegree[i] == 0 {
+				picked = i
+				break
+			}
+		}
+		if picked < 0 {
+			cycle := []string{}
+			for i, c := range containers {
+				if !done[i] {
+					cycle = append(cycle, c.container.Name)
+				}
+			}
+			return nil, fmt.Errorf("%w: %s", ErrOrderCycle, strings.Join(cycle, ", "))
+		}
+		done[picked] = true
+		for _, j := range next[picked] {
+			inDegree[j]--
+		}
+		result = append(result, containers[picked].container)
+	}
+
+	return result, nil
+}
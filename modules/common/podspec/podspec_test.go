@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podspec
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func names(containers []corev1.Container) []string {
+	n := []string{}
+	for _, c := range containers {
+		n = append(n, c.Name)
+	}
+	return n
+}
+
+func TestBuild(t *testing.T) {
+	g := NewWithT(t)
+
+	defaults := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+	}
+	own := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	logVolume := corev1.Volume{Name: "logs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+
+	spec := Spec{
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"service": "keystone"}},
+		},
+		Base: corev1.Container{Name: "keystone-api"},
+		Injections: []Injection{
+			{Container: corev1.Container{Name: "log-rotate"}, Volumes: []corev1.Volume{logVolume}},
+			{Container: corev1.Container{Name: "tls-proxy", Resources: own}, Before: []string{"log-rotate"}},
+			{Container: corev1.Container{Name: "ipa-client"}, Init: true},
+			{Container: corev1.Container{Name: "init-config"}, Init: true, Before: []string{"ipa-client", "not-injected"}},
+		},
+		DefaultResources: defaults,
+	}
+
+	template, err := spec.Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names(template.Spec.Containers)).To(Equal([]string{"keystone-api", "tls-proxy", "log-rotate"}))
+	g.Expect(names(template.Spec.InitContainers)).To(Equal([]string{"init-config", "ipa-client"}))
+	g.Expect(template.Spec.Containers[1].Resources).To(Equal(own))
+	g.Expect(template.Spec.Containers[2].Resources).To(Equal(defaults))
+	g.Expect(template.Spec.Volumes).To(Equal([]corev1.Volume{logVolume}))
+	g.Expect(template.Annotations).To(HaveKeyWithValue(DefaultContainerAnnotation, "keystone-api"))
+	g.Expect(template.Labels).To(HaveKeyWithValue("service", "keystone"))
+	// the spec is not modified
+	g.Expect(spec.Template.Spec.Containers).To(BeEmpty())
+
+	// the same volume from two injections is added once
+	spec.Injections[1].Volumes = []corev1.Volume{logVolume}
+	template, err = spec.Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(template.Spec.Volumes).To(HaveLen(1))
+}
+
+func TestBuildErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := Spec{
+		Base:       corev1.Container{Name: "keystone-api"},
+		Injections: []Injection{{Container: corev1.Container{Name: "keystone-api"}}},
+	}.Build()
+	g.Expect(err).To(MatchError(ErrDuplicateContainer))
+
+	_, err = Spec{
+		Base: corev1.Container{Name: "keystone-api"},
+		Injections: []Injection{
+			{Container: corev1.Container{Name: "a"}, Before: []string{"b"}},
+			{Container: corev1.Container{Name: "b"}, Before: []string{"a"}},
+		},
+	}.Build()
+	g.Expect(err).To(MatchError(ErrOrderCycle))
+	g.Expect(err.Error()).To(ContainSubstring("a, b"))
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preconditions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/dependencies"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	common_net "github.com/openstack-k8s-operators/lib-common/modules/common/net"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/quota"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CRDPresent - requires the API of the kind to be served, e.g. the CRD of
+// an optional dependency to be installed
+func CRDPresent(gvk schema.GroupVersionKind) Requirement {
+	return Requirement{
+		Name: "CRD " + gvk.Kind,
+		Check: func(_ context.Context, h *helper.Helper) (bool, string, error) {
+			_, err := h.GetClient().RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+			if meta.IsNoMatchError(err) {
+				return false, fmt.Sprintf("%s is not installed", gvk), nil
+			}
+			if err != nil {
+				return false, "", err
+			}
+			return true, "", nil
+		},
+	}
+}
+
+// CRReady - requires the CR of the kind to exist and have a Ready
+// condition with status True. If the CR publishes its observed generation,
+// the controller of the CR must also have observed its current generation,
+// otherwise the Ready condition may still reflect the previous spec.
+func CRReady(gvk schema.GroupVersionKind, name string, namespace string) Requirement {
+	return Requirement{
+		Name: fmt.Sprintf("%s %s", gvk.Kind, name),
+		Check: func(ctx context.Context, h *helper.Helper) (bool, string, error) {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvk)
+			err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, obj)
+			if k8s_errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			if err != nil {
+				return false, "", err
+			}
+
+			ready, message := dependencies.IsReady(obj)
+			return ready, message, nil
+		},
+	}
+}
+
+// SecretExists - requires the secret to exist and hold all the given keys
+func SecretExists(name string, namespace string, keys ...string) Requirement {
+	return Requirement{
+		Name: "secret " + name,
+		Check: func(ctx context.Context, h *helper.Helper) (bool, string, error) {
+			secret := &corev1.Secret{}
+			err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
+			if k8s_errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			if err != nil {
+				return false, "", err
+			}
+
+			missing := []string{}
+			for _, key := range keys {
+				if _, ok := secret.Data[key]; !ok {
+					missing = append(missing, key)
+				}
+			}
+			if len(missing) > 0 {
+				return false, "missing keys " + strings.Join(missing, ", "), nil
+			}
+			return true, "", nil
+		},
+	}
+}
+
+// ConfigMapExists - requires the config map to exist and hold all the given
+// keys
+func ConfigMapExists(name string, namespace string, keys ...string) Requirement {
+	return Requirement{
+		Name: "configmap " + name,
+		Check: func(ctx context.Context, h *helper.Helper) (bool, string, error) {
+			cm := &corev1.ConfigMap{}
+			err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cm)
+			if k8s_errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			if err != nil {
+				return false, "", err
+			}
+
+			missing := []string{}
+			for _, key := range keys {
+				_, inData := cm.Data[key]
+				_, inBinaryData := cm.BinaryData[key]
+				if !inData && !inBinaryData {
+					missing = append(missing, key)
+				}
+			}
+			if len(missing) > 0 {
+				return false, "missing keys " + strings.Join(missing, ", "), nil
+			}
+			return true, "", nil
+		},
+	}
+}
+
+// NADExists - requires all the network attachment definitions to exist
+func NADExists(nads []string, namespace string) Requirement {
+	return Requirement{
+		Name: "network attachments",
+		Check: func(ctx context.Context, h *helper.Helper) (bool, string, error) {
+			for _, nad := range nads {
+				_, err := networkattachment.GetNADWithName(ctx, h, nad, namespace)
+				if k8s_errors.IsNotFound(err) {
+					return false, fmt.Sprintf("%s not found", nad), nil
+				}
+				if err != nil {
+					return false, "", err
+				}
+			}
+			return true, "", nil
+		},
+	}
+}
+
+// QuotaAvailable - requires the ResourceQuotas of the namespace to have
+// headroom for the request, e.g. the pods of a new deployment
+func QuotaAvailable(namespace string, request quota.Request) Requirement {
+	return Requirement{
+		Name: "quota",
+		Check: func(ctx context.Context, h *helper.Helper) (bool, string, error) {
+			return quota.Check(ctx, h, namespace, request)
+		},
+	}
+}
+
+// EndpointReachable - requires the URL or host:port target to be reachable,
+// e.g. a user provided rabbitmq or ceph endpoint. The message names the kind
+// of failure, like DNSFailure or TLSFailure.
+func EndpointReachable(name string, target string, opts common_net.ProbeOptions) Requirement {
+	return Requirement{
+		Name: "endpoint " + name,
+		Check: func(ctx context.Context, _ *helper.Helper) (bool, string, error) {
+			result := common_net.Probe(ctx, target, opts)
+			if !result.Reachable() {
+				return false, result.String(), nil
+			}
+			return true, "", nil
+		},
+	}
+}
+
+// ImageArchitecturesSupported - requires image to be available for the
+// architectures of the nodes matching nodeSelector. The resolver is e.g. a
+// util.StaticArchResolver with the metadata of the operator bundle for
+// disconnected clusters, or a util.RegistryArchResolver.
+func ImageArchitecturesSupported(image string, nodeSelector map[string]string, resolver util.ArchResolver) Requirement {
+	return Requirement{
+		Name: "image " + image,
+		Check: func(ctx context.Context, h *helper.Helper) (bool, string, error) {
+			return util.CheckImageArchitectures(ctx, h, resolver, image, nodeSelector)
+		},
+	}
+}
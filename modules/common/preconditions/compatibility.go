@@ -0,0 +1,177 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preconditions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// OperatorVersionAnnotation - annotation of the CRDs with the version of the
+// operator which installed them
+const OperatorVersionAnnotation = "openstack.org/operator-version"
+
+// crdGVK - the CustomResourceDefinition kind, read as unstructured to not
+// depend on the apiextensions API
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// CRDCompatibility - what a consuming operator was built for of the CRD of
+// a dependency operator, e.g. of the Galera CRD of the mariadb-operator
+type CRDCompatibility struct {
+	// GVK - the kind and the API version the consumer uses, the version
+	// must be served
+	GVK schema.GroupVersionKind
+	// MinVersion - minimum version of the operator providing the CRD,
+	// compared against the OperatorVersionAnnotation of the CRD. Not checked
+	// if empty or if the CRD has no such annotation.
+	MinVersion string
+	// Fields - paths of the fields the consumer relies on, which must be in
+	// the schema of the version, e.g. spec.tls.secretName
+	Fields []string
+}
+
+// CRDCompatible - requires the installed CRD of the kind to be compatible
+// with what the consuming operator was built for, e.g. to report an
+// operator which did not get upgraded yet after a partial upgrade, instead
+// of silently misbehaving on fields the CRD does not know.
+//
+// Example:
+//
+//	ctrlResult, err := preconditions.Evaluate(ctx, h, &instance.Status.Conditions, time.Second*10,
+//		preconditions.CRDCompatible(preconditions.CRDCompatibility{
+//			GVK:        database.GaleraGVK,
+//			MinVersion: "1.0.4",
+//			Fields:     []string{"spec.tls.secretName", "status.bootstrapped"},
+//		}),
+//	)
+func CRDCompatible(c CRDCompatibility) Requirement {
+	return Requirement{
+		Name: fmt.Sprintf("CRD %s %s", c.GVK.Kind, c.GVK.Version),
+		Check: func(ctx context.Context, h *helper.Helper) (bool, string, error) {
+			mapping, err := h.GetClient().RESTMapper().RESTMapping(c.GVK.GroupKind(), c.GVK.Version)
+			if meta.IsNoMatchError(err) {
+				return false, fmt.Sprintf("%s is not served", c.GVK.GroupVersion()), nil
+			}
+			if err != nil {
+				return false, "", err
+			}
+
+			crd := &unstructured.Unstructured{}
+			crd.SetGroupVersionKind(crdGVK)
+			name := mapping.Resource.Resource + "." + c.GVK.Group
+			err = h.GetClient().Get(ctx, types.NamespacedName{Name: name}, crd)
+			if k8s_errors.IsNotFound(err) {
+				return false, fmt.Sprintf("CRD %s not found", name), nil
+			}
+			if err != nil {
+				return false, "", err
+			}
+
+			return checkCRDCompatibility(crd, c)
+		},
+	}
+}
+
+// checkCRDCompatibility - checks the operator version annotation and the
+// schema of the crd against c
+func checkCRDCompatibility(crd *unstructured.Unstructured, c CRDCompatibility) (bool, string, error) {
+	installed := crd.GetAnnotations()[OperatorVersionAnnotation]
+	if c.MinVersion != "" && installed != "" {
+		minVersion, err := version.ParseGeneric(c.MinVersion)
+		if err != nil {
+			return false, "", fmt.Errorf("invalid min version %s: %w", c.MinVersion, err)
+		}
+		installedVersion, err := version.ParseGeneric(installed)
+		if err != nil {
+			return false, fmt.Sprintf("CRD %s has invalid version %s", crd.GetName(), installed), nil
+		}
+		if !installedVersion.AtLeast(minVersion) {
+			return false, fmt.Sprintf("CRD %s has version %s, at least %s required", crd.GetName(), installed, c.MinVersion), nil
+		}
+	}
+
+	if len(c.Fields) == 0 {
+		return true, "", nil
+	}
+
+	versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return false, "", err
+	}
+	var schemaProps map[string]interface{}
+	for _, v := range versions {
+		v, ok := v.(map[string]interface{})
+		if !ok || v["name"] != c.GVK.Version {
+			continue
+		}
+		schemaProps, _, err = unstructured.NestedMap(v, "schema", "openAPIV3Schema")
+		if err != nil {
+			return false, "", err
+		}
+	}
+	if schemaProps == nil {
+		return false, fmt.Sprintf("CRD %s has no schema for %s", crd.GetName(), c.GVK.Version), nil
+	}
+
+	missing := []string{}
+	for _, field := range c.Fields {
+		if !schemaHasField(schemaProps, strings.Split(field, ".")) {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return false, fmt.Sprintf("CRD %s misses fields %s", crd.GetName(), strings.Join(missing, ", ")), nil
+	}
+
+	return true, "", nil
+}
+
+// schemaHasField - returns true if the OpenAPI schema has the field at path.
+// The fields of the elements of a list are addressed without an index, the
+// fields below a node which preserves unknown fields are always present.
+func schemaHasField(schemaProps map[string]interface{}, path []string) bool {
+	current := schemaProps
+	for _, p := range path {
+		if preserve, _ := current["x-kubernetes-preserve-unknown-fields"].(bool); preserve {
+			return true
+		}
+		// the fields of the elements of a list
+		if items, ok := current["items"].(map[string]interface{}); ok {
+			current = items
+		}
+		properties, _ := current["properties"].(map[string]interface{})
+		next, ok := properties[p].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		current = next
+	}
+	return true
+}
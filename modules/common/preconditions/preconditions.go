@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preconditions provides an ordered evaluation of the prerequisites
+// of a reconcile which reports the first unmet one in the RequirementsReady
+// condition
+package preconditions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// CheckFunc - checks a prerequisite. Returns true if it is met, otherwise a
+// message describing what is missing. An error is returned if the check
+// itself failed.
+type CheckFunc func(ctx context.Context, h *helper.Helper) (bool, string, error)
+
+// Requirement - a named prerequisite of the reconcile
+type Requirement struct {
+	// Name - short description used in the condition message, e.g.
+	// "secret osp-secret"
+	Name string
+	// Check - checks the requirement
+	Check CheckFunc
+}
+
+// Evaluate - checks the requirements in the given order and stops at the
+// first one not met. The RequirementsReady condition is set to
+// - True if all requirements are met, an empty ctrl.Result is returned
+// - False with RequestedReason and the message of the first unmet
+// requirement, a requeue after requeueTimeout is returned
+// - False with ErrorReason if a check failed, its error is returned
+//
+// Example:
+//
+//	ctrlResult, err := preconditions.Evaluate(ctx, h, &instance.Status.Conditions, time.Second*10,
+//		preconditions.SecretExists(instance.Spec.Secret, instance.Namespace, "AdminPassword"),
+//		preconditions.CRReady(memcachedGVK, instance.Spec.MemcachedInstance, instance.Namespace),
+//		preconditions.NADExists(instance.Spec.NetworkAttachments, instance.Namespace),
+//	)
+//	if (ctrlResult != ctrl.Result{}) || err != nil {
+//		return ctrlResult, err
+//	}
+func Evaluate(
+	ctx context.Context,
+	h *helper.Helper,
+	conditions *condition.Conditions,
+	requeueTimeout time.Duration,
+	requirements ...Requirement,
+) (ctrl.Result, error) {
+	for _, r := range requirements {
+		ready, message, err := r.Check(ctx, h)
+		if err != nil {
+			conditions.Set(condition.FalseCondition(
+				condition.RequirementsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.RequirementsReadyErrorMessage,
+				r.Name,
+				err.Error()))
+			return ctrl.Result{}, fmt.Errorf("requirement %s: %w", r.Name, err)
+		}
+		if !ready {
+			h.GetLogger().Info("Requirement not met, reconcile later", "requirement", r.Name, "status", message, "requeueAfter", requeueTimeout)
+			conditions.Set(condition.FalseCondition(
+				condition.RequirementsReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.RequirementsReadyWaitingMessage,
+				r.Name,
+				message))
+			return ctrl.Result{RequeueAfter: requeueTimeout}, nil
+		}
+	}
+
+	conditions.MarkTrue(condition.RequirementsReadyCondition, condition.RequirementsReadyMessage)
+
+	return ctrl.Result{}, nil
+}
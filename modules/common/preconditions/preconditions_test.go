@@ -0,0 +1,337 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preconditions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/generation"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	common_net "github.com/openstack-k8s-operators/lib-common/modules/common/net"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var errCheck = errors.New("check failed")
+
+func setupHelper(objs ...runtime.Object) (*helper.Helper, error) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "openstack",
+		},
+	}
+	// only ConfigMaps are known to the RESTMapper, to simulate served APIs
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithRESTMapper(mapper).
+		WithRuntimeObjects(objs...).
+		Build()
+
+	return helper.NewHelper(ns, fakeClient, nil, scheme.Scheme, ctrl.Log)
+}
+
+func getSecret(name string, keys ...string) *corev1.Secret {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openstack",
+		},
+		Data: map[string][]byte{},
+	}
+	for _, k := range keys {
+		s.Data[k] = []byte("12345678")
+	}
+	return s
+}
+
+func TestEvaluate(t *testing.T) {
+	failing := Requirement{
+		Name: "failing",
+		Check: func(_ context.Context, _ *helper.Helper) (bool, string, error) {
+			return false, "", errCheck
+		},
+	}
+
+	tests := []struct {
+		name         string
+		objs         []runtime.Object
+		requirements []Requirement
+		wantResult   ctrl.Result
+		wantErr      bool
+		wantStatus   corev1.ConditionStatus
+		wantMessage  string
+	}{
+		{
+			name: "All requirements met",
+			objs: []runtime.Object{getSecret("osp-secret", "AdminPassword")},
+			requirements: []Requirement{
+				CRDPresent(corev1.SchemeGroupVersion.WithKind("ConfigMap")),
+				SecretExists("osp-secret", "openstack", "AdminPassword"),
+			},
+			wantStatus:  corev1.ConditionTrue,
+			wantMessage: condition.RequirementsReadyMessage,
+		},
+		{
+			name: "Missing CRD",
+			requirements: []Requirement{
+				CRDPresent(schema.GroupVersionKind{Group: "memcached.openstack.org", Version: "v1beta1", Kind: "Memcached"}),
+				failing,
+			},
+			wantResult:  ctrl.Result{RequeueAfter: time.Second},
+			wantStatus:  corev1.ConditionFalse,
+			wantMessage: "Waiting for requirement CRD Memcached: memcached.openstack.org/v1beta1, Kind=Memcached is not installed",
+		},
+		{
+			name: "First unmet requirement is reported",
+			objs: []runtime.Object{getSecret("osp-secret")},
+			requirements: []Requirement{
+				ConfigMapExists("config", "openstack"),
+				SecretExists("osp-secret", "openstack", "AdminPassword", "DbPassword"),
+			},
+			wantResult:  ctrl.Result{RequeueAfter: time.Second},
+			wantStatus:  corev1.ConditionFalse,
+			wantMessage: "Waiting for requirement configmap config: not found",
+		},
+		{
+			name: "Missing secret keys",
+			objs: []runtime.Object{getSecret("osp-secret")},
+			requirements: []Requirement{
+				SecretExists("osp-secret", "openstack", "AdminPassword", "DbPassword"),
+			},
+			wantResult:  ctrl.Result{RequeueAfter: time.Second},
+			wantStatus:  corev1.ConditionFalse,
+			wantMessage: "Waiting for requirement secret osp-secret: missing keys AdminPassword, DbPassword",
+		},
+		{
+			name: "Unreachable endpoint",
+			requirements: []Requirement{
+				EndpointReachable("rabbitmq", "rabbitmq", common_net.ProbeOptions{}),
+			},
+			wantResult:  ctrl.Result{RequeueAfter: time.Second},
+			wantStatus:  corev1.ConditionFalse,
+			wantMessage: "Waiting for requirement endpoint rabbitmq: InvalidTarget: address rabbitmq: missing port in address",
+		},
+		{
+			name:         "Failing check",
+			requirements: []Requirement{failing},
+			wantErr:      true,
+			wantStatus:   corev1.ConditionFalse,
+			wantMessage:  "Requirement failing error occurred check failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			h, err := setupHelper(tt.objs...)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			conditions := condition.Conditions{}
+			result, err := Evaluate(context.TODO(), h, &conditions, time.Second, tt.requirements...)
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(errCheck))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(result).To(Equal(tt.wantResult))
+
+			c := conditions.Get(condition.RequirementsReadyCondition)
+			g.Expect(c).ToNot(BeNil())
+			g.Expect(c.Status).To(Equal(tt.wantStatus))
+			g.Expect(c.Message).To(Equal(tt.wantMessage))
+		})
+	}
+}
+
+func TestCRReady(t *testing.T) {
+	g := NewWithT(t)
+
+	// use a Pod with the Ready condition as stand-in for a CR
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "memcached",
+			Namespace: "openstack",
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse, Message: "containers not ready"},
+			},
+		},
+	}
+	h, err := setupHelper(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	gvk := corev1.SchemeGroupVersion.WithKind("Pod")
+	ready, message, err := CRReady(gvk, "memcached", "openstack").Check(context.TODO(), h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ready).To(BeFalse())
+	g.Expect(message).To(Equal("not ready: containers not ready"))
+
+	ready, message, err = CRReady(gvk, "missing", "openstack").Check(context.TODO(), h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ready).To(BeFalse())
+	g.Expect(message).To(Equal("not found"))
+
+	pod.Status.Conditions[0].Status = corev1.ConditionTrue
+	g.Expect(h.GetClient().Status().Update(context.TODO(), pod)).To(Succeed())
+	ready, _, err = CRReady(gvk, "memcached", "openstack").Check(context.TODO(), h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ready).To(BeTrue())
+
+	// the Ready condition is of a previous generation
+	g.Expect(h.GetClient().Get(context.TODO(), types.NamespacedName{Name: "memcached", Namespace: "openstack"}, pod)).To(Succeed())
+	pod.Generation = 2
+	pod.Annotations = map[string]string{generation.ObservedGenerationAnnotation: "1"}
+	g.Expect(h.GetClient().Update(context.TODO(), pod)).To(Succeed())
+	ready, message, err = CRReady(gvk, "memcached", "openstack").Check(context.TODO(), h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ready).To(BeFalse())
+	g.Expect(message).To(Equal("generation 2 not observed yet"))
+}
+
+func getCRD(operatorVersion string, statusFields ...string) *unstructured.Unstructured {
+	status := map[string]interface{}{}
+	for _, f := range statusFields {
+		status[f] = map[string]interface{}{"type": "boolean"}
+	}
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{
+					"name": "v1beta1",
+					"schema": map[string]interface{}{
+						"openAPIV3Schema": map[string]interface{}{
+							"properties": map[string]interface{}{
+								"spec": map[string]interface{}{
+									"properties": map[string]interface{}{
+										"nodeSelector": map[string]interface{}{
+											"x-kubernetes-preserve-unknown-fields": true,
+										},
+										"ports": map[string]interface{}{
+											"items": map[string]interface{}{
+												"properties": map[string]interface{}{
+													"name": map[string]interface{}{"type": "string"},
+												},
+											},
+										},
+									},
+								},
+								"status": map[string]interface{}{
+									"properties": status,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	crd.SetGroupVersionKind(crdGVK)
+	crd.SetName("galeras.mariadb.openstack.org")
+	if operatorVersion != "" {
+		crd.SetAnnotations(map[string]string{OperatorVersionAnnotation: operatorVersion})
+	}
+	return crd
+}
+
+func TestCRDCompatible(t *testing.T) {
+	galeraGVK := schema.GroupVersionKind{Group: "mariadb.openstack.org", Version: "v1beta1", Kind: "Galera"}
+	compatibility := CRDCompatibility{
+		GVK:        galeraGVK,
+		MinVersion: "1.0.4",
+		Fields:     []string{"status.bootstrapped", "spec.ports.name", "spec.nodeSelector.foo"},
+	}
+
+	tests := []struct {
+		name string
+		crd  *unstructured.Unstructured
+		// notServed - the RESTMapper does not know the kind
+		notServed bool
+		ready     bool
+		message   string
+	}{
+		{
+			name:  "Compatible",
+			crd:   getCRD("v1.1.0", "bootstrapped"),
+			ready: true,
+		},
+		{
+			name:  "No version annotation",
+			crd:   getCRD("", "bootstrapped"),
+			ready: true,
+		},
+		{
+			name:    "Operator too old",
+			crd:     getCRD("1.0.3", "bootstrapped"),
+			message: "CRD galeras.mariadb.openstack.org has version 1.0.3, at least 1.0.4 required",
+		},
+		{
+			name:    "Missing field",
+			crd:     getCRD("1.0.4"),
+			message: "CRD galeras.mariadb.openstack.org misses fields status.bootstrapped",
+		},
+		{
+			name:    "CRD not found",
+			message: "CRD galeras.mariadb.openstack.org not found",
+		},
+		{
+			name:      "Version not served",
+			crd:       getCRD("1.0.4", "bootstrapped"),
+			notServed: true,
+			message:   "mariadb.openstack.org/v1beta1 is not served",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{galeraGVK.GroupVersion()})
+			if !tt.notServed {
+				mapper.Add(galeraGVK, meta.RESTScopeNamespace)
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper)
+			if tt.crd != nil {
+				builder = builder.WithObjects(tt.crd)
+			}
+			h, err := helper.NewHelper(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openstack"}},
+				builder.Build(), nil, scheme.Scheme, ctrl.Log)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ready, message, err := CRDCompatible(compatibility).Check(context.TODO(), h)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ready).To(Equal(tt.ready))
+			g.Expect(message).To(Equal(tt.message))
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package predicates provides reusable event filters and rate limiters for
+// the controllers of the operators
+package predicates
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ignoredMetadata - metadata fields which change with every write, e.g.
+// also on status updates
+var ignoredMetadata = []string{"resourceVersion", "managedFields", "generation"}
+
+// SpecChanged - returns a predicate which filters the update events which
+// only changed the status of the object, e.g. the echo of the status update
+// done by the own reconcile. Changes to the spec, the data of objects
+// without spec like Secrets, labels, annotations, finalizers and the
+// deletion timestamp pass.
+//
+// example usage:
+//
+//	ctrl.NewControllerManagedBy(mgr).
+//		For(&keystonev1.KeystoneAPI{}, builder.WithPredicates(predicates.SpecChanged())).
+func SpecChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return true
+			}
+			// a different generation is a spec change for CRs with the
+			// status subresource, no need to compare
+			if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() {
+				return true
+			}
+			oldContent, err := withoutStatus(e.ObjectOld)
+			if err != nil {
+				return true
+			}
+			newContent, err := withoutStatus(e.ObjectNew)
+			if err != nil {
+				return true
+			}
+			return !equality.Semantic.DeepEqual(oldContent, newContent)
+		},
+	}
+}
+
+// withoutStatus - returns the unstructured content of obj without status
+// and the metadata which changes with every write
+func withoutStatus(obj client.Object) (map[string]interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	delete(u, "status")
+	for _, field := range ignoredMetadata {
+		unstructured.RemoveNestedField(u, "metadata", field)
+	}
+	return u, nil
+}
+
+// GenerationChanged - returns a predicate which passes the update events
+// which changed the generation of the object, i.e. its spec. Status,
+// label and annotation only updates get filtered.
+func GenerationChanged() predicate.Predicate {
+	return predicate.GenerationChangedPredicate{}
+}
+
+// GenerationUnchanged - returns a predicate which passes the update events
+// which did not change the generation of the object, e.g. to react on the
+// status updates of a dependency CR without the spec changes of it, which
+// are only reflected once its controller observed them.
+func GenerationUnchanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return true
+			}
+			return e.ObjectOld.GetGeneration() == e.ObjectNew.GetGeneration()
+		},
+	}
+}
+
+// AnnotationsChanged - returns a predicate which passes the update events
+// which changed the value of one of the annotations, e.g. to react on an
+// admin annotation of an object which is otherwise filtered by
+// GenerationChanged. Combine with predicate.Or.
+func AnnotationsChanged(annotations ...string) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return true
+			}
+			oldAnnotations := e.ObjectOld.GetAnnotations()
+			newAnnotations := e.ObjectNew.GetAnnotations()
+			for _, a := range annotations {
+				oldValue, oldOk := oldAnnotations[a]
+				newValue, newOk := newAnnotations[a]
+				if oldOk != newOk || oldValue != newValue {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// HasAnnotation - returns a predicate which passes the events of objects
+// with the annotation set. If value is not empty the annotation must have
+// this value.
+func HasAnnotation(annotation string, value string) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		v, ok := obj.GetAnnotations()[annotation]
+		if !ok {
+			return false
+		}
+		return value == "" || v == value
+	})
+}
+
+// RateLimiterOptions - options of NewRateLimiter
+type RateLimiterOptions struct {
+	// BaseDelay - delay of the first retry of a failed object, doubled on
+	// each further failure
+	BaseDelay time.Duration
+	// MaxDelay - maximum delay of the retries of a failed object
+	MaxDelay time.Duration
+	// QPS - overall rate of requests across all objects
+	QPS float64
+	// Burst - overall burst of requests across all objects
+	Burst int
+}
+
+// DefaultRateLimiterOptions - the options of the controller-runtime default
+// rate limiter
+var DefaultRateLimiterOptions = RateLimiterOptions{
+	BaseDelay: 5 * time.Millisecond,
+	MaxDelay:  1000 * time.Second,
+	QPS:       10,
+	Burst:     100,
+}
+
+// NewRateLimiter - returns a rate limiter for controller.Options which backs
+// off the requests per object exponentially and limits the overall rate of
+// requests. Unset options default to DefaultRateLimiterOptions.
+//
+// example usage:
+//
+//	ctrl.NewControllerManagedBy(mgr).
+//		WithOptions(controller.Options{
+//			RateLimiter: predicates.NewRateLimiter(predicates.RateLimiterOptions{BaseDelay: time.Second}),
+//		}).
+func NewRateLimiter(opts RateLimiterOptions) workqueue.TypedRateLimiter[reconcile.Request] {
+	if opts.BaseDelay == 0 {
+		opts.BaseDelay = DefaultRateLimiterOptions.BaseDelay
+	}
+	if opts.MaxDelay == 0 {
+		opts.MaxDelay = DefaultRateLimiterOptions.MaxDelay
+	}
+	if opts.QPS == 0 {
+		opts.QPS = DefaultRateLimiterOptions.QPS
+	}
+	if opts.Burst == 0 {
+		opts.Burst = DefaultRateLimiterOptions.Burst
+	}
+
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](opts.BaseDelay, opts.MaxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(opts.QPS), opts.Burst)},
+	)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func getDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "keystone",
+			Namespace:       "openstack",
+			Generation:      1,
+			ResourceVersion: "1",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+		},
+	}
+}
+
+func TestSpecChanged(t *testing.T) {
+	p := SpecChanged()
+
+	tests := []struct {
+		name   string
+		update func(d *appsv1.Deployment)
+		want   bool
+	}{
+		{
+			name: "Status only",
+			update: func(d *appsv1.Deployment) {
+				d.Status.ReadyReplicas = 1
+				d.ResourceVersion = "2"
+			},
+			want: false,
+		},
+		{
+			name: "Spec",
+			update: func(d *appsv1.Deployment) {
+				d.Spec.Replicas = ptr.To[int32](2)
+				d.Generation = 2
+			},
+			want: true,
+		},
+		{
+			name: "Labels",
+			update: func(d *appsv1.Deployment) {
+				d.Labels = map[string]string{"foo": "bar"}
+			},
+			want: true,
+		},
+		{
+			name: "Finalizers",
+			update: func(d *appsv1.Deployment) {
+				d.Finalizers = []string{"openstack.org/keystone"}
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			oldObj := getDeployment()
+			newObj := getDeployment()
+			tt.update(newObj)
+			g.Expect(p.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(Equal(tt.want))
+		})
+	}
+
+	// objects without generation, e.g. secrets
+	g := NewWithT(t)
+	oldSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "osp-secret", ResourceVersion: "1"}}
+	newSecret := oldSecret.DeepCopy()
+	newSecret.ResourceVersion = "2"
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: newSecret})).To(BeFalse())
+	newSecret.Data = map[string][]byte{"AdminPassword": []byte("12345678")}
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: newSecret})).To(BeTrue())
+}
+
+func TestAnnotations(t *testing.T) {
+	g := NewWithT(t)
+
+	oldObj := getDeployment()
+	newObj := getDeployment()
+	newObj.Annotations = map[string]string{"other": "true"}
+
+	changed := AnnotationsChanged("openstack.org/reconcile-paused")
+	g.Expect(changed.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeFalse())
+	newObj.Annotations["openstack.org/reconcile-paused"] = "true"
+	g.Expect(changed.Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeTrue())
+
+	has := HasAnnotation("openstack.org/reconcile-paused", "true")
+	g.Expect(has.Create(event.CreateEvent{Object: newObj})).To(BeTrue())
+	g.Expect(has.Create(event.CreateEvent{Object: oldObj})).To(BeFalse())
+	g.Expect(HasAnnotation("openstack.org/reconcile-paused", "false").Create(event.CreateEvent{Object: newObj})).To(BeFalse())
+	g.Expect(HasAnnotation("other", "").Create(event.CreateEvent{Object: newObj})).To(BeTrue())
+
+	g.Expect(GenerationChanged().Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeFalse())
+	g.Expect(GenerationUnchanged().Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeTrue())
+	newObj.Generation = 2
+	g.Expect(GenerationUnchanged().Update(event.UpdateEvent{ObjectOld: oldObj, ObjectNew: newObj})).To(BeFalse())
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	g := NewWithT(t)
+
+	r := NewRateLimiter(RateLimiterOptions{BaseDelay: time.Second, MaxDelay: 4 * time.Second})
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "keystone", Namespace: "openstack"}}
+
+	g.Expect(r.When(req)).To(Equal(time.Second))
+	g.Expect(r.When(req)).To(Equal(2 * time.Second))
+	g.Expect(r.When(req)).To(Equal(4 * time.Second))
+	g.Expect(r.When(req)).To(Equal(4 * time.Second))
+	g.Expect(r.NumRequeues(req)).To(Equal(4))
+
+	r.Forget(req)
+	g.Expect(r.When(req)).To(Equal(time.Second))
+}
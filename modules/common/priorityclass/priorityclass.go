@@ -0,0 +1,207 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package priorityclass provides utilities for managing Kubernetes
+// PriorityClasses and setting them on pod specs
+package priorityclass
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NewPriorityClass returns an initialized PriorityClass.
+func NewPriorityClass(
+	priorityClass *schedulingv1.PriorityClass,
+	timeout time.Duration,
+) *PriorityClass {
+	return &PriorityClass{
+		priorityClass: priorityClass,
+		timeout:       timeout,
+	}
+}
+
+// CreateOrPatch - creates or patches a PriorityClass. PriorityClasses are
+// cluster scoped, no owner reference gets set and the PriorityClass has to
+// be deleted explicitly. As the value of a PriorityClass can not be
+// changed, an error wrapping ErrPriorityClassValueImmutable is returned if
+// the existing PriorityClass has a different value. The same applies to the
+// preemption policy, with ErrPriorityClassPreemptionPolicyImmutable, if one
+// is requested. To change them the PriorityClass has to be deleted and
+// created again.
+func (p *PriorityClass) CreateOrPatch(
+	ctx context.Context,
+	h *helper.Helper,
+) (ctrl.Result, error) {
+	pc := &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: p.priorityClass.Name,
+		},
+	}
+
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), pc, func() error {
+		if pc.ResourceVersion != "" && pc.Value != p.priorityClass.Value {
+			return fmt.Errorf("%w: %s has value %d, requested %d",
+				ErrPriorityClassValueImmutable, pc.Name, pc.Value, p.priorityClass.Value)
+		}
+		if pc.ResourceVersion != "" && p.priorityClass.PreemptionPolicy != nil &&
+			preemptionPolicy(pc) != *p.priorityClass.PreemptionPolicy {
+			return fmt.Errorf("%w: %s has preemption policy %s, requested %s",
+				ErrPriorityClassPreemptionPolicyImmutable, pc.Name, preemptionPolicy(pc), *p.priorityClass.PreemptionPolicy)
+		}
+		pc.Labels = util.MergeStringMaps(pc.Labels, p.priorityClass.Labels)
+		pc.Annotations = util.MergeStringMaps(pc.Annotations, p.priorityClass.Annotations)
+		pc.Value = p.priorityClass.Value
+		pc.GlobalDefault = p.priorityClass.GlobalDefault
+		pc.Description = p.priorityClass.Description
+		if p.priorityClass.PreemptionPolicy != nil {
+			pc.PreemptionPolicy = p.priorityClass.PreemptionPolicy
+		}
+
+		return nil
+	})
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			h.GetLogger().Info("PriorityClass not found, reconcile later", "object", pc.Name, "requeueAfter", p.timeout)
+			return ctrl.Result{RequeueAfter: p.timeout}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if op != controllerutil.OperationResultNone {
+		h.GetLogger().Info("PriorityClass reconciled", "object", pc.Name, "operation", op)
+	}
+
+	p.priorityClass = pc
+
+	return ctrl.Result{}, nil
+}
+
+// preemptionPolicy - returns the preemption policy of pc, the one the API
+// server defaults to if it is not set
+func preemptionPolicy(pc *schedulingv1.PriorityClass) corev1.PreemptionPolicy {
+	if pc.PreemptionPolicy == nil {
+		return corev1.PreemptLowerPriority
+	}
+	return *pc.PreemptionPolicy
+}
+
+// Delete - delete a PriorityClass.
+func (p *PriorityClass) Delete(
+	ctx context.Context,
+	h *helper.Helper,
+) error {
+	return DeletePriorityClassWithName(ctx, h, p.priorityClass.Name)
+}
+
+// GetPriorityClass - get the PriorityClass object.
+func (p *PriorityClass) GetPriorityClass() schedulingv1.PriorityClass {
+	return *p.priorityClass
+}
+
+// GetPriorityClassWithName func
+func GetPriorityClassWithName(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+) (*schedulingv1.PriorityClass, error) {
+	pc := &schedulingv1.PriorityClass{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name}, pc)
+	if err != nil {
+		return pc, err
+	}
+
+	return pc, nil
+}
+
+// DeletePriorityClassWithName deletes a PriorityClass by name. It is not
+// an error to call this on an already deleted PriorityClass.
+func DeletePriorityClassWithName(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+) error {
+	pc := &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+
+	err := h.GetClient().Delete(ctx, pc)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting PriorityClass %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Validate - returns an error wrapping ErrPriorityClassNotFound if the
+// PriorityClass with the name does not exist. An empty name is valid and
+// means the default priority.
+func Validate(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+) error {
+	if name == "" {
+		return nil
+	}
+
+	_, err := GetPriorityClassWithName(ctx, h, name)
+	if k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("%w: %s", ErrPriorityClassNotFound, name)
+	}
+
+	return err
+}
+
+// SetPriorityClassName - sets the PriorityClass of the pod spec, e.g. of the
+// pod template returned by the deployment or statefulset builders of a
+// service. The priority gets reset, as the admission sets it from the
+// PriorityClass and rejects pods with a priority which does not match.
+func SetPriorityClassName(spec *corev1.PodSpec, name string) {
+	if spec.PriorityClassName != name {
+		spec.Priority = nil
+	}
+	spec.PriorityClassName = name
+}
+
+// InjectPriorityClass - validates that the PriorityClass exists and sets it
+// on the pod spec. The pod spec is not modified if the validation fails.
+func InjectPriorityClass(
+	ctx context.Context,
+	h *helper.Helper,
+	spec *corev1.PodSpec,
+	name string,
+) error {
+	err := Validate(ctx, h, name)
+	if err != nil {
+		return err
+	}
+	SetPriorityClassName(spec, name)
+
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorityclass
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func setupHelper(g *WithT) *helper.Helper {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "openstack",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h, err := helper.NewHelper(ns, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	return h
+}
+
+func getPriorityClass(value int32) *schedulingv1.PriorityClass {
+	return &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "openstack-control-plane",
+			Labels: map[string]string{"owner": "openstack"},
+		},
+		Value:       value,
+		Description: "OpenStack control plane services",
+	}
+}
+
+func TestCreateOrPatch(t *testing.T) {
+	g := NewWithT(t)
+	h := setupHelper(g)
+	ctx := context.TODO()
+
+	p := NewPriorityClass(getPriorityClass(1000000), time.Second)
+	result, err := p.CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+
+	pc, err := GetPriorityClassWithName(ctx, h, "openstack-control-plane")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pc.Value).To(Equal(int32(1000000)))
+	g.Expect(pc.Labels).To(HaveKeyWithValue("owner", "openstack"))
+	g.Expect(p.GetPriorityClass().Name).To(Equal("openstack-control-plane"))
+
+	// the description can be updated
+	updated := getPriorityClass(1000000)
+	updated.Description = "updated"
+	_, err = NewPriorityClass(updated, time.Second).CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	pc, err = GetPriorityClassWithName(ctx, h, "openstack-control-plane")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pc.Description).To(Equal("updated"))
+
+	// the value not
+	_, err = NewPriorityClass(getPriorityClass(10), time.Second).CreateOrPatch(ctx, h)
+	g.Expect(err).To(MatchError(ErrPriorityClassValueImmutable))
+
+	// nor the preemption policy, unset is the default of the API server
+	preempting := getPriorityClass(1000000)
+	preempting.PreemptionPolicy = ptr.To(corev1.PreemptLowerPriority)
+	_, err = NewPriorityClass(preempting, time.Second).CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	nonPreempting := getPriorityClass(1000000)
+	nonPreempting.PreemptionPolicy = ptr.To(corev1.PreemptNever)
+	_, err = NewPriorityClass(nonPreempting, time.Second).CreateOrPatch(ctx, h)
+	g.Expect(err).To(MatchError(ErrPriorityClassPreemptionPolicyImmutable))
+	pc, err = GetPriorityClassWithName(ctx, h, "openstack-control-plane")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pc.PreemptionPolicy).To(HaveValue(Equal(corev1.PreemptLowerPriority)))
+
+	g.Expect(p.Delete(ctx, h)).To(Succeed())
+	_, err = GetPriorityClassWithName(ctx, h, "openstack-control-plane")
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+	g.Expect(DeletePriorityClassWithName(ctx, h, "openstack-control-plane")).To(Succeed())
+}
+
+func TestInjectPriorityClass(t *testing.T) {
+	g := NewWithT(t)
+	h := setupHelper(g)
+	ctx := context.TODO()
+
+	spec := &corev1.PodSpec{
+		PriorityClassName: "workload",
+		Priority:          ptr.To[int32](100),
+	}
+
+	err := InjectPriorityClass(ctx, h, spec, "openstack-control-plane")
+	g.Expect(err).To(MatchError(ErrPriorityClassNotFound))
+	g.Expect(spec.PriorityClassName).To(Equal("workload"))
+
+	_, err = NewPriorityClass(getPriorityClass(1000000), time.Second).CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(InjectPriorityClass(ctx, h, spec, "openstack-control-plane")).To(Succeed())
+	g.Expect(spec.PriorityClassName).To(Equal("openstack-control-plane"))
+	g.Expect(spec.Priority).To(BeNil())
+
+	// an empty name resets to the default priority
+	g.Expect(InjectPriorityClass(ctx, h, spec, "")).To(Succeed())
+	g.Expect(spec.PriorityClassName).To(BeEmpty())
+}
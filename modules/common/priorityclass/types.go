@@ -0,0 +1,40 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorityclass
+
+import (
+	"errors"
+	"time"
+
+	schedulingv1 "k8s.io/api/scheduling/v1"
+)
+
+// PriorityClass -
+type PriorityClass struct {
+	priorityClass *schedulingv1.PriorityClass
+	timeout       time.Duration
+}
+
+// Define static errors
+var (
+	// ErrPriorityClassNotFound indicates that the referenced PriorityClass does not exist
+	ErrPriorityClassNotFound = errors.New("PriorityClass not found")
+	// ErrPriorityClassValueImmutable indicates that the value of an existing PriorityClass differs
+	ErrPriorityClassValueImmutable = errors.New("PriorityClass value is immutable")
+	// ErrPriorityClassPreemptionPolicyImmutable indicates that the preemption policy of an existing PriorityClass differs
+	ErrPriorityClassPreemptionPolicyImmutable = errors.New("PriorityClass preemption policy is immutable")
+)
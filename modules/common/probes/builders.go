@@ -0,0 +1,133 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probes
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ErrEmptyCommand - the exec probe has no command
+var ErrEmptyCommand = errors.New("exec probe command must not be empty")
+
+// DefaultProbes - the probe timings used by the service operators if the
+// service does not need others. The startup probe allows 5 minutes for the
+// service to come up.
+var DefaultProbes = OverrideSpec{
+	LivenessProbes: &ProbeConf{
+		TimeoutSeconds:   30,
+		PeriodSeconds:    30,
+		FailureThreshold: 3,
+	},
+	ReadinessProbes: &ProbeConf{
+		TimeoutSeconds:   30,
+		PeriodSeconds:    30,
+		FailureThreshold: 3,
+	},
+	StartupProbes: &ProbeConf{
+		TimeoutSeconds:   5,
+		PeriodSeconds:    10,
+		FailureThreshold: 30,
+	},
+}
+
+// Scheme - returns the URI scheme of the HTTP probes of a service with TLS
+// enabled or not
+func Scheme(tlsEnabled bool) *v1.URIScheme {
+	scheme := v1.URISchemeHTTP
+	if tlsEnabled {
+		scheme = v1.URISchemeHTTPS
+	}
+	return &scheme
+}
+
+// CreateHTTPProbeSet - creates the HTTP probes of a service, using HTTPS if
+// tlsEnabled
+//
+// Example:
+//
+//	probes, err := probes.CreateHTTPProbeSet(keystone.KeystonePublicPort, instance.Spec.TLS.API.Enabled(service.EndpointPublic),
+//		instance.Spec.Override.Probes, probes.DefaultProbes)
+func CreateHTTPProbeSet(
+	port int32,
+	tlsEnabled bool,
+	overrides ProbeOverrides,
+	defaults OverrideSpec,
+) (*ProbeSet, error) {
+	return CreateProbeSet(port, Scheme(tlsEnabled), overrides, defaults)
+}
+
+// CreateTCPProbeSet - creates TCP probes checking port is open. The Path of
+// the ProbeConf is ignored.
+func CreateTCPProbeSet(
+	port int32,
+	overrides ProbeOverrides,
+	defaults OverrideSpec,
+) (*ProbeSet, error) {
+	return createProbeSet(overrides, defaults, func(config ProbeConf) (*v1.Probe, error) {
+		return TCPProbe(port, config)
+	})
+}
+
+// CreateExecProbeSet - creates probes running command in the container. The
+// Path of the ProbeConf is ignored.
+func CreateExecProbeSet(
+	command []string,
+	overrides ProbeOverrides,
+	defaults OverrideSpec,
+) (*ProbeSet, error) {
+	return createProbeSet(overrides, defaults, func(config ProbeConf) (*v1.Probe, error) {
+		return ExecProbe(command, config)
+	})
+}
+
+// TCPProbe - returns a probe checking port is open with the timings of config
+func TCPProbe(port int32, config ProbeConf) (*v1.Probe, error) {
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("%w: %d", util.ErrInvalidPort, port)
+	}
+	probe := timings(config)
+	probe.TCPSocket = &v1.TCPSocketAction{
+		Port: intstr.FromInt32(port),
+	}
+	return probe, nil
+}
+
+// ExecProbe - returns a probe running command with the timings of config
+func ExecProbe(command []string, config ProbeConf) (*v1.Probe, error) {
+	if len(command) == 0 {
+		return nil, ErrEmptyCommand
+	}
+	probe := timings(config)
+	probe.Exec = &v1.ExecAction{
+		Command: append([]string{}, command...),
+	}
+	return probe, nil
+}
+
+func timings(config ProbeConf) *v1.Probe {
+	return &v1.Probe{
+		InitialDelaySeconds: config.InitialDelaySeconds,
+		TimeoutSeconds:      config.TimeoutSeconds,
+		PeriodSeconds:       config.PeriodSeconds,
+		FailureThreshold:    config.FailureThreshold,
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probes
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestCreateHTTPProbeSet(t *testing.T) {
+	g := NewWithT(t)
+
+	defaults := DefaultProbes
+	defaults.LivenessProbes = &ProbeConf{Path: "/v3", TimeoutSeconds: 30, PeriodSeconds: 30, FailureThreshold: 3}
+
+	set, err := CreateHTTPProbeSet(5000, true, OverrideSpec{LivenessProbes: &ProbeConf{PeriodSeconds: 60}}, defaults)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(set.Liveness.HTTPGet.Scheme).To(Equal(v1.URISchemeHTTPS))
+	g.Expect(set.Liveness.HTTPGet.Path).To(Equal("/v3"))
+	g.Expect(set.Liveness.PeriodSeconds).To(Equal(int32(60)))
+	g.Expect(set.Liveness.TimeoutSeconds).To(Equal(int32(30)))
+	g.Expect(set.Startup.FailureThreshold).To(Equal(int32(30)))
+	// the defaults are not modified by the overrides
+	g.Expect(defaults.LivenessProbes.PeriodSeconds).To(Equal(int32(30)))
+
+	set, err = CreateHTTPProbeSet(5000, false, OverrideSpec{}, DefaultProbes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(set.Readiness.HTTPGet.Scheme).To(Equal(v1.URISchemeHTTP))
+}
+
+func TestCreateTCPProbeSet(t *testing.T) {
+	g := NewWithT(t)
+
+	set, err := CreateTCPProbeSet(3306, OverrideSpec{ReadinessProbes: &ProbeConf{FailureThreshold: 10}}, DefaultProbes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(set.Liveness.TCPSocket.Port).To(Equal(intstr.FromInt32(3306)))
+	g.Expect(set.Liveness.HTTPGet).To(BeNil())
+	g.Expect(set.Readiness.FailureThreshold).To(Equal(int32(10)))
+
+	_, err = CreateTCPProbeSet(0, OverrideSpec{}, DefaultProbes)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestCreateExecProbeSet(t *testing.T) {
+	g := NewWithT(t)
+
+	command := []string{"/usr/local/bin/container-scripts/healthcheck.sh"}
+	set, err := CreateExecProbeSet(command, OverrideSpec{}, DefaultProbes)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(set.Startup.Exec.Command).To(Equal(command))
+	g.Expect(set.Startup.PeriodSeconds).To(Equal(int32(10)))
+
+	_, err = CreateExecProbeSet(nil, OverrideSpec{}, DefaultProbes)
+	g.Expect(err).To(MatchError(ErrEmptyCommand))
+}
@@ -57,71 +57,48 @@ func CreateProbeSet(
 	overrides ProbeOverrides,
 	defaults OverrideSpec,
 ) (*ProbeSet, error) {
+	return createProbeSet(overrides, defaults, func(config ProbeConf) (*v1.Probe, error) {
+		return SetProbeConf(port, scheme, config)
+	})
+}
 
-	livenessProbe, err := SetProbeConf(
-		port,
-		scheme,
-		func() ProbeConf {
-			if defaults.LivenessProbes == nil {
-				defaults.LivenessProbes = &ProbeConf{}
-			}
-			baseConf := *defaults.LivenessProbes
-			if p := overrides.GetLivenessProbes(); p != nil {
-				baseConf.merge(*p)
-			}
-			return baseConf
-		}(),
-	)
-
-	// Could not process probes config
-	if err != nil {
-		return nil, err
-	}
-
-	readinessProbe, err := SetProbeConf(
-		port,
-		scheme,
-		func() ProbeConf {
-			if defaults.ReadinessProbes == nil {
-				defaults.ReadinessProbes = &ProbeConf{}
-			}
-			baseConf := *defaults.ReadinessProbes
-			if p := overrides.GetReadinessProbes(); p != nil {
-				baseConf.merge(*p)
-			}
-			return baseConf
-		}(),
-	)
-
-	// Could not process probes config
-	if err != nil {
-		return nil, err
-	}
-
-	startupProbe, err := SetProbeConf(
-		port,
-		scheme,
-		func() ProbeConf {
-			if defaults.StartupProbes == nil {
-				defaults.StartupProbes = &ProbeConf{}
-			}
-			baseConf := *defaults.StartupProbes
-			if p := overrides.GetStartupProbes(); p != nil {
-				baseConf.merge(*p)
-			}
-			return baseConf
-		}(),
-	)
-
-	// Could not process probes config
-	if err != nil {
-		return nil, err
+// createProbeSet - creates the liveness, readiness and startup probes with
+// build from the defaults merged with the overrides
+func createProbeSet(
+	overrides ProbeOverrides,
+	defaults OverrideSpec,
+	build func(config ProbeConf) (*v1.Probe, error),
+) (*ProbeSet, error) {
+	confs := []struct {
+		defaults  *ProbeConf
+		overrides *ProbeConf
+	}{
+		{defaults.LivenessProbes, overrides.GetLivenessProbes()},
+		{defaults.ReadinessProbes, overrides.GetReadinessProbes()},
+		{defaults.StartupProbes, overrides.GetStartupProbes()},
+	}
+
+	probes := make([]*v1.Probe, len(confs))
+	for i, c := range confs {
+		baseConf := ProbeConf{}
+		if c.defaults != nil {
+			baseConf = *c.defaults
+		}
+		if c.overrides != nil {
+			baseConf.merge(*c.overrides)
+		}
+		probe, err := build(baseConf)
+		// Could not process probes config
+		if err != nil {
+			return nil, err
+		}
+		probes[i] = probe
 	}
 
 	return &ProbeSet{
-		Liveness:  livenessProbe,
-		Readiness: readinessProbe,
-		Startup:   startupProbe,
+		Liveness:  probes[0],
+		Readiness: probes[1],
+		Startup:   probes[2],
 	}, nil
 }
 
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/storageclass"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExpansionState - state of a PVC size expansion
+type ExpansionState string
+
+const (
+	// ExpansionNotRequired - the PVC already has the requested size
+	ExpansionNotRequired ExpansionState = "NotRequired"
+	// ExpansionRequired - the requested size is bigger than the PVC spec
+	ExpansionRequired ExpansionState = "Required"
+	// ExpansionInProgress - the volume gets resized by the storage backend
+	ExpansionInProgress ExpansionState = "InProgress"
+	// ExpansionFileSystemResizePending - the volume got resized, the file
+	// system resize is pending until a pod mounts the volume
+	ExpansionFileSystemResizePending ExpansionState = "FileSystemResizePending"
+)
+
+// GetExpansionState - returns the expansion state of the pvc for the
+// requested size. An error is returned if the requested size is smaller
+// than the current size of the pvc, as PVCs can not be shrunk.
+func GetExpansionState(
+	pvc *corev1.PersistentVolumeClaim,
+	requested resource.Quantity,
+) (ExpansionState, error) {
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+
+	switch requested.Cmp(current) {
+	case -1:
+		return "", fmt.Errorf("%w: pvc %s requested %s, current %s", ErrPvcShrinkNotSupported, pvc.Name, requested.String(), current.String())
+	case 1:
+		return ExpansionRequired, nil
+	}
+
+	for _, c := range pvc.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case corev1.PersistentVolumeClaimResizing:
+			return ExpansionInProgress, nil
+		case corev1.PersistentVolumeClaimFileSystemResizePending:
+			return ExpansionFileSystemResizePending, nil
+		}
+	}
+
+	// the capacity in the status gets updated when the resize finished
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if ok && capacity.Cmp(requested) < 0 {
+		return ExpansionInProgress, nil
+	}
+
+	return ExpansionNotRequired, nil
+}
+
+// Expand - expands the existing pvc to the size requested in the Pvc
+// spec. It verifies that the StorageClass of the pvc allows expansion,
+// patches the size request and reports the progress in the
+// PVCExpansionReadyCondition until the resize completed. A requeue is
+// requested while the resize is in progress.
+func (p *Pvc) Expand(
+	ctx context.Context,
+	h *helper.Helper,
+	conditions *condition.Conditions,
+) (ctrl.Result, error) {
+	requested, ok := p.pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		err := fmt.Errorf("%w: pvc %s has no storage request", ErrPvcInvalidRequest, p.pvc.Name)
+		conditions.Set(condition.FalseCondition(
+			condition.PVCExpansionReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			condition.PVCExpansionReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	pvc, err := GetPvcWithName(ctx, h, p.pvc.Name, p.pvc.Namespace)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			h.GetLogger().Info("Pvc not found, reconcile later", "object", p.pvc.Name, "requeueAfter", p.timeout)
+			return ctrl.Result{RequeueAfter: p.timeout}, nil
+		}
+		conditions.Set(condition.FalseCondition(
+			condition.PVCExpansionReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.PVCExpansionReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	state, err := GetExpansionState(pvc, requested)
+	if err != nil {
+		conditions.Set(condition.FalseCondition(
+			condition.PVCExpansionReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			condition.PVCExpansionReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	switch state {
+	case ExpansionNotRequired:
+		conditions.MarkTrue(
+			condition.PVCExpansionReadyCondition,
+			condition.PVCExpansionReadyMessage)
+		p.pvc = pvc
+		return ctrl.Result{}, nil
+	case ExpansionInProgress, ExpansionFileSystemResizePending:
+		h.GetLogger().Info("Pvc expansion in progress, reconcile later", "object", pvc.Name, "state", state, "requeueAfter", p.timeout)
+		conditions.Set(condition.FalseCondition(
+			condition.PVCExpansionReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.PVCExpansionReadyRunningMessage,
+			state))
+		p.pvc = pvc
+		return ctrl.Result{RequeueAfter: p.timeout}, nil
+	}
+
+	err = verifyExpansionAllowed(ctx, h, pvc)
+	if err != nil {
+		conditions.Set(condition.FalseCondition(
+			condition.PVCExpansionReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			condition.PVCExpansionReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	patch := client.MergeFrom(pvc.DeepCopy())
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = requested
+	err = h.GetClient().Patch(ctx, pvc, patch)
+	if err != nil {
+		conditions.Set(condition.FalseCondition(
+			condition.PVCExpansionReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.PVCExpansionReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	h.GetLogger().Info("Pvc expansion requested", "object", pvc.Name, "size", requested.String())
+
+	conditions.Set(condition.FalseCondition(
+		condition.PVCExpansionReadyCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		condition.PVCExpansionReadyRunningMessage,
+		ExpansionRequired))
+	p.pvc = pvc
+
+	return ctrl.Result{RequeueAfter: p.timeout}, nil
+}
+
+// verifyExpansionAllowed - returns an error if the StorageClass of the pvc
+// does not allow volume expansion
+func verifyExpansionAllowed(
+	ctx context.Context,
+	h *helper.Helper,
+	pvc *corev1.PersistentVolumeClaim,
+) error {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return fmt.Errorf("%w: pvc %s has no StorageClass", ErrPvcExpansionNotAllowed, pvc.Name)
+	}
+
+	sc, err := storageclass.GetStorageClass(ctx, h, *pvc.Spec.StorageClassName)
+	if err != nil {
+		return err
+	}
+
+	err = storageclass.Validate(sc, storageclass.Requirements{AllowVolumeExpansion: true})
+	if err != nil {
+		return fmt.Errorf("%w: pvc %s: %w", ErrPvcExpansionNotAllowed, pvc.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pvc
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getPvc(size string, capacity string, conditions ...corev1.PersistentVolumeClaimConditionType) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(size),
+				},
+			},
+		},
+	}
+	if capacity != "" {
+		pvc.Status.Capacity = corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse(capacity),
+		}
+	}
+	for _, c := range conditions {
+		pvc.Status.Conditions = append(pvc.Status.Conditions, corev1.PersistentVolumeClaimCondition{
+			Type:   c,
+			Status: corev1.ConditionTrue,
+		})
+	}
+
+	return pvc
+}
+
+func TestGetExpansionState(t *testing.T) {
+	tests := []struct {
+		name      string
+		pvc       *corev1.PersistentVolumeClaim
+		requested string
+		want      ExpansionState
+		wantErr   bool
+	}{
+		{
+			name:      "size unchanged",
+			pvc:       getPvc("10G", "10G"),
+			requested: "10G",
+			want:      ExpansionNotRequired,
+		},
+		{
+			name:      "size increased",
+			pvc:       getPvc("10G", "10G"),
+			requested: "20G",
+			want:      ExpansionRequired,
+		},
+		{
+			name:      "size decreased",
+			pvc:       getPvc("10G", "10G"),
+			requested: "5G",
+			wantErr:   true,
+		},
+		{
+			name:      "volume resizing",
+			pvc:       getPvc("20G", "10G", corev1.PersistentVolumeClaimResizing),
+			requested: "20G",
+			want:      ExpansionInProgress,
+		},
+		{
+			name:      "file system resize pending",
+			pvc:       getPvc("20G", "10G", corev1.PersistentVolumeClaimFileSystemResizePending),
+			requested: "20G",
+			want:      ExpansionFileSystemResizePending,
+		},
+		{
+			name:      "capacity not yet updated",
+			pvc:       getPvc("20G", "10G"),
+			requested: "20G",
+			want:      ExpansionInProgress,
+		},
+		{
+			name:      "pvc not yet bound",
+			pvc:       getPvc("20G", ""),
+			requested: "20G",
+			want:      ExpansionNotRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			state, err := GetExpansionState(tt.pvc, resource.MustParse(tt.requested))
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(ErrPvcShrinkNotSupported))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(state).To(Equal(tt.want))
+		})
+	}
+}
@@ -19,10 +19,10 @@ package pvc
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
@@ -62,14 +62,20 @@ func (p *Pvc) CreateOrPatch(
 		// For now, we don't support changes to existing PVC specs for the
 		// following fields.  Technically it is possible to change the size
 		// request, but this requires dynamic provisioning and a storage
-		// class that supports such a thing.
+		// class that supports such a thing. Use Expand() for this.
+		// The data source and volume mode are immutable, they are set on
+		// create, e.g. to restore a snapshot, see
+		// volumesnapshot.GetRestorePvc.
 		if pvc.CreationTimestamp.IsZero() {
 			pvc.Spec.Resources.Requests = p.pvc.Spec.Resources.Requests
 			pvc.Spec.StorageClassName = p.pvc.Spec.StorageClassName
 			pvc.Spec.AccessModes = p.pvc.Spec.AccessModes
+			pvc.Spec.DataSource = p.pvc.Spec.DataSource
+			pvc.Spec.DataSourceRef = p.pvc.Spec.DataSourceRef
+			pvc.Spec.VolumeMode = p.pvc.Spec.VolumeMode
 		}
 
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), pvc, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), pvc, h.GetScheme())
 
 		if err != nil {
 			return err
@@ -80,14 +86,14 @@ func (p *Pvc) CreateOrPatch(
 
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Pvc %s not found, reconcile in %s", pvc.Name, p.timeout))
+			h.GetLogger().Info("Pvc not found, reconcile later", "object", pvc.Name, "requeueAfter", p.timeout)
 			return ctrl.Result{RequeueAfter: p.timeout}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("Pvc %s - %s", pvc.Name, op))
+		h.GetLogger().Info("Pvc reconciled", "object", pvc.Name, "operation", op)
 	}
 
 	// update the pvc object of the pvc type
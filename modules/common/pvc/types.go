@@ -17,6 +17,7 @@ limitations under the License.
 package pvc
 
 import (
+	"errors"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -27,3 +28,13 @@ type Pvc struct {
 	pvc     *corev1.PersistentVolumeClaim
 	timeout time.Duration
 }
+
+// Define static errors
+var (
+	// ErrPvcShrinkNotSupported indicates that the requested size is smaller than the current one
+	ErrPvcShrinkNotSupported = errors.New("pvc shrink is not supported")
+	// ErrPvcExpansionNotAllowed indicates that the StorageClass does not allow volume expansion
+	ErrPvcExpansionNotAllowed = errors.New("pvc expansion not allowed")
+	// ErrPvcInvalidRequest indicates that the pvc has an invalid resource request
+	ErrPvcInvalidRequest = errors.New("invalid pvc request")
+)
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota provides a check of the ResourceQuota headroom of a
+// namespace before workloads get created in it
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Request - the resources a workload is about to create, e.g. the
+// additional pods of a scale up
+type Request struct {
+	// Pods - number of pods
+	Pods int64
+	// Services - number of services
+	Services int64
+	// PersistentVolumeClaims - number of pvcs
+	PersistentVolumeClaims int64
+	// Storage - sum of the storage requests of the pvcs
+	Storage resource.Quantity
+}
+
+// ResourceList - returns the request as quota resource list
+func (r Request) ResourceList() corev1.ResourceList {
+	l := corev1.ResourceList{}
+	if r.Pods > 0 {
+		l[corev1.ResourcePods] = *resource.NewQuantity(r.Pods, resource.DecimalSI)
+	}
+	if r.Services > 0 {
+		l[corev1.ResourceServices] = *resource.NewQuantity(r.Services, resource.DecimalSI)
+	}
+	if r.PersistentVolumeClaims > 0 {
+		l[corev1.ResourcePersistentVolumeClaims] = *resource.NewQuantity(r.PersistentVolumeClaims, resource.DecimalSI)
+	}
+	if !r.Storage.IsZero() {
+		l[corev1.ResourceRequestsStorage] = r.Storage
+	}
+	return l
+}
+
+// Check - checks the request against the headroom of all ResourceQuotas
+// in the namespace. Returns true if it fits, otherwise a message listing
+// the quotas it would exceed.
+//
+// NOTE: quotas with Scopes or a ScopeSelector are skipped, as whether they
+// apply depends on the pod specs, e.g. their priority class, which the
+// request does not carry. The quota admission still enforces them.
+func Check(ctx context.Context, h *helper.Helper, namespace string, request Request) (bool, string, error) {
+	quotas := &corev1.ResourceQuotaList{}
+	err := h.GetClient().List(ctx, quotas, client.InNamespace(namespace))
+	if err != nil {
+		return false, "", err
+	}
+
+	requested := request.ResourceList()
+	exceeded := []string{}
+	for _, q := range quotas.Items {
+		if len(q.Spec.Scopes) > 0 || q.Spec.ScopeSelector != nil {
+			continue
+		}
+		for _, name := range sortedNames(requested) {
+			hard, ok := q.Status.Hard[name]
+			if !ok {
+				continue
+			}
+			used := q.Status.Used[name]
+			total := used.DeepCopy()
+			total.Add(requested[name])
+			if total.Cmp(hard) > 0 {
+				req := requested[name]
+				exceeded = append(exceeded, fmt.Sprintf("%s %s: %s of %s used, %s requested",
+					q.Name, name, used.String(), hard.String(), req.String()))
+			}
+		}
+	}
+
+	if len(exceeded) > 0 {
+		return false, "quota exceeded: " + strings.Join(exceeded, ", "), nil
+	}
+	return true, "", nil
+}
+
+func sortedNames(l corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheck(t *testing.T) {
+	g := NewWithT(t)
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "compute",
+			Namespace: "openstack",
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods:            resource.MustParse("10"),
+				corev1.ResourceRequestsStorage: resource.MustParse("100Gi"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourcePods:            resource.MustParse("8"),
+				corev1.ResourceRequestsStorage: resource.MustParse("90Gi"),
+			},
+		},
+	}
+	// scoped quotas are skipped
+	scoped := quota.DeepCopy()
+	scoped.Name = "high-priority"
+	scoped.Spec.ScopeSelector = &corev1.ScopeSelector{MatchExpressions: []corev1.ScopedResourceSelectorRequirement{{
+		ScopeName: corev1.ResourceQuotaScopePriorityClass,
+		Operator:  corev1.ScopeSelectorOpIn,
+		Values:    []string{"high"},
+	}}}
+	scoped.Status.Used[corev1.ResourcePods] = resource.MustParse("10")
+	terminating := scoped.DeepCopy()
+	terminating.Name = "terminating"
+	terminating.Spec.ScopeSelector = nil
+	terminating.Spec.Scopes = []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeTerminating}
+	owner := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openstack"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(quota, scoped, terminating).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ok, _, err := Check(context.TODO(), h, "openstack", Request{Pods: 2, Services: 5, Storage: resource.MustParse("10Gi")})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	ok, message, err := Check(context.TODO(), h, "openstack", Request{Pods: 3, Storage: resource.MustParse("20Gi")})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+	g.Expect(message).To(Equal("quota exceeded: compute pods: 8 of 10 used, 3 requested, " +
+		"compute requests.storage: 90Gi of 100Gi used, 20Gi requested"))
+
+	// no quota in the namespace
+	ok, _, err = Check(context.TODO(), h, "other", Request{Pods: 100})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+}
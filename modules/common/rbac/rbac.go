@@ -28,19 +28,30 @@ type Reconciler interface {
 	RbacResourceName() string
 }
 
+// AutomountReconciler - optional interface of a Reconciler controlling the
+// automount of the token of its serviceaccount
+type AutomountReconciler interface {
+	Reconciler
+
+	// RbacAutomountServiceAccountToken - return if the token of the
+	// serviceaccount gets mounted into the pods, the cluster default if nil
+	RbacAutomountServiceAccountToken() *bool
+}
+
 // ReconcileRbac - configures the serviceaccount, role, and role binding for the Reconciler instance
 func ReconcileRbac(ctx context.Context, h *helper.Helper, instance Reconciler, rules []rbacv1.PolicyRule) (ctrl.Result, error) {
 
 	// ServiceAccount
-	sa := common_serviceaccount.NewServiceAccount(
-		&corev1.ServiceAccount{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      instance.RbacResourceName(),
-				Namespace: instance.RbacNamespace(),
-			},
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.RbacResourceName(),
+			Namespace: instance.RbacNamespace(),
 		},
-		time.Duration(10),
-	)
+	}
+	if a, ok := instance.(AutomountReconciler); ok {
+		serviceAccount.AutomountServiceAccountToken = a.RbacAutomountServiceAccountToken()
+	}
+	sa := common_serviceaccount.NewServiceAccount(serviceAccount, time.Duration(10))
 	saResult, err := sa.CreateOrPatch(ctx, h)
 	if err != nil {
 		instance.RbacConditionsSet(condition.FalseCondition(
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reconcileerrors provides typed errors for the control flow of a reconcile
+// and a handler which converts them into a ctrl.Result and a condition
+package reconcileerrors
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/requeue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	// ErrDependencyNotReady indicates that a dependency of the reconcile,
+	// e.g. a Secret or another CR, is not ready yet
+	ErrDependencyNotReady = errors.New("dependency not ready")
+	// ErrInvalidSpec indicates that the spec of the CR is invalid and a
+	// retry is useless until it gets changed
+	ErrInvalidSpec = errors.New("invalid spec")
+	// ErrExternal indicates that a call to an external system, e.g. the
+	// API server or an OpenStack service, failed
+	ErrExternal = errors.New("external error")
+)
+
+// ErrRequeue - requests a requeue of the reconcile after the given interval,
+// e.g. while waiting for a job to finish
+type ErrRequeue struct {
+	// After - interval to requeue after
+	After time.Duration
+	// Message - describes what is waited for
+	Message string
+}
+
+// Error - returns the message of the requeue request
+func (e *ErrRequeue) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("requeue after %s", e.After)
+	}
+	return fmt.Sprintf("%s, requeue after %s", e.Message, e.After)
+}
+
+// Requeue - returns an ErrRequeue for the given interval
+func Requeue(after time.Duration, format string, a ...interface{}) error {
+	return &ErrRequeue{After: after, Message: fmt.Sprintf(format, a...)}
+}
+
+// DependencyNotReady - returns an error wrapping ErrDependencyNotReady
+func DependencyNotReady(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrDependencyNotReady, fmt.Sprintf(format, a...))
+}
+
+// InvalidSpec - returns an error wrapping ErrInvalidSpec
+func InvalidSpec(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSpec, fmt.Sprintf(format, a...))
+}
+
+// External - returns an error wrapping ErrExternal and err
+func External(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrExternal, err)
+}
+
+// Handle - converts err into the ctrl.Result and error to return from the
+// reconcile and sets conditionType accordingly
+// - nil: an empty ctrl.Result is returned, the condition is not changed
+// - ErrRequeue: False with RequestedReason, a requeue after its interval
+// is returned
+// - ErrDependencyNotReady: False with RequestedReason, a requeue after
+// requeueTimeout is returned
+// - ErrInvalidSpec: False with ErrorReason and SeverityError, err is
+// returned as terminal error to not retry before the spec got changed
+// - object.ErrAdmissionDenied: False with AdmissionDeniedReason and
+// SeverityWarning, a requeue after requeueTimeout is returned as the quota
+// or policy may change without the CR changing
+// - requeue.ErrMaxAttemptsExceeded: False with ErrorReason and
+// SeverityError, err is returned to retry with backoff
+// - ErrExternal and any other error: False with ErrorReason and
+// SeverityWarning, err is returned to retry with backoff
+//
+// Example:
+//
+//	if err := r.reconcileDeployment(ctx, instance, h); err != nil {
+//		return reconcileerrors.Handle(err, &instance.Status.Conditions, condition.DeploymentReadyCondition, time.Second*10)
+//	}
+func Handle(
+	err error,
+	conditions *condition.Conditions,
+	conditionType condition.Type,
+	requeueTimeout time.Duration,
+) (ctrl.Result, error) {
+	if err == nil {
+		return ctrl.Result{}, nil
+	}
+
+	var requeueErr *ErrRequeue
+	switch {
+	case errors.As(err, &requeueErr):
+		conditions.MarkFalse(conditionType, condition.RequestedReason, condition.SeverityInfo, "%s", err.Error())
+		return ctrl.Result{RequeueAfter: requeueErr.After}, nil
+	case errors.Is(err, ErrDependencyNotReady):
+		conditions.MarkFalse(conditionType, condition.RequestedReason, condition.SeverityInfo, "%s", err.Error())
+		return ctrl.Result{RequeueAfter: requeueTimeout}, nil
+	case errors.Is(err, ErrInvalidSpec):
+		conditions.MarkFalse(conditionType, condition.ErrorReason, condition.SeverityError, "%s", err.Error())
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	case errors.Is(err, object.ErrAdmissionDenied):
+		conditions.MarkFalse(conditionType, condition.AdmissionDeniedReason, condition.SeverityWarning, "%s", err.Error())
+		return ctrl.Result{RequeueAfter: requeueTimeout}, nil
+	case errors.Is(err, requeue.ErrMaxAttemptsExceeded):
+		conditions.MarkFalse(conditionType, condition.ErrorReason, condition.SeverityError, "%s", err.Error())
+		return ctrl.Result{}, err
+	default:
+		conditions.MarkFalse(conditionType, condition.ErrorReason, condition.SeverityWarning, "%s", err.Error())
+		return ctrl.Result{}, err
+	}
+}
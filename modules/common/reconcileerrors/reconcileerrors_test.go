@@ -0,0 +1,154 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcileerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/requeue"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	errConnection = errors.New("connection refused")
+	errQuota      = errors.New("exceeded quota: compute-resources")
+)
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantResult ctrl.Result
+		wantErr    error
+		terminal   bool
+		reason     condition.Reason
+		severity   condition.Severity
+		message    string
+	}{
+		{
+			name:       "Requeue",
+			err:        Requeue(5*time.Second, "waiting for job %s", "db-sync"),
+			wantResult: ctrl.Result{RequeueAfter: 5 * time.Second},
+			reason:     condition.RequestedReason,
+			severity:   condition.SeverityInfo,
+			message:    "waiting for job db-sync, requeue after 5s",
+		},
+		{
+			name:       "Wrapped requeue",
+			err:        fmt.Errorf("deployment: %w", &ErrRequeue{After: time.Second}),
+			wantResult: ctrl.Result{RequeueAfter: time.Second},
+			reason:     condition.RequestedReason,
+			severity:   condition.SeverityInfo,
+			message:    "deployment: requeue after 1s",
+		},
+		{
+			name:       "Dependency not ready",
+			err:        DependencyNotReady("memcached %s", "memcached"),
+			wantResult: ctrl.Result{RequeueAfter: 10 * time.Second},
+			reason:     condition.RequestedReason,
+			severity:   condition.SeverityInfo,
+			message:    "dependency not ready: memcached memcached",
+		},
+		{
+			name:     "Invalid spec",
+			err:      InvalidSpec("replicas must not be negative"),
+			wantErr:  ErrInvalidSpec,
+			terminal: true,
+			reason:   condition.ErrorReason,
+			severity: condition.SeverityError,
+			message:  "invalid spec: replicas must not be negative",
+		},
+		{
+			name: "Admission denied",
+			err: &object.AdmissionError{
+				Object: "Deployment/keystone",
+				Reason: object.AdmissionReasonQuota,
+				Err:    errQuota,
+			},
+			wantResult: ctrl.Result{RequeueAfter: 10 * time.Second},
+			reason:     condition.AdmissionDeniedReason,
+			severity:   condition.SeverityWarning,
+			message:    "admission denied: Deployment/keystone QuotaExceeded: exceeded quota: compute-resources",
+		},
+		{
+			name:     "Max attempts exceeded",
+			err:      fmt.Errorf("%w: Job/openstack/db-sync after 3 attempts", requeue.ErrMaxAttemptsExceeded),
+			wantErr:  requeue.ErrMaxAttemptsExceeded,
+			reason:   condition.ErrorReason,
+			severity: condition.SeverityError,
+			message:  "max requeue attempts exceeded: Job/openstack/db-sync after 3 attempts",
+		},
+		{
+			name:     "External",
+			err:      External(errConnection),
+			wantErr:  errConnection,
+			reason:   condition.ErrorReason,
+			severity: condition.SeverityWarning,
+			message:  "external error: connection refused",
+		},
+		{
+			name:     "Other",
+			err:      errConnection,
+			wantErr:  errConnection,
+			reason:   condition.ErrorReason,
+			severity: condition.SeverityWarning,
+			message:  "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			conditions := condition.Conditions{}
+
+			result, err := Handle(tt.err, &conditions, condition.DeploymentReadyCondition, 10*time.Second)
+			g.Expect(result).To(Equal(tt.wantResult))
+			if tt.wantErr == nil {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				g.Expect(errors.Is(err, reconcile.TerminalError(nil))).To(Equal(tt.terminal))
+			}
+
+			c := conditions.Get(condition.DeploymentReadyCondition)
+			g.Expect(c).ToNot(BeNil())
+			g.Expect(c.Status).To(Equal(corev1.ConditionFalse))
+			g.Expect(c.Reason).To(Equal(tt.reason))
+			g.Expect(c.Severity).To(Equal(tt.severity))
+			g.Expect(c.Message).To(Equal(tt.message))
+		})
+	}
+}
+
+func TestHandleNil(t *testing.T) {
+	g := NewWithT(t)
+	conditions := condition.Conditions{}
+
+	result, err := Handle(nil, &conditions, condition.DeploymentReadyCondition, 10*time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(conditions.Has(condition.DeploymentReadyCondition)).To(BeFalse())
+	g.Expect(External(nil)).ToNot(HaveOccurred())
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render provides a dry-run mode for the lib-common modules, which
+// returns the rendered objects and their diff against the live cluster
+// state instead of applying them
+package render
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Operation - the write a module would have done
+type Operation string
+
+const (
+	// OperationCreate - the object does not exist and would be created
+	OperationCreate Operation = "Create"
+	// OperationUpdate - the object exists and would be updated or patched
+	OperationUpdate Operation = "Update"
+	// OperationDelete - the object would be deleted
+	OperationDelete Operation = "Delete"
+)
+
+// Change - a write of a module captured by the render Client
+type Change struct {
+	// Operation - the write which would have been done
+	Operation Operation
+	// GroupVersionKind - kind of the object
+	GroupVersionKind schema.GroupVersionKind
+	// Object - the rendered object as returned by the dry-run of the write
+	Object client.Object
+	// Live - the object in the cluster before the write, nil on create
+	Live client.Object
+}
+
+// objectKey - identifies a rendered object
+type objectKey struct {
+	gvk schema.GroupVersionKind
+	key client.ObjectKey
+}
+
+// Client - client which runs all writes as server side dry-run and records
+// them as Changes. Reads of objects which got written return the rendered
+// object, so that the modules behave as if the write happened. Lists are
+// not overlayed.
+type Client struct {
+	client.Client
+	dryRun   client.Client
+	changes  []Change
+	rendered map[objectKey]client.Object
+}
+
+// NewClient - returns a render Client reading from c
+func NewClient(c client.Client) *Client {
+	return &Client{
+		Client:   c,
+		dryRun:   client.NewDryRunClient(c),
+		changes:  []Change{},
+		rendered: map[objectKey]client.Object{},
+	}
+}
+
+// NewHelper - returns a copy of the helper which uses a render Client, and
+// the render Client. The modules called with the returned helper only
+// render their objects. Writes via the kclient of the helper are not
+// captured and must not be used in render mode.
+func NewHelper(h *helper.Helper) (*helper.Helper, *Client, error) {
+	c := NewClient(h.GetClient())
+	rh, err := helper.NewHelper(h.GetBeforeObject(), c, h.GetKClient(), h.GetScheme(), h.GetLogger())
+	if err != nil {
+		return nil, nil, err
+	}
+	rh.SetPropagatedMetadata(h.GetPropagatedLabels(), h.GetPropagatedAnnotations())
+	rh.SetClock(h.GetClock())
+
+	return rh, c, nil
+}
+
+// GetChanges - returns the recorded changes in the order of the writes
+func (c *Client) GetChanges() []Change {
+	return append([]Change{}, c.changes...)
+}
+
+// GetObjects - returns the rendered objects in the order of the writes,
+// deleted objects are not included
+func (c *Client) GetObjects() []client.Object {
+	objs := []client.Object{}
+	for _, change := range c.changes {
+		if change.Operation != OperationDelete {
+			objs = append(objs, change.Object)
+		}
+	}
+	return objs
+}
+
+// Get - returns the rendered object if it got written before, else the
+// object from the underlying client
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	if rendered, ok := c.rendered[objectKey{gvk, key}]; ok {
+		if rendered == nil {
+			return k8s_errors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, key.Name)
+		}
+		return copyInto(rendered, obj)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+// copyInto - copies the rendered object into obj, converting between typed
+// and unstructured objects
+func copyInto(rendered client.Object, obj client.Object) error {
+	src := reflect.ValueOf(rendered.DeepCopyObject())
+	dst := reflect.ValueOf(obj)
+	if src.Type() == dst.Type() {
+		dst.Elem().Set(src.Elem())
+		return nil
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(rendered)
+	if err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u, obj)
+}
+
+// Create - dry-runs the create and records it
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.dryRun.Create(ctx, obj, opts...)
+	if err != nil {
+		return err
+	}
+	return c.record(ctx, OperationCreate, obj)
+}
+
+// Update - dry-runs the update and records it. An object which got created
+// by the render Client before does not exist, its update gets dry-run as
+// create.
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	live, err := c.getLive(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if live == nil && c.isRendered(obj) {
+		return c.createRendered(ctx, obj)
+	}
+	err = c.dryRun.Update(ctx, obj, opts...)
+	if err != nil {
+		return err
+	}
+	return c.recordWithLive(OperationUpdate, obj, live)
+}
+
+// Patch - dry-runs the patch and records it. An object which got created by
+// the render Client before does not exist, the patched object gets dry-run
+// created instead.
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	live, err := c.getLive(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if live == nil && c.isRendered(obj) {
+		return c.createRendered(ctx, obj)
+	}
+	err = c.dryRun.Patch(ctx, obj, patch, opts...)
+	if err != nil {
+		return err
+	}
+	return c.recordWithLive(OperationUpdate, obj, live)
+}
+
+// Delete - dry-runs the delete and records it
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	live, err := c.getLive(ctx, obj)
+	if err != nil {
+		return err
+	}
+	err = c.dryRun.Delete(ctx, obj, opts...)
+	if err != nil {
+		return err
+	}
+	return c.recordWithLive(OperationDelete, obj, live)
+}
+
+// DeleteAllOf - dry-runs the delete, it is not recorded
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.dryRun.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status - returns a dry-run status writer, status writes are not recorded
+func (c *Client) Status() client.SubResourceWriter {
+	return c.dryRun.Status()
+}
+
+// SubResource - returns a dry-run sub resource client, sub resource writes
+// are not recorded
+func (c *Client) SubResource(subResource string) client.SubResourceClient {
+	return c.dryRun.SubResource(subResource)
+}
+
+// getLive - returns the live object, nil if it does not exist
+func (c *Client) getLive(ctx context.Context, obj client.Object) (client.Object, error) {
+	live := obj.DeepCopyObject().(client.Object)
+	err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	if k8s_errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+// isRendered - returns true if obj got written, and not deleted, by the
+// render Client before
+func (c *Client) isRendered(obj client.Object) bool {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return false
+	}
+	return c.rendered[objectKey{gvk, client.ObjectKeyFromObject(obj)}] != nil
+}
+
+// createRendered - dry-runs the create of obj, which got created by the
+// render Client before, and records it
+func (c *Client) createRendered(ctx context.Context, obj client.Object) error {
+	// the rendered object was read back with the resourceVersion returned
+	// by the dry-run, which a create must not set
+	obj.SetResourceVersion("")
+	err := c.dryRun.Create(ctx, obj)
+	if err != nil {
+		return err
+	}
+	return c.recordWithLive(OperationCreate, obj, nil)
+}
+
+// record - records the write of obj with the current live object
+func (c *Client) record(ctx context.Context, op Operation, obj client.Object) error {
+	live, err := c.getLive(ctx, obj)
+	if err != nil {
+		return err
+	}
+	return c.recordWithLive(op, obj, live)
+}
+
+// recordWithLive - records the write of obj
+func (c *Client) recordWithLive(op Operation, obj client.Object, live client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	if live == nil && op == OperationUpdate {
+		op = OperationCreate
+	}
+
+	rendered := obj.DeepCopyObject().(client.Object)
+	c.changes = append(c.changes, Change{
+		Operation:        op,
+		GroupVersionKind: gvk,
+		Object:           rendered,
+		Live:             live,
+	})
+
+	key := objectKey{gvk, client.ObjectKeyFromObject(obj)}
+	c.rendered[key] = rendered
+	if op == OperationDelete {
+		c.rendered[key] = nil
+	}
+
+	return nil
+}
+
+// Diff - returns a human readable diff between the live and the rendered
+// object of the change, empty if there is no difference. Server managed
+// metadata and the status are ignored.
+func (ch Change) Diff() (string, error) {
+	var live, rendered map[string]interface{}
+	var err error
+
+	if ch.Live != nil {
+		live, err = toComparable(ch.Live)
+		if err != nil {
+			return "", err
+		}
+	}
+	if ch.Operation != OperationDelete {
+		rendered, err = toComparable(ch.Object)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return cmp.Diff(live, rendered), nil
+}
+
+// String - returns a short description of the change
+func (ch Change) String() string {
+	return fmt.Sprintf("%s %s %s/%s", ch.Operation, ch.GroupVersionKind.Kind, ch.Object.GetNamespace(), ch.Object.GetName())
+}
+
+// toComparable - returns the unstructured content of obj without the
+// fields managed by the API server
+func toComparable(obj client.Object) (map[string]interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	delete(u, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "generation", "creationTimestamp", "uid"} {
+		unstructured.RemoveNestedField(u, "metadata", field)
+	}
+	return u, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/deployment"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func getDeployment(name string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openstack",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"service": name}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: name, Image: name + ":latest"}},
+				},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	cr := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "openstack",
+			Namespace: "openstack",
+			UID:       "1234",
+		},
+	}
+	live := getDeployment("keystone", 1)
+	// the fake client does not run dry-run writes, like the API server a
+	// dry-run patch of an object which does not exist fails
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(live).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))
+				if err != nil {
+					return err
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+	h, err := helper.NewHelper(cr, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	rh, c, err := NewHelper(h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// update of an existing object
+	_, err = deployment.NewDeployment(getDeployment("keystone", 3), time.Second).CreateOrPatch(ctx, rh)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// create of a new object, the module reads it back after the write
+	placement := deployment.NewDeployment(getDeployment("placement", 1), time.Second)
+	_, err = placement.CreateOrPatch(ctx, rh)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(*placement.GetDeployment().Spec.Replicas).To(Equal(int32(1)))
+
+	changes := c.GetChanges()
+	g.Expect(changes).To(HaveLen(2))
+	g.Expect(changes[0].Operation).To(Equal(OperationUpdate))
+	g.Expect(changes[0].String()).To(Equal("Update Deployment openstack/keystone"))
+	diff, err := changes[0].Diff()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(diff).To(ContainSubstring("replicas"))
+	g.Expect(diff).To(ContainSubstring("ownerReferences"))
+
+	g.Expect(changes[1].Operation).To(Equal(OperationCreate))
+	g.Expect(changes[1].Live).To(BeNil())
+	g.Expect(c.GetObjects()).To(HaveLen(2))
+
+	// a patch of the created object, e.g. by the next CreateOrPatch, is
+	// dry-run as create as it does not exist
+	placement = deployment.NewDeployment(getDeployment("placement", 2), time.Second)
+	_, err = placement.CreateOrPatch(ctx, rh)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(*placement.GetDeployment().Spec.Replicas).To(Equal(int32(2)))
+	changes = c.GetChanges()
+	g.Expect(changes).To(HaveLen(3))
+	g.Expect(changes[2].Operation).To(Equal(OperationCreate))
+	g.Expect(changes[2].Live).To(BeNil())
+
+	// nothing got applied
+	current := &appsv1.Deployment{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(live), current)).To(Succeed())
+	g.Expect(*current.Spec.Replicas).To(Equal(int32(1)))
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: "placement", Namespace: "openstack"}, current)
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+
+	// a delete hides the object from reads of the render client
+	g.Expect(c.Delete(ctx, getDeployment("keystone", 1))).To(Succeed())
+	err = c.Get(ctx, client.ObjectKeyFromObject(live), current)
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+	g.Expect(c.GetChanges()[3].Operation).To(Equal(OperationDelete))
+	g.Expect(c.GetObjects()).To(HaveLen(3))
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(live), current)).To(Succeed())
+}
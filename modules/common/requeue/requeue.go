@@ -0,0 +1,180 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package requeue provides a requeue policy shared by the modules, to tune
+// the requeue intervals of an operator from one place
+package requeue
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// ErrMaxAttemptsExceeded indicates that the requeues of an object exceeded
+// the max attempts of the policy
+var ErrMaxAttemptsExceeded = errors.New("max requeue attempts exceeded")
+
+// Class - the reason of a requeue
+type Class string
+
+const (
+	// ClassNotFound - the object was not found right after create or patch
+	ClassNotFound Class = "NotFound"
+	// ClassWaiting - waiting for the object to get ready, e.g. a job to
+	// complete or a deployment to roll out
+	ClassWaiting Class = "Waiting"
+	// ClassDependencyNotReady - waiting for a dependency, e.g. a Secret
+	ClassDependencyNotReady Class = "DependencyNotReady"
+)
+
+// evictAfterIntervals - number of the longest requeue intervals after which
+// the attempts of an object which did not get requeued again are evicted
+const evictAfterIntervals = 10
+
+// attempts - the requeues counted for an object
+type attempts struct {
+	count int
+	last  time.Time
+}
+
+// Policy - the requeue intervals per class and the max attempts of the
+// requeues of an object. A Policy is safe for concurrent use, the same
+// Policy is meant to be passed to all modules of an operator.
+type Policy struct {
+	mu          sync.Mutex
+	clock       clock.PassiveClock
+	interval    time.Duration
+	overrides   map[Class]time.Duration
+	maxAttempts int
+	attempts    map[string]*attempts
+}
+
+// NewPolicy - returns a Policy which requeues after interval for all classes
+// and without max attempts
+func NewPolicy(interval time.Duration) *Policy {
+	return &Policy{
+		clock:     clock.RealClock{},
+		interval:  interval,
+		overrides: map[Class]time.Duration{},
+		attempts:  map[string]*attempts{},
+	}
+}
+
+// WithOverride - sets the interval to requeue after for class
+func (p *Policy) WithOverride(class Class, interval time.Duration) *Policy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.overrides[class] = interval
+	return p
+}
+
+// WithMaxAttempts - sets the max number of successive requeues of an object
+// before Next returns ErrMaxAttemptsExceeded. Zero means no limit.
+func (p *Policy) WithMaxAttempts(maxAttempts int) *Policy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.maxAttempts = maxAttempts
+	return p
+}
+
+// WithClock - sets the clock of the policy, e.g. a fake clock in tests
+func (p *Policy) WithClock(c clock.PassiveClock) *Policy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clock = c
+	return p
+}
+
+// After - returns the interval to requeue after for class
+func (p *Policy) After(class Class) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.after(class)
+}
+
+func (p *Policy) after(class Class) time.Duration {
+	if interval, ok := p.overrides[class]; ok {
+		return interval
+	}
+	return p.interval
+}
+
+// Next - counts a requeue of the object identified by key and returns the
+// interval to requeue after for class. Once the requeues exceed the max
+// attempts an error wrapping ErrMaxAttemptsExceeded is returned, which
+// reconcileerrors.Handle reports as failed condition. Reset must be called
+// once the object got ready. The attempts of objects which did not get
+// requeued for ten of the longest requeue intervals, e.g. as they got
+// deleted, are evicted.
+func (p *Policy) Next(key string, class Class) (time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.maxAttempts > 0 {
+		now := p.clock.Now()
+		p.evict(now)
+
+		a, ok := p.attempts[key]
+		if !ok {
+			a = &attempts{}
+			p.attempts[key] = a
+		}
+		a.count++
+		a.last = now
+
+		if a.count > p.maxAttempts {
+			return 0, fmt.Errorf("%w: %s after %d attempts", ErrMaxAttemptsExceeded, key, p.maxAttempts)
+		}
+	}
+
+	return p.after(class), nil
+}
+
+// evict - removes the attempts of the objects last requeued before
+// evictAfterIntervals of the longest interval
+func (p *Policy) evict(now time.Time) {
+	longest := p.interval
+	for _, interval := range p.overrides {
+		longest = max(longest, interval)
+	}
+	for key, a := range p.attempts {
+		if now.Sub(a.last) > evictAfterIntervals*longest {
+			delete(p.attempts, key)
+		}
+	}
+}
+
+// Reset - resets the requeue attempts of the object identified by key
+func (p *Policy) Reset(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.attempts, key)
+}
+
+// Attempts - returns the number of requeues counted for the object
+// identified by key
+func (p *Policy) Attempts(key string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if a, ok := p.attempts[key]; ok {
+		return a.count
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requeue
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	p := NewPolicy(10*time.Second).
+		WithOverride(ClassNotFound, time.Second).
+		WithMaxAttempts(2)
+
+	g.Expect(p.After(ClassNotFound)).To(Equal(time.Second))
+	g.Expect(p.After(ClassWaiting)).To(Equal(10 * time.Second))
+
+	key := "Job/openstack/keystone-db-sync"
+	after, err := p.Next(key, ClassWaiting)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(after).To(Equal(10 * time.Second))
+	after, err = p.Next(key, ClassNotFound)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(after).To(Equal(time.Second))
+	g.Expect(p.Attempts(key)).To(Equal(2))
+
+	_, err = p.Next(key, ClassWaiting)
+	g.Expect(err).To(MatchError(ErrMaxAttemptsExceeded))
+	g.Expect(err.Error()).To(ContainSubstring(key))
+
+	// other objects are counted separately
+	_, err = p.Next("Job/openstack/nova-db-sync", ClassWaiting)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	p.Reset(key)
+	g.Expect(p.Attempts(key)).To(Equal(0))
+	_, err = p.Next(key, ClassWaiting)
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestPolicyWithoutMaxAttempts(t *testing.T) {
+	g := NewWithT(t)
+
+	p := NewPolicy(5 * time.Second)
+	for i := 0; i < 10; i++ {
+		after, err := p.Next("Deployment/openstack/keystone", ClassWaiting)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(after).To(Equal(5 * time.Second))
+	}
+	g.Expect(p.Attempts("Deployment/openstack/keystone")).To(Equal(0))
+}
+
+func TestPolicyEviction(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	p := NewPolicy(10 * time.Second).
+		WithOverride(ClassDependencyNotReady, time.Minute).
+		WithMaxAttempts(5).
+		WithClock(fakeClock)
+
+	_, err := p.Next("Job/openstack/keystone-db-sync", ClassWaiting)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = p.Next("Job/openstack/nova-db-sync", ClassWaiting)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// still requeued within ten of the longest intervals
+	fakeClock.SetTime(fakeClock.Now().Add(5 * time.Minute))
+	_, err = p.Next("Job/openstack/nova-db-sync", ClassWaiting)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(p.Attempts("Job/openstack/keystone-db-sync")).To(Equal(1))
+
+	// not requeued anymore, e.g. deleted
+	fakeClock.SetTime(fakeClock.Now().Add(6 * time.Minute))
+	_, err = p.Next("Job/openstack/nova-db-sync", ClassWaiting)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(p.Attempts("Job/openstack/keystone-db-sync")).To(Equal(0))
+	g.Expect(p.Attempts("Job/openstack/nova-db-sync")).To(Equal(3))
+}
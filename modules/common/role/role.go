@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/internal/metadata"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -44,6 +46,8 @@ func NewRole(
 }
 
 // CreateOrPatch - creates or patches a role, reconciles after Xs if object won't exist.
+// Labels and annotations set by a previous call and no longer in the role
+// get removed, the rules get replaced.
 func (r *Role) CreateOrPatch(
 	ctx context.Context,
 	h *helper.Helper,
@@ -56,10 +60,9 @@ func (r *Role) CreateOrPatch(
 	}
 
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), role, func() error {
-		role.Labels = util.MergeStringMaps(role.Labels, r.role.Labels)
-		role.Annotations = util.MergeStringMaps(role.Labels, r.role.Annotations)
+		metadata.Merge(role, r.role.Labels, r.role.Annotations)
 		role.Rules = r.role.Rules
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), role, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), role, h.GetScheme())
 		if err != nil {
 			return err
 		}
@@ -68,7 +71,7 @@ func (r *Role) CreateOrPatch(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Role %s not found, reconcile in %s", role.Name, r.timeout))
+			h.GetLogger().Info("Role not found, reconcile later", "object", role.Name, "requeueAfter", r.timeout)
 			return ctrl.Result{RequeueAfter: r.timeout}, nil
 		}
 		return ctrl.Result{}, util.WrapErrorForObject(
@@ -78,7 +81,7 @@ func (r *Role) CreateOrPatch(
 		)
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("Role %s - %s", role.Name, op))
+		h.GetLogger().Info("Role reconciled", "object", role.Name, "operation", op)
 	}
 
 	return ctrl.Result{}, nil
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/internal/metadata"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -44,6 +46,8 @@ func NewRoleBinding(
 }
 
 // CreateOrPatch - creates or patches a role binding, reconciles after Xs if object won't exist.
+// Labels and annotations set by a previous call and no longer in the role
+// binding get removed.
 func (r *RoleBinding) CreateOrPatch(
 	ctx context.Context,
 	h *helper.Helper,
@@ -56,13 +60,12 @@ func (r *RoleBinding) CreateOrPatch(
 	}
 
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), rb, func() error {
-		rb.Labels = util.MergeStringMaps(rb.Labels, r.roleBinding.Labels)
-		rb.Annotations = util.MergeStringMaps(rb.Annotations, r.roleBinding.Annotations)
+		metadata.Merge(rb, r.roleBinding.Labels, r.roleBinding.Annotations)
 
 		rb.RoleRef = r.roleBinding.RoleRef
 		rb.Subjects = r.roleBinding.Subjects
 
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), rb, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), rb, h.GetScheme())
 		if err != nil {
 			return err
 		}
@@ -71,7 +74,7 @@ func (r *RoleBinding) CreateOrPatch(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("RoleBinding %s not found, reconcile in %s", rb.Name, r.timeout))
+			h.GetLogger().Info("RoleBinding not found, reconcile later", "object", rb.Name, "requeueAfter", r.timeout)
 			return ctrl.Result{RequeueAfter: r.timeout}, nil
 		}
 		return ctrl.Result{}, util.WrapErrorForObject(
@@ -81,7 +84,7 @@ func (r *RoleBinding) CreateOrPatch(
 		)
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("RoleBinding %s - %s", rb.Name, op))
+		h.GetLogger().Info("RoleBinding reconciled", "object", rb.Name, "operation", op)
 	}
 
 	return ctrl.Result{}, nil
@@ -25,11 +25,14 @@ import (
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -141,7 +144,7 @@ func (r *Route) CreateOrPatch(
 			route.Spec.Host = route.Status.Ingress[0].Host
 		}
 
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), route, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), route, h.GetScheme())
 		if err != nil {
 			return err
 		}
@@ -162,13 +165,13 @@ func (r *Route) CreateOrPatch(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Route %s not found, reconcile in %s", route.Name, r.timeout))
+			h.GetLogger().Info("Route not found, reconcile later", "object", route.Name, "requeueAfter", r.timeout)
 			return ctrl.Result{RequeueAfter: r.timeout}, nil
 		}
 		return ctrl.Result{}, err
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("Route %s - %s", route.Name, op))
+		h.GetLogger().Info("Route reconciled", "object", route.Name, "operation", op)
 	}
 
 	// update the route instance with the host
@@ -177,6 +180,55 @@ func (r *Route) CreateOrPatch(
 	return ctrl.Result{}, nil
 }
 
+// IsAdmitted - returns true if a router admitted the route
+func IsAdmitted(route *routev1.Route) bool {
+	for _, ingress := range route.Status.Ingress {
+		for _, c := range ingress.Conditions {
+			if c.Type == routev1.RouteAdmitted && c.Status == corev1.ConditionTrue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WaitForAdmitted - returns a requeue after the timeout of the Route until
+// a router admitted it. If it does not get admitted within waitTimeout,
+// measured with the clock of the helper from the creation of the Route, an
+// util.ErrWaitTimeout error is returned. Zero waitTimeout waits forever.
+func (r *Route) WaitForAdmitted(
+	ctx context.Context,
+	h *helper.Helper,
+	waitTimeout time.Duration,
+) (ctrl.Result, error) {
+	route := &routev1.Route{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: r.route.Name, Namespace: r.route.Namespace}, route)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			h.GetLogger().Info("Route not found, reconcile later", "object", r.route.Name, "requeueAfter", r.timeout)
+			return ctrl.Result{RequeueAfter: r.timeout}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if IsAdmitted(route) {
+		if len(route.Status.Ingress) > 0 {
+			r.hostname = route.Status.Ingress[0].Host
+		}
+		return ctrl.Result{}, nil
+	}
+
+	requeueAfter, expired := util.RequeueWithDeadline(
+		h.GetClock(), route.CreationTimestamp.Time, waitTimeout, r.timeout)
+	if expired {
+		return ctrl.Result{}, fmt.Errorf("%w: route %s not admitted within %s",
+			util.ErrWaitTimeout, route.Name, waitTimeout)
+	}
+	h.GetLogger().Info("Route not admitted, reconcile later", "object", route.Name, "requeueAfter", requeueAfter)
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
 // Delete - delete a service.
 func (r *Route) Delete(
 	ctx context.Context,
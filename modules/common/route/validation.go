@@ -0,0 +1,141 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// IgnoredAnnotationPrefixes - prefixes of annotations which have no effect
+// on a route, e.g. the ones of ingress controllers users copy from ingress
+// examples. The termination of an ingress converted to a route gets set via
+// route.openshift.io/termination on the ingress, not on the route.
+var IgnoredAnnotationPrefixes = []string{
+	"nginx.ingress.kubernetes.io/",
+	"kubernetes.io/ingress.class",
+	"traefik.ingress.kubernetes.io/",
+	"route.openshift.io/termination",
+}
+
+// ValidateOverride - validates the route override of a CR. Returns errors
+// for
+// - a TLS termination not in supportedTerminations, as the operator
+// configures the service for the termination it supports. No TLS override
+// is allowed if supportedTerminations is empty.
+// - the insecureEdgeTerminationPolicy Allow with passthrough termination,
+// which the router does not support
+// - host and subdomain both set, the subdomain gets ignored in that case
+// - annotations with one of the IgnoredAnnotationPrefixes
+//
+// example usage:
+//
+//	ValidateOverride(field.NewPath("spec").Child("override").Child("route"), spec.Override.Route,
+//		[]routev1.TLSTerminationType{routev1.TLSTerminationEdge, routev1.TLSTerminationReencrypt})
+func ValidateOverride(
+	basePath *field.Path,
+	override *OverrideSpec,
+	supportedTerminations []routev1.TLSTerminationType,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if override == nil {
+		return allErrs
+	}
+
+	if override.EmbeddedLabelsAnnotations != nil {
+		path := basePath.Child("metadata").Child("annotations")
+		keys := make([]string, 0, len(override.Annotations))
+		for k := range override.Annotations {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			for _, prefix := range IgnoredAnnotationPrefixes {
+				if strings.HasPrefix(k, prefix) {
+					allErrs = append(allErrs, field.Forbidden(path.Key(k), "annotation is ignored on routes"))
+					break
+				}
+			}
+		}
+	}
+
+	if override.Spec == nil {
+		return allErrs
+	}
+	specPath := basePath.Child("spec")
+
+	if override.Spec.Host != "" && override.Spec.Subdomain != "" {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("subdomain"), override.Spec.Subdomain,
+			"subdomain is ignored when host is set"))
+	}
+
+	if tls := override.Spec.TLS; tls != nil {
+		tlsPath := specPath.Child("tls")
+		if !slices.Contains(supportedTerminations, tls.Termination) {
+			supported := []string{}
+			for _, t := range supportedTerminations {
+				supported = append(supported, string(t))
+			}
+			allErrs = append(allErrs, field.NotSupported(tlsPath.Child("termination"), tls.Termination, supported))
+		}
+		if tls.Termination == routev1.TLSTerminationPassthrough &&
+			tls.InsecureEdgeTerminationPolicy == routev1.InsecureEdgeTerminationPolicyAllow {
+			allErrs = append(allErrs, field.Invalid(tlsPath.Child("insecureEdgeTerminationPolicy"),
+				tls.InsecureEdgeTerminationPolicy, "not supported with passthrough termination"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateOverrideHosts - validates that the route overrides of the
+// endpoints of a CR do not set the same host, only one of the routes would
+// get admitted by the router
+//
+// example usage:
+//
+//	ValidateOverrideHosts(field.NewPath("spec").Child("override").Child("routes"), map[string]*OverrideSpec{
+//		"public": spec.Override.PublicRoute, "admin": spec.Override.AdminRoute})
+func ValidateOverrideHosts(basePath *field.Path, overrides map[string]*OverrideSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	hosts := map[string]string{}
+	for _, k := range keys {
+		o := overrides[k]
+		if o == nil || o.Spec == nil || o.Spec.Host == "" {
+			continue
+		}
+		path := basePath.Key(k).Child("spec").Child("host")
+		if other, ok := hosts[o.Spec.Host]; ok {
+			allErrs = append(allErrs, field.Invalid(path, o.Spec.Host, "host also used by the "+other+" route"))
+			continue
+		}
+		hosts[o.Spec.Host] = k
+	}
+
+	return allErrs
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	routev1 "github.com/openshift/api/route/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateOverride(t *testing.T) {
+	basePath := field.NewPath("spec").Child("override").Child("route")
+	supported := []routev1.TLSTerminationType{routev1.TLSTerminationEdge, routev1.TLSTerminationReencrypt}
+
+	tests := []struct {
+		name     string
+		override *OverrideSpec
+		want     []string
+	}{
+		{
+			name:     "No override",
+			override: nil,
+		},
+		{
+			name: "Valid override",
+			override: &OverrideSpec{
+				EmbeddedLabelsAnnotations: &EmbeddedLabelsAnnotations{
+					Annotations: map[string]string{"haproxy.router.openshift.io/timeout": "60s"},
+				},
+				Spec: &Spec{
+					Host: "keystone.example.com",
+					TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+				},
+			},
+		},
+		{
+			name: "Unsupported termination",
+			override: &OverrideSpec{
+				Spec: &Spec{TLS: &routev1.TLSConfig{
+					Termination:                   routev1.TLSTerminationPassthrough,
+					InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyAllow,
+				}},
+			},
+			want: []string{
+				"spec.override.route.spec.tls.termination",
+				"spec.override.route.spec.tls.insecureEdgeTerminationPolicy",
+			},
+		},
+		{
+			name: "Host and subdomain, ignored annotations",
+			override: &OverrideSpec{
+				EmbeddedLabelsAnnotations: &EmbeddedLabelsAnnotations{
+					Annotations: map[string]string{
+						"nginx.ingress.kubernetes.io/rewrite-target": "/",
+						"route.openshift.io/termination":             "edge",
+					},
+				},
+				Spec: &Spec{Host: "keystone.example.com", Subdomain: "keystone"},
+			},
+			want: []string{
+				"spec.override.route.metadata.annotations[nginx.ingress.kubernetes.io/rewrite-target]",
+				"spec.override.route.metadata.annotations[route.openshift.io/termination]",
+				"spec.override.route.spec.subdomain",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			paths := []string{}
+			for _, err := range ValidateOverride(basePath, tt.override, supported) {
+				paths = append(paths, err.Field)
+			}
+			g.Expect(paths).To(Equal(append([]string{}, tt.want...)))
+		})
+	}
+}
+
+func TestValidateOverrideHosts(t *testing.T) {
+	g := NewWithT(t)
+
+	basePath := field.NewPath("spec").Child("override")
+	errs := ValidateOverrideHosts(basePath, map[string]*OverrideSpec{
+		"admin":    {Spec: &Spec{Host: "keystone.example.com"}},
+		"internal": nil,
+		"public":   {Spec: &Spec{Host: "keystone.example.com"}},
+	})
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Field).To(Equal("spec.override[public].spec.host"))
+	g.Expect(errs[0].Detail).To(Equal("host also used by the admin route"))
+}
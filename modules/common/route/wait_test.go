@@ -0,0 +1,87 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/gomega" // nolint:revive
+)
+
+func TestWaitForAdmitted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(routev1.AddToScheme(scheme)).To(Succeed())
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := route1.DeepCopy()
+	r.CreationTimestamp = metav1.NewTime(created)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(r).WithStatusSubresource(r).Build()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "namespace"}}
+	h, err := helper.NewHelper(ns, fakeClient, nil, scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	clk := clocktesting.NewFakePassiveClock(created.Add(2 * time.Second))
+	h.SetClock(clk)
+
+	rt, err := NewRoute(route1.DeepCopy(), timeout, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// not admitted, requeue after the route timeout
+	result, err := rt.WaitForAdmitted(ctx, h, time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(timeout))
+
+	// requeue capped to the time left
+	clk.SetTime(created.Add(58 * time.Second))
+	result, err = rt.WaitForAdmitted(ctx, h, time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(2 * time.Second))
+
+	// expired
+	clk.SetTime(created.Add(time.Minute))
+	_, err = rt.WaitForAdmitted(ctx, h, time.Minute)
+	g.Expect(err).To(MatchError(util.ErrWaitTimeout))
+
+	// admitted
+	r.Status.Ingress = []routev1.RouteIngress{
+		{
+			Host: "foo.apps",
+			Conditions: []routev1.RouteIngressCondition{
+				{Type: routev1.RouteAdmitted, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	g.Expect(fakeClient.Status().Update(ctx, r)).To(Succeed())
+	result, err = rt.WaitForAdmitted(ctx, h, time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(rt.GetHostname()).To(Equal("foo.apps"))
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedule provides time based re-reconciles of objects, e.g. to
+// re-verify external endpoints hourly or to check the expiry of certs
+// daily, without returning RequeueAfter on every return path of the
+// reconcile.
+//
+// The schedules are kept in memory only. The reconcile registers them on
+// every run with the time the periodic work was last done, which it keeps
+// in the status of the object, e.g. as LastTransitionTime of a condition.
+// As all objects get reconciled after a restart of the operator, the
+// schedules get restored with their original due times.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ErrInvalidInterval - the interval of a schedule is not positive
+var ErrInvalidInterval = errors.New("schedule interval must be positive")
+
+// key - identifies a schedule, name of it for the object
+type key struct {
+	types.NamespacedName
+	name string
+}
+
+// entry - a registered schedule
+type entry struct {
+	obj      client.Object
+	interval time.Duration
+	due      time.Time
+}
+
+// Scheduler - triggers reconciles of objects when their registered periodic
+// work is due, via the Source to be watched by the controller of the
+// objects. One Scheduler serves the objects of one controller.
+type Scheduler struct {
+	clock   clock.WithTicker
+	events  chan event.GenericEvent
+	wake    chan struct{}
+	mu      sync.Mutex
+	entries map[key]*entry
+}
+
+// NewScheduler - returns a Scheduler, to be added to the manager and its
+// Source watched by the controller
+//
+// Example:
+//
+//	func (r *KeystoneAPIReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+//		r.Scheduler = schedule.NewScheduler()
+//		err := mgr.Add(r.Scheduler)
+//		if err != nil {
+//			return err
+//		}
+//		return ctrl.NewControllerManagedBy(mgr).
+//			For(&keystonev1.KeystoneAPI{}).
+//			WatchesRawSource(r.Scheduler.Source()).
+//			Complete(r)
+//	}
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		clock:   clock.RealClock{},
+		events:  make(chan event.GenericEvent),
+		wake:    make(chan struct{}, 1),
+		entries: map[key]*entry{},
+	}
+}
+
+// SetClock - sets the clock of the scheduler, e.g. a fake clock in tests
+func (s *Scheduler) SetClock(c clock.WithTicker) {
+	s.clock = c
+}
+
+// Source - returns the source of the reconcile requests of the due objects,
+// to be watched by the controller
+func (s *Scheduler) Source() source.Source {
+	return source.Channel(s.events, &handler.EnqueueRequestForObject{})
+}
+
+// Schedule - registers the periodic work name of obj to be due interval
+// after last, or right away if last is zero. Registering it again replaces
+// the schedule, e.g. with the new last time after the work got done. When
+// it is due, obj gets reconciled and the work is due again after interval
+// until registered again. An interval which is not positive is rejected
+// with ErrInvalidInterval.
+//
+// Example:
+//
+//	if r.Scheduler.IsDue(instance.Status.EndpointsVerifiedAt.Time, time.Hour) {
+//		// verify the endpoints
+//		...
+//		instance.Status.EndpointsVerifiedAt = metav1.Now()
+//	}
+//	err := r.Scheduler.Schedule(instance, "verify-endpoints", time.Hour, instance.Status.EndpointsVerifiedAt.Time)
+func (s *Scheduler) Schedule(obj client.Object, name string, interval time.Duration, last time.Time) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: %s of %s: %s", ErrInvalidInterval, name, obj.GetName(), interval)
+	}
+
+	due := last.Add(interval)
+	if last.IsZero() {
+		due = s.clock.Now()
+	}
+
+	// only the name is needed for the reconcile request
+	ref := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()},
+	}
+	s.mu.Lock()
+	s.entries[key{NamespacedName: client.ObjectKeyFromObject(obj), name: name}] = &entry{
+		obj:      ref,
+		interval: interval,
+		due:      due,
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Unschedule - removes the schedule name of obj
+func (s *Scheduler) Unschedule(obj client.Object, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key{NamespacedName: client.ObjectKeyFromObject(obj), name: name})
+}
+
+// Forget - removes all schedules of obj, e.g. in the reconcile of its
+// deletion
+func (s *Scheduler) Forget(obj client.Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.entries {
+		if k.NamespacedName == client.ObjectKeyFromObject(obj) {
+			delete(s.entries, k)
+		}
+	}
+}
+
+// GetDue - returns the time the work name of obj is due next, false if it
+// is not scheduled
+func (s *Scheduler) GetDue(obj client.Object, name string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key{NamespacedName: client.ObjectKeyFromObject(obj), name: name}]
+	if !ok {
+		return time.Time{}, false
+	}
+	return e.due, true
+}
+
+// IsDue - returns true if work last done at last is due again after
+// interval, always if last is zero
+func (s *Scheduler) IsDue(last time.Time, interval time.Duration) bool {
+	return last.IsZero() || !s.clock.Now().Before(last.Add(interval))
+}
+
+// popDue - returns the objects due at now and schedules them again after
+// their interval, and the time the next one is due, zero if none
+func (s *Scheduler) popDue(now time.Time) ([]client.Object, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objs := []client.Object{}
+	next := time.Time{}
+	for _, e := range s.entries {
+		if !now.Before(e.due) {
+			objs = append(objs, e.obj)
+			e.due = now.Add(e.interval)
+		}
+		if next.IsZero() || e.due.Before(next) {
+			next = e.due
+		}
+	}
+	return objs, next
+}
+
+// Start - sends the due objects to the Source until ctx is done, implements
+// the manager.Runnable interface
+func (s *Scheduler) Start(ctx context.Context) error {
+	for {
+		now := s.clock.Now()
+		objs, next := s.popDue(now)
+		for _, obj := range objs {
+			select {
+			case s.events <- event.GenericEvent{Object: obj}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		var timer clock.Timer
+		var expired <-chan time.Time
+		if !next.IsZero() {
+			timer = s.clock.NewTimer(next.Sub(now))
+			expired = timer.C()
+		}
+		select {
+		case <-ctx.Done():
+		case <-s.wake:
+		case <-expired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
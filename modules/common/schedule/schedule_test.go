@@ -0,0 +1,89 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestScheduler(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakeClock(now)
+	s := NewScheduler()
+	s.SetClock(fakeClock)
+	g.Expect(s.Source()).ToNot(BeNil())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Start(ctx) }()
+
+	name := func(e event.GenericEvent) string { return e.Object.GetName() }
+	keystone := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}}
+	glance := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "glance", Namespace: "openstack"}}
+
+	// overdue, e.g. after a restart of the operator
+	g.Expect(s.Schedule(keystone, "verify-endpoints", 0, now)).To(MatchError(ErrInvalidInterval))
+	_, ok := s.GetDue(keystone, "verify-endpoints")
+	g.Expect(ok).To(BeFalse())
+
+	g.Expect(s.Schedule(keystone, "verify-endpoints", time.Hour, now.Add(-2*time.Hour))).To(Succeed())
+	g.Eventually(s.events).Should(Receive(WithTransform(name, Equal("keystone"))))
+	due, ok := s.GetDue(keystone, "verify-endpoints")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(due).To(Equal(now.Add(time.Hour)))
+
+	g.Expect(s.Schedule(glance, "check-certs", 24*time.Hour, now.Add(-23*time.Hour))).To(Succeed())
+	g.Consistently(s.events, 100*time.Millisecond).ShouldNot(Receive())
+	g.Expect(s.IsDue(now.Add(-23*time.Hour), 24*time.Hour)).To(BeFalse())
+	g.Expect(s.IsDue(time.Time{}, 24*time.Hour)).To(BeTrue())
+
+	g.Eventually(fakeClock.HasWaiters).Should(BeTrue())
+	fakeClock.Step(time.Hour)
+	// both due at the same time, in any order
+	names := []string{}
+	for range 2 {
+		var e event.GenericEvent
+		g.Eventually(s.events).Should(Receive(&e))
+		names = append(names, name(e))
+	}
+	g.Expect(names).To(ConsistOf("glance", "keystone"))
+
+	// removed schedules are not due anymore
+	s.Unschedule(glance, "check-certs")
+	s.Forget(keystone)
+	_, ok = s.GetDue(keystone, "verify-endpoints")
+	g.Expect(ok).To(BeFalse())
+	fakeClock.Step(48 * time.Hour)
+	g.Consistently(s.events, 100*time.Millisecond).ShouldNot(Receive())
+
+	// registered without last time, due right away
+	g.Expect(s.Schedule(glance, "check-certs", 24*time.Hour, time.Time{})).To(Succeed())
+	g.Eventually(s.events).Should(Receive(WithTransform(name, Equal("glance"))))
+
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+}
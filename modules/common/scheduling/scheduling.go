@@ -0,0 +1,98 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// +kubebuilder:object:generate:=true
+
+// Package scheduling provides the nodeSelector and tolerations settings of
+// a service and their merge into the pod spec of its workloads
+package scheduling
+
+import (
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Spec - nodeSelector and tolerations of the pods of a service, used for
+// the operator defaults and the user overrides in the CR spec
+type Spec struct {
+	// +kubebuilder:validation:Optional
+	// NodeSelector to target subset of worker nodes running this service. If
+	// set it replaces the default nodeSelector, an empty map removes it.
+	NodeSelector *map[string]string `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Tolerations of the pods of this service. They get added to the default
+	// tolerations, a toleration with the same key and effect as a default
+	// one replaces it.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// Merge - returns the scheduling settings of the defaults with the override
+// applied
+func Merge(defaults Spec, override Spec) Spec {
+	merged := Spec{}
+
+	nodeSelector := defaults.NodeSelector
+	if override.NodeSelector != nil {
+		nodeSelector = override.NodeSelector
+	}
+	if nodeSelector != nil {
+		ns := maps.Clone(*nodeSelector)
+		merged.NodeSelector = &ns
+	}
+
+	merged.Tolerations = MergeTolerations(defaults.Tolerations, override.Tolerations)
+
+	return merged
+}
+
+// MergeTolerations - returns the default tolerations plus the override
+// tolerations. An override toleration with the same key and effect as a
+// default one replaces it in place, identical tolerations are only added
+// once.
+func MergeTolerations(defaults []corev1.Toleration, override []corev1.Toleration) []corev1.Toleration {
+	if len(defaults) == 0 && len(override) == 0 {
+		return nil
+	}
+
+	merged := slices.Clone(defaults)
+	for _, o := range override {
+		idx := slices.IndexFunc(merged, func(t corev1.Toleration) bool {
+			return t.Key == o.Key && t.Effect == o.Effect
+		})
+		if idx >= 0 {
+			merged[idx] = o
+			continue
+		}
+		merged = append(merged, o)
+	}
+
+	return merged
+}
+
+// Apply - sets the nodeSelector and tolerations of the merged defaults and
+// override on the pod spec. Settings of the pod spec are replaced, so that
+// removed overrides get removed from the pods as well.
+func Apply(spec *corev1.PodSpec, defaults Spec, override Spec) {
+	merged := Merge(defaults, override)
+
+	spec.NodeSelector = nil
+	if merged.NodeSelector != nil && len(*merged.NodeSelector) > 0 {
+		spec.NodeSelector = *merged.NodeSelector
+	}
+	spec.Tolerations = merged.Tolerations
+}
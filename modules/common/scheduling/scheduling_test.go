@@ -0,0 +1,111 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	controlPlane = corev1.Toleration{
+		Key:      "node-role.kubernetes.io/control-plane",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	infra = corev1.Toleration{
+		Key:      "node-role.kubernetes.io/infra",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+)
+
+func TestApply(t *testing.T) {
+	defaults := Spec{
+		NodeSelector: &map[string]string{"node-role.kubernetes.io/worker": ""},
+		Tolerations:  []corev1.Toleration{controlPlane},
+	}
+	controlPlaneEqual := corev1.Toleration{
+		Key:      controlPlane.Key,
+		Operator: corev1.TolerationOpEqual,
+		Value:    "true",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+
+	tests := []struct {
+		name             string
+		override         Spec
+		wantNodeSelector map[string]string
+		wantTolerations  []corev1.Toleration
+	}{
+		{
+			name:             "No override",
+			wantNodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+			wantTolerations:  []corev1.Toleration{controlPlane},
+		},
+		{
+			name: "Override replaces the node selector and adds tolerations",
+			override: Spec{
+				NodeSelector: &map[string]string{"type": "openstack"},
+				Tolerations:  []corev1.Toleration{infra},
+			},
+			wantNodeSelector: map[string]string{"type": "openstack"},
+			wantTolerations:  []corev1.Toleration{controlPlane, infra},
+		},
+		{
+			name: "Empty node selector removes the default",
+			override: Spec{
+				NodeSelector: &map[string]string{},
+			},
+			wantTolerations: []corev1.Toleration{controlPlane},
+		},
+		{
+			name: "Toleration with the same key and effect replaces the default",
+			override: Spec{
+				Tolerations: []corev1.Toleration{controlPlaneEqual, infra},
+			},
+			wantNodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+			wantTolerations:  []corev1.Toleration{controlPlaneEqual, infra},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			spec := &corev1.PodSpec{
+				NodeSelector: map[string]string{"stale": "true"},
+			}
+			Apply(spec, defaults, tt.override)
+			g.Expect(spec.NodeSelector).To(Equal(tt.wantNodeSelector))
+			g.Expect(spec.Tolerations).To(Equal(tt.wantTolerations))
+
+			// the defaults are not modified
+			g.Expect(*defaults.NodeSelector).To(HaveLen(1))
+			g.Expect(defaults.Tolerations).To(Equal([]corev1.Toleration{controlPlane}))
+		})
+	}
+}
+
+func TestMergeTolerationsEmpty(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(MergeTolerations(nil, nil)).To(BeNil())
+	g.Expect(MergeTolerations(nil, []corev1.Toleration{infra})).To(Equal([]corev1.Toleration{infra}))
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 
 	corev1 "k8s.io/api/core/v1"
@@ -82,7 +83,7 @@ func GetSecret(
 ) (*corev1.Secret, string, error) {
 	secret := &corev1.Secret{}
 
-	err := h.GetClient().Get(ctx, types.NamespacedName{Name: secretName, Namespace: secretNamespace}, secret)
+	err := h.GetReader().Get(ctx, types.NamespacedName{Name: secretName, Namespace: secretNamespace}, secret)
 	if err != nil {
 		return nil, "", err
 	}
@@ -133,11 +134,12 @@ func CreateOrPatchSecret(
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), s, func() error {
 		s.Annotations = util.MergeStringMaps(s.Annotations, secret.Annotations)
 		s.Labels = util.MergeStringMaps(s.Labels, secret.Labels)
+		h.ApplyPropagatedMetadata(s, secret.Labels, secret.Annotations)
 		s.Immutable = secret.Immutable
 		s.Data = secret.Data
 		s.StringData = secret.StringData
 
-		err := controllerutil.SetControllerReference(obj, s, h.GetScheme())
+		err := object.SetControllerReference(obj, s, h.GetScheme())
 		if err != nil {
 			return err
 		}
@@ -156,6 +158,49 @@ func CreateOrPatchSecret(
 	return secretHash, op, err
 }
 
+// ApplySecret - applies the secret via server-side apply with the field
+// manager of the helper instead of patching it, e.g. for secrets co-managed
+// by multiple operators. Labels, annotations and data keys applied before
+// and no longer in secret get removed, the ones of other field managers are
+// kept. Returns the hash of the resulting secret.
+func ApplySecret(
+	ctx context.Context,
+	h *helper.Helper,
+	obj client.Object,
+	secret *corev1.Secret,
+) (string, error) {
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Labels:      util.MergeStringMaps(secret.Labels),
+			Annotations: util.MergeStringMaps(secret.Annotations),
+		},
+		Immutable:  secret.Immutable,
+		Type:       secret.Type,
+		Data:       secret.Data,
+		StringData: secret.StringData,
+	}
+	h.ApplyPropagatedMetadata(s, secret.Labels, secret.Annotations)
+
+	err := object.SetControllerReference(obj, s, h.GetScheme())
+	if err != nil {
+		return "", err
+	}
+
+	err = h.SSAApply(ctx, s)
+	if err != nil {
+		return "", fmt.Errorf("error applying secret: %w", err)
+	}
+
+	secretHash, err := Hash(s)
+	if err != nil {
+		return "", fmt.Errorf("error calculating configuration hash: %w", err)
+	}
+
+	return secretHash, nil
+}
+
 // CreateOrPatchSecretPreserve creates a secret on first creation and preserves
 // existing Data keys on subsequent reconciles. This is useful for generated
 // credentials (passwords, cookies) that should only be set once and not
@@ -178,6 +223,7 @@ func CreateOrPatchSecretPreserve(
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), s, func() error {
 		s.Annotations = util.MergeStringMaps(s.Annotations, secret.Annotations)
 		s.Labels = util.MergeStringMaps(s.Labels, secret.Labels)
+		h.ApplyPropagatedMetadata(s, secret.Labels, secret.Annotations)
 
 		// Only set data on initial creation (when the object has no data yet)
 		if len(s.Data) == 0 {
@@ -188,7 +234,7 @@ func CreateOrPatchSecretPreserve(
 		}
 
 		if !skipSetOwner {
-			err := controllerutil.SetControllerReference(obj, s, h.GetScheme())
+			err := object.SetControllerReference(obj, s, h.GetScheme())
 			if err != nil {
 				return err
 			}
@@ -233,6 +279,7 @@ func createOrUpdateSecret(
 	// create or update the CM
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), secret, func() error {
 		secret.Labels = util.MergeStringMaps(secret.Labels, st.Labels)
+		h.ApplyPropagatedMetadata(secret, st.Labels, nil)
 		// add data from templates
 		renderedTemplateData, err := util.GetTemplateData(st)
 		if err != nil {
@@ -248,12 +295,17 @@ func createOrUpdateSecret(
 				if err == nil {
 					dataString[k] = vExpanded
 				} else {
-					h.GetLogger().Info(fmt.Sprintf("Skipped customData expansion due to: %s", err))
+					h.GetLogger().Info("Skipped customData expansion", "reason", err.Error())
 					dataString[k] = v
 				}
 			}
 		}
 
+		err = util.ValidateConfigData(st.Name, dataString, st.Validators)
+		if err != nil {
+			return err
+		}
+
 		for k, d := range dataString {
 			data[k] = []byte(d)
 		}
@@ -262,7 +314,7 @@ func createOrUpdateSecret(
 		// Only set controller ref if namespaces are equal, else we hit an error
 		if obj.GetNamespace() == secret.Namespace {
 			if !st.SkipSetOwner {
-				err := controllerutil.SetControllerReference(obj, secret, h.GetScheme())
+				err := object.SetControllerReference(obj, secret, h.GetScheme())
 				if err != nil {
 					return err
 				}
@@ -320,12 +372,12 @@ func createOrGetCustomSecret(
 	foundSecret := &corev1.Secret{}
 	err := h.GetClient().Get(ctx, types.NamespacedName{Name: st.Name, Namespace: st.Namespace}, foundSecret)
 	if err != nil && k8s_errors.IsNotFound(err) {
-		err := controllerutil.SetControllerReference(obj, secret, h.GetScheme())
+		err := object.SetControllerReference(obj, secret, h.GetScheme())
 		if err != nil {
 			return "", err
 		}
 
-		h.GetLogger().Info(fmt.Sprintf("Creating a new Secret %s in namespace %s", st.Namespace, st.Name))
+		h.GetLogger().Info("Creating a new Secret", "object", st.Name, "objectNamespace", st.Namespace)
 		err = h.GetClient().Create(ctx, secret)
 		if err != nil {
 			return "", err
@@ -369,7 +421,7 @@ func EnsureSecrets(
 			return err
 		}
 		if op != controllerutil.OperationResultNone {
-			h.GetLogger().Info(fmt.Sprintf("Secret %s successfully reconciled - operation: %s", s.Name, string(op)))
+			h.GetLogger().Info("Secret reconciled", "object", s.Name, "operation", op)
 		}
 		if envVars != nil {
 			(*envVars)[s.Name] = env.SetValue(hash)
@@ -445,10 +497,14 @@ func GetDataFromSecret(
 
 	data := ""
 
+	if !h.HasBeforeObject() {
+		return data, ctrl.Result{}, helper.ErrNoBeforeObject
+	}
+
 	secret, _, err := GetSecret(ctx, h, secretName, h.GetBeforeObject().GetNamespace())
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Secret %s not found, reconcile in %s", secretName, requeueTimeout))
+			h.GetLogger().Info("Secret not found, reconcile later", "object", secretName, "requeueAfter", requeueTimeout)
 			return data, ctrl.Result{RequeueAfter: requeueTimeout}, nil
 		}
 
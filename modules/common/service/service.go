@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -295,6 +296,21 @@ func MetalLBService(svcInfo *MetalLBServiceDetails) *corev1.Service {
 	}
 }
 
+// SetServerSideApply - if enabled, CreateOrPatch applies the service via
+// server-side apply with the field manager of the helper instead of
+// patching it, e.g. for services co-managed by multiple operators
+func (s *Service) SetServerSideApply(enabled bool) {
+	s.serverSideApply = enabled
+}
+
+// SetAdmissionDryRun - if enabled, CreateOrPatch first submits the service
+// with server-side dry-run and returns an object.AdmissionError if the
+// admission rejects it, e.g. on an exceeded quota or a policy violation,
+// before anything gets applied
+func (s *Service) SetAdmissionDryRun(enabled bool) {
+	s.dryRun = enabled
+}
+
 // CreateOrPatch - creates or patches a service, reconciles after Xs if object won't exist.
 func (s *Service) CreateOrPatch(
 	ctx context.Context,
@@ -307,27 +323,60 @@ func (s *Service) CreateOrPatch(
 		},
 	}
 
-	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), service, func() error {
-		service.Labels = util.MergeStringMaps(s.service.Labels, service.Labels)
-		service.Annotations = util.MergeStringMaps(s.service.Annotations, service.Annotations)
+	if s.serverSideApply {
+		service.Labels = util.MergeStringMaps(s.service.Labels)
+		service.Annotations = util.MergeStringMaps(s.service.Annotations)
+		h.ApplyPropagatedMetadata(service, s.service.Labels, s.service.Annotations)
 		service.Spec = s.service.Spec
 
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), service, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), service, h.GetScheme())
 		if err != nil {
-			return err
+			return ctrl.Result{}, err
 		}
 
-		return nil
-	})
-	if err != nil {
-		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("Service %s not found, reconcile in %s", service.Name, s.timeout))
-			return ctrl.Result{RequeueAfter: s.timeout}, nil
+		if s.dryRun {
+			err = object.DryRunApply(ctx, h, service)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		err = h.SSAApply(ctx, service)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		mutate := func() error {
+			service.Labels = util.MergeStringMaps(s.service.Labels, service.Labels)
+			service.Annotations = util.MergeStringMaps(s.service.Annotations, service.Annotations)
+			h.ApplyPropagatedMetadata(service, s.service.Labels, s.service.Annotations)
+			service.Spec = s.service.Spec
+
+			err := object.SetControllerReference(h.GetBeforeObject(), service, h.GetScheme())
+			if err != nil {
+				return err
+			}
+
+			return nil
+		}
+		if s.dryRun {
+			err := object.DryRun(ctx, h, service, mutate)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), service, mutate)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				h.GetLogger().Info("Service not found, reconcile later", "object", service.Name, "requeueAfter", s.timeout)
+				return ctrl.Result{RequeueAfter: s.timeout}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		if op != controllerutil.OperationResultNone {
+			h.GetLogger().Info("Service reconciled", "object", service.Name, "operation", op)
 		}
-		return ctrl.Result{}, err
-	}
-	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("Service %s - %s", service.Name, op))
 	}
 
 	// update the service instance with the ip/host information
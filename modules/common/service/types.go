@@ -101,6 +101,8 @@ type Service struct {
 	externalIPs     []string
 	ipFamilies      []corev1.IPFamily
 	serviceHostname string
+	serverSideApply bool
+	dryRun          bool
 }
 
 // GenericServiceDetails -
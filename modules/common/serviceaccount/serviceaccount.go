@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/internal/metadata"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -44,6 +46,8 @@ func NewServiceAccount(
 }
 
 // CreateOrPatch - creates or patches a service account, reconciles after Xs if object won't exist.
+// Labels and annotations set by a previous call and no longer in the service
+// account get removed.
 func (s *ServiceAccount) CreateOrPatch(
 	ctx context.Context,
 	h *helper.Helper,
@@ -56,10 +60,12 @@ func (s *ServiceAccount) CreateOrPatch(
 	}
 
 	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), sa, func() error {
-		sa.Labels = util.MergeStringMaps(sa.Labels, s.serviceAccount.Labels)
-		sa.Annotations = util.MergeStringMaps(sa.Annotations, s.serviceAccount.Annotations)
+		metadata.Merge(sa, s.serviceAccount.Labels, s.serviceAccount.Annotations)
+		if s.serviceAccount.AutomountServiceAccountToken != nil {
+			sa.AutomountServiceAccountToken = s.serviceAccount.AutomountServiceAccountToken
+		}
 
-		err := controllerutil.SetControllerReference(h.GetBeforeObject(), sa, h.GetScheme())
+		err := object.SetControllerReference(h.GetBeforeObject(), sa, h.GetScheme())
 		if err != nil {
 			return err
 		}
@@ -68,7 +74,7 @@ func (s *ServiceAccount) CreateOrPatch(
 	})
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("ServiceAccount %s not found, reconcile in %s", sa.Name, s.timeout))
+			h.GetLogger().Info("ServiceAccount not found, reconcile later", "object", sa.Name, "requeueAfter", s.timeout)
 			return ctrl.Result{RequeueAfter: s.timeout}, nil
 		}
 		return ctrl.Result{}, util.WrapErrorForObject(
@@ -78,7 +84,7 @@ func (s *ServiceAccount) CreateOrPatch(
 		)
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("ServiceAccount %s - %s", sa.Name, op))
+		h.GetLogger().Info("ServiceAccount reconciled", "object", sa.Name, "operation", op)
 	}
 
 	return ctrl.Result{}, nil
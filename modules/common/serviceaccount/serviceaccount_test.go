@@ -0,0 +1,68 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateOrPatchRemovesStaleMetadata(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	cr := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack", UID: "1234"}}
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cr).Build()
+	h, err := helper.NewHelper(cr, c, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+		Name:        "keystone-keystone",
+		Namespace:   "openstack",
+		Labels:      map[string]string{"service": "keystone", "stale": "true"},
+		Annotations: map[string]string{"stale": "true"},
+	}}
+	_, err = NewServiceAccount(sa.DeepCopy(), time.Second).CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// metadata added by others is kept
+	current := &corev1.ServiceAccount{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: sa.Name, Namespace: sa.Namespace}, current)).To(Succeed())
+	current.Labels["other"] = "true"
+	g.Expect(c.Update(ctx, current)).To(Succeed())
+
+	delete(sa.Labels, "stale")
+	sa.Annotations = nil
+	_, err = NewServiceAccount(sa.DeepCopy(), time.Second).CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: sa.Name, Namespace: sa.Namespace}, current)).To(Succeed())
+	g.Expect(current.Labels).To(HaveKeyWithValue("service", "keystone"))
+	g.Expect(current.Labels).To(HaveKeyWithValue("other", "true"))
+	g.Expect(current.Labels).ToNot(HaveKey("stale"))
+	g.Expect(current.Annotations).ToNot(HaveKey("stale"))
+}
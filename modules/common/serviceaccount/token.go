@@ -0,0 +1,123 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pod"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// MinTokenExpirationSeconds - the shortest expiry the kube-apiserver
+	// accepts for projected tokens
+	MinTokenExpirationSeconds = 600
+	// DefaultTokenExpirationSeconds - the expiry of projected tokens if not set
+	DefaultTokenExpirationSeconds = 3600
+)
+
+// Define static errors
+var (
+	// ErrInvalidTokenProjection indicates a token projection the kube-apiserver would reject
+	ErrInvalidTokenProjection = errors.New("invalid token projection")
+)
+
+// TokenProjection - a serviceaccount token projected into the pods, e.g.
+// for a service authenticating against an external API with its own audience
+type TokenProjection struct {
+	// Path - path of the token file, relative to the mount path of the volume
+	Path string
+	// Audience - intended audience of the token, the kube-apiserver if empty
+	Audience string
+	// ExpirationSeconds - expiry of the token, DefaultTokenExpirationSeconds if 0.
+	// The kubelet rotates the token at 80% of it.
+	ExpirationSeconds int64
+}
+
+// ProjectedTokenVolume - returns the projected volume named name with the
+// tokens of projections
+func ProjectedTokenVolume(name string, projections ...TokenProjection) (corev1.Volume, error) {
+	sources := []corev1.VolumeProjection{}
+	for _, p := range projections {
+		if p.Path == "" || path.IsAbs(p.Path) {
+			return corev1.Volume{}, fmt.Errorf("%w: path %q must be relative", ErrInvalidTokenProjection, p.Path)
+		}
+		expiration := p.ExpirationSeconds
+		if expiration == 0 {
+			expiration = DefaultTokenExpirationSeconds
+		}
+		if expiration < MinTokenExpirationSeconds {
+			return corev1.Volume{}, fmt.Errorf("%w: expiration of %s must be at least %d seconds",
+				ErrInvalidTokenProjection, p.Path, MinTokenExpirationSeconds)
+		}
+		sources = append(sources, corev1.VolumeProjection{
+			ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+				Path:              p.Path,
+				Audience:          p.Audience,
+				ExpirationSeconds: ptr.To(expiration),
+			},
+		})
+	}
+
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: sources,
+			},
+		},
+	}, nil
+}
+
+// SetTokenAutomount - sets if the token of the serviceaccount gets mounted
+// into the pods of spec. Disabled automount together with InjectProjectedTokens
+// limits the token to the containers which need it.
+func SetTokenAutomount(spec *corev1.PodSpec, automount bool) {
+	spec.AutomountServiceAccountToken = ptr.To(automount)
+}
+
+// InjectProjectedTokens - adds the projected token volume named name to spec
+// and mounts it read only at mountPath into the containers of selector
+//
+// Example:
+//
+//	serviceaccount.SetTokenAutomount(&template.Spec, false)
+//	err := serviceaccount.InjectProjectedTokens(&template.Spec, "vault-token", "/var/run/secrets/tokens",
+//		pod.ContainerSelector{Containers: []string{"barbican-api"}},
+//		serviceaccount.TokenProjection{Path: "vault", Audience: "vault", ExpirationSeconds: 7200})
+func InjectProjectedTokens(
+	spec *corev1.PodSpec,
+	name string,
+	mountPath string,
+	selector pod.ContainerSelector,
+	projections ...TokenProjection,
+) error {
+	volume, err := ProjectedTokenVolume(name, projections...)
+	if err != nil {
+		return err
+	}
+	return pod.InjectVolumes(spec, pod.VolumeSet{
+		Volumes: []corev1.Volume{volume},
+		Mounts: []corev1.VolumeMount{
+			{Name: name, MountPath: mountPath, ReadOnly: true},
+		},
+	}, selector)
+}
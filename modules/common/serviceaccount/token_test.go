@@ -0,0 +1,57 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccount
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pod"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestInjectProjectedTokens(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "barbican-api"}, {Name: "barbican-log"}},
+	}
+	SetTokenAutomount(spec, false)
+	g.Expect(*spec.AutomountServiceAccountToken).To(BeFalse())
+
+	err := InjectProjectedTokens(spec, "vault-token", "/var/run/secrets/tokens",
+		pod.ContainerSelector{Containers: []string{"barbican-api"}},
+		TokenProjection{Path: "vault", Audience: "vault", ExpirationSeconds: 7200},
+		TokenProjection{Path: "api"})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(spec.Volumes).To(HaveLen(1))
+	sources := spec.Volumes[0].Projected.Sources
+	g.Expect(sources).To(HaveLen(2))
+	g.Expect(sources[0].ServiceAccountToken.Audience).To(Equal("vault"))
+	g.Expect(*sources[0].ServiceAccountToken.ExpirationSeconds).To(Equal(int64(7200)))
+	g.Expect(*sources[1].ServiceAccountToken.ExpirationSeconds).To(Equal(int64(DefaultTokenExpirationSeconds)))
+	g.Expect(spec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+		{Name: "vault-token", MountPath: "/var/run/secrets/tokens", ReadOnly: true},
+	}))
+	g.Expect(spec.Containers[1].VolumeMounts).To(BeEmpty())
+
+	_, err = ProjectedTokenVolume("token", TokenProjection{Path: "vault", ExpirationSeconds: 60})
+	g.Expect(err).To(MatchError(ErrInvalidTokenProjection))
+	_, err = ProjectedTokenVolume("token", TokenProjection{Path: "/vault"})
+	g.Expect(err).To(MatchError(ErrInvalidTokenProjection))
+}
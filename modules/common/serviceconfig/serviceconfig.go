@@ -0,0 +1,150 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceconfig
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultCustomServiceConfigKey - default key of the customServiceConfig in
+// the config secret
+const DefaultCustomServiceConfigKey = "custom.conf"
+
+// Rules - how a service accepts and renders its ConfigOverwrite
+// +kubebuilder:object:generate:=false
+type Rules struct {
+	// AllowedKeys - the file names allowed as defaultConfigOverwrite keys,
+	// either names or patterns of path.Match, e.g. "policy.yaml" or
+	// "*.json". No key is allowed if empty.
+	AllowedKeys []string
+	// CustomServiceConfigKey - the key of the customServiceConfig in the
+	// config secret, DefaultCustomServiceConfigKey if empty. It can not be
+	// overwritten via defaultConfigOverwrite.
+	CustomServiceConfigKey string
+}
+
+// customServiceConfigKey - returns the key of the customServiceConfig
+func (r Rules) customServiceConfigKey() string {
+	if r.CustomServiceConfigKey == "" {
+		return DefaultCustomServiceConfigKey
+	}
+	return r.CustomServiceConfigKey
+}
+
+// keyViolations - returns why the defaultConfigOverwrite key is not
+// accepted, none if it is
+func (r Rules) keyViolations(key string) []string {
+	violations := validation.IsConfigMapKey(key)
+	if len(violations) > 0 {
+		return violations
+	}
+	if strings.HasPrefix(key, ".") {
+		return []string{"must not be a hidden file"}
+	}
+	if key == r.customServiceConfigKey() {
+		return []string{"is reserved for the customServiceConfig"}
+	}
+	for _, pattern := range r.AllowedKeys {
+		if ok, _ := path.Match(pattern, key); ok {
+			return nil
+		}
+	}
+	if len(r.AllowedKeys) == 0 {
+		return []string{"no defaultConfigOverwrite is supported"}
+	}
+	return []string{fmt.Sprintf("only %s are supported", strings.Join(r.AllowedKeys, ", "))}
+}
+
+// Validate - validates the defaultConfigOverwrite keys of c against the
+// rules, for the webhook of the service CR. basePath is the path of the
+// inline ConfigOverwrite, the spec if embedded there.
+//
+// Example:
+//
+//	var configRules = serviceconfig.Rules{
+//		AllowedKeys:            []string{"policy.yaml", "logging.conf"},
+//		CustomServiceConfigKey: "02-config.conf",
+//	}
+//	...
+//	allErrs = append(allErrs, configRules.Validate(field.NewPath("spec"), r.Spec.ConfigOverwrite)...)
+func (r Rules) Validate(basePath *field.Path, c ConfigOverwrite) field.ErrorList {
+	allErrs := field.ErrorList{}
+	keysPath := basePath.Child("defaultConfigOverwrite")
+	for _, k := range sortedKeys(c.DefaultConfigOverwrite) {
+		for _, v := range r.keyViolations(k) {
+			allErrs = append(allErrs, field.Invalid(keysPath.Key(k), k, v))
+		}
+	}
+	return allErrs
+}
+
+// AddToTemplate - validates c against the rules and adds the
+// customServiceConfig and the defaultConfigOverwrite files to the CustomData
+// of the config secret template t, replacing rendered files of the same
+// name. Returns a util.ConfigValidationError, to be checked with
+// errors.Is(err, util.ErrInvalidConfig), if c is invalid.
+//
+// Example:
+//
+//	err := configRules.AddToTemplate(&cms[0], instance.Spec.ConfigOverwrite)
+//	if err != nil {
+//		return err
+//	}
+//	err = secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+func (r Rules) AddToTemplate(t *util.Template, c ConfigOverwrite) error {
+	violations := []string{}
+	for _, k := range sortedKeys(c.DefaultConfigOverwrite) {
+		for _, v := range r.keyViolations(k) {
+			violations = append(violations, fmt.Sprintf("defaultConfigOverwrite %s: %s", k, v))
+		}
+	}
+	if len(violations) > 0 {
+		return &util.ConfigValidationError{Name: t.Name, Violations: violations}
+	}
+
+	if t.CustomData == nil {
+		t.CustomData = map[string]string{}
+	}
+	t.CustomData[r.customServiceConfigKey()] = c.CustomServiceConfig
+	for k, v := range c.DefaultConfigOverwrite {
+		t.CustomData[k] = v
+	}
+	return nil
+}
+
+// Hash - returns the hash of c, e.g. to restart the service on changes
+// without hashing the whole config secret
+func (c ConfigOverwrite) Hash() (string, error) {
+	return util.ObjectHash(c)
+}
+
+// sortedKeys - returns the keys of m in order, for stable error messages
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
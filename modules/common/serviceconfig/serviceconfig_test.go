@@ -0,0 +1,137 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceconfig
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var rules = Rules{
+	AllowedKeys:            []string{"policy.yaml", "*.json"},
+	CustomServiceConfigKey: "02-config.conf",
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  Rules
+		keys   []string
+		errors []string
+	}{
+		{
+			name:  "allowed keys",
+			rules: rules,
+			keys:  []string{"policy.yaml", "api-paste.json"},
+		},
+		{
+			name:   "not allowed key",
+			rules:  rules,
+			keys:   []string{"policy.yaml", "keystone.conf"},
+			errors: []string{"spec.defaultConfigOverwrite[keystone.conf]"},
+		},
+		{
+			name:  "invalid file name",
+			rules: Rules{AllowedKeys: []string{"*"}},
+			keys:  []string{"../policy.yaml", ".hidden"},
+			// invalid characters and the leading ".."
+			errors: []string{"spec.defaultConfigOverwrite[../policy.yaml]", "spec.defaultConfigOverwrite[../policy.yaml]", "spec.defaultConfigOverwrite[.hidden]"},
+		},
+		{
+			name:   "customServiceConfig key",
+			rules:  Rules{AllowedKeys: []string{"*.conf"}},
+			keys:   []string{"custom.conf", "other.conf"},
+			errors: []string{"spec.defaultConfigOverwrite[custom.conf]"},
+		},
+		{
+			name:   "no allowed keys",
+			rules:  Rules{},
+			keys:   []string{"policy.yaml"},
+			errors: []string{"spec.defaultConfigOverwrite[policy.yaml]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := ConfigOverwrite{DefaultConfigOverwrite: map[string]string{}}
+			for _, k := range tt.keys {
+				c.DefaultConfigOverwrite[k] = "data"
+			}
+			errs := tt.rules.Validate(field.NewPath("spec"), c)
+			fields := []string{}
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			if len(tt.errors) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(fields).To(ConsistOf(tt.errors))
+			}
+		})
+	}
+}
+
+func TestAddToTemplate(t *testing.T) {
+	g := NewWithT(t)
+
+	tmpl := &util.Template{
+		Name:       "keystone-config-data",
+		CustomData: map[string]string{"my.cnf": "[client]"},
+	}
+	c := ConfigOverwrite{
+		CustomServiceConfig:    "[DEFAULT]\ndebug = true",
+		DefaultConfigOverwrite: map[string]string{"policy.yaml": "rules"},
+	}
+	g.Expect(rules.AddToTemplate(tmpl, c)).To(Succeed())
+	g.Expect(tmpl.CustomData).To(Equal(map[string]string{
+		"my.cnf":         "[client]",
+		"02-config.conf": "[DEFAULT]\ndebug = true",
+		"policy.yaml":    "rules",
+	}))
+
+	tmpl = &util.Template{Name: "keystone-config-data"}
+	c.DefaultConfigOverwrite["keystone.conf"] = "[DEFAULT]"
+	err := rules.AddToTemplate(tmpl, c)
+	g.Expect(errors.Is(err, util.ErrInvalidConfig)).To(BeTrue())
+	g.Expect(err.Error()).To(ContainSubstring("keystone-config-data"))
+	g.Expect(err.Error()).To(ContainSubstring("defaultConfigOverwrite keystone.conf"))
+	g.Expect(tmpl.CustomData).To(BeNil())
+}
+
+func TestHash(t *testing.T) {
+	g := NewWithT(t)
+
+	c := ConfigOverwrite{
+		CustomServiceConfig:    "[DEFAULT]\ndebug = true",
+		DefaultConfigOverwrite: map[string]string{"policy.yaml": "rules", "api-paste.json": "{}"},
+	}
+	hash, err := c.Hash()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	copied := c.DeepCopy()
+	g.Expect(copied.Hash()).To(Equal(hash))
+
+	copied.DefaultConfigOverwrite["policy.yaml"] = "other"
+	g.Expect(copied.Hash()).ToNot(Equal(hash))
+	g.Expect(c.DefaultConfigOverwrite["policy.yaml"]).To(Equal("rules"))
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:object:generate:=true
+
+// Package serviceconfig provides the customServiceConfig and
+// defaultConfigOverwrite API of the service operators and their rendering
+// into the config secret of a service
+package serviceconfig
+
+// ConfigOverwrite - the user provided config of a service, to be embedded
+// inline in the spec of a service CR
+type ConfigOverwrite struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=""
+	// CustomServiceConfig - customize the service config using this parameter to change service defaults,
+	// or overwrite rendered information using raw OpenStack config format. The content gets added
+	// to the /etc/<service>/<service>.conf.d directory as a custom config file.
+	CustomServiceConfig string `json:"customServiceConfig,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DefaultConfigOverwrite - interface to overwrite default config files like e.g. policy.yaml.
+	// The keys are the file names, only the ones the service allows are accepted.
+	DefaultConfigOverwrite map[string]string `json:"defaultConfigOverwrite,omitempty"`
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package singleton provides background tasks of an operator, e.g. periodic
+// pruning or metrics sweeps, which run in only one replica of the operator
+// at a time, guarded by a Lease of their own
+package singleton
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// DefaultLeaseDuration - default duration the other replicas wait
+	// before taking over the Lease of a Task which is not renewed
+	DefaultLeaseDuration = 15 * time.Second
+	// DefaultRenewDeadline - default duration the holder retries to renew
+	// the Lease before it stops the Task
+	DefaultRenewDeadline = 10 * time.Second
+	// DefaultRetryPeriod - default interval to try to acquire or renew the
+	// Lease
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+// Define static errors
+var (
+	// ErrInvalidTask indicates a Task which can not be started
+	ErrInvalidTask = errors.New("invalid singleton task")
+)
+
+// Task - a periodic background task run in only one replica of the operator
+// at a time, the one holding the Lease Name in Namespace. The Lease is
+// independent of the leader election of the manager, the task can run in a
+// different replica than the controllers and keeps running while the
+// manager changes its leader.
+type Task struct {
+	// Name - name of the task and of its Lease
+	Name string
+	// Namespace - namespace of the Lease, usually the one of the operator
+	Namespace string
+	// Identity - identity of the replica in the Lease, the hostname, i.e.
+	// the pod name, if empty
+	Identity string
+	// KClient - client for the Lease, e.g.
+	// kubernetes.NewForConfig(mgr.GetConfig())
+	KClient kubernetes.Interface
+	// Interval - interval Run gets called with while the Lease is held, the
+	// first call is right after acquiring it
+	Interval time.Duration
+	// Run - the work of the task. Its context is canceled when the Lease is
+	// lost or the manager stops. An error is logged and Run gets called
+	// again after the next Interval.
+	Run func(ctx context.Context) error
+	// Log - logger to report the Lease transitions and errors of Run
+	Log logr.Logger
+
+	// LeaseDuration - DefaultLeaseDuration if zero
+	LeaseDuration time.Duration
+	// RenewDeadline - DefaultRenewDeadline if zero
+	RenewDeadline time.Duration
+	// RetryPeriod - DefaultRetryPeriod if zero
+	RetryPeriod time.Duration
+
+	leading atomic.Bool
+	// running - held while the task runs, a re-acquired Lease waits for the
+	// run of the lost one to return
+	running sync.Mutex
+}
+
+// IsLeading - returns true while this replica holds the Lease and runs the
+// task
+func (t *Task) IsLeading() bool {
+	return t.leading.Load()
+}
+
+// NeedLeaderElection - implements the manager.LeaderElectionRunnable
+// interface. The task gets started in all replicas of the operator, not
+// only the leader of the manager, and competes for its own Lease.
+func (t *Task) NeedLeaderElection() bool {
+	return false
+}
+
+// elector - returns the leader elector of the Lease of the task
+func (t *Task) elector() (*leaderelection.LeaderElector, error) {
+	if t.Name == "" || t.Namespace == "" || t.KClient == nil || t.Run == nil || t.Interval <= 0 {
+		return nil, fmt.Errorf("%w: name, namespace, client, run and a positive interval are required", ErrInvalidTask)
+	}
+
+	identity := t.Identity
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("%w: no identity: %w", ErrInvalidTask, err)
+		}
+		identity = hostname
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      t.Name,
+			Namespace: t.Namespace,
+		},
+		Client:     t.KClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Name:            t.Name,
+		Lock:            lock,
+		LeaseDuration:   durationOrDefault(t.LeaseDuration, DefaultLeaseDuration),
+		RenewDeadline:   durationOrDefault(t.RenewDeadline, DefaultRenewDeadline),
+		RetryPeriod:     durationOrDefault(t.RetryPeriod, DefaultRetryPeriod),
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: t.lead,
+			// also called when the Lease was never acquired
+			OnStoppedLeading: func() {
+				if t.leading.Swap(false) {
+					t.Log.Info("Singleton task stopped", "task", t.Name, "identity", identity)
+				}
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTask, err)
+	}
+	return elector, nil
+}
+
+// lead - calls Run every Interval until ctx is done, i.e. the Lease is lost
+func (t *Task) lead(ctx context.Context) {
+	t.running.Lock()
+	defer t.running.Unlock()
+	t.leading.Store(true)
+	t.Log.Info("Singleton task started", "task", t.Name)
+
+	ticker := time.NewTicker(t.Interval)
+	defer ticker.Stop()
+	for {
+		err := t.Run(ctx)
+		if err != nil && ctx.Err() == nil {
+			t.Log.Error(err, "Singleton task failed, retrying after the interval", "task", t.Name)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Start - competes for the Lease until ctx is done and runs the task while
+// holding it, implements the manager.Runnable interface. A lost Lease stops
+// the task and the replica competes for it again. The Lease is released
+// when ctx is done, so another replica takes over without waiting for the
+// LeaseDuration.
+//
+// Example:
+//
+//	kclient, err := kubernetes.NewForConfig(mgr.GetConfig())
+//	...
+//	err = mgr.Add(&singleton.Task{
+//		Name:      "keystone-operator-token-flush",
+//		Namespace: operatorNamespace,
+//		KClient:   kclient,
+//		Interval:  time.Hour,
+//		Run:       flushExpiredTokens,
+//		Log:       ctrl.Log.WithName("token-flush"),
+//	})
+func (t *Task) Start(ctx context.Context) error {
+	elector, err := t.elector()
+	if err != nil {
+		return err
+	}
+	for ctx.Err() == nil {
+		// returns when the Lease is lost or ctx is done
+		elector.Run(ctx)
+	}
+	return nil
+}
+
+// durationOrDefault - returns d, or def if d is zero
+func durationOrDefault(d time.Duration, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package singleton
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func getTask(kclient kubernetes.Interface, identity string, runs *atomic.Int32) *Task {
+	return &Task{
+		Name:          "test-operator-prune",
+		Namespace:     "openstack-operators",
+		Identity:      identity,
+		KClient:       kclient,
+		Interval:      20 * time.Millisecond,
+		LeaseDuration: time.Second,
+		RenewDeadline: 500 * time.Millisecond,
+		RetryPeriod:   50 * time.Millisecond,
+		Log:           ctrl.Log,
+		Run: func(_ context.Context) error {
+			runs.Add(1)
+			return errors.New("failed runs get retried")
+		},
+	}
+}
+
+func TestTask(t *testing.T) {
+	g := NewWithT(t)
+
+	kclient := fake.NewSimpleClientset()
+	var runsA, runsB atomic.Int32
+	a := getTask(kclient, "operator-a", &runsA)
+	b := getTask(kclient, "operator-b", &runsB)
+	g.Expect(a.NeedLeaderElection()).To(BeFalse())
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	doneA := make(chan error)
+	go func() { doneA <- a.Start(ctxA) }()
+	g.Eventually(a.IsLeading).Should(BeTrue())
+	g.Eventually(runsA.Load).Should(BeNumerically(">", 2))
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	go func() { _ = b.Start(ctxB) }()
+	g.Consistently(b.IsLeading, 300*time.Millisecond).Should(BeFalse())
+	g.Expect(runsB.Load()).To(BeZero())
+
+	// the Lease gets released on stop, b takes over before it expires
+	cancelA()
+	g.Eventually(doneA).Should(Receive(BeNil()))
+	g.Expect(a.IsLeading()).To(BeFalse())
+	g.Eventually(b.IsLeading, 500*time.Millisecond).Should(BeTrue())
+	g.Eventually(runsB.Load).Should(BeNumerically(">", 0))
+}
+
+func TestTaskInvalid(t *testing.T) {
+	g := NewWithT(t)
+
+	var runs atomic.Int32
+	task := getTask(fake.NewSimpleClientset(), "operator-a", &runs)
+	task.Interval = 0
+	g.Expect(task.Start(context.Background())).To(MatchError(ErrInvalidTask))
+
+	task = getTask(fake.NewSimpleClientset(), "operator-a", &runs)
+	task.RenewDeadline = 2 * time.Second
+	g.Expect(task.Start(context.Background())).To(MatchError(ErrInvalidTask))
+	g.Expect(runs.Load()).To(BeZero())
+}
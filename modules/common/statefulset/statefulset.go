@@ -23,11 +23,15 @@ import (
 	"time"
 
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pod"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/scheduling"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	appsv1 "k8s.io/api/apps/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -43,6 +47,30 @@ func NewStatefulSet(
 	}
 }
 
+// SetScheduling - sets the nodeSelector and tolerations of the merged
+// operator defaults and user override on the pod template of the
+// statefulset. Must be called before CreateOrPatch.
+func (s *StatefulSet) SetScheduling(defaults scheduling.Spec, override scheduling.Spec) {
+	scheduling.Apply(&s.statefulset.Spec.Template.Spec, defaults, override)
+}
+
+// SetAdmissionDryRun - if enabled, CreateOrPatch first submits the
+// statefulset with server-side dry-run and returns an object.AdmissionError
+// if the admission rejects it, e.g. on an exceeded quota or a policy
+// violation, before anything gets applied
+func (s *StatefulSet) SetAdmissionDryRun(enabled bool) {
+	s.dryRun = enabled
+}
+
+// SetNormalizeTemplate - if enabled, CreateOrPatch brings the pod template
+// into the form the api server stores it in, see pod.NormalizeTemplate, so
+// that it does not get patched on every reconcile. Enabling it for an
+// existing statefulset changes its pod template once, e.g. the order of
+// the env vars and volumes, which triggers a rollout.
+func (s *StatefulSet) SetNormalizeTemplate(enabled bool) {
+	s.normalize = enabled
+}
+
 // CreateOrPatch - creates or patches a statefulset, reconciles after Xs if object won't exist.
 func (s *StatefulSet) CreateOrPatch(
 	ctx context.Context,
@@ -55,9 +83,14 @@ func (s *StatefulSet) CreateOrPatch(
 		},
 	}
 
-	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), statefulset, func() error {
+	if s.normalize {
+		pod.NormalizeTemplate(&s.statefulset.Spec.Template)
+	}
+
+	mutate := func() error {
 		statefulset.Labels = util.MergeStringMaps(statefulset.Labels, s.statefulset.Labels)
 		statefulset.Annotations = util.MergeStringMaps(statefulset.Annotations, s.statefulset.Annotations)
+		h.ApplyPropagatedMetadata(statefulset, s.statefulset.Labels, s.statefulset.Annotations)
 
 		// Selector and VolumeClaimTemplates are immutable after creation.
 		// Preserve the existing values so the full Spec overwrite below
@@ -92,17 +125,25 @@ func (s *StatefulSet) CreateOrPatch(
 			s.statefulset.Spec.Template.Spec.InitContainers,
 		)
 
-		return controllerutil.SetControllerReference(h.GetBeforeObject(), statefulset, h.GetScheme())
-	})
+		return object.SetControllerReference(h.GetBeforeObject(), statefulset, h.GetScheme())
+	}
+	if s.dryRun {
+		err := object.DryRun(ctx, h, statefulset, mutate)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), statefulset, mutate)
 	if err != nil {
 		if k8s_errors.IsNotFound(err) {
-			h.GetLogger().Info(fmt.Sprintf("StatefulSet %s not found, reconcile in %s", statefulset.Name, s.timeout))
+			h.GetLogger().Info("StatefulSet not found, reconcile later", "object", statefulset.Name, "requeueAfter", s.timeout)
 			return ctrl.Result{RequeueAfter: s.timeout}, nil
 		}
 		return ctrl.Result{}, err
 	}
 	if op != controllerutil.OperationResultNone {
-		h.GetLogger().Info(fmt.Sprintf("StatefulSet %s - %s", statefulset.Name, op))
+		h.GetLogger().Info("StatefulSet reconciled", "object", statefulset.Name, "operation", op)
 	}
 
 	// update the statefulset object of the statefulset type
@@ -136,6 +177,21 @@ func GetStatefulSetWithName(
 	return depl, nil
 }
 
+// GetReplicaReport - returns the replica counts of the StatefulSet, as of the
+// last CreateOrPatch, and the details of its pods which are not ready
+func (s *StatefulSet) GetReplicaReport(
+	ctx context.Context,
+	h *helper.Helper,
+) (*pod.ReplicaReport, error) {
+	return pod.GetReplicaReport(ctx, h, s.statefulset.Namespace, s.statefulset.Spec.Selector, pod.ReplicaReport{
+		Desired:   ptr.Deref(s.statefulset.Spec.Replicas, 1),
+		Current:   s.statefulset.Status.Replicas,
+		Ready:     s.statefulset.Status.ReadyReplicas,
+		Updated:   s.statefulset.Status.UpdatedReplicas,
+		Available: s.statefulset.Status.AvailableReplicas,
+	})
+}
+
 // Delete - delete a statefulset.
 func (s *StatefulSet) Delete(
 	ctx context.Context,
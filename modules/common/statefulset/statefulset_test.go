@@ -0,0 +1,77 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getStatefulSet(name string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openstack"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"service": name}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  name,
+						Image: name + ":latest",
+						Env:   []corev1.EnvVar{{Name: "B", Value: "b"}, {Name: "A", Value: "a"}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestCreateOrPatchNormalizeTemplate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "openstack", Namespace: "openstack", UID: "1234"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the template is kept as is by default
+	sts := NewStatefulSet(getStatefulSet("galera"), time.Second)
+	_, err = sts.CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	env := sts.GetStatefulSet().Spec.Template.Spec.Containers[0].Env
+	g.Expect(env[0].Name).To(Equal("B"))
+	g.Expect(sts.GetStatefulSet().Spec.Template.Spec.RestartPolicy).To(BeEmpty())
+
+	// and normalized if enabled
+	sts = NewStatefulSet(getStatefulSet("rabbitmq"), time.Second)
+	sts.SetNormalizeTemplate(true)
+	_, err = sts.CreateOrPatch(ctx, h)
+	g.Expect(err).ToNot(HaveOccurred())
+	env = sts.GetStatefulSet().Spec.Template.Spec.Containers[0].Env
+	g.Expect(env[0].Name).To(Equal("A"))
+	g.Expect(sts.GetStatefulSet().Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyAlways))
+}
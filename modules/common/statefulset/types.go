@@ -26,4 +26,6 @@ import (
 type StatefulSet struct {
 	statefulset *appsv1.StatefulSet
 	timeout     time.Duration
+	dryRun      bool
+	normalize   bool
 }
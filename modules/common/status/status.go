@@ -0,0 +1,158 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// +kubebuilder:object:generate:=true
+
+// Package status provides the conventional status block of the OpenStack
+// service CRs and the bookkeeping of it during a reconcile
+package status
+
+import (
+	"context"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Status - the conventional status fields of an OpenStack service CR, to be
+// embedded inline in the status of the CR
+type Status struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty"`
+
+	// Map of hashes to track e.g. job status
+	Hash map[string]string `json:"hash,omitempty"`
+
+	// ObservedGeneration - the most recent generation observed for this
+	// service. If the observed generation is less than the spec generation,
+	// then the controller has not processed the latest changes injected by
+	// the openstack-operator in the top-level CR (e.g. the ContainerImage)
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ReadyCount of the service
+	ReadyCount int32 `json:"readyCount,omitempty"`
+
+	// NetworkAttachments status of the deployment pods
+	NetworkAttachments map[string][]string `json:"networkAttachments,omitempty"`
+}
+
+// Tracker - tracks the changes of the Status of a CR during a reconcile
+// +kubebuilder:object:generate:=false
+type Tracker struct {
+	status          *Status
+	savedConditions condition.Conditions
+}
+
+// Begin - starts the reconcile of a CR at generation. The conditions get
+// initialized with the ReadyCondition and the given conditions, all
+// Unknown, the observedGeneration is set and the maps are initialized.
+// The previous conditions are saved to keep their LastTransitionTime if
+// they end in the same state.
+//
+// Example:
+//
+//	func (r *KeystoneAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, _err error) {
+//		...
+//		st := status.Begin(&instance.Status.Status, instance.Generation,
+//			condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
+//		)
+//		defer func() {
+//			if err := st.Patch(ctx, h, instance); err != nil {
+//				_err = err
+//			}
+//		}()
+//		...
+//	}
+func Begin(s *Status, generation int64, conditions ...*condition.Condition) *Tracker {
+	t := &Tracker{
+		status:          s,
+		savedConditions: s.Conditions.DeepCopy(),
+	}
+
+	cl := condition.CreateList(conditions...)
+	s.Conditions.Init(&cl)
+	s.ObservedGeneration = generation
+	if s.Hash == nil {
+		s.Hash = map[string]string{}
+	}
+	if s.NetworkAttachments == nil {
+		s.NetworkAttachments = map[string][]string{}
+	}
+
+	return t
+}
+
+// SetHash - sets the hash of key, returns true if it changed
+func (t *Tracker) SetHash(key string, value string) bool {
+	var changed bool
+	t.status.Hash, changed = util.SetHash(t.status.Hash, key, value)
+	return changed
+}
+
+// SetReadyCount - sets the ReadyCount
+func (t *Tracker) SetReadyCount(readyCount int32) {
+	t.status.ReadyCount = readyCount
+}
+
+// SetNetworkAttachments - sets the NetworkAttachments
+func (t *Tracker) SetNetworkAttachments(networkAttachments map[string][]string) {
+	t.status.NetworkAttachments = networkAttachments
+}
+
+// Finalize - sets the ReadyCondition to True if all other conditions are
+// True, otherwise mirrors the one with the highest priority into it if it
+// is still Unknown, and restores the LastTransitionTime of the conditions
+// which did not change their state during the reconcile
+func (t *Tracker) Finalize() {
+	if t.status.Conditions.AllSubConditionIsTrue() {
+		t.status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+	} else if t.status.Conditions.IsUnknown(condition.ReadyCondition) {
+		t.status.Conditions.Set(t.status.Conditions.Mirror(condition.ReadyCondition))
+	}
+	condition.RestoreLastTransitionTimes(&t.status.Conditions, t.savedConditions)
+}
+
+// Patch - finalizes the status and patches it on instance, which must hold
+// the Status the Tracker was created for. On a conflict the latest version
+// of instance is read and the patch retried, the status of instance wins
+// over the one of the latest version. Metadata changes, e.g. finalizers,
+// are not patched.
+func (t *Tracker) Patch(ctx context.Context, h *helper.Helper, instance client.Object) error {
+	t.Finalize()
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := instance.DeepCopyObject().(client.Object)
+		err := h.GetClient().Get(ctx, client.ObjectKeyFromObject(instance), latest)
+		if err != nil {
+			return err
+		}
+		// keep the status of instance, the patch response overwrites it
+		desired := instance.DeepCopyObject().(client.Object)
+		desired.SetResourceVersion(latest.GetResourceVersion())
+
+		err = h.GetClient().Status().Patch(ctx, desired, client.MergeFromWithOptions(latest, client.MergeFromWithOptimisticLock{}))
+		if err != nil {
+			return err
+		}
+		instance.SetResourceVersion(desired.GetResourceVersion())
+		return nil
+	})
+	// the instance got deleted in the meantime
+	return client.IgnoreNotFound(err)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestTracker(t *testing.T) {
+	g := NewWithT(t)
+
+	transition := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	s := &Status{
+		Conditions: condition.Conditions{
+			{
+				Type:               condition.DeploymentReadyCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             condition.ReadyReason,
+				Message:            condition.DeploymentReadyMessage,
+				LastTransitionTime: transition,
+			},
+		},
+	}
+
+	st := Begin(s, 3,
+		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
+		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
+	)
+	g.Expect(s.ObservedGeneration).To(Equal(int64(3)))
+	g.Expect(s.Hash).ToNot(BeNil())
+	g.Expect(s.NetworkAttachments).ToNot(BeNil())
+	g.Expect(s.Conditions.IsUnknown(condition.ReadyCondition)).To(BeTrue())
+
+	g.Expect(st.SetHash("dbsync", "abc")).To(BeTrue())
+	g.Expect(st.SetHash("dbsync", "abc")).To(BeFalse())
+	st.SetReadyCount(2)
+	st.SetNetworkAttachments(map[string][]string{"openstack/internalapi": {"172.17.0.30"}})
+	g.Expect(s.ReadyCount).To(Equal(int32(2)))
+	g.Expect(s.NetworkAttachments).To(HaveKey("openstack/internalapi"))
+
+	s.Conditions.MarkTrue(condition.DeploymentReadyCondition, condition.DeploymentReadyMessage)
+	s.Conditions.MarkFalse(condition.ServiceConfigReadyCondition, condition.ErrorReason, condition.SeverityWarning, condition.ServiceConfigReadyErrorMessage, "failed")
+
+	// Ready mirrors the failed condition, the unchanged one keeps its time
+	st.Finalize()
+	g.Expect(s.Conditions.IsFalse(condition.ReadyCondition)).To(BeTrue())
+	g.Expect(s.Conditions.Get(condition.DeploymentReadyCondition).LastTransitionTime).To(Equal(transition))
+
+	s.Conditions.MarkTrue(condition.ServiceConfigReadyCondition, condition.ServiceConfigReadyMessage)
+	st.Finalize()
+	g.Expect(s.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+}
+
+func TestPatchConflict(t *testing.T) {
+	g := NewWithT(t)
+
+	depl := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "keystone",
+			Namespace: "openstack",
+		},
+	}
+
+	conflicts := 0
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(depl).
+		WithStatusSubresource(depl).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				if conflicts == 0 {
+					conflicts++
+					return k8s_errors.NewConflict(schema.GroupResource{Resource: "deployments"}, obj.GetName(), nil)
+				}
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	instance := &appsv1.Deployment{}
+	g.Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(depl), instance)).To(Succeed())
+	h, err := helper.NewHelper(instance, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	st := Begin(&Status{}, 1)
+	instance.Status.ReadyReplicas = 1
+	g.Expect(st.Patch(context.TODO(), h, instance)).To(Succeed())
+	g.Expect(conflicts).To(Equal(1))
+
+	latest := &appsv1.Deployment{}
+	g.Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(depl), latest)).To(Succeed())
+	g.Expect(latest.Status.ReadyReplicas).To(Equal(int32(1)))
+	g.Expect(instance.ResourceVersion).To(Equal(latest.ResourceVersion))
+}
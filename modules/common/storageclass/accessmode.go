@@ -0,0 +1,274 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageclass
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// AccessModesAnnotation - annotation on a StorageClass to explicitly list
+	// the supported access modes as a comma separated list, e.g.
+	// "ReadWriteOnce,ReadWriteMany". It takes precedence over the provisioner
+	// based detection.
+	AccessModesAnnotation = "storage.openstack.org/access-modes"
+
+	// probePvcSize - size of the pvc created by ProbeAccessMode
+	probePvcSize = "1Mi"
+)
+
+// ProbeResult - result of an access mode probe
+type ProbeResult string
+
+const (
+	// ProbeSupported - the probe pvc got bound
+	ProbeSupported ProbeResult = "Supported"
+	// ProbeUnsupported - the provisioning of the probe pvc failed
+	ProbeUnsupported ProbeResult = "Unsupported"
+	// ProbePending - the probe pvc is not yet bound
+	ProbePending ProbeResult = "Pending"
+)
+
+var allAccessModes = []corev1.PersistentVolumeAccessMode{
+	corev1.ReadWriteOnce,
+	corev1.ReadOnlyMany,
+	corev1.ReadWriteMany,
+	corev1.ReadWriteOncePod,
+}
+
+var rwoAccessModes = []corev1.PersistentVolumeAccessMode{
+	corev1.ReadWriteOnce,
+	corev1.ReadWriteOncePod,
+}
+
+// KnownProvisionerAccessModes - access modes supported by well known
+// provisioners. Provisioners not in the list are reported as unknown.
+var KnownProvisionerAccessModes = map[string][]corev1.PersistentVolumeAccessMode{
+	// shared file systems
+	"openshift-storage.cephfs.csi.ceph.com": allAccessModes,
+	"cephfs.csi.ceph.com":                   allAccessModes,
+	"nfs.csi.k8s.io":                        allAccessModes,
+	"manila.csi.openstack.org":              allAccessModes,
+	"efs.csi.aws.com":                       allAccessModes,
+	"file.csi.azure.com":                    allAccessModes,
+	"filestore.csi.storage.gke.io":          allAccessModes,
+	// block devices, RWX only for volumeMode Block, which is not usable for
+	// shared file system mounts
+	"openshift-storage.rbd.csi.ceph.com": rwoAccessModes,
+	"rbd.csi.ceph.com":                   rwoAccessModes,
+	"cinder.csi.openstack.org":           rwoAccessModes,
+	"ebs.csi.aws.com":                    rwoAccessModes,
+	"disk.csi.azure.com":                 rwoAccessModes,
+	"pd.csi.storage.gke.io":              rwoAccessModes,
+	"topolvm.io":                         rwoAccessModes,
+	"kubernetes.io/no-provisioner":       rwoAccessModes,
+}
+
+// GetSupportedAccessModes - returns the access modes the StorageClass
+// supports for file system volumes, based on the AccessModesAnnotation or
+// the KnownProvisionerAccessModes. The bool return is false if the
+// supported access modes are unknown.
+func GetSupportedAccessModes(sc *storagev1.StorageClass) ([]corev1.PersistentVolumeAccessMode, bool) {
+	if value, ok := sc.Annotations[AccessModesAnnotation]; ok {
+		modes := []corev1.PersistentVolumeAccessMode{}
+		for _, m := range strings.Split(value, ",") {
+			m = strings.TrimSpace(m)
+			if m != "" {
+				modes = append(modes, corev1.PersistentVolumeAccessMode(m))
+			}
+		}
+		return modes, true
+	}
+
+	modes, ok := KnownProvisionerAccessModes[sc.Provisioner]
+	return modes, ok
+}
+
+// SupportsAccessMode - returns true if the StorageClass with the given name
+// supports the access mode. If the support can not be determined from the
+// StorageClass an error wrapping ErrStorageClassAccessModeUnknown is
+// returned, ProbeAccessMode can be used in this case.
+//
+// For CSI provisioners it also verifies the CSIDriver is registered in the
+// cluster, an error wrapping ErrCSIDriverNotFound is returned otherwise, and
+// that the driver supports persistent volumes. The CSIDriver does not
+// publish the access modes of the driver, those are always taken from the
+// StorageClass.
+func SupportsAccessMode(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	mode corev1.PersistentVolumeAccessMode,
+) (bool, error) {
+	sc, err := GetStorageClass(ctx, h, name)
+	if err != nil {
+		return false, err
+	}
+
+	modes, known := GetSupportedAccessModes(sc)
+	if !known {
+		return false, fmt.Errorf("%w: %s with provisioner %s", ErrStorageClassAccessModeUnknown, sc.Name, sc.Provisioner)
+	}
+
+	if strings.Contains(sc.Provisioner, ".") && !strings.HasPrefix(sc.Provisioner, "kubernetes.io/") {
+		driver := &storagev1.CSIDriver{}
+		err = h.GetClient().Get(ctx, types.NamespacedName{Name: sc.Provisioner}, driver)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				return false, fmt.Errorf("%w: %s of StorageClass %s", ErrCSIDriverNotFound, sc.Provisioner, sc.Name)
+			}
+			return false, err
+		}
+		// an empty list defaults to Persistent
+		lifecycleModes := driver.Spec.VolumeLifecycleModes
+		if len(lifecycleModes) > 0 && !slices.Contains(lifecycleModes, storagev1.VolumeLifecyclePersistent) {
+			return false, nil
+		}
+	}
+
+	return slices.Contains(modes, mode), nil
+}
+
+// ProbeAccessMode - probes if the StorageClass supports the access mode by
+// creating a small pvc named probeName in the namespace. The result is
+// ProbePending and a requeue is requested until the pvc got bound or the
+// provisioning failed. The probe pvc is deleted when a final result got
+// reached. Only StorageClasses with the Immediate volume binding mode can
+// be probed. The helper needs a kubernetes clientset to look up the
+// provisioning events of the pvc.
+func ProbeAccessMode(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	mode corev1.PersistentVolumeAccessMode,
+	probeName string,
+	namespace string,
+	timeout time.Duration,
+) (ProbeResult, ctrl.Result, error) {
+	if h.GetKClient() == nil {
+		return "", ctrl.Result{}, ErrNoKubernetesClient
+	}
+	sc, err := GetStorageClass(ctx, h, name)
+	if err != nil {
+		return "", ctrl.Result{}, err
+	}
+	err = Validate(sc, Requirements{VolumeBindingModes: []storagev1.VolumeBindingMode{storagev1.VolumeBindingImmediate}})
+	if err != nil {
+		return "", ctrl.Result{}, err
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: probeName, Namespace: namespace}, pvc)
+	if err != nil {
+		if !k8s_errors.IsNotFound(err) {
+			return "", ctrl.Result{}, err
+		}
+
+		pvc = &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      probeName,
+				Namespace: namespace,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{mode},
+				StorageClassName: &sc.Name,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(probePvcSize),
+					},
+				},
+			},
+		}
+		err = h.GetClient().Create(ctx, pvc)
+		if err != nil {
+			return "", ctrl.Result{}, err
+		}
+		h.GetLogger().Info("Probing StorageClass access mode", "storageClass", sc.Name, "accessMode", mode, "pvc", probeName)
+
+		return ProbePending, ctrl.Result{RequeueAfter: timeout}, nil
+	}
+
+	result := ProbePending
+	if pvc.Status.Phase == corev1.ClaimBound {
+		result = ProbeSupported
+	} else {
+		failed, err := hasProvisioningFailed(ctx, h, pvc)
+		if err != nil {
+			return "", ctrl.Result{}, err
+		}
+		if failed {
+			result = ProbeUnsupported
+		}
+	}
+
+	if result == ProbePending {
+		return result, ctrl.Result{RequeueAfter: timeout}, nil
+	}
+
+	h.GetLogger().Info("StorageClass access mode probed", "storageClass", sc.Name, "accessMode", mode, "result", result)
+	err = h.GetClient().Delete(ctx, pvc)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return result, ctrl.Result{}, err
+	}
+
+	return result, ctrl.Result{}, nil
+}
+
+// hasProvisioningFailed - returns true if a ProvisioningFailed event got
+// recorded for the pvc
+func hasProvisioningFailed(
+	ctx context.Context,
+	h *helper.Helper,
+	pvc *corev1.PersistentVolumeClaim,
+) (bool, error) {
+	events, err := h.GetKClient().CoreV1().Events(pvc.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": "PersistentVolumeClaim",
+			"involvedObject.name": pvc.Name,
+			"reason":              "ProvisioningFailed",
+		}).String(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, e := range events.Items {
+		// an event of an earlier pvc with the same name has another uid
+		if e.InvolvedObject.Kind == "PersistentVolumeClaim" &&
+			e.InvolvedObject.Name == pvc.Name &&
+			(e.InvolvedObject.UID == "" || e.InvolvedObject.UID == pvc.UID) &&
+			e.Reason == "ProvisioningFailed" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
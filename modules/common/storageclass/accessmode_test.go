@@ -0,0 +1,162 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageclass
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestGetSupportedAccessModes(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Now()
+
+	cephfs := getStorageClass("cephfs", false, now)
+	cephfs.Provisioner = "openshift-storage.cephfs.csi.ceph.com"
+	modes, known := GetSupportedAccessModes(cephfs)
+	g.Expect(known).To(BeTrue())
+	g.Expect(modes).To(ContainElement(corev1.ReadWriteMany))
+
+	unknown := getStorageClass("unknown", false, now)
+	_, known = GetSupportedAccessModes(unknown)
+	g.Expect(known).To(BeFalse())
+	// unknown support does not fail the validation
+	g.Expect(Validate(unknown, Requirements{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+	})).To(Succeed())
+
+	annotated := getStorageClass("annotated", false, now)
+	annotated.Annotations = map[string]string{AccessModesAnnotation: "ReadWriteOnce, ReadWriteMany"}
+	modes, known = GetSupportedAccessModes(annotated)
+	g.Expect(known).To(BeTrue())
+	g.Expect(modes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadWriteMany}))
+
+	rbd := getStorageClass("rbd", false, now)
+	rbd.Provisioner = "rbd.csi.ceph.com"
+	g.Expect(Validate(rbd, Requirements{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+	})).To(MatchError(ErrStorageClassAccessMode))
+}
+
+func TestSupportsAccessMode(t *testing.T) {
+	now := time.Now()
+
+	cephfs := getStorageClass("cephfs", false, now)
+	cephfs.Provisioner = "cephfs.csi.ceph.com"
+	nfs := getStorageClass("nfs", false, now)
+	nfs.Provisioner = "nfs.csi.k8s.io"
+	manila := getStorageClass("manila", false, now)
+	manila.Provisioner = "manila.csi.openstack.org"
+	unknown := getStorageClass("unknown", false, now)
+	driver := &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "cephfs.csi.ceph.com"}}
+	ephemeralDriver := &storagev1.CSIDriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "manila.csi.openstack.org"},
+		Spec: storagev1.CSIDriverSpec{
+			VolumeLifecycleModes: []storagev1.VolumeLifecycleMode{storagev1.VolumeLifecycleEphemeral},
+		},
+	}
+
+	h, err := setupHelper(cephfs, nfs, manila, unknown, driver, ephemeralDriver)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	t.Run("RWX supported", func(t *testing.T) {
+		g := NewWithT(t)
+		ok, err := SupportsAccessMode(context.TODO(), h, "cephfs", corev1.ReadWriteMany)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+	})
+
+	t.Run("CSIDriver not registered", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := SupportsAccessMode(context.TODO(), h, "nfs", corev1.ReadWriteMany)
+		g.Expect(err).To(MatchError(ErrCSIDriverNotFound))
+		g.Expect(GetStorageClassReadyCondition(err).Reason).To(BeEquivalentTo(condition.RequestedReason))
+	})
+
+	t.Run("CSIDriver without persistent volumes", func(t *testing.T) {
+		g := NewWithT(t)
+		ok, err := SupportsAccessMode(context.TODO(), h, "manila", corev1.ReadWriteMany)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeFalse())
+	})
+
+	t.Run("Unknown provisioner", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := SupportsAccessMode(context.TODO(), h, "unknown", corev1.ReadWriteMany)
+		g.Expect(err).To(MatchError(ErrStorageClassAccessModeUnknown))
+	})
+}
+
+func TestProbeAccessMode(t *testing.T) {
+	g := NewWithT(t)
+
+	sc := getStorageClass("unknown", false, time.Now())
+	h, err := setupHelper(sc)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, _, err = ProbeAccessMode(context.TODO(), h, sc.Name, corev1.ReadWriteMany, "rwx-probe", "openstack", time.Second)
+	g.Expect(err).To(MatchError(ErrNoKubernetesClient))
+
+	kclient := kfake.NewSimpleClientset()
+	h, err = helper.NewHelper(h.GetBeforeObject(), h.GetClient(), kclient, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	probe := types.NamespacedName{Name: "rwx-probe", Namespace: "openstack"}
+
+	// first call creates the probe pvc
+	result, ctrlResult, err := ProbeAccessMode(context.TODO(), h, sc.Name, corev1.ReadWriteMany, probe.Name, probe.Namespace, time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ProbePending))
+	g.Expect(ctrlResult.RequeueAfter).To(Equal(time.Second))
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	g.Expect(h.GetClient().Get(context.TODO(), probe, pvc)).To(Succeed())
+	g.Expect(pvc.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}))
+
+	// provisioning failed
+	_, err = kclient.CoreV1().Events(probe.Namespace).Create(context.TODO(), &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "rwx-probe.1", Namespace: probe.Namespace},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: probe.Name,
+		},
+		Reason: "ProvisioningFailed",
+	}, metav1.CreateOptions{})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	result, ctrlResult, err = ProbeAccessMode(context.TODO(), h, sc.Name, corev1.ReadWriteMany, probe.Name, probe.Namespace, time.Second)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ProbeUnsupported))
+	g.Expect(ctrlResult.RequeueAfter).To(BeZero())
+
+	// the probe pvc got deleted
+	err = h.GetClient().Get(context.TODO(), probe, pvc)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storageclass provides utilities to detect and validate Kubernetes StorageClass resources
+package storageclass
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// IsDefaultStorageClassAnnotation - annotation marking the default StorageClass
+	IsDefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	// BetaIsDefaultStorageClassAnnotation - deprecated beta annotation marking the default StorageClass
+	BetaIsDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// Define static errors
+var (
+	// ErrNoDefaultStorageClass indicates that the cluster has no default StorageClass
+	ErrNoDefaultStorageClass = errors.New("no default StorageClass found")
+	// ErrStorageClassNotFound indicates that the StorageClass does not exist
+	ErrStorageClassNotFound = errors.New("StorageClass not found")
+	// ErrStorageClassExpansionNotAllowed indicates that the StorageClass does not allow volume expansion
+	ErrStorageClassExpansionNotAllowed = errors.New("StorageClass does not allow volume expansion")
+	// ErrStorageClassBindingMode indicates that the StorageClass has an unexpected volume binding mode
+	ErrStorageClassBindingMode = errors.New("StorageClass has an unsupported volume binding mode")
+	// ErrStorageClassAccessMode indicates that the StorageClass does not support a required access mode
+	ErrStorageClassAccessMode = errors.New("StorageClass does not support the access mode")
+	// ErrStorageClassAccessModeUnknown indicates that the supported access modes of the StorageClass are unknown
+	ErrStorageClassAccessModeUnknown = errors.New("StorageClass access mode support unknown")
+	// ErrCSIDriverNotFound indicates that the CSIDriver of the StorageClass provisioner is not registered
+	ErrCSIDriverNotFound = errors.New("CSIDriver not found")
+	// ErrNoKubernetesClient indicates that the helper has no kubernetes clientset
+	ErrNoKubernetesClient = errors.New("helper has no kubernetes client")
+)
+
+// Requirements - the properties a StorageClass has to provide
+type Requirements struct {
+	// AllowVolumeExpansion - the StorageClass has to allow volume expansion
+	AllowVolumeExpansion bool
+	// VolumeBindingModes - the accepted binding modes, any mode if empty
+	VolumeBindingModes []storagev1.VolumeBindingMode
+	// AccessModes - the access modes the StorageClass has to support. The
+	// check is skipped if the supported access modes of the StorageClass
+	// are unknown, see GetSupportedAccessModes.
+	AccessModes []corev1.PersistentVolumeAccessMode
+}
+
+// IsDefault - returns true if the StorageClass is annotated as the cluster default
+func IsDefault(sc *storagev1.StorageClass) bool {
+	return sc.Annotations[IsDefaultStorageClassAnnotation] == "true" ||
+		sc.Annotations[BetaIsDefaultStorageClassAnnotation] == "true"
+}
+
+// GetStorageClass - returns the StorageClass with the given name. The returned
+// error wraps ErrStorageClassNotFound if the StorageClass does not exist.
+func GetStorageClass(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+) (*storagev1.StorageClass, error) {
+	sc := &storagev1.StorageClass{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name}, sc)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrStorageClassNotFound, name)
+		}
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+// GetDefaultStorageClass - returns the default StorageClass of the cluster. If
+// multiple StorageClasses are marked as default the newest one is returned,
+// matching the behavior of the kube-apiserver admission plugin.
+func GetDefaultStorageClass(
+	ctx context.Context,
+	h *helper.Helper,
+) (*storagev1.StorageClass, error) {
+	scList := &storagev1.StorageClassList{}
+	err := h.GetClient().List(ctx, scList)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultSC *storagev1.StorageClass
+	for i := range scList.Items {
+		sc := &scList.Items[i]
+		if !IsDefault(sc) {
+			continue
+		}
+		if defaultSC == nil ||
+			defaultSC.CreationTimestamp.Before(&sc.CreationTimestamp) ||
+			(defaultSC.CreationTimestamp.Equal(&sc.CreationTimestamp) && sc.Name < defaultSC.Name) {
+			defaultSC = sc
+		}
+	}
+
+	if defaultSC == nil {
+		return nil, ErrNoDefaultStorageClass
+	}
+
+	return defaultSC, nil
+}
+
+// GetStorageClassName - returns the requested StorageClass name, or the name
+// of the default StorageClass if none was requested. Use it to default the
+// spec.storageClass of a CR.
+func GetStorageClassName(
+	ctx context.Context,
+	h *helper.Helper,
+	requested string,
+) (string, error) {
+	if requested != "" {
+		return requested, nil
+	}
+
+	sc, err := GetDefaultStorageClass(ctx, h)
+	if err != nil {
+		return "", err
+	}
+
+	return sc.Name, nil
+}
+
+// Validate - validates the StorageClass meets the requirements
+func Validate(sc *storagev1.StorageClass, req Requirements) error {
+	if req.AllowVolumeExpansion &&
+		(sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion) {
+		return fmt.Errorf("%w: %s", ErrStorageClassExpansionNotAllowed, sc.Name)
+	}
+
+	if len(req.AccessModes) > 0 {
+		supported, known := GetSupportedAccessModes(sc)
+		if known {
+			for _, m := range req.AccessModes {
+				if !slices.Contains(supported, m) {
+					return fmt.Errorf("%w: %s does not support %s", ErrStorageClassAccessMode, sc.Name, m)
+				}
+			}
+		}
+	}
+
+	if len(req.VolumeBindingModes) > 0 {
+		// Immediate is the default if not set
+		mode := storagev1.VolumeBindingImmediate
+		if sc.VolumeBindingMode != nil {
+			mode = *sc.VolumeBindingMode
+		}
+		if !slices.Contains(req.VolumeBindingModes, mode) {
+			return fmt.Errorf("%w: %s has %s, expected one of %v", ErrStorageClassBindingMode, sc.Name, mode, req.VolumeBindingModes)
+		}
+	}
+
+	return nil
+}
+
+// ValidateStorageClass - validates the StorageClass with the given name exists
+// and meets the requirements. If name is empty the default StorageClass gets
+// validated.
+func ValidateStorageClass(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	req Requirements,
+) (*storagev1.StorageClass, error) {
+	var sc *storagev1.StorageClass
+	var err error
+	if name == "" {
+		sc, err = GetDefaultStorageClass(ctx, h)
+	} else {
+		sc, err = GetStorageClass(ctx, h, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return sc, Validate(sc, req)
+}
+
+// GetStorageClassReadyCondition - returns the StorageClassReadyCondition
+// reflecting the result of ValidateStorageClass or SupportsAccessMode. A
+// missing StorageClass or CSIDriver is reported with the RequestedReason as
+// it can show up later, any other
+// validation failure is reported as an error.
+func GetStorageClassReadyCondition(err error) *condition.Condition {
+	switch {
+	case err == nil:
+		return condition.TrueCondition(
+			condition.StorageClassReadyCondition,
+			condition.StorageClassReadyMessage)
+	case errors.Is(err, ErrNoDefaultStorageClass), errors.Is(err, ErrStorageClassNotFound),
+		errors.Is(err, ErrCSIDriverNotFound):
+		return condition.FalseCondition(
+			condition.StorageClassReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityWarning,
+			condition.StorageClassReadyWaitingMessage,
+			err.Error())
+	default:
+		return condition.FalseCondition(
+			condition.StorageClassReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			condition.StorageClassReadyErrorMessage,
+			err.Error())
+	}
+}
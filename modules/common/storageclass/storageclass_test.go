@@ -0,0 +1,189 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageclass
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func setupHelper(objs ...client.Object) (*helper.Helper, error) {
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objs...).
+		Build()
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+		},
+	}
+
+	return helper.NewHelper(ns, fakeClient, nil, scheme.Scheme, ctrl.Log)
+}
+
+func getStorageClass(name string, isDefault bool, created time.Time) *storagev1.StorageClass {
+	sc := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Provisioner: "csi.example.com",
+	}
+	if isDefault {
+		sc.Annotations = map[string]string{IsDefaultStorageClassAnnotation: "true"}
+	}
+	return sc
+}
+
+func TestGetDefaultStorageClass(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	t.Run("No default StorageClass", func(t *testing.T) {
+		g := NewWithT(t)
+		h, err := setupHelper(getStorageClass("local", false, now))
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = GetDefaultStorageClass(context.TODO(), h)
+		g.Expect(err).To(MatchError(ErrNoDefaultStorageClass))
+
+		cond := GetStorageClassReadyCondition(err)
+		g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+		g.Expect(cond.Reason).To(Equal(condition.Reason(condition.RequestedReason)))
+	})
+
+	t.Run("Single default StorageClass", func(t *testing.T) {
+		g := NewWithT(t)
+		h, err := setupHelper(
+			getStorageClass("local", false, now),
+			getStorageClass("ceph", true, now),
+		)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		sc, err := GetDefaultStorageClass(context.TODO(), h)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(sc.Name).To(Equal("ceph"))
+
+		name, err := GetStorageClassName(context.TODO(), h, "")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(name).To(Equal("ceph"))
+
+		name, err = GetStorageClassName(context.TODO(), h, "local")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(name).To(Equal("local"))
+	})
+
+	t.Run("Multiple default StorageClasses, newest wins", func(t *testing.T) {
+		g := NewWithT(t)
+		h, err := setupHelper(
+			getStorageClass("old", true, now.Add(-time.Hour)),
+			getStorageClass("new", true, now),
+		)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		sc, err := GetDefaultStorageClass(context.TODO(), h)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(sc.Name).To(Equal("new"))
+	})
+}
+
+func TestValidateStorageClass(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	expandable := getStorageClass("expandable", false, now)
+	expandable.AllowVolumeExpansion = ptr.To(true)
+	expandable.VolumeBindingMode = ptr.To(storagev1.VolumeBindingWaitForFirstConsumer)
+
+	fixed := getStorageClass("fixed", true, now)
+
+	h, err := setupHelper(expandable, fixed)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name    string
+		sc      string
+		req     Requirements
+		wantErr error
+	}{
+		{
+			name: "no requirements",
+			sc:   "fixed",
+		},
+		{
+			name: "default StorageClass",
+			sc:   "",
+		},
+		{
+			name:    "missing StorageClass",
+			sc:      "missing",
+			wantErr: ErrStorageClassNotFound,
+		},
+		{
+			name: "expansion allowed",
+			sc:   "expandable",
+			req:  Requirements{AllowVolumeExpansion: true},
+		},
+		{
+			name:    "expansion not allowed",
+			sc:      "fixed",
+			req:     Requirements{AllowVolumeExpansion: true},
+			wantErr: ErrStorageClassExpansionNotAllowed,
+		},
+		{
+			name: "binding mode matches",
+			sc:   "expandable",
+			req: Requirements{VolumeBindingModes: []storagev1.VolumeBindingMode{
+				storagev1.VolumeBindingWaitForFirstConsumer,
+			}},
+		},
+		{
+			name: "binding mode defaults to Immediate",
+			sc:   "fixed",
+			req: Requirements{VolumeBindingModes: []storagev1.VolumeBindingMode{
+				storagev1.VolumeBindingWaitForFirstConsumer,
+			}},
+			wantErr: ErrStorageClassBindingMode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := ValidateStorageClass(context.TODO(), h, tt.sc, tt.req)
+			if tt.wantErr != nil {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
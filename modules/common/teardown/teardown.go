@@ -0,0 +1,251 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package teardown provides the deletion of the children of a CR in a
+// declared order from its finalizer, e.g. to deregister the keystone
+// endpoints before the routes and services get deleted, instead of leaving
+// it to the unordered garbage collection
+package teardown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/hooks"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Define static errors
+var (
+	// ErrInvalidStage indicates that the stage definition is incomplete
+	ErrInvalidStage = errors.New("invalid teardown stage")
+	// ErrDuplicateStage indicates that a stage with the same name is already added
+	ErrDuplicateStage = errors.New("duplicate teardown stage")
+)
+
+// Stage - a step of the teardown. Its Func runs first, then its Objects get
+// deleted. The stage completes when Func returned true and all Objects are
+// gone.
+type Stage struct {
+	// Name - name of the stage, unique per Teardown. It is used in the
+	// condition type of the stage and should be CamelCase, e.g.
+	// KeystoneEndpoints.
+	Name string
+	// Func - function to run, e.g. to deregister the endpoints of the
+	// service. It must be idempotent, as it gets called again on every
+	// reconcile until all stages completed.
+	Func hooks.Func
+	// Objects - children to delete, only name and namespace need to be set
+	Objects []client.Object
+	// Finalizer - finalizer the CR set on the Objects, e.g. with
+	// object.AddConsumerFinalizer, which gets removed before they are
+	// deleted
+	Finalizer string
+}
+
+// Teardown - ordered stages of the deletion of a CR
+type Teardown struct {
+	stages  []Stage
+	timeout time.Duration
+}
+
+// NewTeardown - returns an empty Teardown. The timeout is the requeue
+// interval while a stage waits for its Func or Objects.
+func NewTeardown(timeout time.Duration) *Teardown {
+	return &Teardown{
+		stages:  []Stage{},
+		timeout: timeout,
+	}
+}
+
+// AddStage - adds the stage. The stages run in the order they got added, a
+// stage only starts after the previous one completed.
+func (t *Teardown) AddStage(stage Stage) error {
+	if stage.Name == "" || (stage.Func == nil && len(stage.Objects) == 0) {
+		return fmt.Errorf("%w: stage %q must have a name and a Func or Objects", ErrInvalidStage, stage.Name)
+	}
+	for _, existing := range t.stages {
+		if existing.Name == stage.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicateStage, stage.Name)
+		}
+	}
+	t.stages = append(t.stages, stage)
+
+	return nil
+}
+
+// GetStages - returns the stages in their order
+func (t *Teardown) GetStages() []Stage {
+	return t.stages
+}
+
+// GetConditionType - returns the condition type of the stage, e.g.
+// TeardownKeystoneEndpointsReady
+func GetConditionType(stage Stage) condition.Type {
+	return condition.Type(fmt.Sprintf("Teardown%sReady", stage.Name))
+}
+
+// InitConditions - returns the init conditions of the stages, to be added
+// to the condition list of the CR when its deletion starts
+func (t *Teardown) InitConditions() condition.Conditions {
+	cl := condition.Conditions{}
+	for _, stage := range t.stages {
+		cl = append(cl, *condition.UnknownCondition(
+			GetConditionType(stage),
+			condition.InitReason,
+			condition.TeardownStageReadyInitMessage,
+			stage.Name))
+	}
+	return cl
+}
+
+// Run - runs the stages one after the other. A requeue is requested while a
+// stage waits for its Func or Objects. The progress of each stage is
+// reported in its own condition. The caller must only remove its finalizer
+// once Run returned an empty result and no error.
+//
+// Example:
+//
+//	func (r *KeystoneAPIReconciler) reconcileDelete(ctx context.Context, instance *keystonev1.KeystoneAPI, helper *helper.Helper) (ctrl.Result, error) {
+//		t := teardown.NewTeardown(time.Second * 5)
+//		err := t.AddStage(teardown.Stage{Name: "Endpoints", Func: r.deregisterEndpoints})
+//		...
+//		err = t.AddStage(teardown.Stage{Name: "Network", Objects: []client.Object{
+//			&routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: "keystone-public", Namespace: instance.Namespace}},
+//			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "keystone-public", Namespace: instance.Namespace}},
+//		}})
+//		...
+//		err = t.AddStage(teardown.Stage{Name: "Database", Finalizer: helper.GetFinalizer(), Objects: []client.Object{
+//			&mariadbv1.MariaDBDatabase{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: instance.Namespace}},
+//		}})
+//		...
+//		ctrlResult, err := t.Run(ctx, helper, &instance.Status.Conditions)
+//		if (ctrlResult != ctrl.Result{}) || err != nil {
+//			return ctrlResult, err
+//		}
+//		controllerutil.RemoveFinalizer(instance, helper.GetFinalizer())
+//		return ctrl.Result{}, nil
+//	}
+func (t *Teardown) Run(
+	ctx context.Context,
+	h *helper.Helper,
+	conditions *condition.Conditions,
+) (ctrl.Result, error) {
+	for _, stage := range t.stages {
+		pending, err := t.runStage(ctx, h, stage)
+		if err != nil {
+			conditions.Set(condition.FalseCondition(
+				GetConditionType(stage),
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TeardownStageReadyErrorMessage,
+				stage.Name,
+				err.Error()))
+			return ctrl.Result{}, fmt.Errorf("teardown stage %s: %w", stage.Name, err)
+		}
+		if len(pending) > 0 {
+			conditions.Set(condition.FalseCondition(
+				GetConditionType(stage),
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.TeardownStageReadyRunningMessage,
+				stage.Name,
+				strings.Join(pending, ", ")))
+			h.GetLogger().Info("Teardown stage waiting, reconcile later", "stage", stage.Name,
+				"pending", pending, "requeueAfter", t.timeout)
+			return ctrl.Result{RequeueAfter: t.timeout}, nil
+		}
+		conditions.MarkTrue(GetConditionType(stage), condition.TeardownStageReadyMessage, stage.Name)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// runStage - runs the Func of the stage and deletes its Objects, returns
+// what the stage still waits for, none when it completed
+func (t *Teardown) runStage(ctx context.Context, h *helper.Helper, stage Stage) ([]string, error) {
+	if stage.Func != nil {
+		done, err := stage.Func(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			return []string{"function"}, nil
+		}
+	}
+
+	pending := []string{}
+	for _, obj := range stage.Objects {
+		gone, err := deleteObject(ctx, h, obj, stage.Finalizer)
+		if err != nil {
+			return nil, err
+		}
+		if !gone {
+			kind := fmt.Sprintf("%T", obj)
+			if gvk, err := apiutil.GVKForObject(obj, h.GetScheme()); err == nil {
+				kind = gvk.Kind
+			}
+			pending = append(pending, fmt.Sprintf("%s %s", kind, obj.GetName()))
+		}
+	}
+	return pending, nil
+}
+
+// deleteObject - removes the finalizer from obj and deletes it, returns true
+// when it is gone
+func deleteObject(ctx context.Context, h *helper.Helper, obj client.Object, finalizer string) (bool, error) {
+	current := obj.DeepCopyObject().(client.Object)
+	err := h.GetClient().Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if k8s_errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if finalizer != "" {
+		err = object.RemoveConsumerFinalizer(ctx, h, current, finalizer)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if current.GetDeletionTimestamp().IsZero() {
+		background := metav1.DeletePropagationBackground
+		err = h.GetClient().Delete(ctx, current, &client.DeleteOptions{PropagationPolicy: &background})
+		if k8s_errors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		h.GetLogger().Info("Teardown deleted object", "object", current.GetName())
+	}
+
+	// objects with other finalizers remain until those are removed, the
+	// others are gone right away
+	return len(current.GetFinalizers()) == 0, nil
+}
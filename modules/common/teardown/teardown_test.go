@@ -0,0 +1,133 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teardown
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const finalizer = "openstack.org/keystone"
+
+var errDeregister = errors.New("deregister failed")
+
+func getMeta(name string, finalizers ...string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: "openstack", Finalizers: finalizers}
+}
+
+func TestAddStage(t *testing.T) {
+	g := NewWithT(t)
+	td := NewTeardown(time.Second)
+
+	noop := func(_ context.Context, _ *helper.Helper) (bool, error) { return true, nil }
+	g.Expect(td.AddStage(Stage{Name: "Endpoints", Func: noop})).To(Succeed())
+	g.Expect(td.AddStage(Stage{Name: "Endpoints", Func: noop})).To(MatchError(ErrDuplicateStage))
+	g.Expect(td.AddStage(Stage{Name: "Empty"})).To(MatchError(ErrInvalidStage))
+	g.Expect(td.AddStage(Stage{Func: noop})).To(MatchError(ErrInvalidStage))
+
+	g.Expect(td.GetStages()).To(HaveLen(1))
+	g.Expect(td.InitConditions()).To(HaveLen(1))
+	g.Expect(td.InitConditions()[0].Type).To(Equal(condition.Type("TeardownEndpointsReady")))
+}
+
+func TestRun(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	cr := &corev1.ConfigMap{ObjectMeta: getMeta("keystone")}
+	svc := &corev1.Service{ObjectMeta: getMeta("keystone-public")}
+	db := &corev1.Secret{ObjectMeta: getMeta("keystone-db", finalizer, "openstack.org/mariadb")}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cr, svc, db).Build()
+	h, err := helper.NewHelper(cr, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	deregistered := false
+	var deregisterErr error
+	td := NewTeardown(time.Second)
+	g.Expect(td.AddStage(Stage{
+		Name: "Endpoints",
+		Func: func(_ context.Context, _ *helper.Helper) (bool, error) {
+			return deregistered, deregisterErr
+		},
+	})).To(Succeed())
+	g.Expect(td.AddStage(Stage{
+		Name:    "Network",
+		Objects: []client.Object{&corev1.Service{ObjectMeta: getMeta("keystone-public")}},
+	})).To(Succeed())
+	g.Expect(td.AddStage(Stage{
+		Name:      "Database",
+		Finalizer: finalizer,
+		Objects:   []client.Object{&corev1.Secret{ObjectMeta: getMeta("keystone-db")}},
+	})).To(Succeed())
+
+	conditions := condition.Conditions{}
+	initConditions := td.InitConditions()
+	conditions.Init(&initConditions)
+
+	// a failed stage stops the teardown
+	deregisterErr = errDeregister
+	_, err = td.Run(ctx, h, &conditions)
+	g.Expect(err).To(MatchError(errDeregister))
+	g.Expect(conditions.Get("TeardownEndpointsReady").Reason).To(BeEquivalentTo(condition.ErrorReason))
+
+	// later stages wait for the previous ones
+	deregisterErr = nil
+	result, err := td.Run(ctx, h, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+	g.Expect(conditions.Get("TeardownEndpointsReady").Reason).To(BeEquivalentTo(condition.RequestedReason))
+	g.Expect(conditions.Get("TeardownNetworkReady").Reason).To(BeEquivalentTo(condition.InitReason))
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(svc), &corev1.Service{})).To(Succeed())
+
+	// the db waits for the finalizer of its own controller
+	deregistered = true
+	result, err = td.Run(ctx, h, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+	g.Expect(conditions.IsTrue("TeardownEndpointsReady")).To(BeTrue())
+	g.Expect(conditions.IsTrue("TeardownNetworkReady")).To(BeTrue())
+	g.Expect(conditions.Get("TeardownDatabaseReady").Message).To(Equal(
+		"Teardown stage Database waiting for Secret keystone-db"))
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(svc), &corev1.Service{})
+	g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+
+	current := &corev1.Secret{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(db), current)).To(Succeed())
+	g.Expect(current.DeletionTimestamp).ToNot(BeNil())
+	g.Expect(current.Finalizers).To(Equal([]string{"openstack.org/mariadb"}))
+
+	controllerutil.RemoveFinalizer(current, "openstack.org/mariadb")
+	g.Expect(fakeClient.Update(ctx, current)).To(Succeed())
+	result, err = td.Run(ctx, h, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(conditions.IsTrue("TeardownDatabaseReady")).To(BeTrue())
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package functional
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2" // nolint:revive
+	. "github.com/onsi/gomega"    // nolint:revive
+	common_net "github.com/openstack-k8s-operators/lib-common/modules/common/net"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("net package", func() {
+	var namespace string
+
+	BeforeEach(func() {
+		// NOTE(gibi): We need to create a unique namespace for each test run
+		// as namespaces cannot be deleted in a locally running envtest. See
+		// https://book.kubebuilder.io/reference/envtest.html#namespace-usage-limitation
+		namespace = uuid.New().String()
+		th.CreateNamespace(namespace)
+		// We still request the delete of the Namespace to properly cleanup if
+		// we run the test in an existing cluster.
+		DeferCleanup(th.DeleteNamespace, namespace)
+	})
+
+	It("detects the IP family of the cluster", func() {
+		family, err := common_net.GetClusterIPFamily(ctx, h)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(family).To(Equal(ipFamily))
+	})
+
+	It("creates services in the IP family of the cluster", func() {
+		s, err := service.NewService(
+			getExampleService(namespace, int32(80)),
+			timeout,
+			&service.OverrideSpec{},
+		)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = s.CreateOrPatch(ctx, h)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		svc := th.AssertServiceExists(types.NamespacedName{Namespace: namespace, Name: "test-svc"})
+		Expect(svc.Spec.IPFamilies).To(Equal([]corev1.IPFamily{ipFamily}))
+		Expect(common_net.ValidateIPFamily(field.NewPath("clusterIPs"), svc.Spec.ClusterIPs, ipFamily)).To(BeEmpty())
+
+		// URLs to the cluster IP are valid in both families
+		clusterIP := svc.Spec.ClusterIP
+		u, err := url.Parse(fmt.Sprintf("http://%s/v3", common_net.FormatHost(clusterIP)))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(u.Hostname()).To(Equal(clusterIP))
+		Expect(u.Port()).To(BeEmpty())
+	})
+
+	It("uses bind addresses of the IP family of the cluster", func() {
+		bindFamily, err := common_net.GetIPFamily(common_net.BindAddress(ipFamily))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(bindFamily).To(Equal(ipFamily))
+		loopbackFamily, err := common_net.GetIPFamily(common_net.LoopbackAddress(ipFamily))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(loopbackFamily).To(Equal(ipFamily))
+	})
+})
@@ -18,6 +18,7 @@ package functional
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -57,6 +58,13 @@ const (
 	// that a condition is still valid. This is intended to be used in
 	// asserts using `Consistently`.
 	// consistencyTimeout = timeout
+
+	// ipFamilyEnv - env var selecting the IP family of the test environment,
+	// IPv6 runs the API server with an IPv6 service network, e.g.
+	// make test-ipv6
+	ipFamilyEnv = "ENVTEST_IP_FAMILY"
+	// ipv6ServiceCIDR - the service network of the IPv6 profile
+	ipv6ServiceCIDR = "fd00:10:96::/112"
 )
 
 var (
@@ -68,6 +76,8 @@ var (
 	logger  logr.Logger
 	h       *helper.Helper
 	th      *TestHelper
+	// ipFamily - the IP family of the test environment
+	ipFamily corev1.IPFamily
 )
 
 func TestCommon(t *testing.T) {
@@ -90,6 +100,12 @@ var _ = BeforeSuite(func() {
 		},
 		ErrorIfCRDPathMissing: true,
 	}
+	ipFamily = corev1.IPv4Protocol
+	if os.Getenv(ipFamilyEnv) == string(corev1.IPv6Protocol) {
+		ipFamily = corev1.IPv6Protocol
+		testEnv.ControlPlane.GetAPIServer().Configure().
+			Set("service-cluster-ip-range", ipv6ServiceCIDR)
+	}
 	var err error
 
 	// cfg is defined in this file globally.
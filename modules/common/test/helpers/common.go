@@ -55,6 +55,9 @@ type TestHelper struct {
 	Timeout   time.Duration
 	Interval  time.Duration
 	Logger    logr.Logger
+
+	// tracked - objects deleted by CleanupAll
+	tracked *objectTracker
 }
 
 // NewTestHelper returns a TestHelper
@@ -71,6 +74,7 @@ func NewTestHelper(
 		Timeout:   getTestTimeout(timeout),
 		Interval:  interval,
 		Logger:    logger,
+		tracked:   &objectTracker{},
 	}
 }
 
@@ -14,7 +14,11 @@ limitations under the License.
 package helpers
 
 import (
+	"fmt"
+	"strings"
+
 	t "github.com/onsi/gomega"
+	gomegatypes "github.com/onsi/gomega/types"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -44,12 +48,12 @@ func (tc *TestHelper) ExpectCondition(
 		g.Expect(conditions).NotTo(
 			t.BeNil(), "Conditions in nil")
 		g.Expect(conditions.Has(conditionType)).To(
-			t.BeTrue(), "Does not have condition type %s", conditionType)
+			t.BeTrue(), "Does not have condition type %s, Conditions:\n%s", conditionType, FormatConditions(conditions))
 		actual := conditions.Get(conditionType).Status
 		g.Expect(actual).To(
 			t.Equal(expectedStatus),
-			"%s condition is in an unexpected state. Expected: %s, Actual: %s, instance name: %s, Conditions: %v",
-			conditionType, expectedStatus, actual, name, conditions)
+			"%s condition is in an unexpected state. Expected: %s, Actual: %s, instance name: %s, Conditions:\n%s",
+			conditionType, expectedStatus, actual, name, FormatConditions(conditions))
 	}, tc.Timeout, tc.Interval).Should(t.Succeed())
 	tc.Logger.Info("ExpectCondition succeeded", "type", conditionType, "expected status", expectedStatus, "on", name)
 }
@@ -81,7 +85,7 @@ func (tc *TestHelper) ExpectConditionWithDetails(
 		g.Expect(conditions).NotTo(
 			t.BeNil(), "Status.Conditions in nil")
 		g.Expect(conditions.Has(conditionType)).To(
-			t.BeTrue(), "Condition type is not in Status.Conditions %s", conditionType)
+			t.BeTrue(), "Condition type is not in Status.Conditions %s, Conditions:\n%s", conditionType, FormatConditions(conditions))
 		actualCondition := conditions.Get(conditionType)
 		g.Expect(actualCondition.Status).To(
 			t.Equal(expectedStatus),
@@ -89,11 +93,160 @@ func (tc *TestHelper) ExpectConditionWithDetails(
 			conditionType, expectedStatus, actualCondition.Status)
 		g.Expect(actualCondition.Reason).To(
 			t.Equal(expectedReason),
-			"%s condition has a different reason. Actual condition: %v", conditionType, actualCondition)
+			"%s condition has a different reason. Conditions:\n%s", conditionType, FormatConditions(conditions))
 		g.Expect(actualCondition.Message).To(
 			t.Equal(expecteMessage),
-			"%s condition has a different message. Actual condition: %v", conditionType, actualCondition)
+			"%s condition has a different message. Conditions:\n%s", conditionType, FormatConditions(conditions))
 	}, tc.Timeout, tc.Interval).Should(t.Succeed())
 
 	tc.Logger.Info("ExpectConditionWithDetails succeeded", "type", conditionType, "expected status", expectedStatus, "on", name)
 }
+
+// ExpectConditionWithReason - used to assert that a specific condition on a
+// k8s resource matches an expected status and reason.
+//
+// Example usage:
+//
+//	th.ExpectConditionWithReason(
+//		novaNames.NovaName,
+//		ConditionGetterFunc(NovaConditionGetter),
+//		condition.DBReadyCondition,
+//		corev1.ConditionFalse,
+//		condition.RequestedReason,
+//	)
+func (tc *TestHelper) ExpectConditionWithReason(
+	name types.NamespacedName,
+	getter conditionsGetter,
+	conditionType condition.Type,
+	expectedStatus corev1.ConditionStatus,
+	expectedReason condition.Reason,
+) {
+	tc.Logger.Info("ExpectConditionWithReason", "type", conditionType, "expected status", expectedStatus, "expected reason", expectedReason, "on", name)
+	t.Eventually(func(g t.Gomega) {
+		conditions := getter.GetConditions(name)
+		actualCondition := conditions.Get(conditionType)
+		g.Expect(actualCondition).NotTo(
+			t.BeNil(), "Condition type is not in Status.Conditions %s, Conditions:\n%s", conditionType, FormatConditions(conditions))
+		g.Expect(actualCondition.Status).To(
+			t.Equal(expectedStatus),
+			"%s condition is in an unexpected state. Conditions:\n%s", conditionType, FormatConditions(conditions))
+		g.Expect(actualCondition.Reason).To(
+			t.Equal(expectedReason),
+			"%s condition has a different reason. Conditions:\n%s", conditionType, FormatConditions(conditions))
+	}, tc.Timeout, tc.Interval).Should(t.Succeed())
+	tc.Logger.Info("ExpectConditionWithReason succeeded", "type", conditionType, "expected status", expectedStatus, "on", name)
+}
+
+// ExpectConditionWithMessage - used to assert that a specific condition on
+// a k8s resource matches an expected status and its message matches the
+// messageMatcher, e.g. ContainSubstring or MatchRegexp.
+//
+// Example usage:
+//
+//	th.ExpectConditionWithMessage(
+//		novaNames.NovaName,
+//		ConditionGetterFunc(NovaConditionGetter),
+//		condition.InputReadyCondition,
+//		corev1.ConditionFalse,
+//		ContainSubstring("secret not found"),
+//	)
+func (tc *TestHelper) ExpectConditionWithMessage(
+	name types.NamespacedName,
+	getter conditionsGetter,
+	conditionType condition.Type,
+	expectedStatus corev1.ConditionStatus,
+	messageMatcher gomegatypes.GomegaMatcher,
+) {
+	tc.Logger.Info("ExpectConditionWithMessage", "type", conditionType, "expected status", expectedStatus, "on", name)
+	t.Eventually(func(g t.Gomega) {
+		conditions := getter.GetConditions(name)
+		actualCondition := conditions.Get(conditionType)
+		g.Expect(actualCondition).NotTo(
+			t.BeNil(), "Condition type is not in Status.Conditions %s, Conditions:\n%s", conditionType, FormatConditions(conditions))
+		g.Expect(actualCondition.Status).To(
+			t.Equal(expectedStatus),
+			"%s condition is in an unexpected state. Conditions:\n%s", conditionType, FormatConditions(conditions))
+		g.Expect(actualCondition.Message).To(
+			messageMatcher,
+			"%s condition has a different message. Conditions:\n%s", conditionType, FormatConditions(conditions))
+	}, tc.Timeout, tc.Interval).Should(t.Succeed())
+	tc.Logger.Info("ExpectConditionWithMessage succeeded", "type", conditionType, "expected status", expectedStatus, "on", name)
+}
+
+// WaitForConditionTransition - records the current state of the condition,
+// runs trigger and waits until the condition transitioned to the
+// expectedStatus. A condition which already has the expectedStatus before
+// the trigger has to change its LastTransitionTime, so a stale status does
+// not satisfy the wait. Returns the condition after the transition.
+//
+// Example usage:
+//
+//	th.WaitForConditionTransition(
+//		novaNames.NovaName,
+//		ConditionGetterFunc(NovaConditionGetter),
+//		condition.ReadyCondition,
+//		corev1.ConditionTrue,
+//		func() { th.SimulateStatefulSetReplicaReady(novaNames.APIStatefulSetName) },
+//	)
+func (tc *TestHelper) WaitForConditionTransition(
+	name types.NamespacedName,
+	getter conditionsGetter,
+	conditionType condition.Type,
+	expectedStatus corev1.ConditionStatus,
+	trigger func(),
+) condition.Condition {
+	var before *condition.Condition
+	conditions := getter.GetConditions(name)
+	if c := conditions.Get(conditionType); c != nil {
+		before = c.DeepCopy()
+	}
+	tc.Logger.Info("WaitForConditionTransition", "type", conditionType, "before", before, "expected status", expectedStatus, "on", name)
+
+	if trigger != nil {
+		trigger()
+	}
+
+	var after condition.Condition
+	t.Eventually(func(g t.Gomega) {
+		conditions := getter.GetConditions(name)
+		actualCondition := conditions.Get(conditionType)
+		g.Expect(actualCondition).NotTo(
+			t.BeNil(), "Condition type is not in Status.Conditions %s, Conditions:\n%s", conditionType, FormatConditions(conditions))
+		g.Expect(actualCondition.Status).To(
+			t.Equal(expectedStatus),
+			"%s condition did not transition to %s. Conditions:\n%s", conditionType, expectedStatus, FormatConditions(conditions))
+		if before != nil && before.Status == expectedStatus {
+			g.Expect(actualCondition.LastTransitionTime.Equal(&before.LastTransitionTime)).To(
+				t.BeFalse(),
+				"%s condition did not transition, it is still in the %s state from before. Conditions:\n%s",
+				conditionType, expectedStatus, FormatConditions(conditions))
+		}
+		after = *actualCondition
+	}, tc.Timeout, tc.Interval).Should(t.Succeed())
+	tc.Logger.Info("WaitForConditionTransition succeeded", "type", conditionType, "expected status", expectedStatus, "on", name)
+
+	return after
+}
+
+// FormatConditions - returns the conditions one per line in a readable
+// form, to be used in assertion failure messages
+func FormatConditions(conditions condition.Conditions) string {
+	if len(conditions) == 0 {
+		return "  <no conditions>"
+	}
+	lines := []string{}
+	for _, c := range conditions {
+		line := fmt.Sprintf("  %s=%s", c.Type, c.Status)
+		if c.Reason != "" {
+			line = fmt.Sprintf("%s reason=%s", line, c.Reason)
+		}
+		if c.Severity != "" {
+			line = fmt.Sprintf("%s severity=%s", line, c.Severity)
+		}
+		if c.Message != "" {
+			line = fmt.Sprintf("%s message=%q", line, c.Message)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/onsi/gomega"
+	gomegatypes "github.com/onsi/gomega/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MinLeakValueLength - secret values shorter than this are ignored by
+// AssertNoSecretLeaks as they match unrelated content too easily
+const MinLeakValueLength = 6
+
+// ExpectSecretWithKeys waits until the Secret exists and has all the given
+// keys in its data, and returns it.
+//
+// Example usage:
+//
+//	secret := th.ExpectSecretWithKeys(
+//		types.NamespacedName{Name: "keystone-config-data", Namespace: "openstack"},
+//		"keystone.conf", "my.cnf",
+//	)
+func (tc *TestHelper) ExpectSecretWithKeys(name types.NamespacedName, keys ...string) *corev1.Secret {
+	secret := &corev1.Secret{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, secret)).Should(gomega.Succeed())
+		for _, key := range keys {
+			g.Expect(secret.Data).To(gomega.HaveKey(key), "secret %s misses key %s", name, key)
+		}
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	return secret
+}
+
+// ExpectSecretDataMatching waits until the value of the key in the Secret
+// satisfies the matcher, e.g. gomega.ContainSubstring or MatchRegexp.
+//
+// Example usage:
+//
+//	th.ExpectSecretDataMatching(
+//		types.NamespacedName{Name: "keystone-config-data", Namespace: "openstack"},
+//		"keystone.conf",
+//		gomega.ContainSubstring("[database]"),
+//	)
+func (tc *TestHelper) ExpectSecretDataMatching(
+	name types.NamespacedName,
+	key string,
+	matcher gomegatypes.GomegaMatcher,
+) *corev1.Secret {
+	secret := &corev1.Secret{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, secret)).Should(gomega.Succeed())
+		g.Expect(secret.Data).To(gomega.HaveKey(key))
+		g.Expect(string(secret.Data[key])).To(matcher)
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	return secret
+}
+
+// ExpectConfigMapWithKeys waits until the ConfigMap exists and has all the
+// given keys in its data, and returns it.
+//
+// Example usage:
+//
+//	cm := th.ExpectConfigMapWithKeys(
+//		types.NamespacedName{Name: "keystone-scripts", Namespace: "openstack"},
+//		"bootstrap.sh",
+//	)
+func (tc *TestHelper) ExpectConfigMapWithKeys(name types.NamespacedName, keys ...string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, cm)).Should(gomega.Succeed())
+		for _, key := range keys {
+			g.Expect(cm.Data).To(gomega.HaveKey(key), "configmap %s misses key %s", name, key)
+		}
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	return cm
+}
+
+// ExpectConfigMapDataMatching waits until the value of the key in the
+// ConfigMap satisfies the matcher. Use gomega.MatchRegexp for a regex
+// expectation or MatchRenderedTemplate for a template rendered one.
+//
+// Example usage:
+//
+//	th.ExpectConfigMapDataMatching(
+//		types.NamespacedName{Name: "keystone-config", Namespace: "openstack"},
+//		"httpd.conf",
+//		gomega.MatchRegexp(`Listen \d+`),
+//	)
+func (tc *TestHelper) ExpectConfigMapDataMatching(
+	name types.NamespacedName,
+	key string,
+	matcher gomegatypes.GomegaMatcher,
+) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, cm)).Should(gomega.Succeed())
+		g.Expect(cm.Data).To(gomega.HaveKey(key))
+		g.Expect(cm.Data[key]).To(matcher)
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	return cm
+}
+
+// MatchRenderedTemplate returns a matcher which succeeds if the actual
+// string is equal to the go text/template rendered with the data, ignoring
+// leading and trailing whitespace.
+//
+// Example usage:
+//
+//	th.ExpectConfigMapDataMatching(name, "my.cnf", MatchRenderedTemplate(
+//		"[client]\nhost={{ .Host }}\n", map[string]string{"Host": "openstack.openstack.svc"}))
+func MatchRenderedTemplate(tmpl string, data interface{}) gomegatypes.GomegaMatcher {
+	t, err := template.New("expected").Parse(tmpl)
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	var buf bytes.Buffer
+	gomega.Expect(t.Execute(&buf, data)).To(gomega.Succeed())
+
+	return gomega.WithTransform(strings.TrimSpace, gomega.Equal(strings.TrimSpace(buf.String())))
+}
+
+// AssertNoSecretLeaks asserts that none of the values of the given Secrets
+// show up in plain text in any ConfigMap of the namespace, e.g. passwords
+// rendered into a config file which should live in a Secret. Values shorter
+// than MinLeakValueLength are ignored.
+//
+// Example usage:
+//
+//	th.AssertNoSecretLeaks("openstack", types.NamespacedName{Name: "osp-secret", Namespace: "openstack"})
+func (tc *TestHelper) AssertNoSecretLeaks(namespace string, secrets ...types.NamespacedName) {
+	values := map[string]string{}
+	for _, name := range secrets {
+		secret := tc.GetSecret(name)
+		for key, value := range secret.Data {
+			if len(value) >= MinLeakValueLength {
+				values[string(value)] = name.String() + "/" + key
+			}
+		}
+	}
+
+	for _, cm := range tc.ListConfigMaps(namespace).Items {
+		for cmKey, content := range cm.Data {
+			for value, source := range values {
+				gomega.Expect(strings.Contains(content, value)).To(gomega.BeFalse(),
+					"configmap %s/%s key %s contains the value of secret %s",
+					cm.Namespace, cm.Name, cmKey, source)
+			}
+		}
+		for cmKey, content := range cm.BinaryData {
+			for value, source := range values {
+				gomega.Expect(bytes.Contains(content, []byte(value))).To(gomega.BeFalse(),
+					"configmap %s/%s key %s contains the value of secret %s",
+					cm.Namespace, cm.Name, cmKey, source)
+			}
+		}
+	}
+}
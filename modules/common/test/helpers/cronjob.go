@@ -0,0 +1,97 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// GetCronJob - retrieves a CronJob resource.
+//
+// example usage:
+//
+//	th.GetCronJob(types.NamespacedName{Name: "test-cronjob", Namespace: "test-namespace"})
+func (tc *TestHelper) GetCronJob(name types.NamespacedName) *batchv1.CronJob {
+	cj := &batchv1.CronJob{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, cj)).Should(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	return cj
+}
+
+// SimulateCronJobRun - simulates a scheduled run of the CronJob. A Job is
+// created from the jobTemplate of the CronJob, owned by it like the
+// CronJob controller does, and it gets marked as succeeded or failed. The
+// status of the CronJob gets updated accordingly. Returns the name of the
+// created Job.
+//
+// example usage:
+//
+//	jobName := th.SimulateCronJobRun(types.NamespacedName{Name: "test-cronjob", Namespace: "test-namespace"}, true)
+func (tc *TestHelper) SimulateCronJobRun(name types.NamespacedName, succeed bool) types.NamespacedName {
+	cj := tc.GetCronJob(name)
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%d", name.Name, now.Unix()/60),
+			Namespace:   name.Namespace,
+			Labels:      cj.Spec.JobTemplate.Labels,
+			Annotations: cj.Spec.JobTemplate.Annotations,
+		},
+		Spec: *cj.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+	gomega.Expect(controllerutil.SetControllerReference(cj, job, tc.K8sClient.Scheme())).To(gomega.Succeed())
+	gomega.Expect(tc.K8sClient.Create(tc.Ctx, job)).To(gomega.Succeed())
+	jobName := types.NamespacedName{Name: job.Name, Namespace: job.Namespace}
+
+	if succeed {
+		tc.SimulateJobSuccess(jobName)
+	} else {
+		tc.SimulateJobFailure(jobName)
+	}
+
+	gomega.Eventually(func(g gomega.Gomega) {
+		cj := tc.GetCronJob(name)
+		cj.Status.LastScheduleTime = &now
+		if succeed {
+			cj.Status.LastSuccessfulTime = &now
+		}
+		cj.Status.Active = []corev1.ObjectReference{}
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, cj)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated CronJob run", "on", name, "job", jobName, "succeeded", succeed)
+
+	return jobName
+}
+
+// AssertCronJobDoesNotExist ensures the CronJob resource does not exist in a k8s cluster.
+func (tc *TestHelper) AssertCronJobDoesNotExist(name types.NamespacedName) {
+	instance := &batchv1.CronJob{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		err := tc.K8sClient.Get(tc.Ctx, name, instance)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDaemonSet - retrieves a DaemonSet resource.
+//
+// example usage:
+//
+//	th.GetDaemonSet(types.NamespacedName{Name: "test-daemonset", Namespace: "test-namespace"})
+func (tc *TestHelper) GetDaemonSet(name types.NamespacedName) *appsv1.DaemonSet {
+	ds := &appsv1.DaemonSet{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, ds)).Should(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	return ds
+}
+
+// SimulateDaemonSetReady - simulates the DaemonSet got scheduled and is
+// ready on the given number of nodes.
+//
+// example usage:
+//
+//	th.SimulateDaemonSetReady(types.NamespacedName{Name: "test-daemonset", Namespace: "test-namespace"}, 3)
+func (tc *TestHelper) SimulateDaemonSetReady(name types.NamespacedName, nodes int32) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		ds := tc.GetDaemonSet(name)
+		ds.Status.DesiredNumberScheduled = nodes
+		ds.Status.CurrentNumberScheduled = nodes
+		ds.Status.NumberReady = nodes
+		ds.Status.NumberAvailable = nodes
+		ds.Status.UpdatedNumberScheduled = nodes
+		ds.Status.NumberUnavailable = 0
+		ds.Status.ObservedGeneration = ds.Generation
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, ds)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated daemonset ready", "on", name, "nodes", nodes)
+}
+
+// SimulateDaemonSetProgressing - simulates a rollout of the DaemonSet where
+// not all nodes run the updated pods yet.
+//
+// example usage:
+//
+//	th.SimulateDaemonSetProgressing(types.NamespacedName{Name: "test-daemonset", Namespace: "test-namespace"}, 3)
+func (tc *TestHelper) SimulateDaemonSetProgressing(name types.NamespacedName, nodes int32) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		ds := tc.GetDaemonSet(name)
+		ds.Status.DesiredNumberScheduled = nodes
+		ds.Status.CurrentNumberScheduled = nodes
+		ds.Status.NumberReady = nodes - 1
+		ds.Status.NumberAvailable = nodes - 1
+		ds.Status.UpdatedNumberScheduled = nodes - 1
+		ds.Status.NumberUnavailable = 1
+		ds.Status.ObservedGeneration = ds.Generation
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, ds)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated daemonset progressing", "on", name, "nodes", nodes)
+}
+
+// AssertDaemonSetDoesNotExist ensures the DaemonSet resource does not exist in a k8s cluster.
+func (tc *TestHelper) AssertDaemonSetDoesNotExist(name types.NamespacedName) {
+	instance := &appsv1.DaemonSet{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		err := tc.K8sClient.Get(tc.Ctx, name, instance)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+}
@@ -16,10 +16,13 @@ package helpers
 import (
 	"github.com/onsi/gomega"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // GetJob retrieves a specified Job resource from the cluster.
@@ -89,6 +92,68 @@ func (tc *TestHelper) SimulateJobSuccess(name types.NamespacedName) {
 	tc.Logger.Info("Simulated Job success", "on", name)
 }
 
+// SimulateJobFailureWithMessage simulates the failure of a Kubernetes Job
+// resource and creates a failed pod for the Job whose containers terminated
+// with the given termination message, so that code reading the failure
+// reason from the job pods can be tested.
+//
+// Example usage:
+//
+//	th.SimulateJobFailureWithMessage(
+//		types.NamespacedName{Name: "test-job", Namespace: "default"},
+//		"ERROR: database not reachable",
+//	)
+func (tc *TestHelper) SimulateJobFailureWithMessage(name types.NamespacedName, message string) {
+	job := tc.GetJob(name)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name.Name + "-",
+			Namespace:    name.Namespace,
+			Labels:       map[string]string{},
+		},
+		Spec: *job.Spec.Template.Spec.DeepCopy(),
+	}
+	for k, v := range job.Spec.Template.Labels {
+		pod.Labels[k] = v
+	}
+	pod.Labels[batchv1.JobNameLabel] = name.Name
+	pod.Labels[batchv1.ControllerUidLabel] = string(job.UID)
+	// NOTE: volumes are not simulated, see SimulateDeploymentReadyWithPods
+	pod.Spec.Volumes = []corev1.Volume{}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = []corev1.VolumeMount{}
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].VolumeMounts = []corev1.VolumeMount{}
+	}
+	gomega.Expect(controllerutil.SetControllerReference(job, pod, tc.K8sClient.Scheme())).To(gomega.Succeed())
+	gomega.Expect(tc.K8sClient.Create(tc.Ctx, pod)).To(gomega.Succeed())
+
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, client.ObjectKeyFromObject(pod), pod)).To(gomega.Succeed())
+		pod.Status.Phase = corev1.PodFailed
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{}
+		for _, c := range pod.Spec.Containers {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+				Name:  c.Name,
+				Image: c.Image,
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Reason:   "Error",
+						Message:  message,
+					},
+				},
+			})
+		}
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, pod)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.SimulateJobFailure(name)
+	tc.Logger.Info("Simulated Job failure with message", "on", name, "pod", pod.Name)
+}
+
 // AssertJobDoesNotExist ensures the Job resource does not exist in a k8s cluster.
 func (tc *TestHelper) AssertJobDoesNotExist(name types.NamespacedName) {
 	instance := &batchv1.Job{}
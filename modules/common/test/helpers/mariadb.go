@@ -0,0 +1,286 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+
+	"github.com/onsi/gomega"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The mariadb-operator depends on lib-common, so its API cannot be imported
+// here. The helpers below handle its CRs as unstructured objects. The CRDs
+// can be loaded into envtest via test.GetMariaDBCRDDir.
+var (
+	// MariaDBDatabaseGVK - GroupVersionKind of the MariaDBDatabase CR
+	MariaDBDatabaseGVK = schema.GroupVersionKind{
+		Group:   "mariadb.openstack.org",
+		Version: "v1beta1",
+		Kind:    "MariaDBDatabase",
+	}
+	// MariaDBAccountGVK - GroupVersionKind of the MariaDBAccount CR
+	MariaDBAccountGVK = schema.GroupVersionKind{
+		Group:   "mariadb.openstack.org",
+		Version: "v1beta1",
+		Kind:    "MariaDBAccount",
+	}
+	// GaleraGVK - GroupVersionKind of the Galera CR
+	GaleraGVK = schema.GroupVersionKind{
+		Group:   "mariadb.openstack.org",
+		Version: "v1beta1",
+		Kind:    "Galera",
+	}
+)
+
+const (
+	// MariaDBAccountPasswordKey - key in the account secret holding the
+	// database password
+	MariaDBAccountPasswordKey = "DatabasePassword"
+)
+
+// GetMariaDBObject fetches a mariadb-operator CR of the given kind as an
+// unstructured object.
+//
+// Example usage:
+//
+//	db := th.GetMariaDBObject(helpers.MariaDBDatabaseGVK, types.NamespacedName{Name: "keystone", Namespace: "openstack"})
+func (tc *TestHelper) GetMariaDBObject(gvk schema.GroupVersionKind, name types.NamespacedName) *unstructured.Unstructured {
+	instance := &unstructured.Unstructured{}
+	instance.SetGroupVersionKind(gvk)
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, instance)).Should(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	return instance
+}
+
+// CreateGalera creates a Galera CR with the given number of replicas.
+//
+// Example usage:
+//
+//	th.CreateGalera(types.NamespacedName{Name: "openstack", Namespace: "openstack"}, 3)
+func (tc *TestHelper) CreateGalera(name types.NamespacedName, replicas int64) *unstructured.Unstructured {
+	raw := map[string]interface{}{
+		"apiVersion": GaleraGVK.GroupVersion().String(),
+		"kind":       GaleraGVK.Kind,
+		"metadata": map[string]interface{}{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": map[string]interface{}{
+			"replicas":       replicas,
+			"secret":         "osp-secret",
+			"storageRequest": "500M",
+		},
+	}
+	return tc.CreateUnstructured(raw)
+}
+
+// CreateMariaDBDatabase creates a MariaDBDatabase CR bound to the given
+// Galera instance.
+//
+// Example usage:
+//
+//	th.CreateMariaDBDatabase(types.NamespacedName{Name: "keystone", Namespace: "openstack"}, "openstack")
+func (tc *TestHelper) CreateMariaDBDatabase(name types.NamespacedName, galera string) *unstructured.Unstructured {
+	raw := map[string]interface{}{
+		"apiVersion": MariaDBDatabaseGVK.GroupVersion().String(),
+		"kind":       MariaDBDatabaseGVK.Kind,
+		"metadata": map[string]interface{}{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+			"labels": map[string]interface{}{
+				"dbName": galera,
+			},
+		},
+		"spec": map[string]interface{}{
+			"name": name.Name,
+		},
+	}
+	return tc.CreateUnstructured(raw)
+}
+
+// CreateMariaDBAccount creates a MariaDBAccount CR for the given database
+// with the user name and secret name derived from the account name.
+//
+// Example usage:
+//
+//	th.CreateMariaDBAccount(types.NamespacedName{Name: "keystone", Namespace: "openstack"}, "keystone")
+func (tc *TestHelper) CreateMariaDBAccount(name types.NamespacedName, database string) *unstructured.Unstructured {
+	raw := map[string]interface{}{
+		"apiVersion": MariaDBAccountGVK.GroupVersion().String(),
+		"kind":       MariaDBAccountGVK.Kind,
+		"metadata": map[string]interface{}{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+			"labels": map[string]interface{}{
+				"mariaDBDatabaseName": database,
+			},
+		},
+		"spec": map[string]interface{}{
+			"userName": name.Name,
+			"secret":   name.Name + "-db-secret",
+		},
+	}
+	return tc.CreateUnstructured(raw)
+}
+
+// SimulateGaleraReady simulates that the Galera cluster is bootstrapped and
+// all of its replicas are ready.
+//
+// Example usage:
+//
+//	th.SimulateGaleraReady(types.NamespacedName{Name: "openstack", Namespace: "openstack"})
+func (tc *TestHelper) SimulateGaleraReady(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		galera := tc.GetMariaDBObject(GaleraGVK, name)
+		replicas, _, err := unstructured.NestedInt64(galera.Object, "spec", "replicas")
+		g.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+		g.Expect(unstructured.SetNestedField(galera.Object, true, "status", "bootstrapped")).To(gomega.Succeed())
+		g.Expect(unstructured.SetNestedField(galera.Object, replicas, "status", "readyCount")).To(gomega.Succeed())
+		setUnstructuredConditions(g, galera, condition.ReadyCondition)
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, galera)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated Galera ready", "on", name)
+}
+
+// SimulateMariaDBDatabaseReady simulates that the database got created in
+// the Galera cluster.
+//
+// Example usage:
+//
+//	th.SimulateMariaDBDatabaseReady(types.NamespacedName{Name: "keystone", Namespace: "openstack"})
+func (tc *TestHelper) SimulateMariaDBDatabaseReady(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		db := tc.GetMariaDBObject(MariaDBDatabaseGVK, name)
+		setUnstructuredConditions(g, db, condition.ReadyCondition)
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, db)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated MariaDBDatabase ready", "on", name)
+}
+
+// SimulateMariaDBAccountCompleted creates the credential secret referenced by
+// the MariaDBAccount, if missing, and simulates that the database user got
+// created. Returns the credential secret.
+//
+// Example usage:
+//
+//	secret := th.SimulateMariaDBAccountCompleted(types.NamespacedName{Name: "keystone", Namespace: "openstack"})
+func (tc *TestHelper) SimulateMariaDBAccountCompleted(name types.NamespacedName) *corev1.Secret {
+	account := tc.GetMariaDBObject(MariaDBAccountGVK, name)
+	secretName, _, err := unstructured.NestedString(account.Object, "spec", "secret")
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+	gomega.Expect(secretName).NotTo(gomega.BeEmpty())
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: name.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(tc.Ctx, tc.K8sClient, secret, func() error {
+		if _, ok := secret.Data[MariaDBAccountPasswordKey]; !ok {
+			secret.Data = map[string][]byte{
+				MariaDBAccountPasswordKey: []byte("12345678"),
+			}
+		}
+		return nil
+	})
+	gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+
+	gomega.Eventually(func(g gomega.Gomega) {
+		account := tc.GetMariaDBObject(MariaDBAccountGVK, name)
+		g.Expect(unstructured.SetNestedField(
+			account.Object, secretName, "status", "currentSecret")).To(gomega.Succeed())
+		setUnstructuredConditions(g, account, condition.ReadyCondition, "MariaDBAccountReady", "MariaDBServerReady")
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, account)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated MariaDBAccount completed", "on", name, "secret", secretName)
+
+	return secret
+}
+
+// SimulateMariaDBAccountFailure simulates that creating the database user
+// failed with the given message.
+//
+// Example usage:
+//
+//	th.SimulateMariaDBAccountFailure(types.NamespacedName{Name: "keystone", Namespace: "openstack"}, "access denied")
+func (tc *TestHelper) SimulateMariaDBAccountFailure(name types.NamespacedName, message string) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		account := tc.GetMariaDBObject(MariaDBAccountGVK, name)
+		conditions := condition.Conditions{}
+		conditions.Set(condition.FalseCondition(
+			condition.ReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			"%s", message))
+		setUnstructuredConditionList(g, account, conditions)
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, account)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated MariaDBAccount failure", "on", name, "message", message)
+}
+
+// AssertMariaDBAccountDoesNotExist ensures the MariaDBAccount does not exist
+// in a k8s cluster.
+func (tc *TestHelper) AssertMariaDBAccountDoesNotExist(name types.NamespacedName) {
+	instance := &unstructured.Unstructured{}
+	instance.SetGroupVersionKind(MariaDBAccountGVK)
+	gomega.Eventually(func(g gomega.Gomega) {
+		err := tc.K8sClient.Get(tc.Ctx, name, instance)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+}
+
+// setUnstructuredConditions - sets the given condition types to True in the
+// status of the unstructured object
+func setUnstructuredConditions(
+	g gomega.Gomega,
+	obj *unstructured.Unstructured,
+	conditionTypes ...condition.Type,
+) {
+	conditions := condition.Conditions{}
+	for _, t := range conditionTypes {
+		conditions.Set(condition.TrueCondition(t, "Ready"))
+	}
+	setUnstructuredConditionList(g, obj, conditions)
+}
+
+// setUnstructuredConditionList - stores the conditions in the status of the
+// unstructured object
+func setUnstructuredConditionList(
+	g gomega.Gomega,
+	obj *unstructured.Unstructured,
+	conditions condition.Conditions,
+) {
+	raw, err := json.Marshal(conditions)
+	g.Expect(err).ShouldNot(gomega.HaveOccurred())
+	list := []interface{}{}
+	g.Expect(json.Unmarshal(raw, &list)).To(gomega.Succeed())
+	g.Expect(unstructured.SetNestedSlice(obj.Object, list, "status", "conditions")).To(gomega.Succeed())
+}
@@ -14,6 +14,8 @@ limitations under the License.
 package helpers
 
 import (
+	"encoding/json"
+
 	"github.com/onsi/gomega"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -43,3 +45,48 @@ func (tc *TestHelper) CreateNetworkAttachmentDefinition(name types.NamespacedNam
 
 	return instance
 }
+
+// CreateNetworkAttachmentDefinitionWithIPAM creates a new macvlan
+// NetworkAttachmentDefinition resource using whereabouts IPAM for the given
+// range. If gateway is not empty it gets set in the IPAM config, which
+// results in a gateway request in the networks annotation generated by
+// networkattachment.EnsureNetworksAnnotation.
+//
+// Example usage:
+//
+//	internalAPINADName := types.NamespacedName{Namespace: "testname", Name: "internalapi"}
+//	nad := th.CreateNetworkAttachmentDefinitionWithIPAM(internalAPINADName, "172.17.0.0/24", "172.17.0.1")
+func (tc *TestHelper) CreateNetworkAttachmentDefinitionWithIPAM(
+	name types.NamespacedName,
+	ipRange string,
+	gateway string,
+) client.Object {
+	ipam := map[string]interface{}{
+		"type":  "whereabouts",
+		"range": ipRange,
+	}
+	if gateway != "" {
+		ipam["gateway"] = gateway
+	}
+	config, err := json.Marshal(map[string]interface{}{
+		"cniVersion": "0.3.1",
+		"name":       name.Name,
+		"type":       "macvlan",
+		"master":     name.Name,
+		"ipam":       ipam,
+	})
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	instance := &networkv1.NetworkAttachmentDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: networkv1.NetworkAttachmentDefinitionSpec{
+			Config: string(config),
+		},
+	}
+	gomega.Expect(tc.K8sClient.Create(tc.Ctx, instance)).Should(gomega.Succeed())
+
+	return instance
+}
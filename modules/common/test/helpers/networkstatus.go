@@ -0,0 +1,161 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+	"net/netip"
+	"sort"
+	"strings"
+
+	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
+)
+
+// GetNetworkIPs - returns the index-th host IP of each of the CIDRs, e.g.
+// one IPv4 and one IPv6 CIDR for a dual stack network. Can be used to
+// generate distinct IPs per pod.
+//
+// Example usage:
+//
+//	ips := GetNetworkIPs(5, "172.17.0.0/24", "fd00:bbbb::/64")
+//	// ips == []string{"172.17.0.5", "fd00:bbbb::5"}
+func GetNetworkIPs(index int, cidrs ...string) []string {
+	ips := []string{}
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ip := prefix.Masked().Addr()
+		for i := 0; i < index; i++ {
+			ip = ip.Next()
+		}
+		gomega.Expect(prefix.Contains(ip)).To(gomega.BeTrue(), "index %d out of range of %s", index, cidr)
+		ips = append(ips, ip.String())
+	}
+	return ips
+}
+
+// SimulatePodNetworkStatus - sets the network-status annotation on the pod
+// like multus does, with the given IPs per network. The network names are
+// in the <namespace>/<nad name> form.
+//
+// Example usage:
+//
+//	th.SimulatePodNetworkStatus(
+//		types.NamespacedName{Namespace: "openstack", Name: "octavia-worker-0"},
+//		map[string][]string{"openstack/octavia": GetNetworkIPs(10, "172.23.0.0/24", "fd00:cccc::/64")},
+//	)
+func (tc *TestHelper) SimulatePodNetworkStatus(name types.NamespacedName, networkIPs map[string][]string) {
+	networks := make([]string, 0, len(networkIPs))
+	for network := range networkIPs {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	netStatus := []networkv1.NetworkStatus{}
+	for _, network := range networks {
+		nad := network
+		if _, n, ok := strings.Cut(network, "/"); ok {
+			nad = n
+		}
+		netStatus = append(netStatus, networkv1.NetworkStatus{
+			Name:      network,
+			Interface: networkattachment.GetNetworkIFName(nad),
+			IPs:       networkIPs[network],
+		})
+	}
+	netStatusAnnotation, err := json.Marshal(netStatus)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+	gomega.Eventually(func(g gomega.Gomega) {
+		pod := &corev1.Pod{}
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, pod)).To(gomega.Succeed())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[networkv1.NetworkStatusAnnot] = string(netStatusAnnotation)
+		g.Expect(tc.K8sClient.Update(tc.Ctx, pod)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated pod network status", "on", name, "networks", networkIPs)
+}
+
+// SimulatePodsNetworkStatus - sets the network-status annotation on all pods
+// in the namespace matching the labels. Each pod gets distinct IPs from the
+// CIDRs of each network, starting at host index 10, the pods are handled in
+// name order.
+//
+// Example usage:
+//
+//	th.SimulatePodsNetworkStatus(
+//		"openstack",
+//		map[string]string{"service": "octavia"},
+//		map[string][]string{"openstack/octavia": {"172.23.0.0/24", "fd00:cccc::/64"}},
+//	)
+func (tc *TestHelper) SimulatePodsNetworkStatus(
+	namespace string,
+	labels map[string]string,
+	networkCIDRs map[string][]string,
+) {
+	pods := &corev1.PodList{}
+	gomega.Expect(tc.K8sClient.List(tc.Ctx, pods, client.InNamespace(namespace), client.MatchingLabels(labels))).To(gomega.Succeed())
+	sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
+
+	for i, pod := range pods.Items {
+		networkIPs := map[string][]string{}
+		for network, cidrs := range networkCIDRs {
+			networkIPs[network] = GetNetworkIPs(10+i, cidrs...)
+		}
+		tc.SimulatePodNetworkStatus(types.NamespacedName{Namespace: namespace, Name: pod.Name}, networkIPs)
+	}
+}
+
+// GetNetworksAnnotation - returns the network selection elements of the
+// k8s.v1.cni.cncf.io/networks annotation
+func GetNetworksAnnotation(annotations map[string]string) []networkv1.NetworkSelectionElement {
+	networks := []networkv1.NetworkSelectionElement{}
+	value, ok := annotations[networkv1.NetworkAttachmentAnnot]
+	if !ok {
+		return networks
+	}
+	gomega.Expect(json.Unmarshal([]byte(value), &networks)).To(gomega.Succeed())
+	return networks
+}
+
+// AssertNetworksAnnotation - asserts that the k8s.v1.cni.cncf.io/networks
+// annotation, as generated by the networkattachment module, requests
+// exactly the given NADs of the namespace with the expected interface
+// names. The order of the NADs is not relevant.
+//
+// Example usage:
+//
+//	deployment := th.GetDeployment(name)
+//	AssertNetworksAnnotation(deployment.Spec.Template.Annotations, "openstack", "internalapi", "storage")
+func AssertNetworksAnnotation(annotations map[string]string, namespace string, nads ...string) {
+	networks := GetNetworksAnnotation(annotations)
+
+	actual := []string{}
+	for _, n := range networks {
+		gomega.Expect(n.Namespace).To(gomega.Equal(namespace), "network %s in wrong namespace", n.Name)
+		gomega.Expect(n.InterfaceRequest).To(gomega.Equal(networkattachment.GetNetworkIFName(n.Name)),
+			"network %s with unexpected interface", n.Name)
+		actual = append(actual, n.Name)
+	}
+	gomega.Expect(actual).To(gomega.ConsistOf(nads), "networks annotation: %s", annotations[networkv1.NetworkAttachmentAnnot])
+}
@@ -18,10 +18,42 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ocp_config "github.com/openshift/api/config/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	securityv1 "github.com/openshift/api/security/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
-// CreateClusterNetworkConfig creates a fake cluster network config CR
+// AddOpenShiftToScheme registers the OpenShift API types the lib-common
+// modules use (config, route, security, image) into the scheme. Use it
+// together with test.GetOpenShiftCRDDirs to run functional tests outside of
+// an OpenShift cluster.
+//
+// Example usage:
+//
+//	err = helpers.AddOpenShiftToScheme(scheme.Scheme)
+//	Expect(err).NotTo(HaveOccurred())
+func AddOpenShiftToScheme(scheme *runtime.Scheme) error {
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		ocp_config.AddToScheme,
+		routev1.AddToScheme,
+		securityv1.AddToScheme,
+		imagev1.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CreateClusterNetworkConfig creates a fake cluster network config CR. As it
+// is cluster scoped it is tracked for CleanupAll.
 func (tc *TestHelper) CreateClusterNetworkConfig() client.Object {
 	instance := &ocp_config.Network{
 		ObjectMeta: metav1.ObjectMeta{
@@ -38,6 +70,124 @@ func (tc *TestHelper) CreateClusterNetworkConfig() client.Object {
 		},
 	}
 	gomega.Expect(tc.K8sClient.Create(tc.Ctx, instance)).Should(gomega.Succeed())
+	tc.TrackObject(instance)
+
+	return instance
+}
+
+// CreateSCC creates a SecurityContextConstraints which grants the given
+// users, e.g. "system:serviceaccount:openstack:nova-nova", the use of
+// privileged containers if privileged is true. As it is cluster scoped it
+// is tracked for CleanupAll.
+//
+// Example usage:
+//
+//	th.CreateSCC("anyuid", []string{"system:serviceaccount:openstack:keystone"}, false)
+func (tc *TestHelper) CreateSCC(name string, users []string, privileged bool) *securityv1.SecurityContextConstraints {
+	instance := &securityv1.SecurityContextConstraints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		AllowPrivilegedContainer: privileged,
+		Users:                    users,
+		RunAsUser: securityv1.RunAsUserStrategyOptions{
+			Type: securityv1.RunAsUserStrategyRunAsAny,
+		},
+		SELinuxContext: securityv1.SELinuxContextStrategyOptions{
+			Type: securityv1.SELinuxStrategyMustRunAs,
+		},
+		Volumes: []securityv1.FSType{
+			securityv1.FSTypeConfigMap,
+			securityv1.FSTypeDownwardAPI,
+			securityv1.FSTypeEmptyDir,
+			securityv1.FSTypePersistentVolumeClaim,
+			securityv1.FSProjected,
+			securityv1.FSTypeSecret,
+		},
+	}
+	gomega.Expect(tc.K8sClient.Create(tc.Ctx, instance)).Should(gomega.Succeed())
+	tc.TrackObject(instance)
+
+	return instance
+}
+
+// GetSCC fetches a SecurityContextConstraints
+func (tc *TestHelper) GetSCC(name string) *securityv1.SecurityContextConstraints {
+	instance := &securityv1.SecurityContextConstraints{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		g.Expect(tc.K8sClient.Get(tc.Ctx, types.NamespacedName{Name: name}, instance)).Should(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	return instance
+}
+
+// AssertSCCDoesNotExist ensures the SecurityContextConstraints does not
+// exist in a k8s cluster.
+func (tc *TestHelper) AssertSCCDoesNotExist(name string) {
+	instance := &securityv1.SecurityContextConstraints{}
+	gomega.Eventually(func(g gomega.Gomega) {
+		err := tc.K8sClient.Get(tc.Ctx, types.NamespacedName{Name: name}, instance)
+		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+}
+
+// CreateImageStream creates an ImageStream with a tag pointing to the
+// given image.
+//
+// Example usage:
+//
+//	th.CreateImageStream(types.NamespacedName{Name: "keystone", Namespace: "openstack"}, "latest", "quay.io/podified-antelope-centos9/openstack-keystone:current-podified")
+func (tc *TestHelper) CreateImageStream(name types.NamespacedName, tag string, image string) *imagev1.ImageStream {
+	instance := &imagev1.ImageStream{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: imagev1.ImageStreamSpec{
+			Tags: []imagev1.TagReference{
+				{
+					Name: tag,
+					From: &corev1.ObjectReference{
+						Kind: "DockerImage",
+						Name: image,
+					},
+				},
+			},
+		},
+	}
+	gomega.Expect(tc.K8sClient.Create(tc.Ctx, instance)).Should(gomega.Succeed())
 
 	return instance
 }
+
+// SimulateImageStreamImported simulates that the images of all the spec
+// tags of the ImageStream got imported, like the openshift-apiserver does.
+func (tc *TestHelper) SimulateImageStreamImported(name types.NamespacedName) {
+	gomega.Eventually(func(g gomega.Gomega) {
+		instance := &imagev1.ImageStream{}
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, instance)).Should(gomega.Succeed())
+
+		instance.Status.DockerImageRepository = "image-registry.openshift-image-registry.svc:5000/" +
+			name.Namespace + "/" + name.Name
+		instance.Status.Tags = []imagev1.NamedTagEventList{}
+		for _, tag := range instance.Spec.Tags {
+			if tag.From == nil {
+				continue
+			}
+			instance.Status.Tags = append(instance.Status.Tags, imagev1.NamedTagEventList{
+				Tag: tag.Name,
+				Items: []imagev1.TagEvent{
+					{
+						Created:              metav1.Now(),
+						DockerImageReference: tag.From.Name,
+						Image:                "sha256:" + name.Name + "-" + tag.Name,
+						Generation:           instance.Generation,
+					},
+				},
+			})
+		}
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, instance)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated ImageStream imported", "on", name)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxNamePrefixLength - keeps the names generated by UniqueName within the
+// 63 character limit of DNS labels
+const maxNamePrefixLength = 40
+
+// objectTracker - objects created by a test which need to be deleted at the
+// end of it
+type objectTracker struct {
+	lock    sync.Mutex
+	objects []client.Object
+}
+
+// UniqueName returns a name with the given prefix which does not collide
+// with names generated by other tests, including ones running in parallel
+// ginkgo processes. The name is a valid DNS label.
+//
+// Example usage:
+//
+//	name := th.UniqueName("keystone")
+func (tc *TestHelper) UniqueName(prefix string) string {
+	prefix = strings.ToLower(prefix)
+	if len(prefix) > maxNamePrefixLength {
+		prefix = prefix[:maxNamePrefixLength]
+	}
+	prefix = strings.TrimSuffix(prefix, "-")
+
+	return fmt.Sprintf("%s-p%d-%s", prefix, ginkgo.GinkgoParallelProcess(), uuid.New().String()[:8])
+}
+
+// CreateTestNamespace creates a Namespace with a unique name, tracks it for
+// CleanupAll and returns its name. It replaces the uuid.New().String()
+// namespaces used in the suites.
+//
+// Example usage:
+//
+//	BeforeEach(func() {
+//		th.DeferCleanupAll()
+//		namespace = th.CreateTestNamespace()
+//	})
+func (tc *TestHelper) CreateTestNamespace() string {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tc.UniqueName("test"),
+		},
+	}
+	gomega.Expect(tc.K8sClient.Create(tc.Ctx, ns)).Should(gomega.Succeed())
+	tc.TrackObject(ns)
+
+	return ns.Name
+}
+
+// TrackObject registers an object to be deleted by CleanupAll. Objects are
+// deleted in the reverse order they got tracked. Use it for cluster scoped
+// objects, which are not removed together with the test namespace.
+//
+// Example usage:
+//
+//	th.TrackObject(clusterRole)
+func (tc *TestHelper) TrackObject(obj client.Object) {
+	if tc.tracked == nil {
+		tc.tracked = &objectTracker{}
+	}
+	tc.tracked.lock.Lock()
+	defer tc.tracked.lock.Unlock()
+
+	tc.tracked.objects = append(tc.tracked.objects, obj)
+}
+
+// GetTrackedObjects returns the objects CleanupAll would delete
+func (tc *TestHelper) GetTrackedObjects() []client.Object {
+	if tc.tracked == nil {
+		return nil
+	}
+	tc.tracked.lock.Lock()
+	defer tc.tracked.lock.Unlock()
+
+	return append([]client.Object{}, tc.tracked.objects...)
+}
+
+// CleanupAll deletes all the tracked objects in reverse order, ignoring the
+// ones already gone, and forgets them.
+func (tc *TestHelper) CleanupAll() {
+	if tc.tracked == nil {
+		return
+	}
+	tc.tracked.lock.Lock()
+	objects := tc.tracked.objects
+	tc.tracked.objects = nil
+	tc.tracked.lock.Unlock()
+
+	for i := len(objects) - 1; i >= 0; i-- {
+		obj := objects[i]
+		err := tc.K8sClient.Delete(tc.Ctx, obj)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			gomega.Expect(err).ShouldNot(gomega.HaveOccurred(),
+				"failed to delete %T %s", obj, tc.GetName(obj))
+		}
+		tc.Logger.Info("Deleted tracked object", "type", fmt.Sprintf("%T", obj), "name", tc.GetName(obj))
+	}
+}
+
+// DeferCleanupAll registers CleanupAll as ginkgo DeferCleanup of the
+// current spec, so every object tracked during the spec gets deleted at
+// its end. Must be called from a setup node, e.g. BeforeEach.
+//
+// Example usage:
+//
+//	BeforeEach(func() {
+//		th.DeferCleanupAll()
+//	})
+func (tc *TestHelper) DeferCleanupAll() {
+	ginkgo.DeferCleanup(tc.CleanupAll)
+}
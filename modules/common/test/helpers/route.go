@@ -17,7 +17,9 @@ import (
 	"github.com/onsi/gomega"
 
 	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -58,3 +60,86 @@ func (tc *TestHelper) AssertRouteDoesNotExist(name types.NamespacedName) {
 		g.Expect(k8s_errors.IsNotFound(err)).To(gomega.BeTrue())
 	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
 }
+
+// CreateRoute creates a Route exposing the given service. If host is empty
+// the Route gets the host <name>-<namespace>.apps-crc.testing once admitted.
+//
+// Example usage:
+//
+//	th.CreateRoute(types.NamespacedName{Name: "keystone-public", Namespace: "openstack"}, "", "keystone-public")
+func (tc *TestHelper) CreateRoute(name types.NamespacedName, host string, serviceName string) *routev1.Route {
+	instance := &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+		},
+		Spec: routev1.RouteSpec{
+			Host: host,
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: serviceName,
+			},
+		},
+	}
+	gomega.Expect(tc.K8sClient.Create(tc.Ctx, instance)).Should(gomega.Succeed())
+
+	return instance
+}
+
+// SimulateRouteAdmitted simulates that the default router admitted the
+// Route, setting the host in the Route spec, if not set, and the Admitted
+// ingress condition. Returns the host of the Route.
+//
+// Example usage:
+//
+//	host := th.SimulateRouteAdmitted(types.NamespacedName{Name: "keystone-public", Namespace: "openstack"})
+func (tc *TestHelper) SimulateRouteAdmitted(name types.NamespacedName) string {
+	return tc.setRouteIngressCondition(name, corev1.ConditionTrue, "", "")
+}
+
+// SimulateRouteRejected simulates that the default router rejected the
+// Route with the given reason and message, e.g. "HostAlreadyClaimed".
+func (tc *TestHelper) SimulateRouteRejected(name types.NamespacedName, reason string, message string) {
+	tc.setRouteIngressCondition(name, corev1.ConditionFalse, reason, message)
+}
+
+func (tc *TestHelper) setRouteIngressCondition(
+	name types.NamespacedName,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+) string {
+	var host string
+	gomega.Eventually(func(g gomega.Gomega) {
+		route := &routev1.Route{}
+		g.Expect(tc.K8sClient.Get(tc.Ctx, name, route)).Should(gomega.Succeed())
+		if route.Spec.Host == "" {
+			route.Spec.Host = name.Name + "-" + name.Namespace + ".apps-crc.testing"
+			g.Expect(tc.K8sClient.Update(tc.Ctx, route)).Should(gomega.Succeed())
+		}
+		host = route.Spec.Host
+
+		now := metav1.Now()
+		route.Status.Ingress = []routev1.RouteIngress{
+			{
+				Host:       host,
+				RouterName: "default",
+				Conditions: []routev1.RouteIngressCondition{
+					{
+						Type:               routev1.RouteAdmitted,
+						Status:             status,
+						Reason:             reason,
+						Message:            message,
+						LastTransitionTime: &now,
+					},
+				},
+				WildcardPolicy: routev1.WildcardPolicyNone,
+			},
+		}
+		g.Expect(tc.K8sClient.Status().Update(tc.Ctx, route)).To(gomega.Succeed())
+	}, tc.Timeout, tc.Interval).Should(gomega.Succeed())
+
+	tc.Logger.Info("Simulated Route admission", "on", name, "status", status)
+
+	return host
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WebhookRegistration - a validator and/or defaulter to register for the
+// Object with the webhook server of the test manager
+type WebhookRegistration struct {
+	// Object - the API type the webhooks are for, e.g. &keystonev1.KeystoneAPI{}
+	Object client.Object
+	// Resource - plural resource name of the Object, e.g. keystoneapis
+	Resource string
+	// Validator - validating webhook, optional
+	Validator admission.CustomValidator
+	// Defaulter - mutating webhook, optional
+	Defaulter admission.CustomDefaulter
+}
+
+// NewWebhookTestEnvironment returns an envtest.Environment which installs
+// the CRDs from crdPaths and the webhook configurations from webhookPaths,
+// e.g. config/webhook of the operator. envtest generates the serving
+// certificate and rewrites the webhook client config to the local serving
+// host and port when the environment gets started.
+func NewWebhookTestEnvironment(crdPaths []string, webhookPaths []string) *envtest.Environment {
+	return &envtest.Environment{
+		CRDDirectoryPaths:     crdPaths,
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: webhookPaths,
+		},
+	}
+}
+
+// AddWebhookConfigurations - adds validating and mutating webhook
+// configurations for the registrations to the environment, for operators
+// which don't ship webhook manifests or to test lib-common webhooks. Has to
+// be called before the environment gets started.
+func AddWebhookConfigurations(
+	testEnv *envtest.Environment,
+	scheme *runtime.Scheme,
+	registrations ...WebhookRegistration,
+) error {
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+
+	for _, r := range registrations {
+		gvk, err := apiutil.GVKForObject(r.Object, scheme)
+		if err != nil {
+			return err
+		}
+		rules := []admissionregistrationv1.RuleWithOperations{
+			{
+				Operations: []admissionregistrationv1.OperationType{
+					admissionregistrationv1.Create,
+					admissionregistrationv1.Update,
+				},
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{gvk.Group},
+					APIVersions: []string{gvk.Version},
+					Resources:   []string{r.Resource},
+				},
+			},
+		}
+		name := fmt.Sprintf("%s.%s", strings.ToLower(gvk.Kind), gvk.Group)
+
+		if r.Validator != nil {
+			path := GetWebhookPath("validate", gvk)
+			rules := append([]admissionregistrationv1.RuleWithOperations{}, rules...)
+			rules[0].Operations = append(rules[0].Operations, admissionregistrationv1.Delete)
+			testEnv.WebhookInstallOptions.ValidatingWebhooks = append(
+				testEnv.WebhookInstallOptions.ValidatingWebhooks,
+				&admissionregistrationv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: "v" + name},
+					Webhooks: []admissionregistrationv1.ValidatingWebhook{
+						{
+							Name:                    "v" + name,
+							ClientConfig:            admissionregistrationv1.WebhookClientConfig{Service: &admissionregistrationv1.ServiceReference{Path: &path}},
+							Rules:                   rules,
+							FailurePolicy:           &failurePolicy,
+							SideEffects:             &sideEffects,
+							AdmissionReviewVersions: []string{"v1"},
+						},
+					},
+				})
+		}
+		if r.Defaulter != nil {
+			path := GetWebhookPath("mutate", gvk)
+			testEnv.WebhookInstallOptions.MutatingWebhooks = append(
+				testEnv.WebhookInstallOptions.MutatingWebhooks,
+				&admissionregistrationv1.MutatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: "m" + name},
+					Webhooks: []admissionregistrationv1.MutatingWebhook{
+						{
+							Name:                    "m" + name,
+							ClientConfig:            admissionregistrationv1.WebhookClientConfig{Service: &admissionregistrationv1.ServiceReference{Path: &path}},
+							Rules:                   rules,
+							FailurePolicy:           &failurePolicy,
+							SideEffects:             &sideEffects,
+							AdmissionReviewVersions: []string{"v1"},
+						},
+					},
+				})
+		}
+	}
+
+	return nil
+}
+
+// GetWebhookPath - returns the path controller-runtime serves the webhook
+// of the given type ("validate" or "mutate") for the gvk on
+func GetWebhookPath(webhookType string, gvk schema.GroupVersionKind) string {
+	return "/" + webhookType + "-" + strings.ReplaceAll(gvk.Group, ".", "-") + "-" +
+		gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
+
+// StartWebhookManager - creates a manager serving the webhooks on the
+// host, port and certificates envtest prepared, registers the webhooks and
+// starts the manager in the background. It returns when the webhook server
+// accepts TLS connections, so that the tests don't fail with connection
+// refused errors. The manager stops when ctx gets cancelled.
+//
+// example usage:
+//
+//	testEnv = NewWebhookTestEnvironment(crdPaths, []string{filepath.Join("..", "..", "config", "webhook")})
+//	cfg, err = testEnv.Start()
+//	...
+//	_, err = StartWebhookManager(ctx, testEnv, cfg, scheme.Scheme, func(mgr ctrl.Manager) error {
+//		return (&keystonev1.KeystoneAPI{}).SetupWebhookWithManager(mgr)
+//	})
+func StartWebhookManager(
+	ctx context.Context,
+	testEnv *envtest.Environment,
+	cfg *rest.Config,
+	scheme *runtime.Scheme,
+	setup func(mgr ctrl.Manager) error,
+	registrations ...WebhookRegistration,
+) (ctrl.Manager, error) {
+	webhookInstallOptions := &testEnv.WebhookInstallOptions
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme: scheme,
+		WebhookServer: webhook.NewServer(
+			webhook.Options{
+				Host:    webhookInstallOptions.LocalServingHost,
+				Port:    webhookInstallOptions.LocalServingPort,
+				CertDir: webhookInstallOptions.LocalServingCertDir,
+			}),
+		LeaderElection: false,
+		Metrics: metricsserver.Options{
+			BindAddress: "0",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range registrations {
+		b := ctrl.NewWebhookManagedBy(mgr).For(r.Object)
+		if r.Validator != nil {
+			b = b.WithValidator(r.Validator)
+		}
+		if r.Defaulter != nil {
+			b = b.WithDefaulter(r.Defaulter)
+		}
+		err = b.Complete()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if setup != nil {
+		err = setup(mgr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	go func() {
+		err := mgr.Start(ctx)
+		if err != nil {
+			ctrl.Log.Error(err, "webhook test manager stopped")
+		}
+	}()
+
+	err = WaitForWebhookServer(webhookInstallOptions, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return mgr, nil
+}
+
+// WaitForWebhookServer - waits until the webhook server accepts TLS
+// connections, or the timeout is reached
+func WaitForWebhookServer(
+	webhookInstallOptions *envtest.WebhookInstallOptions,
+	timeout time.Duration,
+) error {
+	dialer := &net.Dialer{Timeout: time.Second}
+	addrPort := net.JoinHostPort(
+		webhookInstallOptions.LocalServingHost,
+		fmt.Sprintf("%d", webhookInstallOptions.LocalServingPort))
+
+	deadline := time.Now().Add(timeout)
+	for {
+		// #nosec G402 -- the certificate is self signed by envtest
+		conn, err := tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{InsecureSkipVerify: true})
+		if err == nil {
+			return conn.Close()
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("webhook server %s not ready: %w", addrPort, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
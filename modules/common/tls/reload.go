@@ -0,0 +1,193 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"bytes"
+	"context"
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultReloadInterval - default interval the CertReloader checks the cert
+// secret for changes
+const DefaultReloadInterval = 30 * time.Second
+
+// Define static errors
+var (
+	// ErrInvalidKeyPair indicates a cert secret without a usable cert and key
+	ErrInvalidKeyPair = errors.New("invalid TLS key pair")
+	// ErrNoCertificate indicates the CertReloader did not load a cert yet
+	ErrNoCertificate = errors.New("no TLS certificate loaded")
+)
+
+// ParseKeyPair - parses the cert and key of the cert secret s, see CertKey
+// and PrivateKey. Returns an error wrapping ErrInvalidKeyPair if they are
+// missing, do not match, or the cert is not valid at now.
+func ParseKeyPair(s *corev1.Secret, now time.Time) (*cryptotls.Certificate, error) {
+	for _, key := range []string{CertKey, PrivateKey} {
+		if _, ok := s.Data[key]; !ok {
+			return nil, fmt.Errorf("%w: %w: field %s not found in Secret %s", ErrInvalidKeyPair, util.ErrFieldNotFound, key, s.Name)
+		}
+	}
+
+	keyPair, err := cryptotls.X509KeyPair(s.Data[CertKey], s.Data[PrivateKey])
+	if err != nil {
+		return nil, fmt.Errorf("%w: secret %s: %w", ErrInvalidKeyPair, s.Name, err)
+	}
+	leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: secret %s: %w", ErrInvalidKeyPair, s.Name, err)
+	}
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return nil, fmt.Errorf("%w: secret %s: cert only valid from %s to %s",
+			ErrInvalidKeyPair, s.Name, leaf.NotBefore.UTC(), leaf.NotAfter.UTC())
+	}
+	keyPair.Leaf = leaf
+
+	return &keyPair, nil
+}
+
+// CertReloader - serves the key pair of a cert secret to a TLS server run by
+// the operator itself, e.g. its metrics or webhook server, and reloads it
+// when the secret changes. This allows the cert to get rotated without
+// restarting the operator. The secret is polled every Interval, not
+// watched, so a rotated cert gets served up to Interval later.
+// +kubebuilder:object:generate:=false
+type CertReloader struct {
+	// Secret - the cert secret
+	Secret types.NamespacedName
+	// Reader - reads the secret, usually the API reader of the manager, as
+	// the cache of the manager is not started yet when the cert is loaded
+	// before the servers start
+	Reader client.Reader
+	// Interval - interval to check the secret for changes,
+	// DefaultReloadInterval if zero
+	Interval time.Duration
+	// Log - logger to report reloads and invalid key pairs
+	Log logr.Logger
+
+	mu   sync.RWMutex
+	cert *cryptotls.Certificate
+	data [][]byte
+}
+
+// Load - loads the key pair of the secret if it changed. An invalid key
+// pair, e.g. an expired cert or while cert and key are not updated both, is
+// returned as error and the previous key pair is kept.
+func (r *CertReloader) Load(ctx context.Context) error {
+	s := &corev1.Secret{}
+	err := r.Reader.Get(ctx, r.Secret, s)
+	if err != nil {
+		return fmt.Errorf("get secret %s failed: %w", r.Secret, err)
+	}
+
+	data := [][]byte{s.Data[CertKey], s.Data[PrivateKey]}
+	r.mu.RLock()
+	unchanged := r.cert != nil && bytes.Equal(r.data[0], data[0]) && bytes.Equal(r.data[1], data[1])
+	r.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	cert, err := ParseKeyPair(s, time.Now())
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.cert = cert
+	r.data = data
+	r.mu.Unlock()
+	r.Log.Info("TLS certificate loaded", "secret", r.Secret, "notAfter", cert.Leaf.NotAfter)
+
+	return nil
+}
+
+// GetCertificate - returns the current key pair, for tls.Config.GetCertificate
+func (r *CertReloader) GetCertificate(_ *cryptotls.ClientHelloInfo) (*cryptotls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.cert == nil {
+		return nil, ErrNoCertificate
+	}
+	return r.cert, nil
+}
+
+// TLSOption - sets GetCertificate on the tls.Config, e.g. as TLSOpts of the
+// metrics or webhook server options of the manager
+//
+// Example:
+//
+//	reloader := &tls.CertReloader{
+//		Secret: types.NamespacedName{Name: "metrics-cert", Namespace: namespace},
+//		Reader: mgr.GetAPIReader(),
+//		Log:    ctrl.Log.WithName("cert-reloader"),
+//	}
+//	// load the cert before the servers start, the cache of the client of
+//	// the manager is not started yet
+//	err = reloader.Load(ctx)
+//	...
+//	webhookServer := webhook.NewServer(webhook.Options{
+//		TLSOpts: []func(*cryptotls.Config){reloader.TLSOption},
+//	})
+//	...
+//	err = mgr.Add(reloader)
+func (r *CertReloader) TLSOption(c *cryptotls.Config) {
+	c.GetCertificate = r.GetCertificate
+}
+
+// NeedLeaderElection - implements the manager.LeaderElectionRunnable
+// interface. The reloader gets started in all replicas of the operator, as
+// all of them run the metrics and webhook servers.
+func (r *CertReloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start - checks the secret for changes every Interval until ctx is done,
+// implements the manager.Runnable interface. Failed loads are logged and
+// retried, the previous key pair keeps being served.
+func (r *CertReloader) Start(ctx context.Context) error {
+	interval := r.Interval
+	if interval == 0 {
+		interval = DefaultReloadInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			err := r.Load(ctx)
+			if err != nil {
+				r.Log.Error(err, "TLS certificate reload failed, keeping the current one", "secret", r.Secret)
+			}
+		}
+	}
+}
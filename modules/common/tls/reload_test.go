@@ -0,0 +1,136 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// getKeyPair - returns a self signed cert and its key in PEM
+func getKeyPair(g *WithT, commonName string, notAfter time.Time) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	g.Expect(err).ToNot(HaveOccurred())
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestCertReloader(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	cert, key := getKeyPair(g, "metrics-1", time.Now().Add(time.Hour))
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics-cert", Namespace: "openstack"},
+		Data:       map[string][]byte{CertKey: cert, PrivateKey: key},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(s).Build()
+
+	r := &CertReloader{
+		Secret: types.NamespacedName{Name: "metrics-cert", Namespace: "openstack"},
+		Reader: fakeClient,
+		Log:    ctrl.Log,
+	}
+	_, err := r.GetCertificate(nil)
+	g.Expect(err).To(MatchError(ErrNoCertificate))
+
+	g.Expect(r.Load(ctx)).To(Succeed())
+	current, err := r.GetCertificate(nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(current.Leaf.Subject.CommonName).To(Equal("metrics-1"))
+
+	// a cert not matching the key is rejected and the current one kept
+	rotated, rotatedKey := getKeyPair(g, "metrics-2", time.Now().Add(time.Hour))
+	s.Data[CertKey] = rotated
+	g.Expect(fakeClient.Update(ctx, s)).To(Succeed())
+	g.Expect(r.Load(ctx)).To(MatchError(ErrInvalidKeyPair))
+	current, _ = r.GetCertificate(nil)
+	g.Expect(current.Leaf.Subject.CommonName).To(Equal("metrics-1"))
+
+	// the rotated key pair is loaded
+	s.Data[PrivateKey] = rotatedKey
+	g.Expect(fakeClient.Update(ctx, s)).To(Succeed())
+	g.Expect(r.Load(ctx)).To(Succeed())
+	current, _ = r.GetCertificate(nil)
+	g.Expect(current.Leaf.Subject.CommonName).To(Equal("metrics-2"))
+
+	// started on all replicas, not only the leader
+	g.Expect(r.NeedLeaderElection()).To(BeFalse())
+
+	// Start polls the secret for changes
+	r.Interval = 10 * time.Millisecond
+	startCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() { done <- r.Start(startCtx) }()
+	polled, polledKey := getKeyPair(g, "metrics-3", time.Now().Add(time.Hour))
+	s.Data = map[string][]byte{CertKey: polled, PrivateKey: polledKey}
+	g.Expect(fakeClient.Update(ctx, s)).To(Succeed())
+	g.Eventually(func() string {
+		current, _ := r.GetCertificate(nil)
+		return current.Leaf.Subject.CommonName
+	}).Should(Equal("metrics-3"))
+	cancel()
+	g.Eventually(done).Should(Receive(BeNil()))
+}
+
+func TestParseKeyPair(t *testing.T) {
+	g := NewWithT(t)
+
+	cert, key := getKeyPair(g, "expired", time.Now().Add(-time.Minute))
+	s := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert"},
+		Data:       map[string][]byte{CertKey: cert},
+	}
+	_, err := ParseKeyPair(s, time.Now())
+	g.Expect(err).To(MatchError(ErrInvalidKeyPair))
+	g.Expect(err).To(MatchError(ContainSubstring("field tls.key not found")))
+
+	s.Data[PrivateKey] = key
+	_, err = ParseKeyPair(s, time.Now())
+	g.Expect(err).To(MatchError(ContainSubstring("cert only valid from")))
+
+	keyPair, err := ParseKeyPair(s, time.Now().Add(-2*time.Minute))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(keyPair.Leaf.Subject.CommonName).To(Equal("expired"))
+}
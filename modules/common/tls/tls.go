@@ -280,7 +280,7 @@ func (s *Service) ValidateCertSecret(ctx context.Context, h *helper.Helper, name
 		ctx,
 		types.NamespacedName{Name: s.SecretName, Namespace: namespace},
 		keys,
-		h.GetClient(),
+		h.GetReader(),
 		5*time.Second)
 	if err != nil {
 		return "", err
@@ -350,6 +350,18 @@ func (s *Service) getKeyMountPath(serviceID string) string {
 	return keyMountPath
 }
 
+// CertMountPath - returns the path CreateVolumeMounts mounts the cert of the
+// service to, e.g. to be set in the service config
+func (s *Service) CertMountPath(serviceID string) string {
+	return s.getCertMountPath(serviceID)
+}
+
+// KeyMountPath - returns the path CreateVolumeMounts mounts the key of the
+// service to, e.g. to be set in the service config
+func (s *Service) KeyMountPath(serviceID string) string {
+	return s.getKeyMountPath(serviceID)
+}
+
 // CreateVolumeMounts - add volume mount for TLS certificates and CA certificate for the service
 func (s *Service) CreateVolumeMounts(serviceID string) []corev1.VolumeMount {
 	volumeMounts := []corev1.VolumeMount{}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// +kubebuilder:object:generate:=true
+
+package update
+
+import (
+	"context"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+const (
+	// ApprovalAnnotation - annotation an admin sets on the CR to the target
+	// version to approve an update, if the approval is required
+	ApprovalAnnotation = "openstack.org/update-approved-version"
+)
+
+// Status - progress of the minor update, to be embedded in the status of
+// the CR so that it persists between reconciles
+type Status struct {
+	// DeployedVersion - version all components run
+	DeployedVersion string `json:"deployedVersion,omitempty"`
+	// TargetVersion - version the running update rolls the components to
+	TargetVersion string `json:"targetVersion,omitempty"`
+	// UpdatedComponents - components which already run the TargetVersion
+	UpdatedComponents []string `json:"updatedComponents,omitempty"`
+}
+
+// Component - a part of the service which gets updated, e.g. the api or
+// the scheduler deployment
+// +kubebuilder:object:generate:=false
+type Component struct {
+	// Name - name of the component
+	Name string
+	// Apply - applies the target version to the component, e.g. sets the
+	// new container image. It gets called on each reconcile until the
+	// component is ready and must be idempotent.
+	Apply func(ctx context.Context, h *helper.Helper, version string) error
+	// IsReady - returns true if the component is Ready at the target
+	// version, else a message describing what it waits for
+	IsReady func(ctx context.Context, h *helper.Helper, version string) (bool, string, error)
+}
+
+// Update - rolls the components in order to a new version
+// +kubebuilder:object:generate:=false
+type Update struct {
+	components      []Component
+	requireApproval bool
+	timeout         time.Duration
+}
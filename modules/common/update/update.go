@@ -0,0 +1,177 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package update provides the minor update workflow, which rolls the
+// components of a service one after the other to a new version
+package update
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// NewUpdate - returns an Update which rolls the components in the given
+// order. If requireApproval is true an update only starts once the
+// ApprovalAnnotation on the CR is set to the target version. The timeout is
+// the requeue interval while waiting for a component to get ready.
+func NewUpdate(
+	timeout time.Duration,
+	requireApproval bool,
+	components ...Component,
+) *Update {
+	return &Update{
+		components:      components,
+		requireApproval: requireApproval,
+		timeout:         timeout,
+	}
+}
+
+// GetImagesVersion - returns a version string for services without an
+// explicit version, derived from the hash of the container images, so that
+// an image change is handled as update
+func GetImagesVersion(images map[string]string) (string, error) {
+	return util.ObjectHash(images)
+}
+
+// IsUpdateRequired - returns true if the deployed version differs from the
+// target version. The initial deployment is not an update.
+func (s *Status) IsUpdateRequired(targetVersion string) bool {
+	return s.DeployedVersion != "" && s.DeployedVersion != targetVersion
+}
+
+// IsApproved - returns true if the update to the target version got
+// approved via the ApprovalAnnotation
+func IsApproved(obj metav1.Object, targetVersion string) bool {
+	return obj.GetAnnotations()[ApprovalAnnotation] == targetVersion
+}
+
+// Reconcile - drives the update of the components to the target version
+// and reports the progress in the UpdateProgressingCondition and
+// UpdateCompletedCondition. The components get updated one after the
+// other, the next one only when the previous is Ready at the target
+// version. A requeue is requested while the update is running. The caller
+// should only run its regular deployment logic at the target version if
+// status.DeployedVersion equals the target version after the call, e.g.:
+//
+//	ctrlResult, err := u.Reconcile(ctx, h, instance, version, &instance.Status.Update, &instance.Status.Conditions)
+//	if err != nil || (ctrlResult != ctrl.Result{}) {
+//		return ctrlResult, err
+//	}
+//	if instance.Status.Update.DeployedVersion != version {
+//		// waiting for approval
+//		return ctrl.Result{}, nil
+//	}
+func (u *Update) Reconcile(
+	ctx context.Context,
+	h *helper.Helper,
+	obj metav1.Object,
+	targetVersion string,
+	status *Status,
+	conditions *condition.Conditions,
+) (ctrl.Result, error) {
+	if !status.IsUpdateRequired(targetVersion) {
+		status.DeployedVersion = targetVersion
+		status.TargetVersion = ""
+		status.UpdatedComponents = nil
+		conditions.Remove(condition.UpdateProgressingCondition)
+		conditions.MarkTrue(condition.UpdateCompletedCondition, condition.UpdateCompletedMessage, targetVersion)
+		return ctrl.Result{}, nil
+	}
+
+	// the target changed during a running update, start over
+	if status.TargetVersion != targetVersion {
+		status.TargetVersion = targetVersion
+		status.UpdatedComponents = nil
+	}
+
+	if u.requireApproval && !IsApproved(obj, targetVersion) {
+		h.GetLogger().Info("Update waiting for approval", "fromVersion", status.DeployedVersion, "toVersion", targetVersion)
+		conditions.Remove(condition.UpdateProgressingCondition)
+		conditions.Set(condition.FalseCondition(
+			condition.UpdateCompletedCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.UpdateCompletedWaitingApprovalMessage,
+			targetVersion,
+			ApprovalAnnotation))
+		// the annotation change triggers the next reconcile
+		return ctrl.Result{}, nil
+	}
+
+	for _, c := range u.components {
+		if slices.Contains(status.UpdatedComponents, c.Name) {
+			continue
+		}
+
+		err := c.Apply(ctx, h, targetVersion)
+		if err != nil {
+			return setError(c, targetVersion, err, conditions)
+		}
+
+		ready, message, err := c.IsReady(ctx, h, targetVersion)
+		if err != nil {
+			return setError(c, targetVersion, err, conditions)
+		}
+		if !ready {
+			h.GetLogger().Info("Component update in progress, reconcile later", "component", c.Name, "toVersion", targetVersion, "status", message, "requeueAfter", u.timeout)
+			conditions.MarkTrue(condition.UpdateProgressingCondition, condition.UpdateProgressingMessage, c.Name, targetVersion, message)
+			conditions.Set(condition.FalseCondition(
+				condition.UpdateCompletedCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				condition.UpdateCompletedRunningMessage,
+				targetVersion))
+			return ctrl.Result{RequeueAfter: u.timeout}, nil
+		}
+
+		h.GetLogger().Info("Component updated", "component", c.Name, "toVersion", targetVersion)
+		status.UpdatedComponents = append(status.UpdatedComponents, c.Name)
+	}
+
+	h.GetLogger().Info("Update completed", "fromVersion", status.DeployedVersion, "toVersion", targetVersion)
+	status.DeployedVersion = targetVersion
+	status.TargetVersion = ""
+	status.UpdatedComponents = nil
+	conditions.Remove(condition.UpdateProgressingCondition)
+	conditions.MarkTrue(condition.UpdateCompletedCondition, condition.UpdateCompletedMessage, targetVersion)
+
+	return ctrl.Result{}, nil
+}
+
+// setError - reports the failed update of the component
+func setError(
+	c Component,
+	targetVersion string,
+	err error,
+	conditions *condition.Conditions,
+) (ctrl.Result, error) {
+	conditions.Set(condition.FalseCondition(
+		condition.UpdateCompletedCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		condition.UpdateCompletedErrorMessage,
+		targetVersion,
+		err.Error()))
+	return ctrl.Result{}, fmt.Errorf("update of %s to version %s: %w", c.Name, targetVersion, err)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var errApply = errors.New("apply failed")
+
+// fakeComponent - component which gets ready on the next IsReady call
+// after the version got applied
+type fakeComponent struct {
+	applied  string
+	running  string
+	applyErr error
+}
+
+func (f *fakeComponent) component(name string) Component {
+	return Component{
+		Name: name,
+		Apply: func(_ context.Context, _ *helper.Helper, version string) error {
+			if f.applyErr != nil {
+				return f.applyErr
+			}
+			f.applied = version
+			return nil
+		},
+		IsReady: func(_ context.Context, _ *helper.Helper, version string) (bool, string, error) {
+			if f.running == version {
+				return true, "", nil
+			}
+			f.running = f.applied
+			return false, "rollout in progress", nil
+		},
+	}
+}
+
+func setup(g *WithT) (*helper.Helper, *corev1.ConfigMap) {
+	cr := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nova",
+			Namespace: "openstack",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	h, err := helper.NewHelper(cr, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+	return h, cr
+}
+
+func TestReconcile(t *testing.T) {
+	g := NewWithT(t)
+	h, cr := setup(g)
+	ctx := context.TODO()
+
+	api := &fakeComponent{running: "1.0"}
+	scheduler := &fakeComponent{running: "1.0"}
+	u := NewUpdate(time.Second, true, api.component("api"), scheduler.component("scheduler"))
+
+	status := Status{}
+	conditions := condition.Conditions{}
+
+	// initial deployment
+	result, err := u.Reconcile(ctx, h, cr, "1.0", &status, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(status.DeployedVersion).To(Equal("1.0"))
+	g.Expect(conditions.IsTrue(condition.UpdateCompletedCondition)).To(BeTrue())
+
+	// new version waits for approval
+	result, err = u.Reconcile(ctx, h, cr, "1.1", &status, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(status.DeployedVersion).To(Equal("1.0"))
+	g.Expect(conditions.Get(condition.UpdateCompletedCondition).Message).To(
+		Equal("Update to version 1.1 waiting for approval via annotation " + ApprovalAnnotation))
+	g.Expect(api.applied).To(BeEmpty())
+
+	// the api gets updated first
+	cr.Annotations = map[string]string{ApprovalAnnotation: "1.1"}
+	result, err = u.Reconcile(ctx, h, cr, "1.1", &status, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+	g.Expect(api.applied).To(Equal("1.1"))
+	g.Expect(scheduler.applied).To(BeEmpty())
+	g.Expect(conditions.IsTrue(condition.UpdateProgressingCondition)).To(BeTrue())
+	g.Expect(conditions.Get(condition.UpdateProgressingCondition).Message).To(
+		Equal("Updating api to version 1.1: rollout in progress"))
+	g.Expect(conditions.IsFalse(condition.UpdateCompletedCondition)).To(BeTrue())
+
+	// then the scheduler
+	scheduler.applyErr = errApply
+	_, err = u.Reconcile(ctx, h, cr, "1.1", &status, &conditions)
+	g.Expect(err).To(MatchError(errApply))
+	g.Expect(status.UpdatedComponents).To(Equal([]string{"api"}))
+	g.Expect(conditions.Get(condition.UpdateCompletedCondition).Reason).To(Equal(condition.Reason(condition.ErrorReason)))
+
+	scheduler.applyErr = nil
+	result, err = u.Reconcile(ctx, h, cr, "1.1", &status, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Second}))
+	g.Expect(scheduler.applied).To(Equal("1.1"))
+
+	result, err = u.Reconcile(ctx, h, cr, "1.1", &status, &conditions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(status).To(Equal(Status{DeployedVersion: "1.1"}))
+	g.Expect(conditions.Has(condition.UpdateProgressingCondition)).To(BeFalse())
+	g.Expect(conditions.Get(condition.UpdateCompletedCondition).Message).To(Equal("Version 1.1 deployed"))
+}
+
+func TestGetImagesVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	v1, err := GetImagesVersion(map[string]string{"api": "nova-api:1.0"})
+	g.Expect(err).ToNot(HaveOccurred())
+	v2, err := GetImagesVersion(map[string]string{"api": "nova-api:1.1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(v1).ToNot(Equal(v2))
+
+	status := Status{DeployedVersion: v1}
+	g.Expect(status.IsUpdateRequired(v1)).To(BeFalse())
+	g.Expect(status.IsUpdateRequired(v2)).To(BeTrue())
+	g.Expect((&Status{}).IsUpdateRequired(v2)).To(BeFalse())
+}
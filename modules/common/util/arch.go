@@ -0,0 +1,475 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Define static errors
+var (
+	ErrInvalidImage         = errors.New("invalid image reference")
+	ErrUnknownImage         = errors.New("no architecture metadata for image")
+	ErrManifestNotSupported = errors.New("unsupported image manifest")
+	ErrInvalidPullSecret    = errors.New("invalid pull secret")
+)
+
+const (
+	// ArchLabel - node label holding the architecture of the node
+	ArchLabel = corev1.LabelArchStable
+
+	mediaTypeOCIIndex         = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest      = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerList       = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest   = "application/vnd.docker.distribution.manifest.v2+json"
+	defaultRegistry           = "registry-1.docker.io"
+	manifestAcceptMediaTypes  = mediaTypeOCIIndex + "," + mediaTypeDockerList + "," + mediaTypeOCIManifest + "," + mediaTypeDockerManifest
+	maxManifestResponseLength = 4 << 20
+
+	// DefaultRegistryTimeout - timeout of the requests of a
+	// RegistryArchResolver without Client
+	DefaultRegistryTimeout = 30 * time.Second
+)
+
+// ArchResolver - returns the architectures an image is available for
+type ArchResolver interface {
+	Architectures(ctx context.Context, image string) ([]string, error)
+}
+
+// StaticArchResolver - offline ArchResolver using operator provided
+// metadata, e.g. from the bundle of the operator, keyed by image. An image
+// without an entry matches the entry of its repository without tag or
+// digest.
+type StaticArchResolver map[string][]string
+
+// Architectures -
+func (r StaticArchResolver) Architectures(_ context.Context, image string) ([]string, error) {
+	if archs, ok := r[image]; ok {
+		return archs, nil
+	}
+	ref, err := ParseImageReference(image)
+	if err != nil {
+		return nil, err
+	}
+	if archs, ok := r[ref.Repository()]; ok {
+		return archs, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownImage, image)
+}
+
+// ImageReference - the parts of an image reference
+type ImageReference struct {
+	// Registry - host of the registry
+	Registry string
+	// Name - name of the image in the registry, e.g. podified-antelope-centos9/openstack-keystone
+	Name string
+	// Reference - tag or digest, latest if not set
+	Reference string
+}
+
+// Repository - returns the registry and name of the image
+func (r ImageReference) Repository() string {
+	return r.Registry + "/" + r.Name
+}
+
+// ParseImageReference - parses image, e.g.
+// quay.io/podified-antelope-centos9/openstack-keystone:current-podified.
+// Images without registry refer to docker.io.
+func ParseImageReference(image string) (ImageReference, error) {
+	if image == "" || strings.ContainsAny(image, " \t\n") {
+		return ImageReference{}, fmt.Errorf("%w: %q", ErrInvalidImage, image)
+	}
+	ref := ImageReference{Reference: "latest"}
+
+	name := image
+	if before, digest, ok := strings.Cut(image, "@"); ok {
+		name, ref.Reference = before, digest
+	} else if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, ref.Reference = image[:i], image[i+1:]
+	}
+
+	first, rest, ok := strings.Cut(name, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry, ref.Name = first, rest
+	} else {
+		ref.Registry, ref.Name = defaultRegistry, name
+		if !ok {
+			ref.Name = "library/" + name
+		}
+	}
+	if ref.Name == "" || ref.Reference == "" {
+		return ImageReference{}, fmt.Errorf("%w: %q", ErrInvalidImage, image)
+	}
+	return ref, nil
+}
+
+// RegistryCredentials - credentials to access a registry
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// RegistryCredentialsFromSecret - returns the registry credentials of the
+// pull secret s, of type kubernetes.io/dockerconfigjson, keyed by registry
+// host
+func RegistryCredentialsFromSecret(s *corev1.Secret) (map[string]RegistryCredentials, error) {
+	data, ok := s.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: %w: field %s not found in Secret %s",
+			ErrInvalidPullSecret, ErrFieldNotFound, corev1.DockerConfigJsonKey, s.Name)
+	}
+	config := struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%w: secret %s: %w", ErrInvalidPullSecret, s.Name, err)
+	}
+
+	creds := map[string]RegistryCredentials{}
+	for server, auth := range config.Auths {
+		c := RegistryCredentials{Username: auth.Username, Password: auth.Password}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("%w: secret %s: auth of %s: %w", ErrInvalidPullSecret, s.Name, server, err)
+			}
+			c.Username, c.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		// the servers can be given as URL, e.g. https://index.docker.io/v1/
+		host := server
+		if _, after, ok := strings.Cut(host, "://"); ok {
+			host = after
+		}
+		host, _, _ = strings.Cut(host, "/")
+		creds[host] = c
+	}
+	return creds, nil
+}
+
+// RegistryArchResolver - ArchResolver reading the manifests of the images
+// from their registries, or their mirrors
+type RegistryArchResolver struct {
+	// Client - HTTP client, a client with DefaultRegistryTimeout if nil
+	Client *http.Client
+	// Insecure - use http instead of https, e.g. for a test registry
+	Insecure bool
+	// Credentials - credentials keyed by registry host, e.g. of the pull
+	// secret of the service, see RegistryCredentialsFromSecret. Registries
+	// without credentials are accessed anonymously.
+	Credentials map[string]RegistryCredentials
+	// Mirrors - mirrors keyed by source, a registry or repository as
+	// returned by ImageReference.Repository, like the mirrors of an
+	// ImageDigestMirrorSet, e.g. "quay.io/podified-antelope-centos9":
+	// {"mirror.example.com:5000/podified"}. The mirrors of the most specific
+	// source are tried in order before the source.
+	Mirrors map[string][]string
+}
+
+type manifest struct {
+	MediaType string `json:"mediaType"`
+	Manifests []struct {
+		Platform struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+		} `json:"platform"`
+	} `json:"manifests"`
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+}
+
+// Architectures -
+func (r RegistryArchResolver) Architectures(ctx context.Context, image string) ([]string, error) {
+	ref, err := ParseImageReference(image)
+	if err != nil {
+		return nil, err
+	}
+
+	var archs []string
+	for _, m := range r.mirrorsOf(ref) {
+		archs, err = r.architectures(ctx, m, image)
+		if err == nil {
+			return archs, nil
+		}
+	}
+	return r.architectures(ctx, ref, image)
+}
+
+// mirrorsOf - returns the references of the image ref in the mirrors of
+// the most specific source of the image
+func (r RegistryArchResolver) mirrorsOf(ref ImageReference) []ImageReference {
+	repository := ref.Repository()
+	source := ""
+	for s := range r.Mirrors {
+		if (repository == s || strings.HasPrefix(repository, s+"/")) && len(s) > len(source) {
+			source = s
+		}
+	}
+	if source == "" {
+		return nil
+	}
+
+	separator := ":"
+	if strings.Contains(ref.Reference, ":") {
+		separator = "@"
+	}
+	refs := []ImageReference{}
+	for _, mirror := range r.Mirrors[source] {
+		m, err := ParseImageReference(mirror + strings.TrimPrefix(repository, source) + separator + ref.Reference)
+		if err == nil {
+			refs = append(refs, m)
+		}
+	}
+	return refs
+}
+
+// architectures - returns the architectures of the image ref
+func (r RegistryArchResolver) architectures(ctx context.Context, ref ImageReference, image string) ([]string, error) {
+	body, mediaType, err := r.get(ctx, ref, "manifests/"+ref.Reference, manifestAcceptMediaTypes)
+	if err != nil {
+		return nil, err
+	}
+	m := manifest{}
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrManifestNotSupported, image, err)
+	}
+	if m.MediaType != "" {
+		mediaType = m.MediaType
+	}
+
+	archs := []string{}
+	switch mediaType {
+	case mediaTypeOCIIndex, mediaTypeDockerList:
+		for _, entry := range m.Manifests {
+			if entry.Platform.Architecture != "" && entry.Platform.Architecture != "unknown" {
+				archs = append(archs, entry.Platform.Architecture)
+			}
+		}
+	case mediaTypeOCIManifest, mediaTypeDockerManifest:
+		// single arch image, the architecture is in its config
+		body, _, err := r.get(ctx, ref, "blobs/"+m.Config.Digest, "*/*")
+		if err != nil {
+			return nil, err
+		}
+		config := struct {
+			Architecture string `json:"architecture"`
+		}{}
+		if err := json.Unmarshal(body, &config); err != nil {
+			return nil, fmt.Errorf("%w: config of %s: %w", ErrManifestNotSupported, image, err)
+		}
+		archs = append(archs, config.Architecture)
+	default:
+		return nil, fmt.Errorf("%w: %s has media type %q", ErrManifestNotSupported, image, mediaType)
+	}
+
+	return sortedUnique(archs), nil
+}
+
+// client - returns the HTTP client of the resolver
+func (r RegistryArchResolver) client() *http.Client {
+	if r.Client == nil {
+		return &http.Client{Timeout: DefaultRegistryTimeout}
+	}
+	return r.Client
+}
+
+// get - returns the body and content type of path of the repository of ref,
+// authorizing if the registry asks for it
+func (r RegistryArchResolver) get(ctx context.Context, ref ImageReference, path string, accept string) ([]byte, string, error) {
+	c := r.client()
+	scheme := "https"
+	if r.Insecure {
+		scheme = "http"
+	}
+	u := fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Registry, ref.Name, path)
+
+	authorization := ""
+	for attempt := 0; attempt < 2; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		req.Header.Set("Accept", accept)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := c.Do(req)
+		if err != nil {
+			return nil, "", err
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestResponseLength))
+		resp.Body.Close()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && authorization == "" {
+			authorization, err = r.authorize(ctx, c, ref.Registry, resp.Header.Get("WWW-Authenticate"))
+			if err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, "", fmt.Errorf("get %s: %s", u, resp.Status)
+		}
+		return body, strings.Split(resp.Header.Get("Content-Type"), ";")[0], nil
+	}
+	return nil, "", fmt.Errorf("get %s: unauthorized", u)
+}
+
+// authorize - returns the Authorization header for the challenge of a
+// WWW-Authenticate header of registry, with its credentials if there are
+// any, anonymous otherwise
+func (r RegistryArchResolver) authorize(ctx context.Context, c *http.Client, registry string, challenge string) (string, error) {
+	creds, hasCreds := r.Credentials[registry]
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch {
+	case strings.EqualFold(scheme, "Basic") && hasCreds:
+		return "Basic " + basicAuth(creds), nil
+	case !strings.EqualFold(scheme, "Bearer"):
+		return "", fmt.Errorf("unsupported registry authentication %q", challenge)
+	}
+
+	values := map[string]string{}
+	for _, p := range strings.Split(params, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+		values[k] = strings.Trim(v, `"`)
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || values["realm"] == "" {
+		return "", fmt.Errorf("invalid registry authentication realm %q", values["realm"])
+	}
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if values[k] != "" {
+			q.Set(k, values[k])
+		}
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if hasCreds {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get registry token: %s", resp.Status)
+	}
+	t := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestResponseLength)).Decode(&t); err != nil {
+		return "", err
+	}
+	if t.Token != "" {
+		return "Bearer " + t.Token, nil
+	}
+	return "Bearer " + t.AccessToken, nil
+}
+
+// basicAuth - returns the basic authorization of creds
+func basicAuth(creds RegistryCredentials) string {
+	return base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password))
+}
+
+// NodeArchitectures - returns the sorted architectures of the nodes matching
+// nodeSelector, all nodes if empty
+func NodeArchitectures(ctx context.Context, h *helper.Helper, nodeSelector map[string]string) ([]string, error) {
+	nodes := &corev1.NodeList{}
+	err := h.GetClient().List(ctx, nodes, client.MatchingLabels(nodeSelector))
+	if err != nil {
+		return nil, err
+	}
+	archs := []string{}
+	for _, n := range nodes.Items {
+		if arch, ok := n.Labels[ArchLabel]; ok {
+			archs = append(archs, arch)
+		}
+	}
+	return sortedUnique(archs), nil
+}
+
+// CheckImageArchitectures - checks image is available for the
+// architectures of the nodes matching nodeSelector. Returns true if it is,
+// otherwise a message naming the missing architectures, e.g. for a
+// precondition condition instead of ImagePullBackOff pods.
+func CheckImageArchitectures(
+	ctx context.Context,
+	h *helper.Helper,
+	resolver ArchResolver,
+	image string,
+	nodeSelector map[string]string,
+) (bool, string, error) {
+	nodeArchs, err := NodeArchitectures(ctx, h, nodeSelector)
+	if err != nil {
+		return false, "", err
+	}
+	imageArchs, err := resolver.Architectures(ctx, image)
+	if err != nil {
+		return false, "", err
+	}
+
+	missing := []string{}
+	for _, arch := range nodeArchs {
+		if !StringInSlice(arch, imageArchs) {
+			missing = append(missing, arch)
+		}
+	}
+	if len(missing) > 0 {
+		return false, fmt.Sprintf("image %s is not available for node architectures %s (available: %s)",
+			image, strings.Join(missing, ","), strings.Join(imageArchs, ",")), nil
+	}
+	return true, "", nil
+}
+
+func sortedUnique(in []string) []string {
+	out := []string{}
+	for _, s := range in {
+		if !StringInSlice(s, out) {
+			out = append(out, s)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseImageReference(t *testing.T) {
+	g := NewWithT(t)
+
+	for image, want := range map[string]ImageReference{
+		"quay.io/podified-antelope-centos9/openstack-keystone:current-podified": {
+			Registry: "quay.io", Name: "podified-antelope-centos9/openstack-keystone", Reference: "current-podified"},
+		"localhost:5000/keystone@sha256:abc": {Registry: "localhost:5000", Name: "keystone", Reference: "sha256:abc"},
+		"busybox":                            {Registry: "registry-1.docker.io", Name: "library/busybox", Reference: "latest"},
+		"centos/centos:stream9":              {Registry: "registry-1.docker.io", Name: "centos/centos", Reference: "stream9"},
+	} {
+		ref, err := ParseImageReference(image)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ref).To(Equal(want), image)
+	}
+
+	_, err := ParseImageReference("")
+	g.Expect(err).To(MatchError(ErrInvalidImage))
+	_, err = ParseImageReference("quay.io/keystone:")
+	g.Expect(err).To(MatchError(ErrInvalidImage))
+}
+
+func TestRegistryArchResolver(t *testing.T) {
+	g := NewWithT(t)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			g.Expect(r.URL.Query().Get("scope")).To(Equal("repository:keystone:pull"))
+			fmt.Fprint(w, `{"token": "anonymous"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:keystone:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/keystone/manifests/multi":
+			w.Header().Set("Content-Type", mediaTypeOCIIndex)
+			fmt.Fprint(w, `{"manifests": [
+				{"platform": {"architecture": "arm64", "os": "linux"}},
+				{"platform": {"architecture": "amd64", "os": "linux"}},
+				{"platform": {"architecture": "unknown", "os": "unknown"}}]}`)
+		case "/v2/keystone/manifests/single":
+			w.Header().Set("Content-Type", mediaTypeDockerManifest)
+			fmt.Fprint(w, `{"config": {"digest": "sha256:config"}}`)
+		case "/v2/keystone/blobs/sha256:config":
+			fmt.Fprint(w, `{"architecture": "amd64"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "http://")
+	resolver := RegistryArchResolver{Insecure: true}
+
+	archs, err := resolver.Architectures(context.TODO(), registry+"/keystone:multi")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(archs).To(Equal([]string{"amd64", "arm64"}))
+
+	archs, err = resolver.Architectures(context.TODO(), registry+"/keystone:single")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(archs).To(Equal([]string{"amd64"}))
+
+	_, err = resolver.Architectures(context.TODO(), registry+"/keystone:missing")
+	g.Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+
+	// a mirror which requires credentials, its token request gets them
+	var mirror *httptest.Server
+	mirror = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, password, _ := r.BasicAuth()
+			if user != "mirror" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"access_token": "mirror"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer mirror" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token"`, mirror.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/v2/podified/keystone/manifests/mirrored" {
+			w.Header().Set("Content-Type", mediaTypeOCIIndex)
+			fmt.Fprint(w, `{"manifests": [{"platform": {"architecture": "ppc64le", "os": "linux"}}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mirror.Close()
+	mirrorRegistry := strings.TrimPrefix(mirror.URL, "http://")
+	resolver.Mirrors = map[string][]string{
+		registry:                      {mirrorRegistry + "/unused"},
+		registry + "/keystone":        {mirrorRegistry + "/podified/keystone"},
+		registry + "/keystone-plugin": {mirrorRegistry + "/unused"},
+	}
+
+	_, err = resolver.Architectures(context.TODO(), registry+"/keystone:mirrored")
+	g.Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+
+	resolver.Credentials = map[string]RegistryCredentials{
+		mirrorRegistry: {Username: "mirror", Password: "secret"},
+	}
+	archs, err = resolver.Architectures(context.TODO(), registry+"/keystone:mirrored")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(archs).To(Equal([]string{"ppc64le"}))
+
+	// not in the mirror, falls back to the source
+	archs, err = resolver.Architectures(context.TODO(), registry+"/keystone:multi")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(archs).To(Equal([]string{"amd64", "arm64"}))
+}
+
+func TestRegistryCredentialsFromSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: "openstack"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths": {
+				"quay.io": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("robot:token")) + `"},
+				"https://index.docker.io/v1/": {"username": "user", "password": "pass"}}}`),
+		},
+	}
+	creds, err := RegistryCredentialsFromSecret(secret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(creds).To(Equal(map[string]RegistryCredentials{
+		"quay.io":         {Username: "robot", Password: "token"},
+		"index.docker.io": {Username: "user", Password: "pass"},
+	}))
+
+	secret.Data = map[string][]byte{}
+	_, err = RegistryCredentialsFromSecret(secret)
+	g.Expect(err).To(MatchError(ErrInvalidPullSecret))
+	g.Expect(err).To(MatchError(ErrFieldNotFound))
+
+	secret.Data[corev1.DockerConfigJsonKey] = []byte("{")
+	_, err = RegistryCredentialsFromSecret(secret)
+	g.Expect(err).To(MatchError(ErrInvalidPullSecret))
+}
+
+func TestCheckImageArchitectures(t *testing.T) {
+	g := NewWithT(t)
+
+	node := func(name string, arch string, role string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{ArchLabel: arch, "role": role},
+		}}
+	}
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "keystone", Namespace: "openstack"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		node("a", "amd64", "control"), node("b", "amd64", "control"), node("c", "arm64", "compute"),
+	).Build()
+	h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	resolver := StaticArchResolver{
+		"quay.io/podified-antelope-centos9/openstack-keystone": {"amd64"},
+	}
+	image := "quay.io/podified-antelope-centos9/openstack-keystone:current-podified"
+
+	ok, _, err := CheckImageArchitectures(context.TODO(), h, resolver, image, map[string]string{"role": "control"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	ok, message, err := CheckImageArchitectures(context.TODO(), h, resolver, image, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ok).To(BeFalse())
+	g.Expect(message).To(Equal("image " + image + " is not available for node architectures arm64 (available: amd64)"))
+
+	_, _, err = CheckImageArchitectures(context.TODO(), h, resolver, "quay.io/other:latest", nil)
+	g.Expect(err).To(MatchError(ErrUnknownImage))
+}
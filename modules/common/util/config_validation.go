@@ -0,0 +1,193 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ErrInvalidConfig - the rendered config got rejected by a ConfigValidator
+var ErrInvalidConfig = errors.New("invalid config")
+
+// ConfigValidator - validates the rendered data of a key of a cm/secret.
+// Returns the violations found, none if the data is valid or the validator
+// does not apply to the key.
+type ConfigValidator interface {
+	ValidateConfig(key string, data string) []string
+}
+
+// ConfigValidatorFunc - adapter to use a function as ConfigValidator
+type ConfigValidatorFunc func(key string, data string) []string
+
+// ValidateConfig - calls f
+func (f ConfigValidatorFunc) ValidateConfig(key string, data string) []string {
+	return f(key, data)
+}
+
+// ConfigValidationError - the violations found in the data of a cm/secret
+type ConfigValidationError struct {
+	Name       string
+	Violations []string
+}
+
+// Error -
+func (e *ConfigValidationError) Error() string {
+	return fmt.Sprintf("%s in %s: %s", ErrInvalidConfig, e.Name, strings.Join(e.Violations, "; "))
+}
+
+// Unwrap - returns ErrInvalidConfig to be checked with errors.Is
+func (e *ConfigValidationError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// ValidateConfigData - runs the validators against all keys of the data of
+// the cm/secret name. Returns a ConfigValidationError with the violations of
+// all keys, or nil.
+//
+// It is run by the configmap and secret modules for the Validators of a
+// Template, the error is returned before the cm/secret gets created or
+// updated. Operators can mark the ServiceConfigReadyCondition with it:
+//
+//	err = secret.EnsureSecrets(ctx, h, instance, cms, envVars)
+//	if errors.Is(err, util.ErrInvalidConfig) {
+//		instance.Status.Conditions.Set(condition.FalseCondition(
+//			condition.ServiceConfigReadyCondition,
+//			condition.ErrorReason,
+//			condition.SeverityError,
+//			condition.ServiceConfigReadyInvalidMessage,
+//			err.Error()))
+//		return ctrl.Result{}, nil
+//	}
+func ValidateConfigData(name string, data map[string]string, validators []ConfigValidator) error {
+	if len(validators) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	violations := []string{}
+	for _, k := range keys {
+		for _, v := range validators {
+			for _, violation := range v.ValidateConfig(k, data[k]) {
+				violations = append(violations, fmt.Sprintf("%s: %s", k, violation))
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &ConfigValidationError{Name: name, Violations: violations}
+	}
+	return nil
+}
+
+// ConfigFieldErrors - returns the violations of err as field errors on path,
+// e.g. to reject a customServiceConfig in a webhook
+func ConfigFieldErrors(path *field.Path, value string, err error) field.ErrorList {
+	errorList := field.ErrorList{}
+	var validationErr *ConfigValidationError
+	if errors.As(err, &validationErr) {
+		for _, v := range validationErr.Violations {
+			errorList = append(errorList, field.Invalid(path, value, v))
+		}
+	} else if err != nil {
+		errorList = append(errorList, field.Invalid(path, value, err.Error()))
+	}
+	return errorList
+}
+
+// matchesKey - returns true if key is one of keys, or has the .conf suffix
+// if keys is empty
+func matchesKey(keys []string, key string) bool {
+	if len(keys) == 0 {
+		return strings.HasSuffix(key, ".conf")
+	}
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// IniSyntaxValidator - checks the data of Keys, all *.conf keys if empty,
+// parses as INI as oslo.config expects it
+type IniSyntaxValidator struct {
+	Keys []string
+}
+
+// ValidateConfig -
+func (v IniSyntaxValidator) ValidateConfig(key string, data string) []string {
+	if !matchesKey(v.Keys, key) {
+		return nil
+	}
+	if _, err := ParseIni(data); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+// ForbiddenOptionsValidator - rejects options in the data of Keys, e.g.
+// options the operator manages and users must not set. Keys must list the
+// keys of the user provided config only, i.e. the customServiceConfig and
+// defaultConfigOverwrite added as CustomData, as the configs the operator
+// renders itself set those options. It does not apply to any key if Keys is
+// empty. Only Section and Key of the IniOptions are used.
+//
+// Example:
+//
+//	keys := []string{"custom.conf"}
+//	for k := range instance.Spec.DefaultConfigOverwrite {
+//		keys = append(keys, k)
+//	}
+//	validators := []util.ConfigValidator{
+//		util.IniSyntaxValidator{},
+//		util.ForbiddenOptionsValidator{Keys: keys, Options: []util.IniOption{
+//			{Section: "database", Key: "connection"},
+//		}},
+//	}
+type ForbiddenOptionsValidator struct {
+	Keys    []string
+	Options []IniOption
+}
+
+// ValidateConfig -
+func (v ForbiddenOptionsValidator) ValidateConfig(key string, data string) []string {
+	if !slices.Contains(v.Keys, key) {
+		return nil
+	}
+	config, err := ParseIni(data)
+	if err != nil {
+		// reported by the IniSyntaxValidator
+		return nil
+	}
+	violations := []string{}
+	for _, o := range v.Options {
+		if _, ok := config.Get(o.Section, o.Key); ok {
+			violations = append(violations, fmt.Sprintf("option [%s] %s must not be set", o.Section, o.Key))
+		}
+	}
+	return violations
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateConfigData(t *testing.T) {
+	g := NewWithT(t)
+
+	validators := []ConfigValidator{
+		IniSyntaxValidator{},
+		ForbiddenOptionsValidator{Keys: []string{"02-custom.conf"}, Options: []IniOption{
+			{Section: "database", Key: "connection"},
+			{Section: "DEFAULT", Key: "transport_url"},
+		}},
+	}
+	data := map[string]string{
+		// rendered by the operator, which sets the forbidden options itself
+		"01-keystone.conf": "[DEFAULT]\ndebug = true\n[database]\nconnection = mysql+pymysql://\n",
+		"my.cnf":           "not ini at all",
+	}
+	g.Expect(ValidateConfigData("keystone-config-data", data, validators)).To(Succeed())
+
+	data["02-custom.conf"] = "[database]\nconnection = sqlite://\n"
+	data["03-broken.conf"] = "debug = true\n"
+	err := ValidateConfigData("keystone-config-data", data, validators)
+	g.Expect(errors.Is(err, ErrInvalidConfig)).To(BeTrue())
+	g.Expect(err.Error()).To(Equal("invalid config in keystone-config-data: " +
+		"02-custom.conf: option [database] connection must not be set; " +
+		"03-broken.conf: key outside of a section 1: debug = true"))
+
+	errs := ConfigFieldErrors(field.NewPath("spec", "customServiceConfig"), data["02-custom.conf"], err)
+	g.Expect(errs).To(HaveLen(2))
+	g.Expect(errs[0].Field).To(Equal("spec.customServiceConfig"))
+
+	// a function as validator, limited to the my.cnf key
+	maxSize := ConfigValidatorFunc(func(key string, data string) []string {
+		if key == "my.cnf" && len(data) > 5 {
+			return []string{"too long"}
+		}
+		return nil
+	})
+	err = ValidateConfigData("keystone-config-data", map[string]string{"my.cnf": "not ini at all"}, []ConfigValidator{maxSize})
+	g.Expect(err).To(MatchError("invalid config in keystone-config-data: my.cnf: too long"))
+}
@@ -25,4 +25,6 @@ var (
 	ErrNoPodSubdomain = errors.New("no subdomain or hostname")
 	// ErrPodsInterfaces indicates that pod interfaces aren't configured
 	ErrPodsInterfaces = errors.New("not all pods have interfaces")
+	// ErrWaitTimeout indicates that waiting for a resource took too long
+	ErrWaitTimeout = errors.New("timed out waiting")
 )
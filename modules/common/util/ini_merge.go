@@ -0,0 +1,226 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Define static errors
+var (
+	ErrIniKeyOutsideSection = errors.New("key outside of a section")
+	ErrIniInvalidLine       = errors.New("invalid line")
+)
+
+// IniConfig - a parsed INI config, e.g. a customServiceConfig, keeping the
+// order of the sections and keys. Keys set multiple times in a section,
+// like oslo.config multi string options, keep all values.
+type IniConfig struct {
+	sections []*iniSection
+}
+
+type iniSection struct {
+	name string
+	keys []*iniKey
+}
+
+type iniKey struct {
+	name   string
+	values []string
+}
+
+// IniConflict - a key set by the defaults and the custom config to
+// different values. The values can hold credentials, e.g. the database
+// connection or the transport_url, and must not be logged.
+type IniConflict struct {
+	Section string
+	Key     string
+	Default []string
+	Custom  []string
+}
+
+// String - returns the conflict in a form suitable for a log or condition
+// message, with the section and key only
+func (c IniConflict) String() string {
+	return fmt.Sprintf("[%s] %s overridden", c.Section, c.Key)
+}
+
+// ParseIni - parses the INI config in. Comments and blank lines are dropped,
+// indented lines continue the value of the previous key.
+func ParseIni(in string) (*IniConfig, error) {
+	c := &IniConfig{}
+	var section *iniSection
+	var last *iniKey
+
+	for n, rawLine := range strings.Split(in, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = c.section(strings.TrimSpace(strings.Trim(line, "[]")), true)
+			last = nil
+			continue
+		}
+		if last != nil && rawLine != line && strings.TrimLeft(rawLine, " \t") == line {
+			// continuation of a multiline value
+			last.values[len(last.values)-1] += "\n" + line
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w %d: %s", ErrIniInvalidLine, n+1, line)
+		}
+		if section == nil {
+			return nil, fmt.Errorf("%w %d: %s", ErrIniKeyOutsideSection, n+1, line)
+		}
+		name := strings.TrimSpace(kv[0])
+		value := strings.TrimSpace(kv[1])
+		last = section.key(name)
+		if last == nil {
+			last = &iniKey{name: name}
+			section.keys = append(section.keys, last)
+		}
+		last.values = append(last.values, value)
+	}
+
+	return c, nil
+}
+
+func (c *IniConfig) section(name string, create bool) *iniSection {
+	for _, s := range c.sections {
+		if s.name == name {
+			return s
+		}
+	}
+	if !create {
+		return nil
+	}
+	s := &iniSection{name: name}
+	c.sections = append(c.sections, s)
+	return s
+}
+
+func (s *iniSection) key(name string) *iniKey {
+	for _, k := range s.keys {
+		if k.name == name {
+			return k
+		}
+	}
+	return nil
+}
+
+// Get - returns the values of key in section
+func (c *IniConfig) Get(section string, key string) ([]string, bool) {
+	s := c.section(section, false)
+	if s == nil {
+		return nil, false
+	}
+	k := s.key(key)
+	if k == nil {
+		return nil, false
+	}
+	return append([]string{}, k.values...), true
+}
+
+// Set - sets the values of key in section, adding both if missing
+func (c *IniConfig) Set(section string, key string, values ...string) {
+	s := c.section(section, true)
+	k := s.key(key)
+	if k == nil {
+		k = &iniKey{name: key}
+		s.keys = append(s.keys, k)
+	}
+	k.values = append([]string{}, values...)
+}
+
+// String - renders the config, one key = value line per value
+func (c *IniConfig) String() string {
+	b := strings.Builder{}
+	for i, s := range c.sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[%s]\n", s.name)
+		for _, k := range s.keys {
+			for _, v := range k.values {
+				line := fmt.Sprintf("%s = %s", k.name, strings.ReplaceAll(v, "\n", "\n  "))
+				// no trailing space for empty values and values starting on the next line
+				line = strings.Replace(strings.TrimRight(line, " "), " = \n", " =\n", 1)
+				b.WriteString(line + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// MergeIni - merges the custom config into the defaults per section and key.
+// A key of custom replaces all values of the key in defaults, sections and
+// keys only in custom get appended. Returns the effective config and the
+// keys custom set to a value different from the default.
+//
+// Example:
+//
+//	merged, conflicts, err := util.MergeIni(defaultConfig, instance.Spec.CustomServiceConfig)
+//	for _, c := range conflicts {
+//		// c.String() does not include the values, which can hold credentials
+//		h.GetLogger().Info("Default config overridden", "override", c.String())
+//	}
+func MergeIni(defaults string, custom string) (*IniConfig, []IniConflict, error) {
+	merged, err := ParseIni(defaults)
+	if err != nil {
+		return nil, nil, fmt.Errorf("defaults: %w", err)
+	}
+	overrides, err := ParseIni(custom)
+	if err != nil {
+		return nil, nil, fmt.Errorf("custom config: %w", err)
+	}
+
+	conflicts := []IniConflict{}
+	for _, s := range overrides.sections {
+		for _, k := range s.keys {
+			if current, ok := merged.Get(s.name, k.name); ok && !equalValues(current, k.values) {
+				conflicts = append(conflicts, IniConflict{
+					Section: s.name,
+					Key:     k.name,
+					Default: current,
+					Custom:  append([]string{}, k.values...),
+				})
+			}
+			merged.Set(s.name, k.name, k.values...)
+		}
+		// keep sections without keys, e.g. a backend enabled by its name
+		merged.section(s.name, true)
+	}
+
+	return merged, conflicts, nil
+}
+
+func equalValues(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+)
+
+func TestMergeIni(t *testing.T) {
+	g := NewWithT(t)
+
+	defaults := `[DEFAULT]
+debug = false
+# a comment
+log_dir = /var/log/keystone
+
+[database]
+connection = mysql+pymysql://keystone@db/keystone
+max_retries = -1
+`
+	custom := `
+[DEFAULT]
+debug=true
+log_dir = /var/log/keystone
+[cache]
+backend = dogpile.cache.memcached
+memcache_servers = a:11211
+memcache_servers = b:11211
+[database]
+connection_recycle_time =
+  600
+`
+
+	merged, conflicts, err := MergeIni(defaults, custom)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(conflicts).To(Equal([]IniConflict{
+		{Section: "DEFAULT", Key: "debug", Default: []string{"false"}, Custom: []string{"true"}},
+	}))
+	// the values are not included, they can hold credentials
+	g.Expect(conflicts[0].String()).To(Equal("[DEFAULT] debug overridden"))
+
+	values, ok := merged.Get("cache", "memcache_servers")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(values).To(Equal([]string{"a:11211", "b:11211"}))
+
+	g.Expect(merged.String()).To(Equal(`[DEFAULT]
+debug = true
+log_dir = /var/log/keystone
+
+[database]
+connection = mysql+pymysql://keystone@db/keystone
+max_retries = -1
+connection_recycle_time =
+  600
+
+[cache]
+backend = dogpile.cache.memcached
+memcache_servers = a:11211
+memcache_servers = b:11211
+`))
+
+	// the rendered config parses to the same config
+	reparsed, err := ParseIni(merged.String())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reparsed.String()).To(Equal(merged.String()))
+}
+
+func TestParseIniErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := ParseIni("debug = true\n[DEFAULT]")
+	g.Expect(err).To(MatchError(ErrIniKeyOutsideSection))
+
+	_, _, err = MergeIni("[DEFAULT]\n", "[DEFAULT]\nnot a key value\n")
+	g.Expect(err).To(MatchError(ErrIniInvalidLine))
+	g.Expect(err.Error()).To(ContainSubstring("custom config: invalid line 2"))
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// MergeCtrlResults - merges the results of sub-reconciles into the result
+// to return from the reconcile. A requeue without interval takes precedence
+// over the smallest RequeueAfter of the results, so no requeue requested by
+// any of them gets lost.
+func MergeCtrlResults(results ...ctrl.Result) ctrl.Result {
+	merged := ctrl.Result{}
+	for _, r := range results {
+		if r.Requeue && r.RequeueAfter == 0 {
+			// requeue now
+			return ctrl.Result{Requeue: true}
+		}
+		if r.RequeueAfter > 0 && (merged.RequeueAfter == 0 || r.RequeueAfter < merged.RequeueAfter) {
+			merged.RequeueAfter = r.RequeueAfter
+		}
+	}
+	return merged
+}
+
+// ResultBuilder - accumulates the results and errors of sub-reconciles, see
+// MergeCtrlResults
+//
+// Example:
+//
+//	results := util.ResultBuilder{}
+//	results.Add(r.reconcileDeployment(ctx, instance, h))
+//	results.Add(r.reconcileCronJob(ctx, instance, h))
+//	return results.Result()
+type ResultBuilder struct {
+	results []ctrl.Result
+	errs    []error
+}
+
+// Add - adds the result and the error of a sub-reconcile
+func (b *ResultBuilder) Add(result ctrl.Result, err error) {
+	b.results = append(b.results, result)
+	if err != nil {
+		b.errs = append(b.errs, err)
+	}
+}
+
+// HasError - returns true if a sub-reconcile returned an error, e.g. to
+// skip sub-reconciles depending on the previous ones
+func (b *ResultBuilder) HasError() bool {
+	return len(b.errs) > 0
+}
+
+// Result - returns the merged result, or an empty result and the joined
+// errors if a sub-reconcile returned an error, as controller-runtime
+// ignores the result on errors and requeues with backoff
+func (b *ResultBuilder) Result() (ctrl.Result, error) {
+	if len(b.errs) > 0 {
+		return ctrl.Result{}, errors.Join(b.errs...)
+	}
+	return MergeCtrlResults(b.results...), nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util // nolint:revive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var errSubReconcile = errors.New("sub-reconcile failed")
+
+func TestMergeCtrlResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []ctrl.Result
+		want    ctrl.Result
+	}{
+		{
+			name: "None",
+			want: ctrl.Result{},
+		},
+		{
+			name:    "Smallest RequeueAfter",
+			results: []ctrl.Result{{}, {RequeueAfter: time.Minute}, {RequeueAfter: 5 * time.Second}, {}},
+			want:    ctrl.Result{RequeueAfter: 5 * time.Second},
+		},
+		{
+			name:    "Requeue now",
+			results: []ctrl.Result{{RequeueAfter: 5 * time.Second}, {Requeue: true}},
+			want:    ctrl.Result{Requeue: true},
+		},
+		{
+			name:    "Requeue with interval",
+			results: []ctrl.Result{{Requeue: true, RequeueAfter: time.Minute}, {RequeueAfter: 2 * time.Minute}},
+			want:    ctrl.Result{RequeueAfter: time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(MergeCtrlResults(tt.results...)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestResultBuilder(t *testing.T) {
+	g := NewWithT(t)
+
+	b := ResultBuilder{}
+	b.Add(ctrl.Result{RequeueAfter: time.Minute}, nil)
+	b.Add(ctrl.Result{RequeueAfter: 10 * time.Second}, nil)
+	g.Expect(b.HasError()).To(BeFalse())
+	result, err := b.Result()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: 10 * time.Second}))
+
+	b.Add(ctrl.Result{}, errSubReconcile)
+	g.Expect(b.HasError()).To(BeTrue())
+	result, err = b.Result()
+	g.Expect(err).To(MatchError(errSubReconcile))
+	g.Expect(result).To(Equal(ctrl.Result{}))
+}
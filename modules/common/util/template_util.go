@@ -59,6 +59,7 @@ type Template struct {
 	ConfigOptions      map[string]interface{} // map of parameters as input data to render the templates
 	SkipSetOwner       bool                   // skip setting ownership on the associated configmap
 	Version            string                 // optional version string to separate templates inside the InstanceType/Type directory. E.g. placementapi/config/18.0
+	Validators         []ConfigValidator      // validators run against the rendered data before the cm/secret gets created or updated, see ValidateConfigData
 }
 
 // GetTemplatesPath get path to templates, either running local or deployed as container
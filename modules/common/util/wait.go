@@ -0,0 +1,49 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// RequeueWithDeadline - returns the interval to requeue after while
+// waiting for something which started at since and must finish within
+// waitTimeout. The interval is capped to the time left so the expiry is
+// noticed in time. Returns true if the deadline already passed. A zero
+// waitTimeout means to wait forever.
+func RequeueWithDeadline(
+	clk clock.PassiveClock,
+	since time.Time,
+	waitTimeout time.Duration,
+	interval time.Duration,
+) (time.Duration, bool) {
+	if waitTimeout <= 0 || since.IsZero() {
+		return interval, false
+	}
+
+	left := since.Add(waitTimeout).Sub(clk.Now())
+	if left <= 0 {
+		return 0, true
+	}
+	if left < interval {
+		return left, false
+	}
+
+	return interval, false
+}
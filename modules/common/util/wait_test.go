@@ -0,0 +1,84 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util // nolint:revive
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestRequeueWithDeadline(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		elapsed     time.Duration
+		waitTimeout time.Duration
+		since       time.Time
+		want        time.Duration
+		wantExpired bool
+	}{
+		{
+			name:        "No wait timeout waits forever",
+			elapsed:     time.Hour,
+			waitTimeout: 0,
+			since:       start,
+			want:        10 * time.Second,
+		},
+		{
+			name:        "Unknown start waits forever",
+			elapsed:     time.Hour,
+			waitTimeout: time.Minute,
+			want:        10 * time.Second,
+		},
+		{
+			name:        "Interval before the deadline",
+			elapsed:     10 * time.Second,
+			waitTimeout: time.Minute,
+			since:       start,
+			want:        10 * time.Second,
+		},
+		{
+			name:        "Interval capped to the time left",
+			elapsed:     55 * time.Second,
+			waitTimeout: time.Minute,
+			since:       start,
+			want:        5 * time.Second,
+		},
+		{
+			name:        "Deadline reached",
+			elapsed:     time.Minute,
+			waitTimeout: time.Minute,
+			since:       start,
+			wantExpired: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clk := clocktesting.NewFakePassiveClock(start.Add(tt.elapsed))
+			requeueAfter, expired := RequeueWithDeadline(clk, tt.since, tt.waitTimeout, 10*time.Second)
+			g.Expect(expired).To(Equal(tt.wantExpired))
+			g.Expect(requeueAfter).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumesnapshot
+
+import (
+	"errors"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Group - API group of the CSI VolumeSnapshot resources
+	Group = "snapshot.storage.k8s.io"
+	// Version - API version of the CSI VolumeSnapshot resources
+	Version = "v1"
+	// Kind - kind of the VolumeSnapshot resource
+	Kind = "VolumeSnapshot"
+	// ListKind - kind of the VolumeSnapshotList resource
+	ListKind = "VolumeSnapshotList"
+)
+
+// GroupVersionKind - GVK of the VolumeSnapshot resource
+var GroupVersionKind = schema.GroupVersionKind{Group: Group, Version: Version, Kind: Kind}
+
+// Define static errors
+var (
+	// ErrSnapshotFailed indicates that the snapshot controller reported an error for the snapshot
+	ErrSnapshotFailed = errors.New("volume snapshot failed")
+	// ErrSnapshotNotReady indicates that the snapshot is not ready to use
+	ErrSnapshotNotReady = errors.New("volume snapshot not ready to use")
+	// ErrInvalidKeep indicates a negative number of snapshots to keep
+	ErrInvalidKeep = errors.New("number of volume snapshots to keep must not be negative")
+)
+
+// VolumeSnapshot -
+type VolumeSnapshot struct {
+	name                    string
+	namespace               string
+	pvcName                 string
+	volumeSnapshotClassName string
+	labels                  map[string]string
+	annotations             map[string]string
+	timeout                 time.Duration
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package volumesnapshot provides utilities for managing CSI VolumeSnapshots of
+// operator managed PersistentVolumeClaims, e.g. pre-upgrade safety snapshots.
+//
+// The VolumeSnapshot resources are handled as unstructured objects so that
+// consumers do not need to depend on the external-snapshotter client.
+package volumesnapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewVolumeSnapshot returns an initialized VolumeSnapshot of the pvc. If
+// volumeSnapshotClassName is empty the default VolumeSnapshotClass is used.
+//
+// NOTE: no owner reference gets set on the snapshot, so that a safety
+// snapshot survives the deletion of the CR. Use labels to track and prune
+// the snapshots of a CR.
+func NewVolumeSnapshot(
+	name string,
+	namespace string,
+	pvcName string,
+	volumeSnapshotClassName string,
+	labels map[string]string,
+	annotations map[string]string,
+	timeout time.Duration,
+) *VolumeSnapshot {
+	return &VolumeSnapshot{
+		name:                    name,
+		namespace:               namespace,
+		pvcName:                 pvcName,
+		volumeSnapshotClassName: volumeSnapshotClassName,
+		labels:                  labels,
+		annotations:             annotations,
+		timeout:                 timeout,
+	}
+}
+
+// Create - creates the VolumeSnapshot if it does not exist yet and requeues
+// after the timeout until the snapshot is readyToUse. VolumeSnapshots are
+// immutable, an existing snapshot is not updated.
+func (s *VolumeSnapshot) Create(
+	ctx context.Context,
+	h *helper.Helper,
+) (ctrl.Result, error) {
+	snapshot, err := GetVolumeSnapshotWithName(ctx, h, s.name, s.namespace)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+
+	if k8s_errors.IsNotFound(err) {
+		snapshot = s.render()
+		err = h.GetClient().Create(ctx, snapshot)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error creating volume snapshot %s: %w", s.name, err)
+		}
+		h.GetLogger().Info("VolumeSnapshot created", "object", s.name, "pvc", s.pvcName)
+	}
+
+	if msg := GetError(snapshot); msg != "" {
+		return ctrl.Result{}, fmt.Errorf("%w: %s: %s", ErrSnapshotFailed, s.name, msg)
+	}
+
+	if !IsReadyToUse(snapshot) {
+		h.GetLogger().Info("VolumeSnapshot not ready to use, reconcile later", "object", s.name, "requeueAfter", s.timeout)
+		return ctrl.Result{RequeueAfter: s.timeout}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// Delete - deletes the VolumeSnapshot
+func (s *VolumeSnapshot) Delete(
+	ctx context.Context,
+	h *helper.Helper,
+) error {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+	snapshot.SetName(s.name)
+	snapshot.SetNamespace(s.namespace)
+
+	err := h.GetClient().Delete(ctx, snapshot)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting volume snapshot %s: %w", s.name, err)
+	}
+
+	return nil
+}
+
+// render - returns the unstructured VolumeSnapshot object
+func (s *VolumeSnapshot) render() *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": s.pvcName,
+		},
+	}
+	if s.volumeSnapshotClassName != "" {
+		spec["volumeSnapshotClassName"] = s.volumeSnapshotClassName
+	}
+
+	snapshot := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+	snapshot.SetName(s.name)
+	snapshot.SetNamespace(s.namespace)
+	snapshot.SetLabels(util.MergeStringMaps(s.labels))
+	snapshot.SetAnnotations(util.MergeStringMaps(s.annotations))
+
+	return snapshot
+}
+
+// GetVolumeSnapshotWithName - get the VolumeSnapshot object
+func GetVolumeSnapshotWithName(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	namespace string,
+) (*unstructured.Unstructured, error) {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, snapshot)
+	if err != nil {
+		return snapshot, err
+	}
+
+	return snapshot, nil
+}
+
+// IsReadyToUse - returns true if status.readyToUse of the snapshot is true
+func IsReadyToUse(snapshot *unstructured.Unstructured) bool {
+	ready, found, err := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	return err == nil && found && ready
+}
+
+// GetError - returns the status.error.message of the snapshot, if any
+func GetError(snapshot *unstructured.Unstructured) string {
+	msg, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message")
+	return msg
+}
+
+// GetRestoreSize - returns the status.restoreSize of the snapshot, which is
+// the minimum size of a pvc restored from the snapshot
+func GetRestoreSize(snapshot *unstructured.Unstructured) (resource.Quantity, bool) {
+	size, found, err := unstructured.NestedString(snapshot.Object, "status", "restoreSize")
+	if err != nil || !found {
+		return resource.Quantity{}, false
+	}
+	q, err := resource.ParseQuantity(size)
+	if err != nil {
+		return resource.Quantity{}, false
+	}
+	return q, true
+}
+
+// ListVolumeSnapshots - returns the VolumeSnapshots in the namespace matching
+// the labels, sorted by creation time, oldest first
+func ListVolumeSnapshots(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	labelSelectorMap map[string]string,
+) ([]unstructured.Unstructured, error) {
+	snapshots := &unstructured.UnstructuredList{}
+	snapshots.SetGroupVersionKind(GroupVersionKind.GroupVersion().WithKind(ListKind))
+
+	listOpts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels(labelSelectorMap),
+	}
+	err := h.GetClient().List(ctx, snapshots, listOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	items := snapshots.Items
+	SortByCreationTimestamp(items)
+
+	return items, nil
+}
+
+// SortByCreationTimestamp - sorts the snapshots by creation time, oldest first
+func SortByCreationTimestamp(snapshots []unstructured.Unstructured) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		ti := snapshots[i].GetCreationTimestamp()
+		tj := snapshots[j].GetCreationTimestamp()
+		if ti.Equal(&tj) {
+			return snapshots[i].GetName() < snapshots[j].GetName()
+		}
+		return ti.Before(&tj)
+	})
+}
+
+// PruneVolumeSnapshots - deletes the oldest VolumeSnapshots in the namespace
+// matching the labels, keeping the newest keep snapshots. Returns the names
+// of the deleted snapshots. A negative keep is rejected with ErrInvalidKeep,
+// keep 0 deletes all matching snapshots.
+func PruneVolumeSnapshots(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	labelSelectorMap map[string]string,
+	keep int,
+) ([]string, error) {
+	if keep < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidKeep, keep)
+	}
+
+	snapshots, err := ListVolumeSnapshots(ctx, h, namespace, labelSelectorMap)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []string{}
+	for i := 0; i < len(snapshots)-keep; i++ {
+		err := h.GetClient().Delete(ctx, &snapshots[i])
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return deleted, fmt.Errorf("error deleting volume snapshot %s: %w", snapshots[i].GetName(), err)
+		}
+		h.GetLogger().Info("VolumeSnapshot pruned", "object", snapshots[i].GetName())
+		deleted = append(deleted, snapshots[i].GetName())
+	}
+
+	return deleted, nil
+}
+
+// GetRestorePvc - returns a pvc restored from the snapshot, based on the pvc
+// template. The returned object can be passed to pvc.NewPvc() for creation,
+// which sets its data source on create. If the requested size is smaller
+// than the restoreSize of the snapshot, the restoreSize is requested.
+func GetRestorePvc(
+	snapshot *unstructured.Unstructured,
+	template *corev1.PersistentVolumeClaim,
+) (*corev1.PersistentVolumeClaim, error) {
+	if !IsReadyToUse(snapshot) {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotNotReady, snapshot.GetName())
+	}
+
+	pvc := template.DeepCopy()
+	pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To(Group),
+		Kind:     Kind,
+		Name:     snapshot.GetName(),
+	}
+	pvc.Spec.DataSourceRef = nil
+
+	if restoreSize, ok := GetRestoreSize(snapshot); ok {
+		if pvc.Spec.Resources.Requests == nil {
+			pvc.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if requested.Cmp(restoreSize) < 0 {
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = restoreSize
+		}
+	}
+
+	return pvc, nil
+}
+
+// GetSnapshotName - returns a snapshot name for the pvc with the time as
+// suffix, e.g. <pvc>-20250101120000
+func GetSnapshotName(pvcName string, t metav1.Time) string {
+	return fmt.Sprintf("%s-%s", pvcName, t.UTC().Format("20060102150405"))
+}
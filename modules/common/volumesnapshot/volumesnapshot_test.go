@@ -0,0 +1,200 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumesnapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/pvc"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getSnapshot(name string, created time.Time, status map[string]interface{}) unstructured.Unstructured {
+	s := unstructured.Unstructured{Object: map[string]interface{}{}}
+	s.SetGroupVersionKind(GroupVersionKind)
+	s.SetName(name)
+	s.SetCreationTimestamp(metav1.NewTime(created))
+	if status != nil {
+		s.Object["status"] = status
+	}
+	return s
+}
+
+func TestRender(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewVolumeSnapshot("db-snap", "openstack", "db", "csi-snapclass", map[string]string{"foo": "bar"}, nil, time.Second)
+	obj := s.render()
+
+	g.Expect(obj.GroupVersionKind()).To(Equal(GroupVersionKind))
+	g.Expect(obj.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+	pvcName, _, _ := unstructured.NestedString(obj.Object, "spec", "source", "persistentVolumeClaimName")
+	g.Expect(pvcName).To(Equal("db"))
+	className, _, _ := unstructured.NestedString(obj.Object, "spec", "volumeSnapshotClassName")
+	g.Expect(className).To(Equal("csi-snapclass"))
+
+	s = NewVolumeSnapshot("db-snap", "openstack", "db", "", nil, nil, time.Second)
+	_, found, _ := unstructured.NestedString(s.render().Object, "spec", "volumeSnapshotClassName")
+	g.Expect(found).To(BeFalse())
+}
+
+func TestStatus(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Now()
+
+	s := getSnapshot("pending", now, nil)
+	g.Expect(IsReadyToUse(&s)).To(BeFalse())
+	g.Expect(GetError(&s)).To(BeEmpty())
+
+	s = getSnapshot("failed", now, map[string]interface{}{
+		"readyToUse": false,
+		"error":      map[string]interface{}{"message": "backend error"},
+	})
+	g.Expect(IsReadyToUse(&s)).To(BeFalse())
+	g.Expect(GetError(&s)).To(Equal("backend error"))
+
+	s = getSnapshot("ready", now, map[string]interface{}{
+		"readyToUse":  true,
+		"restoreSize": "10Gi",
+	})
+	g.Expect(IsReadyToUse(&s)).To(BeTrue())
+	size, ok := GetRestoreSize(&s)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(size.String()).To(Equal("10Gi"))
+}
+
+func TestSortByCreationTimestamp(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Now()
+
+	snapshots := []unstructured.Unstructured{
+		getSnapshot("c", now, nil),
+		getSnapshot("a", now.Add(-time.Hour), nil),
+		getSnapshot("b", now, nil),
+	}
+	SortByCreationTimestamp(snapshots)
+
+	names := []string{}
+	for _, s := range snapshots {
+		names = append(names, s.GetName())
+	}
+	g.Expect(names).To(Equal([]string{"a", "b", "c"}))
+}
+
+func TestGetRestorePvc(t *testing.T) {
+	template := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db-restored",
+			Namespace: "openstack",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("5Gi"),
+				},
+			},
+		},
+	}
+
+	t.Run("Snapshot not ready", func(t *testing.T) {
+		g := NewWithT(t)
+		s := getSnapshot("snap", time.Now(), nil)
+		_, err := GetRestorePvc(&s, template)
+		g.Expect(err).To(MatchError(ErrSnapshotNotReady))
+	})
+
+	t.Run("Restore size bigger than the request", func(t *testing.T) {
+		g := NewWithT(t)
+		s := getSnapshot("snap", time.Now(), map[string]interface{}{
+			"readyToUse":  true,
+			"restoreSize": "10Gi",
+		})
+		pvc, err := GetRestorePvc(&s, template)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(pvc.Spec.DataSource.Kind).To(Equal(Kind))
+		g.Expect(pvc.Spec.DataSource.Name).To(Equal("snap"))
+		g.Expect(*pvc.Spec.DataSource.APIGroup).To(Equal(Group))
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		g.Expect(size.String()).To(Equal("10Gi"))
+		// the template is not modified
+		g.Expect(template.Spec.DataSource).To(BeNil())
+	})
+
+	t.Run("Restore via pvc.CreateOrPatch", func(t *testing.T) {
+		g := NewWithT(t)
+		ctx := context.TODO()
+		s := getSnapshot("snap", time.Now(), map[string]interface{}{"readyToUse": true})
+		restore, err := GetRestorePvc(&s, template)
+		g.Expect(err).ToNot(HaveOccurred())
+		restore.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+
+		owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "glance", Namespace: "openstack"}}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		h, err := helper.NewHelper(owner, fakeClient, nil, scheme.Scheme, ctrl.Log)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, err = pvc.NewPvc(restore, time.Second).CreateOrPatch(ctx, h)
+		g.Expect(err).ToNot(HaveOccurred())
+		created, err := pvc.GetPvcWithName(ctx, h, "db-restored", "openstack")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(created.Spec.DataSource).To(Equal(restore.Spec.DataSource))
+		g.Expect(created.Spec.VolumeMode).To(HaveValue(Equal(corev1.PersistentVolumeBlock)))
+	})
+}
+
+func TestPruneVolumeSnapshots(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	now := time.Now()
+
+	objs := []client.Object{}
+	for name, age := range map[string]time.Duration{"snap-new": 0, "snap-mid": time.Minute, "snap-old": time.Hour} {
+		s := getSnapshot(name, now.Add(-age), nil)
+		s.SetNamespace("openstack")
+		s.SetLabels(map[string]string{"service": "glance"})
+		objs = append(objs, &s)
+	}
+	cr := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "glance", Namespace: "openstack"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(append(objs, cr)...).Build()
+	h, err := helper.NewHelper(cr, fakeClient, nil, scheme.Scheme, ctrl.Log)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = PruneVolumeSnapshots(ctx, h, "openstack", map[string]string{"service": "glance"}, -1)
+	g.Expect(err).To(MatchError(ErrInvalidKeep))
+
+	deleted, err := PruneVolumeSnapshots(ctx, h, "openstack", map[string]string{"service": "glance"}, 1)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(Equal([]string{"snap-old", "snap-mid"}))
+
+	snapshots, err := ListVolumeSnapshots(ctx, h, "openstack", map[string]string{"service": "glance"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(HaveLen(1))
+	g.Expect(snapshots[0].GetName()).To(Equal("snap-new"))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watches provides the setup of the field indexes and watches for
+// the objects referenced by the CRs of a controller, e.g. the Topology, the
+// TLS secrets and the NetworkAttachmentDefinitions, from a declarative table
+package watches
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Reference - a kind of object referenced by name from the CRs of a
+// controller. References by several fields of the CR to the same kind, e.g.
+// the CA bundle and the cert secrets, are separate Reference entries with
+// their own Field.
+type Reference struct {
+	// Field - name of the field index, e.g. ".spec.topologyRef.name"
+	Field string
+	// Object - the referenced kind, e.g. &corev1.Secret{}
+	Object client.Object
+	// Names - returns the names of the objects the CR references, in the
+	// namespace of the CR
+	Names func(cr client.Object) []string
+	// Predicates - optional predicates of the watch of the kind
+	Predicates []predicate.Predicate
+}
+
+// IndexFunc - returns the function indexing a CR by the names of the
+// objects it references, empty names are skipped
+func (r Reference) IndexFunc() client.IndexerFunc {
+	return func(cr client.Object) []string {
+		names := []string{}
+		for _, name := range r.Names(cr) {
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+}
+
+// Table - the references of the CRs of a controller
+type Table []Reference
+
+// Index - registers a field index for each reference, which indexes the CRs
+// of the kind cr by the names of the objects they reference
+func (t Table) Index(ctx context.Context, indexer client.FieldIndexer, cr client.Object) error {
+	for _, r := range t {
+		err := indexer.IndexField(ctx, cr, r.Field, r.IndexFunc())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Watches - adds a watch for each reference to the builder, which enqueues
+// the CRs of list referencing the changed object. The field indexes must
+// have been registered with Index.
+func (t Table) Watches(b *builder.Builder, c client.Client, list client.ObjectList) *builder.Builder {
+	for _, r := range t {
+		b = b.Watches(
+			r.Object,
+			handler.EnqueueRequestsFromMapFunc(MapFunc(c, list, r.Field)),
+			builder.WithPredicates(r.Predicates...),
+		)
+	}
+	return b
+}
+
+// Setup - registers the field indexes with the manager and adds the watches
+// to the builder
+//
+// Example:
+//
+//	var keystoneWatches = watches.Table{
+//		{Field: ".spec.topologyRef.name", Object: &topologyv1.Topology{}, Names: func(cr client.Object) []string {
+//			return []string{cr.(*keystonev1.KeystoneAPI).Spec.TopologyRef.Name}
+//		}},
+//		{Field: ".spec.tls.caBundleSecretName", Object: &corev1.Secret{}, Names: ...},
+//	}
+//
+//	func (r *KeystoneAPIReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+//		b, err := keystoneWatches.Setup(ctx, mgr,
+//			ctrl.NewControllerManagedBy(mgr).For(&keystonev1.KeystoneAPI{}),
+//			&keystonev1.KeystoneAPI{}, &keystonev1.KeystoneAPIList{})
+//		if err != nil {
+//			return err
+//		}
+//		return b.Complete(r)
+//	}
+func (t Table) Setup(
+	ctx context.Context,
+	mgr ctrl.Manager,
+	b *builder.Builder,
+	cr client.Object,
+	list client.ObjectList,
+) (*builder.Builder, error) {
+	err := t.Index(ctx, mgr.GetFieldIndexer(), cr)
+	if err != nil {
+		return nil, err
+	}
+	return t.Watches(b, mgr.GetClient(), list), nil
+}
+
+// MapFunc - returns a handler.MapFunc which enqueues the CRs of list, in
+// the namespace of the changed object, whose field index has its name
+func MapFunc(c client.Client, list client.ObjectList, field string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		log := ctrl.LoggerFrom(ctx)
+
+		crList := list.DeepCopyObject().(client.ObjectList)
+		err := c.List(ctx, crList,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{field: obj.GetName()})
+		if err != nil {
+			log.Error(err, "Unable to list the CRs referencing the object", "object", obj.GetName(), "field", field)
+			return nil
+		}
+		items, err := meta.ExtractList(crList)
+		if err != nil {
+			log.Error(err, "Unable to extract the CRs referencing the object", "object", obj.GetName(), "field", field)
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, item := range items {
+			if cr, ok := item.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+			}
+		}
+		return requests
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ConfigMaps as stand-in for the CRs, referencing secrets by their data
+var table = Table{
+	{
+		Field:  ".data.secret",
+		Object: &corev1.Secret{},
+		Names: func(cr client.Object) []string {
+			return []string{cr.(*corev1.ConfigMap).Data["secret"]}
+		},
+	},
+}
+
+type recordingIndexer struct {
+	fields []string
+}
+
+func (i *recordingIndexer) IndexField(_ context.Context, _ client.Object, field string, _ client.IndexerFunc) error {
+	i.fields = append(i.fields, field)
+	return nil
+}
+
+func getCR(name string, namespace string, secret string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{"secret": secret},
+	}
+}
+
+func TestIndex(t *testing.T) {
+	g := NewWithT(t)
+
+	indexer := &recordingIndexer{}
+	g.Expect(table.Index(context.TODO(), indexer, &corev1.ConfigMap{})).To(Succeed())
+	g.Expect(indexer.fields).To(Equal([]string{".data.secret"}))
+
+	g.Expect(table[0].IndexFunc()(getCR("keystone", "openstack", "tls"))).To(Equal([]string{"tls"}))
+	g.Expect(table[0].IndexFunc()(getCR("keystone", "openstack", ""))).To(BeEmpty())
+}
+
+func TestMapFunc(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(
+			getCR("keystone", "openstack", "tls"),
+			getCR("glance", "openstack", "other"),
+			getCR("nova", "other", "tls"),
+		).
+		WithIndex(&corev1.ConfigMap{}, table[0].Field, table[0].IndexFunc()).
+		Build()
+
+	mapFunc := MapFunc(c, &corev1.ConfigMapList{}, table[0].Field)
+	requests := mapFunc(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "openstack"}})
+	g.Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: "keystone", Namespace: "openstack"}}))
+
+	requests = mapFunc(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: "openstack"}})
+	g.Expect(requests).To(BeEmpty())
+}
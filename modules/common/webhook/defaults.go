@@ -0,0 +1,173 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrNoSchema is returned when the CRD has no schema for the requested version
+var ErrNoSchema = errors.New("CRD has no schema for version")
+
+// crdGVK - the CustomResourceDefinition kind, read as unstructured to not
+// depend on the apiextensions API
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// Schema - the structural OpenAPI v3 schema of a version of a CRD
+type Schema map[string]interface{}
+
+// LoadSchema - returns the schema of version from the CRD manifest data, e.g.
+// of the CRD in config/crd/bases of the operator embedded via go:embed, so
+// that webhooks and tests default with the same values as the API server.
+//
+// Example:
+//
+//	//go:embed bases/keystone.openstack.org_keystoneapis.yaml
+//	var keystoneAPICRD []byte
+//	...
+//	s, err := webhook.LoadSchema(keystoneAPICRD, "v1beta1")
+//	...
+//	err = s.Default(keystoneAPI)
+func LoadSchema(data []byte, version string) (Schema, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CRD: %w", err)
+	}
+	// converts whole numbers to int64, like the unstructured objects of the
+	// client have them
+	crd := map[string]interface{}{}
+	err = json.Unmarshal(jsonData, &crd)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CRD: %w", err)
+	}
+
+	return schemaOfVersion(&unstructured.Unstructured{Object: crd}, version)
+}
+
+// GetSchema - returns the schema of the version of gvk from the CRD of the
+// kind installed in the cluster
+func GetSchema(ctx context.Context, c client.Client, gvk schema.GroupVersionKind) (Schema, error) {
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(crdGVK)
+	err = c.Get(ctx, types.NamespacedName{Name: mapping.Resource.Resource + "." + gvk.Group}, crd)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemaOfVersion(crd, gvk.Version)
+}
+
+// schemaOfVersion - returns the openAPIV3Schema of version of the crd
+func schemaOfVersion(crd *unstructured.Unstructured, version string) (Schema, error) {
+	versions, _, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		v, ok := v.(map[string]interface{})
+		if !ok || v["name"] != version {
+			continue
+		}
+		s, found, err := unstructured.NestedMap(v, "schema", "openAPIV3Schema")
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoSchema, crd.GetName(), version)
+}
+
+// Default - applies the defaults of the schema to obj, either an
+// *unstructured.Unstructured or a typed object, like the API server does on
+// create. Like for the API server, fields of a typed object without
+// omitempty are never absent, so they do not get defaulted.
+func (s Schema) Default(obj runtime.Object) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		applyDefaults(u.Object, s)
+		return nil
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	applyDefaults(u, s)
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u, obj)
+}
+
+// applyDefaults - sets the defaults of the properties of s absent in x and
+// recurses into the properties, the items of lists and the values of maps.
+// The defaults are applied before recursing, so defaults nested in a
+// defaulted value get applied as well.
+func applyDefaults(x interface{}, s map[string]interface{}) {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		properties, _ := s["properties"].(map[string]interface{})
+		for k, p := range properties {
+			prop, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if _, found := x[k]; !found {
+				if def, ok := prop["default"]; ok {
+					x[k] = runtime.DeepCopyJSONValue(def)
+				}
+			}
+			if v, found := x[k]; found {
+				applyDefaults(v, prop)
+			}
+		}
+		if values, ok := s["additionalProperties"].(map[string]interface{}); ok {
+			for k, v := range x {
+				if _, known := properties[k]; !known {
+					applyDefaults(v, values)
+				}
+			}
+		}
+	case []interface{}:
+		items, ok := s["items"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for _, v := range x {
+			applyDefaults(v, items)
+		}
+	}
+}
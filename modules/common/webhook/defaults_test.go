@@ -0,0 +1,131 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+// the defaults of a pod like CRD
+const podCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: pods.example.openstack.org
+spec:
+  versions:
+  - name: v1beta1
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            default: {}
+            properties:
+              restartPolicy:
+                type: string
+                default: Always
+              terminationGracePeriodSeconds:
+                type: integer
+                default: 30
+              containers:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    imagePullPolicy:
+                      type: string
+                      default: IfNotPresent
+              nodeSelector:
+                type: object
+                additionalProperties:
+                  type: string
+              overhead:
+                type: object
+                additionalProperties:
+                  type: object
+                  properties:
+                    unit:
+                      type: string
+                      default: Mi
+`
+
+func TestSchemaDefault(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := LoadSchema([]byte(podCRD), "v1")
+	g.Expect(err).To(MatchError(ErrNoSchema))
+
+	s, err := LoadSchema([]byte(podCRD), "v1beta1")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	t.Run("Unstructured", func(t *testing.T) {
+		g := NewWithT(t)
+
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"restartPolicy": "Never",
+				"containers": []interface{}{
+					map[string]interface{}{"name": "a"},
+					map[string]interface{}{"name": "b", "imagePullPolicy": "Always"},
+				},
+				"overhead": map[string]interface{}{
+					"memory": map[string]interface{}{},
+				},
+			},
+		}}
+		g.Expect(s.Default(u)).To(Succeed())
+		g.Expect(u.Object["spec"]).To(Equal(map[string]interface{}{
+			"restartPolicy":                 "Never",
+			"terminationGracePeriodSeconds": int64(30),
+			"containers": []interface{}{
+				map[string]interface{}{"name": "a", "imagePullPolicy": "IfNotPresent"},
+				map[string]interface{}{"name": "b", "imagePullPolicy": "Always"},
+			},
+			"overhead": map[string]interface{}{
+				"memory": map[string]interface{}{"unit": "Mi"},
+			},
+		}))
+
+		// the defaulted spec gets defaulted as well
+		u = &unstructured.Unstructured{Object: map[string]interface{}{}}
+		g.Expect(s.Default(u)).To(Succeed())
+		g.Expect(u.Object["spec"]).To(Equal(map[string]interface{}{
+			"restartPolicy":                 "Always",
+			"terminationGracePeriodSeconds": int64(30),
+		}))
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		pod := &corev1.Pod{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "a"}},
+		}}
+		g.Expect(s.Default(pod)).To(Succeed())
+		g.Expect(pod.Spec.TerminationGracePeriodSeconds).To(Equal(ptr.To[int64](30)))
+		g.Expect(pod.Spec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+		g.Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyAlways))
+	})
+}
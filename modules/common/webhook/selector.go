@@ -0,0 +1,86 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateLabelSelector - validates a label selector of a spec via
+// labels.ValidateLabelSelector and returns the result as field errors of
+// basePath.
+//
+// example usage:
+//
+//	ValidateLabelSelector(field.NewPath("spec").Child("selector"), spec.Selector)
+func ValidateLabelSelector(basePath *field.Path, selector *metav1.LabelSelector) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	err := labels.ValidateLabelSelector(selector)
+	if errors.Is(err, labels.ErrEmptyLabelSelector) {
+		allErrs = append(allErrs, field.Required(basePath, "label selector must not be empty"))
+	} else if err != nil {
+		allErrs = append(allErrs, field.Invalid(basePath, selector, err.Error()))
+	}
+
+	return allErrs
+}
+
+// ValidateSelectorLabelsUpdate - validates that none of the labels used in
+// the selector of Deployments/StatefulSets changes on update. The selector
+// of those is immutable, a change would either fail late in the reconcile
+// with a "field is immutable" error, or orphan the existing pods. If
+// selectorKeys is empty all old labels are treated as selector labels.
+//
+// example usage:
+//
+//	ValidateSelectorLabelsUpdate(field.NewPath("spec").Child("serviceLabels"), old.Spec.ServiceLabels, new.Spec.ServiceLabels, nil)
+func ValidateSelectorLabelsUpdate(
+	basePath *field.Path,
+	oldLabels map[string]string,
+	newLabels map[string]string,
+	selectorKeys []string,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	keys := selectorKeys
+	if len(keys) == 0 {
+		for k := range oldLabels {
+			keys = append(keys, k)
+		}
+	}
+	keys = append([]string{}, keys...)
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		oldValue, oldOk := oldLabels[k]
+		newValue, newOk := newLabels[k]
+		if oldOk == newOk && oldValue == newValue {
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(basePath.Key(k),
+			fmt.Sprintf("label %s is used in an immutable selector and can not be changed from %q to %q", k, oldValue, newValue)))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateLabelSelector(t *testing.T) {
+	path := field.NewPath("spec").Child("selector")
+
+	tests := []struct {
+		name     string
+		selector *metav1.LabelSelector
+		want     field.ErrorType
+	}{
+		{
+			name:     "valid",
+			selector: &metav1.LabelSelector{MatchLabels: map[string]string{"service": "nova"}},
+		},
+		{
+			name: "nil",
+			want: field.ErrorTypeRequired,
+		},
+		{
+			name:     "empty",
+			selector: &metav1.LabelSelector{},
+			want:     field.ErrorTypeRequired,
+		},
+		{
+			name: "invalid",
+			selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "service", Operator: "Foo"}},
+			},
+			want: field.ErrorTypeInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			errs := ValidateLabelSelector(path, tt.selector)
+			if tt.want == "" {
+				g.Expect(errs).To(BeEmpty())
+				return
+			}
+			g.Expect(errs).To(HaveLen(1))
+			g.Expect(errs[0].Type).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidateSelectorLabelsUpdate(t *testing.T) {
+	path := field.NewPath("spec").Child("serviceLabels")
+	old := map[string]string{"service": "nova", "component": "api"}
+
+	t.Run("Unchanged", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(ValidateSelectorLabelsUpdate(path, old, map[string]string{"service": "nova", "component": "api"}, nil)).To(BeEmpty())
+	})
+
+	t.Run("Changed and removed", func(t *testing.T) {
+		g := NewWithT(t)
+		errs := ValidateSelectorLabelsUpdate(path, old, map[string]string{"service": "nova2"}, nil)
+		g.Expect(errs).To(HaveLen(2))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+		g.Expect(errs[0].Field).To(Equal("spec.serviceLabels[component]"))
+		g.Expect(errs[1].Field).To(Equal("spec.serviceLabels[service]"))
+	})
+
+	t.Run("Only selector keys", func(t *testing.T) {
+		g := NewWithT(t)
+		errs := ValidateSelectorLabelsUpdate(path, old, map[string]string{"service": "nova", "component": "conductor", "foo": "bar"}, []string{"service"})
+		g.Expect(errs).To(BeEmpty())
+
+		errs = ValidateSelectorLabelsUpdate(path, old, map[string]string{"service": "nova", "new": "x"}, []string{"service", "new"})
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Field).To(Equal("spec.serviceLabels[new]"))
+	})
+}
@@ -80,3 +80,35 @@ func ValidateStorageRequest(basePath *field.Path, req string, minReq string, err
 	}
 	return allWarn, allErrs
 }
+
+// ValidateStorageRequestResize - validates that a storage request update does not
+// shrink the storage, as PVCs can only be expanded. The returned error uses the
+// Forbidden type so it can be returned from a ValidateUpdate webhook directly.
+//
+// example usage:
+//
+//	ValidateStorageRequestResize(<path>, oldSpec.StorageRequest, newSpec.StorageRequest)
+func ValidateStorageRequestResize(basePath *field.Path, oldReq string, newReq string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	oldRequest, parseError := resource.ParseQuantity(oldReq)
+	if parseError != nil {
+		// nothing to compare with, the old request was never valid
+		return allErrs
+	}
+
+	newRequest, parseError := resource.ParseQuantity(newReq)
+	if parseError != nil {
+		allErrs = append(allErrs, field.Invalid(basePath, newReq,
+			fmt.Sprintf("Field %s: %s is invalid", basePath.String(), newReq)))
+		return allErrs
+	}
+
+	if newRequest.Cmp(oldRequest) < 0 {
+		allErrs = append(allErrs, field.Forbidden(basePath,
+			fmt.Sprintf("%s can not be decreased from %s to %s, shrinking storage is not supported",
+				basePath.String(), oldReq, newReq)))
+	}
+
+	return allErrs
+}
@@ -111,3 +111,58 @@ func TestValidateStorageRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateStorageRequestResize(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldReq  string
+		newReq  string
+		wantErr bool
+	}{
+		{
+			name:    "size unchanged",
+			oldReq:  "10G",
+			newReq:  "10G",
+			wantErr: false,
+		},
+		{
+			name:    "size increased",
+			oldReq:  "10G",
+			newReq:  "20Gi",
+			wantErr: false,
+		},
+		{
+			name:    "size decreased, want error",
+			oldReq:  "10G",
+			newReq:  "5G",
+			wantErr: true,
+		},
+		{
+			name:    "new size is a wrong string, want error",
+			oldReq:  "10G",
+			newReq:  "foo",
+			wantErr: true,
+		},
+		{
+			name:    "old size is a wrong string",
+			oldReq:  "foo",
+			newReq:  "5G",
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := field.NewPath("spec").Child("storageRequest")
+
+			errs := ValidateStorageRequestResize(p, tt.oldReq, tt.newReq)
+			if tt.wantErr {
+				g.Expect(errs).To(HaveLen(1))
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -81,7 +81,7 @@ func EnsureWebhookTrigger(
 			}
 		}
 		// Annotation exists but not stale yet - webhook is still processing
-		log.Info(fmt.Sprintf("Waiting for webhook to process: %s", reason))
+		log.Info("Waiting for webhook to process", "reason", reason)
 		return ctrl.Result{Requeue: true}, nil
 	}
 
@@ -89,7 +89,7 @@ func EnsureWebhookTrigger(
 	// The defer block will save this change, which triggers the UPDATE webhook
 	annotations[annotationKey] = metav1.Now().Format(time.RFC3339)
 	obj.SetAnnotations(annotations)
-	log.Info(fmt.Sprintf("Adding reconcile-trigger annotation: %s", reason))
+	log.Info("Adding reconcile-trigger annotation", "reason", reason)
 
 	// Requeue - defer will save annotation, which triggers webhook
 	return ctrl.Result{Requeue: true}, nil
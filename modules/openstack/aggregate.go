@@ -0,0 +1,237 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	aggregates "github.com/gophercloud/gophercloud/v2/openstack/compute/v2/aggregates"
+)
+
+// AggregateNotFound - aggregate not found error message"
+const AggregateNotFound = "aggregate not found in nova"
+
+// availabilityZoneMetadata - metadata key nova stores the availability zone
+// of an aggregate in
+const availabilityZoneMetadata = "availability_zone"
+
+// Aggregate - the desired state of a host aggregate
+type Aggregate struct {
+	// Name - name of the aggregate
+	Name string
+	// AvailabilityZone - availability zone the hosts of the aggregate are
+	// exposed in, none if empty
+	AvailabilityZone string
+	// Hosts - the compute hosts of the aggregate, hosts not in the list get
+	// removed
+	Hosts []string
+	// Metadata - metadata of the aggregate, e.g. for the
+	// AggregateInstanceExtraSpecsFilter. Keys not in the map get removed.
+	Metadata map[string]string
+}
+
+// AggregateResult - what EnsureAggregate changed
+type AggregateResult struct {
+	// ID - ID of the aggregate
+	ID int
+	// Created - the aggregate did not exist
+	Created bool
+	// Updated - the availability zone or the metadata got changed
+	Updated bool
+	// AddedHosts - hosts added to the aggregate
+	AddedHosts []string
+	// RemovedHosts - hosts removed from the aggregate
+	RemovedHosts []string
+}
+
+// Changed - returns true if anything got changed
+func (r AggregateResult) Changed() bool {
+	return r.Created || r.Updated || len(r.AddedHosts) > 0 || len(r.RemovedHosts) > 0
+}
+
+// GetAggregate - get aggregate with name
+func (o *OpenStack) GetAggregate(
+	ctx context.Context,
+	log logr.Logger,
+	name string,
+) (*aggregates.Aggregate, error) {
+	allPages, err := aggregates.List(o.osclient).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allAggregates, err := aggregates.ExtractAggregates(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range allAggregates {
+		if allAggregates[i].Name == name {
+			return &allAggregates[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s %s", name, AggregateNotFound) // nolint:err113
+}
+
+// EnsureAggregate - creates the aggregate, or reconciles the availability
+// zone, metadata and host membership of an existing one. The client must be
+// a compute client, see GetNovaOpenStackClient.
+//
+// Example:
+//
+//	result, err := os.EnsureAggregate(ctx, log, openstack.Aggregate{
+//		Name:             "edge1",
+//		AvailabilityZone: "edge1",
+//		Hosts:            []string{"compute-0.example.com", "compute-1.example.com"},
+//	})
+func (o *OpenStack) EnsureAggregate(
+	ctx context.Context,
+	log logr.Logger,
+	a Aggregate,
+) (AggregateResult, error) {
+	result := AggregateResult{}
+
+	aggregate, err := o.GetAggregate(ctx, log, a.Name)
+	if err != nil && !strings.Contains(err.Error(), AggregateNotFound) {
+		return result, err
+	}
+
+	if aggregate == nil {
+		aggregate, err = aggregates.Create(ctx, o.osclient, aggregates.CreateOpts{
+			Name:             a.Name,
+			AvailabilityZone: a.AvailabilityZone,
+		}).Extract()
+		if err != nil {
+			return result, err
+		}
+		log.Info(fmt.Sprintf("Aggregate Created - Name %s, ID %d", a.Name, aggregate.ID))
+		result.Created = true
+	}
+	result.ID = aggregate.ID
+
+	// metadata, including the availability zone nova stores in it
+	desired := map[string]string{}
+	for k, v := range a.Metadata {
+		desired[k] = v
+	}
+	if a.AvailabilityZone != "" {
+		desired[availabilityZoneMetadata] = a.AvailabilityZone
+	}
+	metadata := map[string]interface{}{}
+	for k, v := range desired {
+		if aggregate.Metadata[k] != v {
+			metadata[k] = v
+		}
+	}
+	for k := range aggregate.Metadata {
+		if _, ok := desired[k]; !ok {
+			// null removes the key
+			metadata[k] = nil
+		}
+	}
+	if len(metadata) > 0 {
+		_, err = aggregates.SetMetadata(ctx, o.osclient, aggregate.ID, aggregates.SetMetadataOpts{
+			Metadata: metadata,
+		}).Extract()
+		if err != nil {
+			return result, err
+		}
+		if !result.Created {
+			log.Info(fmt.Sprintf("Aggregate %s metadata updated", a.Name))
+			result.Updated = true
+		}
+	}
+
+	// host membership
+	current := map[string]bool{}
+	for _, host := range aggregate.Hosts {
+		current[host] = true
+	}
+	requested := map[string]bool{}
+	for _, host := range a.Hosts {
+		requested[host] = true
+		if current[host] {
+			continue
+		}
+		_, err = aggregates.AddHost(ctx, o.osclient, aggregate.ID, aggregates.AddHostOpts{Host: host}).Extract()
+		if err != nil {
+			return result, err
+		}
+		log.Info(fmt.Sprintf("Added host %s to aggregate %s", host, a.Name))
+		result.AddedHosts = append(result.AddedHosts, host)
+	}
+	for _, host := range aggregate.Hosts {
+		if requested[host] {
+			continue
+		}
+		_, err = aggregates.RemoveHost(ctx, o.osclient, aggregate.ID, aggregates.RemoveHostOpts{Host: host}).Extract()
+		if err != nil {
+			return result, err
+		}
+		log.Info(fmt.Sprintf("Removed host %s from aggregate %s", host, a.Name))
+		result.RemovedHosts = append(result.RemovedHosts, host)
+	}
+	sort.Strings(result.AddedHosts)
+	sort.Strings(result.RemovedHosts)
+
+	return result, nil
+}
+
+// EnsureAvailabilityZone - ensures the availability zone with the hosts,
+// backed by an aggregate of the same name
+func (o *OpenStack) EnsureAvailabilityZone(
+	ctx context.Context,
+	log logr.Logger,
+	name string,
+	hosts []string,
+) (AggregateResult, error) {
+	return o.EnsureAggregate(ctx, log, Aggregate{
+		Name:             name,
+		AvailabilityZone: name,
+		Hosts:            hosts,
+	})
+}
+
+// DeleteAggregate - removes the hosts from the aggregate with name and
+// deletes it, nova refuses to delete aggregates with hosts
+func (o *OpenStack) DeleteAggregate(
+	ctx context.Context,
+	log logr.Logger,
+	name string,
+) error {
+	aggregate, err := o.GetAggregate(ctx, log, name)
+	if err != nil {
+		if strings.Contains(err.Error(), AggregateNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	for _, host := range aggregate.Hosts {
+		_, err = aggregates.RemoveHost(ctx, o.osclient, aggregate.ID, aggregates.RemoveHostOpts{Host: host}).Extract()
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info(fmt.Sprintf("Deleting aggregate %s", name))
+	return aggregates.Delete(ctx, o.osclient, aggregate.ID).ExtractErr()
+}
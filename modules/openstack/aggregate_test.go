@@ -0,0 +1,80 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	gophercloud "github.com/gophercloud/gophercloud/v2"
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack/test/helpers"
+)
+
+func TestEnsureAggregate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+
+	f := helpers.NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+	nova := helpers.NewNovaAPIFixture(f)
+	defer nova.Close()
+
+	os, err := openstack.GetNovaOpenStackClient(ctx, log, f.AuthOpts(),
+		gophercloud.EndpointOpts{Region: "regionOne", Availability: gophercloud.AvailabilityInternal})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	result, err := os.EnsureAvailabilityZone(ctx, log, "edge1", []string{"compute-1", "compute-0"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Created).To(BeTrue())
+	g.Expect(result.AddedHosts).To(Equal([]string{"compute-0", "compute-1"}))
+
+	aggs := nova.GetAggregates()
+	g.Expect(aggs).To(HaveLen(1))
+	g.Expect(aggs[0].AvailabilityZone).To(Equal("edge1"))
+
+	// unchanged
+	result, err = os.EnsureAvailabilityZone(ctx, log, "edge1", []string{"compute-0", "compute-1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Changed()).To(BeFalse())
+
+	// membership and metadata get reconciled
+	result, err = os.EnsureAggregate(ctx, log, openstack.Aggregate{
+		Name:     "edge1",
+		Hosts:    []string{"compute-1", "compute-2"},
+		Metadata: map[string]string{"ssd": "true"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Updated).To(BeTrue())
+	g.Expect(result.AddedHosts).To(Equal([]string{"compute-2"}))
+	g.Expect(result.RemovedHosts).To(Equal([]string{"compute-0"}))
+	aggs = nova.GetAggregates()
+	g.Expect(aggs[0].AvailabilityZone).To(BeEmpty())
+	g.Expect(aggs[0].Metadata).To(Equal(map[string]string{"ssd": "true"}))
+
+	err = os.DeleteAggregate(ctx, log, "edge1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(nova.GetAggregates()).To(BeEmpty())
+
+	// deleting a missing aggregate is no error
+	err = os.DeleteAggregate(ctx, log, "edge1")
+	g.Expect(err).ToNot(HaveOccurred())
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	gophercloud "github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	limits "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/limits"
+)
+
+// QuotaAPI - the quota API of a service
+type QuotaAPI struct {
+	// Path - path of the quotas of a project, relative to the service
+	// endpoint
+	Path string
+	// Key - key of the quotas in the request and response body
+	Key string
+	// NewClient - creates the client of the service
+	NewClient func(*gophercloud.ProviderClient, gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error)
+}
+
+var (
+	// ComputeQuotaAPI - the nova quota sets API
+	ComputeQuotaAPI = QuotaAPI{Path: "os-quota-sets", Key: "quota_set", NewClient: openstack.NewComputeV2}
+	// BlockStorageQuotaAPI - the cinder quota sets API
+	BlockStorageQuotaAPI = QuotaAPI{Path: "os-quota-sets", Key: "quota_set", NewClient: openstack.NewBlockStorageV3}
+	// NetworkQuotaAPI - the neutron quotas API
+	NetworkQuotaAPI = QuotaAPI{Path: "quotas", Key: "quota", NewClient: openstack.NewNetworkV2}
+)
+
+// QuotaChange - a quota or limit of a project which differed and got set
+type QuotaChange struct {
+	// Resource - the resource, e.g. cores
+	Resource string
+	// Previous - the value before, 0 if Created
+	Previous int
+	// Current - the value set
+	Current int
+	// Created - the limit did not exist before
+	Created bool
+}
+
+// String - returns the change, e.g. "cores: 20 -> 40"
+func (c QuotaChange) String() string {
+	if c.Created {
+		return fmt.Sprintf("%s: %d (created)", c.Resource, c.Current)
+	}
+	return fmt.Sprintf("%s: %d -> %d", c.Resource, c.Previous, c.Current)
+}
+
+// QuotaChangesMessage - returns the changes as comma separated list, e.g. to
+// be logged or used in a condition message
+func QuotaChangesMessage(changes []QuotaChange) string {
+	msgs := make([]string, 0, len(changes))
+	for _, c := range changes {
+		msgs = append(msgs, c.String())
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// GetQuotaOpenStackClient creates a new instance of the openstack struct for
+// the service of the quota API from a config struct
+func GetQuotaOpenStackClient(
+	ctx context.Context,
+	log logr.Logger,
+	cfg AuthOpts,
+	api QuotaAPI,
+	endpointOpts gophercloud.EndpointOpts,
+) (*OpenStack, error) {
+	providerClient, err := GetOpenStackProvider(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceClient, err := api.NewClient(providerClient, endpointOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenStack{
+		osclient: serviceClient,
+		region:   cfg.Region,
+		authURL:  cfg.AuthURL,
+	}, nil
+}
+
+// EnsureProjectQuotas - sets the quotas of the project via the quota API
+// of the service the client got created for, see GetQuotaOpenStackClient.
+// Only quotas which differ get updated, -1 means unlimited. Returns the
+// changed quotas, none if the project already had them.
+//
+// Example:
+//
+//	os, err := openstack.GetQuotaOpenStackClient(ctx, log, authOpts, openstack.ComputeQuotaAPI, endpointOpts)
+//	...
+//	changes, err := os.EnsureProjectQuotas(ctx, log, openstack.ComputeQuotaAPI, serviceProjectID,
+//		map[string]int{"instances": -1, "cores": -1, "ram": -1})
+func (o *OpenStack) EnsureProjectQuotas(
+	ctx context.Context,
+	log logr.Logger,
+	api QuotaAPI,
+	projectID string,
+	quotas map[string]int,
+) ([]QuotaChange, error) {
+	url := o.osclient.ServiceURL(api.Path, projectID)
+
+	current := map[string]map[string]interface{}{}
+	_, err := o.osclient.Get(ctx, url, &current, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []QuotaChange{}
+	update := map[string]int{}
+	for _, resource := range sortedResources(quotas) {
+		value, ok := current[api.Key][resource].(float64)
+		if !ok {
+			return nil, fmt.Errorf("quota %s not known for project %s", resource, projectID) // nolint:err113
+		}
+		if int(value) == quotas[resource] {
+			continue
+		}
+		update[resource] = quotas[resource]
+		changes = append(changes, QuotaChange{
+			Resource: resource,
+			Previous: int(value),
+			Current:  quotas[resource],
+		})
+	}
+
+	if len(update) == 0 {
+		return changes, nil
+	}
+
+	_, err = o.osclient.Put(ctx, url, map[string]interface{}{api.Key: update}, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{http.StatusOK},
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Updated quotas of project %s - %s", projectID, QuotaChangesMessage(changes)))
+
+	return changes, nil
+}
+
+// ProjectLimits - the keystone unified limits of a project for the
+// resources of a service
+type ProjectLimits struct {
+	// ProjectID - the project
+	ProjectID string
+	// ServiceID - the service the resources belong to
+	ServiceID string
+	// RegionID - the region of the limits, if the registered limits are
+	// per region
+	RegionID string
+	// Limits - resource name to limit
+	Limits map[string]int
+}
+
+// EnsureProjectLimits - creates the unified limits of the project, or
+// updates them if they differ. The registered limits of the resources must
+// exist, see CreateOrUpdateRegisteredLimit. Returns the changed limits,
+// none if the project already had them.
+func (o *OpenStack) EnsureProjectLimits(
+	ctx context.Context,
+	log logr.Logger,
+	p ProjectLimits,
+) ([]QuotaChange, error) {
+	allPages, err := limits.List(o.osclient, limits.ListOpts{
+		ProjectID: p.ProjectID,
+		ServiceID: p.ServiceID,
+		RegionID:  p.RegionID,
+	}).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allLimits, err := limits.ExtractLimits(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]limits.Limit{}
+	for _, l := range allLimits {
+		existing[l.ResourceName] = l
+	}
+
+	changes := []QuotaChange{}
+	createOpts := limits.BatchCreateOpts{}
+	for _, resource := range sortedResources(p.Limits) {
+		value := p.Limits[resource]
+		l, ok := existing[resource]
+		if !ok {
+			createOpts = append(createOpts, limits.CreateOpts{
+				ResourceName:  resource,
+				ResourceLimit: value,
+				ServiceID:     p.ServiceID,
+				ProjectID:     p.ProjectID,
+				RegionID:      p.RegionID,
+			})
+			changes = append(changes, QuotaChange{Resource: resource, Current: value, Created: true})
+			continue
+		}
+		if l.ResourceLimit == value {
+			continue
+		}
+
+		_, err = limits.Update(ctx, o.osclient, l.ID, limits.UpdateOpts{ResourceLimit: &value}).Extract()
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, QuotaChange{Resource: resource, Previous: l.ResourceLimit, Current: value})
+	}
+
+	if len(createOpts) > 0 {
+		_, err = limits.BatchCreate(ctx, o.osclient, createOpts).Extract()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(changes) > 0 {
+		log.Info(fmt.Sprintf("Updated limits of project %s - %s", p.ProjectID, QuotaChangesMessage(changes)))
+	}
+
+	return changes, nil
+}
+
+// sortedResources - returns the resource names in a stable order
+func sortedResources(values map[string]int) []string {
+	resources := make([]string, 0, len(values))
+	for resource := range values {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
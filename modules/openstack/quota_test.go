@@ -0,0 +1,91 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	gophercloud "github.com/gophercloud/gophercloud/v2"
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack/test/helpers"
+)
+
+func TestEnsureProjectQuotas(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+
+	f := helpers.NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+	nova := helpers.NewNovaAPIFixture(f)
+	defer nova.Close()
+
+	os, err := openstack.GetQuotaOpenStackClient(ctx, log, f.AuthOpts(), openstack.ComputeQuotaAPI,
+		gophercloud.EndpointOpts{Region: "regionOne", Availability: gophercloud.AvailabilityInternal})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	quotas := map[string]int{"instances": -1, "cores": 20}
+	changes, err := os.EnsureProjectQuotas(ctx, log, openstack.ComputeQuotaAPI, "service", quotas)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(openstack.QuotaChangesMessage(changes)).To(Equal("instances: 10 -> -1"))
+	g.Expect(nova.GetComputeQuotas("service")).To(HaveKeyWithValue("instances", -1))
+	g.Expect(nova.GetComputeQuotas("other")).To(Equal(helpers.DefaultComputeQuotas))
+
+	changes, err = os.EnsureProjectQuotas(ctx, log, openstack.ComputeQuotaAPI, "service", quotas)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changes).To(BeEmpty())
+
+	_, err = os.EnsureProjectQuotas(ctx, log, openstack.ComputeQuotaAPI, "service", map[string]int{"unknown": 1})
+	g.Expect(err).To(MatchError(ContainSubstring("quota unknown not known")))
+}
+
+func TestEnsureProjectLimits(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+
+	f := helpers.NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+
+	os, err := openstack.NewOpenStack(ctx, log, f.AuthOpts())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	p := openstack.ProjectLimits{
+		ProjectID: "service",
+		ServiceID: "glance",
+		Limits:    map[string]int{"image_count_total": 100, "image_size_total": 1000},
+	}
+	changes, err := os.EnsureProjectLimits(ctx, log, p)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changes).To(HaveLen(2))
+	g.Expect(changes).To(HaveEach(HaveField("Created", true)))
+	g.Expect(f.GetLimits("service")).To(HaveLen(2))
+
+	p.Limits["image_size_total"] = 2000
+	changes, err = os.EnsureProjectLimits(ctx, log, p)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(openstack.QuotaChangesMessage(changes)).To(Equal("image_size_total: 1000 -> 2000"))
+	g.Expect(f.GetLimits("service")[1].ResourceLimit).To(Equal(2000))
+
+	changes, err = os.EnsureProjectLimits(ctx, log, p)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changes).To(BeEmpty())
+}
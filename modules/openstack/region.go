@@ -0,0 +1,308 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	gophercloud "github.com/gophercloud/gophercloud/v2"
+)
+
+// KeystoneTarget - a keystone instance and region services and endpoints
+// get registered in. Multiple targets can point to the same keystone using
+// different regions (multi region), or to different keystone instances
+// (e.g. edge/DCN sites with a local keystone).
+type KeystoneTarget struct {
+	// Name - unique name of the target, e.g. the region or the site name
+	Name string
+	// AuthOpts - connection settings for the keystone instance. The
+	// AuthOpts.Region is the region the endpoints get registered in.
+	AuthOpts AuthOpts
+}
+
+// RegionStatus - registration status of a single KeystoneTarget
+type RegionStatus struct {
+	// Ready - true if the last registration in the target succeeded
+	Ready bool
+	// Region - the keystone region of the target
+	Region string
+	// ServiceID - ID of the service in the target keystone
+	ServiceID string
+	// EndpointIDs - endpoint interface to endpoint ID
+	EndpointIDs map[string]string
+	// Message - error message if the last registration failed
+	Message string
+}
+
+// MultiRegion - set of identity clients, one per KeystoneTarget, which
+// tracks the registration status per target.
+type MultiRegion struct {
+	targets []string
+	clients map[string]*OpenStack
+	status  map[string]*RegionStatus
+}
+
+// WithRegion - returns a copy of the OpenStack client which registers and
+// looks up endpoints in the given region of the same keystone instance.
+func (o *OpenStack) WithRegion(region string) *OpenStack {
+	return &OpenStack{
+		osclient: o.osclient,
+		region:   region,
+		authURL:  o.authURL,
+	}
+}
+
+// NewMultiRegion - creates an identity client for each of the targets.
+// A target whose keystone can not be reached does not fail the call, it
+// is reported as not ready in its RegionStatus so that the other targets
+// can still be handled. An error is returned for invalid target lists.
+func NewMultiRegion(
+	ctx context.Context,
+	log logr.Logger,
+	targets []KeystoneTarget,
+) (*MultiRegion, error) {
+	m := &MultiRegion{
+		clients: map[string]*OpenStack{},
+		status:  map[string]*RegionStatus{},
+	}
+
+	for _, t := range targets {
+		if t.Name == "" {
+			return nil, fmt.Errorf("keystone target without name for region %s", t.AuthOpts.Region) // nolint:err113
+		}
+		if _, ok := m.status[t.Name]; ok {
+			return nil, fmt.Errorf("duplicate keystone target %s", t.Name) // nolint:err113
+		}
+
+		m.targets = append(m.targets, t.Name)
+		m.status[t.Name] = &RegionStatus{
+			Region:      t.AuthOpts.Region,
+			EndpointIDs: map[string]string{},
+		}
+
+		os, err := NewOpenStack(ctx, log, t.AuthOpts)
+		if err != nil {
+			log.Info(fmt.Sprintf("Keystone target %s not reachable: %s", t.Name, err))
+			m.status[t.Name].Message = err.Error()
+			continue
+		}
+		m.clients[t.Name] = os
+	}
+	sort.Strings(m.targets)
+
+	return m, nil
+}
+
+// NewMultiRegionFromClients - creates a MultiRegion from already
+// initialized clients, keyed by the target name.
+func NewMultiRegionFromClients(clients map[string]*OpenStack) *MultiRegion {
+	m := &MultiRegion{
+		clients: map[string]*OpenStack{},
+		status:  map[string]*RegionStatus{},
+	}
+	for name, os := range clients {
+		m.targets = append(m.targets, name)
+		m.clients[name] = os
+		m.status[name] = &RegionStatus{
+			Region:      os.GetRegion(),
+			EndpointIDs: map[string]string{},
+		}
+	}
+	sort.Strings(m.targets)
+
+	return m
+}
+
+// GetClient - returns the client for the target, nil if the target is
+// unknown or was not reachable
+func (m *MultiRegion) GetClient(name string) *OpenStack {
+	return m.clients[name]
+}
+
+// GetStatus - returns the registration status of the target
+func (m *MultiRegion) GetStatus(name string) (RegionStatus, bool) {
+	s, ok := m.status[name]
+	if !ok {
+		return RegionStatus{}, false
+	}
+	return *s, true
+}
+
+// GetTargets - returns the sorted target names
+func (m *MultiRegion) GetTargets() []string {
+	return append([]string{}, m.targets...)
+}
+
+// IsReady - returns true if the registration succeeded in all targets
+func (m *MultiRegion) IsReady() bool {
+	return len(m.NotReady()) == 0
+}
+
+// NotReady - returns the sorted names of the targets which are not ready
+func (m *MultiRegion) NotReady() []string {
+	notReady := []string{}
+	for _, name := range m.targets {
+		if !m.status[name].Ready {
+			notReady = append(notReady, name)
+		}
+	}
+	sort.Strings(notReady)
+
+	return notReady
+}
+
+// NotReadyMessage - returns a message listing the targets which are not
+// ready with their error, to be used in a condition message
+func (m *MultiRegion) NotReadyMessage() string {
+	msgs := []string{}
+	for _, name := range m.NotReady() {
+		msg := name
+		if m.status[name].Message != "" {
+			msg = fmt.Sprintf("%s: %s", name, m.status[name].Message)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return strings.Join(msgs, ", ")
+}
+
+// ForEach - runs fn against every reachable target, in the order of the
+// sorted target names, and records the result in the per target
+// RegionStatus. Errors from the individual targets do not stop the
+// processing of the remaining targets, they are returned combined.
+func (m *MultiRegion) ForEach(
+	fn func(name string, os *OpenStack, status *RegionStatus) error,
+) error {
+	errs := []string{}
+	for _, name := range m.targets {
+		status := m.status[name]
+		os, ok := m.clients[name]
+		if !ok {
+			status.Ready = false
+			errs = append(errs, fmt.Sprintf("%s: %s", name, status.Message))
+			continue
+		}
+
+		err := fn(name, os, status)
+		if err != nil {
+			status.Ready = false
+			status.Message = err.Error()
+			errs = append(errs, fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+		status.Ready = true
+		status.Message = ""
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("registration failed for keystone targets %s", strings.Join(errs, "; ")) // nolint:err113
+	}
+
+	return nil
+}
+
+// RegisterService - creates or updates the service in all targets
+func (m *MultiRegion) RegisterService(
+	ctx context.Context,
+	log logr.Logger,
+	s Service,
+) error {
+	return m.ForEach(func(name string, os *OpenStack, status *RegionStatus) error {
+		serviceID, _, err := os.ensureService(ctx, log, s)
+		if err != nil {
+			return err
+		}
+		status.ServiceID = serviceID
+		return nil
+	})
+}
+
+// RegisterServiceEndpoints - creates or updates the service and the
+// endpoints in all targets via EnsureRegistration, which also removes
+// duplicate endpoints. The endpoints map holds the endpoint interface
+// (admin, internal, public) to URL per target name. Targets which have no
+// entry in the endpoints map get the endpoints from the "" entry, if any.
+func (m *MultiRegion) RegisterServiceEndpoints(
+	ctx context.Context,
+	log logr.Logger,
+	s Service,
+	endpoints map[string]map[string]string,
+) error {
+	return m.ForEach(func(name string, os *OpenStack, status *RegionStatus) error {
+		eps, ok := endpoints[name]
+		if !ok {
+			eps = endpoints[""]
+		}
+
+		registration, err := os.EnsureRegistration(ctx, log, Registration{
+			Service:   s,
+			Endpoints: eps,
+		})
+		status.ServiceID = registration.ServiceID
+		status.EndpointIDs = registration.EndpointIDs
+		return err
+	})
+}
+
+// DeleteServiceEndpoints - deletes the endpoints of the service in all
+// targets. The service itself is only deleted if deleteService is true.
+func (m *MultiRegion) DeleteServiceEndpoints(
+	ctx context.Context,
+	log logr.Logger,
+	s Service,
+	deleteService bool,
+) error {
+	return m.ForEach(func(name string, os *OpenStack, status *RegionStatus) error {
+		service, err := os.GetService(ctx, log, s.Type, s.Name)
+		if err != nil {
+			if strings.Contains(err.Error(), ServiceNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		for _, availability := range []gophercloud.Availability{
+			gophercloud.AvailabilityAdmin,
+			gophercloud.AvailabilityInternal,
+			gophercloud.AvailabilityPublic,
+		} {
+			err = os.DeleteEndpoint(ctx, log, Endpoint{
+				Name:         s.Name,
+				ServiceID:    service.ID,
+				Availability: availability,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		status.EndpointIDs = map[string]string{}
+
+		if deleteService {
+			err = os.DeleteService(ctx, log, service.ID)
+			if err != nil {
+				return err
+			}
+			status.ServiceID = ""
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack/test/helpers"
+)
+
+func TestMultiRegion(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+
+	central := helpers.NewKeystoneAPIFixture("regionOne")
+	defer central.Close()
+	edge := helpers.NewKeystoneAPIFixture("edge1")
+	defer edge.Close()
+	unreachable := helpers.NewKeystoneAPIFixture("edge2")
+	unreachable.Close()
+
+	// the targets are sorted, independent of the order they are passed in
+	m, err := openstack.NewMultiRegion(ctx, log, []openstack.KeystoneTarget{
+		{Name: "edge2", AuthOpts: unreachable.AuthOpts()},
+		{Name: "edge1", AuthOpts: edge.AuthOpts()},
+		{Name: "central", AuthOpts: central.AuthOpts()},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(m.GetTargets()).To(Equal([]string{"central", "edge1", "edge2"}))
+	g.Expect(m.GetClient("central")).ToNot(BeNil())
+	g.Expect(m.GetClient("edge2")).To(BeNil())
+
+	fromClients := openstack.NewMultiRegionFromClients(map[string]*openstack.OpenStack{
+		"edge1":   m.GetClient("edge1"),
+		"central": m.GetClient("central"),
+	})
+	g.Expect(fromClients.GetTargets()).To(Equal([]string{"central", "edge1"}))
+
+	// the unreachable target fails, the others get registered
+	s := openstack.Service{Name: "glance", Type: "image", Description: "Image", Enabled: true}
+	err = m.RegisterServiceEndpoints(ctx, log, s, map[string]map[string]string{
+		"":      {"public": "http://glance-public"},
+		"edge1": {"public": "http://glance-edge1", "internal": "http://glance-edge1-internal"},
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("edge2")))
+	g.Expect(m.IsReady()).To(BeFalse())
+	g.Expect(m.NotReady()).To(Equal([]string{"edge2"}))
+	g.Expect(m.NotReadyMessage()).To(HavePrefix("edge2: "))
+
+	status, ok := m.GetStatus("central")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(status.Ready).To(BeTrue())
+	g.Expect(status.Region).To(Equal("regionOne"))
+	g.Expect(status.EndpointIDs).To(HaveKey("public"))
+	g.Expect(central.GetEndpoints(status.ServiceID)).To(ConsistOf(
+		HaveField("URL", "http://glance-public")))
+
+	status, _ = m.GetStatus("edge1")
+	g.Expect(status.Ready).To(BeTrue())
+	g.Expect(edge.GetEndpoints(status.ServiceID)).To(ConsistOf(
+		HaveField("URL", "http://glance-edge1"),
+		HaveField("URL", "http://glance-edge1-internal"),
+	))
+	_, ok = m.GetStatus("missing")
+	g.Expect(ok).To(BeFalse())
+
+	// the service is kept unless requested
+	err = m.DeleteServiceEndpoints(ctx, log, s, false)
+	g.Expect(err).To(MatchError(ContainSubstring("edge2")))
+	g.Expect(edge.GetEndpoints("")).To(BeEmpty())
+	g.Expect(edge.GetServices()).To(HaveLen(1))
+
+	err = fromClients.DeleteServiceEndpoints(ctx, log, s, true)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fromClients.IsReady()).To(BeTrue())
+	g.Expect(central.GetServices()).To(BeEmpty())
+	g.Expect(edge.GetServices()).To(BeEmpty())
+
+	// invalid target lists
+	_, err = openstack.NewMultiRegion(ctx, log, []openstack.KeystoneTarget{{AuthOpts: central.AuthOpts()}})
+	g.Expect(err).To(HaveOccurred())
+	_, err = openstack.NewMultiRegion(ctx, log, []openstack.KeystoneTarget{
+		{Name: "central", AuthOpts: central.AuthOpts()},
+		{Name: "central", AuthOpts: edge.AuthOpts()},
+	})
+	g.Expect(err).To(HaveOccurred())
+}
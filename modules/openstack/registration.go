@@ -0,0 +1,448 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	gophercloud "github.com/gophercloud/gophercloud/v2"
+	endpoints "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/endpoints"
+	users "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/users"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+// RegistrationAction - what EnsureRegistration did with an item
+type RegistrationAction string
+
+const (
+	// RegistrationCreated - the item did not exist and got created
+	RegistrationCreated RegistrationAction = "Created"
+	// RegistrationUpdated - the item drifted, e.g. the URL or region of an
+	// endpoint changed, and got repaired
+	RegistrationUpdated RegistrationAction = "Updated"
+	// RegistrationUnchanged - the item matched the registration
+	RegistrationUnchanged RegistrationAction = "Unchanged"
+	// RegistrationDeleted - the item is no longer requested and got deleted
+	RegistrationDeleted RegistrationAction = "Deleted"
+	// RegistrationFailed - the item could not be reconciled
+	RegistrationFailed RegistrationAction = "Failed"
+)
+
+const (
+	// KeystoneUserReadyCondition - condition reporting the service user of
+	// a Registration
+	KeystoneUserReadyCondition condition.Type = "KeystoneUserReady"
+	// KeystoneRoleAssignmentReadyCondition - condition reporting the role
+	// assignments of the service user of a Registration
+	KeystoneRoleAssignmentReadyCondition condition.Type = "KeystoneRoleAssignmentReady"
+)
+
+// Registration - the desired registration of a service in keystone
+type Registration struct {
+	// Service - the service
+	Service Service
+	// Endpoints - endpoint interface (admin, internal, public) to URL
+	Endpoints map[string]string
+	// PruneEndpoints - delete the endpoints of the service in the region
+	// for interfaces not in Endpoints
+	PruneEndpoints bool
+	// PreviousRegion - region the endpoints were registered in before, e.g.
+	// after the region got renamed. Its endpoints get moved to the region
+	// of the client instead of being left behind.
+	PreviousRegion string
+	// User - the service user, optional. Keystone does not return the
+	// password, it is only set when the user gets created.
+	User *User
+	// Roles - roles of the User in its ProjectID, or in its DomainID if no
+	// project is set
+	Roles []string
+}
+
+// RegistrationItem - result for a single item of a Registration
+type RegistrationItem struct {
+	// Condition - the condition the item gets reported in
+	Condition condition.Type
+	// Name - the item, e.g. "endpoint public"
+	Name string
+	// ID - keystone ID of the item, if any
+	ID string
+	// Action - what got done with the item
+	Action RegistrationAction
+	// Message - the error if the item failed
+	Message string
+}
+
+// RegistrationStatus - result of EnsureRegistration
+type RegistrationStatus struct {
+	// ServiceID - ID of the service
+	ServiceID string
+	// UserID - ID of the service user
+	UserID string
+	// EndpointIDs - endpoint interface to endpoint ID
+	EndpointIDs map[string]string
+	// Items - the result per item, in the order they got reconciled
+	Items []RegistrationItem
+}
+
+// IsReady - returns true if all items got reconciled
+func (s RegistrationStatus) IsReady() bool {
+	for _, item := range s.Items {
+		if item.Action == RegistrationFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// Drifted - returns the items which differed from the registration and got
+// repaired or deleted
+func (s RegistrationStatus) Drifted() []RegistrationItem {
+	drifted := []RegistrationItem{}
+	for _, item := range s.Items {
+		if item.Action == RegistrationUpdated || item.Action == RegistrationDeleted {
+			drifted = append(drifted, item)
+		}
+	}
+	return drifted
+}
+
+// MarkConditions - sets one condition per kind of item, e.g. the
+// KeystoneServiceReadyCondition and the KeystoneEndpointReadyCondition,
+// false with the errors of the failed items, otherwise true
+func (s RegistrationStatus) MarkConditions(conditions *condition.Conditions) {
+	types := []condition.Type{}
+	failed := map[condition.Type][]string{}
+	for _, item := range s.Items {
+		if _, ok := failed[item.Condition]; !ok {
+			types = append(types, item.Condition)
+			failed[item.Condition] = []string{}
+		}
+		if item.Action == RegistrationFailed {
+			failed[item.Condition] = append(failed[item.Condition], fmt.Sprintf("%s: %s", item.Name, item.Message))
+		}
+	}
+
+	for _, t := range types {
+		if len(failed[t]) > 0 {
+			conditions.MarkFalse(
+				t,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				"Keystone registration failed: %s",
+				strings.Join(failed[t], "; "))
+			continue
+		}
+		conditions.MarkTrue(t, "Keystone registration completed")
+	}
+}
+
+// EnsureRegistration - ensures the service, its endpoints in the region of
+// the client, the service user and its roles in one call. Items which
+// drifted, e.g. an endpoint with a changed URL or in the PreviousRegion,
+// get repaired. A failing item does not stop the remaining ones, unless
+// they depend on it, the errors are returned combined and reported per
+// item in the status.
+//
+// Example:
+//
+//	status, err := os.EnsureRegistration(ctx, log, openstack.Registration{
+//		Service:   openstack.Service{Name: "glance", Type: "image", Enabled: true},
+//		Endpoints: map[string]string{"internal": internalURL, "public": publicURL},
+//		User:      &openstack.User{Name: "glance", Password: password, ProjectID: serviceProjectID},
+//		Roles:     []string{"admin", "service"},
+//	})
+//	status.MarkConditions(&instance.Status.Conditions)
+//	if err != nil {
+//		return ctrl.Result{}, err
+//	}
+func (o *OpenStack) EnsureRegistration(
+	ctx context.Context,
+	log logr.Logger,
+	r Registration,
+) (RegistrationStatus, error) {
+	status := RegistrationStatus{
+		EndpointIDs: map[string]string{},
+	}
+	errs := []string{}
+	record := func(item RegistrationItem, err error) {
+		if err != nil {
+			item.Action = RegistrationFailed
+			item.Message = err.Error()
+			errs = append(errs, fmt.Sprintf("%s: %s", item.Name, err))
+		} else if item.Action != RegistrationUnchanged {
+			log.Info(fmt.Sprintf("Keystone %s %s", item.Name, strings.ToLower(string(item.Action))))
+		}
+		status.Items = append(status.Items, item)
+	}
+
+	serviceID, action, serviceErr := o.ensureService(ctx, log, r.Service)
+	status.ServiceID = serviceID
+	record(RegistrationItem{
+		Condition: condition.KeystoneServiceReadyCondition,
+		Name:      "service " + r.Service.Name,
+		ID:        serviceID,
+		Action:    action,
+	}, serviceErr)
+
+	interfaces := make([]string, 0, len(r.Endpoints))
+	for endpointInterface := range r.Endpoints {
+		interfaces = append(interfaces, endpointInterface)
+	}
+	sort.Strings(interfaces)
+
+	for _, endpointInterface := range interfaces {
+		item := RegistrationItem{
+			Condition: condition.KeystoneEndpointReadyCondition,
+			Name:      "endpoint " + endpointInterface,
+		}
+		if serviceErr != nil {
+			record(item, fmt.Errorf("service %s not registered", r.Service.Name)) // nolint:err113
+			continue
+		}
+		id, action, err := o.ensureRegionEndpoint(
+			ctx, log, r.Service.Name, serviceID, endpointInterface, r.Endpoints[endpointInterface], r.PreviousRegion)
+		if err == nil {
+			status.EndpointIDs[endpointInterface] = id
+		}
+		item.ID = id
+		item.Action = action
+		record(item, err)
+	}
+
+	if r.PruneEndpoints && serviceErr == nil {
+		for _, availability := range []gophercloud.Availability{
+			gophercloud.AvailabilityAdmin,
+			gophercloud.AvailabilityInternal,
+			gophercloud.AvailabilityPublic,
+		} {
+			if _, ok := r.Endpoints[string(availability)]; ok {
+				continue
+			}
+			deleted, err := o.pruneEndpoints(ctx, log, serviceID, string(availability))
+			if deleted || err != nil {
+				record(RegistrationItem{
+					Condition: condition.KeystoneEndpointReadyCondition,
+					Name:      "endpoint " + string(availability),
+					Action:    RegistrationDeleted,
+				}, err)
+			}
+		}
+	}
+
+	if r.User != nil {
+		userID, action, userErr := o.ensureUser(ctx, log, *r.User)
+		status.UserID = userID
+		record(RegistrationItem{
+			Condition: KeystoneUserReadyCondition,
+			Name:      "user " + r.User.Name,
+			ID:        userID,
+			Action:    action,
+		}, userErr)
+
+		for _, roleName := range r.Roles {
+			item := RegistrationItem{
+				Condition: KeystoneRoleAssignmentReadyCondition,
+				Name:      "role " + roleName,
+				Action:    RegistrationUnchanged,
+			}
+			if userErr != nil {
+				record(item, fmt.Errorf("user %s not registered", r.User.Name)) // nolint:err113
+				continue
+			}
+			assigned, err := o.ensureUserRole(ctx, log, roleName, userID, r.User.ProjectID, r.User.DomainID)
+			if assigned {
+				item.Action = RegistrationCreated
+			}
+			record(item, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return status, fmt.Errorf("keystone registration of %s failed: %s", r.Service.Name, strings.Join(errs, "; ")) // nolint:err113
+	}
+
+	return status, nil
+}
+
+// ensureService - creates the service, or updates it if it differs
+func (o *OpenStack) ensureService(
+	ctx context.Context,
+	log logr.Logger,
+	s Service,
+) (string, RegistrationAction, error) {
+	service, err := o.GetService(ctx, log, s.Type, s.Name)
+	if err != nil && !strings.Contains(err.Error(), ServiceNotFound) {
+		return "", RegistrationFailed, err
+	}
+
+	if service == nil {
+		serviceID, err := o.CreateService(ctx, log, s)
+		return serviceID, RegistrationCreated, err
+	}
+
+	description, _ := service.Extra["description"].(string)
+	if service.Enabled == s.Enabled && description == s.Description {
+		return service.ID, RegistrationUnchanged, nil
+	}
+
+	err = o.UpdateService(ctx, log, s, service.ID)
+	return service.ID, RegistrationUpdated, err
+}
+
+// ensureRegionEndpoint - creates the endpoint in the region of the client,
+// or updates it if its URL changed or it is in the previousRegion. Further
+// endpoints of the interface in either region are duplicates and get
+// deleted.
+func (o *OpenStack) ensureRegionEndpoint(
+	ctx context.Context,
+	log logr.Logger,
+	name string,
+	serviceID string,
+	endpointInterface string,
+	url string,
+	previousRegion string,
+) (string, RegistrationAction, error) {
+	availability, err := GetAvailability(endpointInterface)
+	if err != nil {
+		return "", RegistrationFailed, err
+	}
+
+	allPages, err := endpoints.List(o.osclient, endpoints.ListOpts{
+		ServiceID:    serviceID,
+		Availability: availability,
+	}).AllPages(ctx)
+	if err != nil {
+		return "", RegistrationFailed, err
+	}
+	allEndpoints, err := endpoints.ExtractEndpoints(allPages)
+	if err != nil {
+		return "", RegistrationFailed, err
+	}
+
+	// endpoints of the region first, then the ones of the previous region
+	candidates := []endpoints.Endpoint{}
+	previous := []endpoints.Endpoint{}
+	for _, e := range allEndpoints {
+		switch {
+		case e.Region == o.region:
+			candidates = append(candidates, e)
+		case previousRegion != "" && e.Region == previousRegion:
+			previous = append(previous, e)
+		}
+	}
+	candidates = append(candidates, previous...)
+
+	e := Endpoint{
+		Name:         name,
+		ServiceID:    serviceID,
+		Availability: availability,
+		URL:          url,
+	}
+
+	if len(candidates) == 0 {
+		endpointID, err := o.CreateEndpoint(ctx, log, e)
+		return endpointID, RegistrationCreated, err
+	}
+
+	action := RegistrationUnchanged
+	endpointID := candidates[0].ID
+	if candidates[0].URL != url || candidates[0].Region != o.region {
+		endpointID, err = o.UpdateEndpoint(ctx, log, e, candidates[0].ID)
+		if err != nil {
+			return "", RegistrationFailed, err
+		}
+		action = RegistrationUpdated
+	}
+
+	for _, duplicate := range candidates[1:] {
+		log.Info(fmt.Sprintf("Deleting duplicate endpoint %s %s - %s", duplicate.Region, endpointInterface, duplicate.URL))
+		err = endpoints.Delete(ctx, o.osclient, duplicate.ID).ExtractErr()
+		if err != nil {
+			return endpointID, RegistrationFailed, err
+		}
+		action = RegistrationUpdated
+	}
+
+	return endpointID, action, nil
+}
+
+// pruneEndpoints - deletes the endpoints of the interface in the region of
+// the client. Returns true if any got deleted.
+func (o *OpenStack) pruneEndpoints(
+	ctx context.Context,
+	log logr.Logger,
+	serviceID string,
+	endpointInterface string,
+) (bool, error) {
+	allEndpoints, err := o.GetEndpoints(ctx, log, serviceID, endpointInterface)
+	if err != nil {
+		return false, err
+	}
+
+	for _, e := range allEndpoints {
+		err = endpoints.Delete(ctx, o.osclient, e.ID).ExtractErr()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return len(allEndpoints) > 0, nil
+}
+
+// ensureUser - creates the user, or enables it and sets its default project
+// if they differ
+func (o *OpenStack) ensureUser(
+	ctx context.Context,
+	log logr.Logger,
+	u User,
+) (string, RegistrationAction, error) {
+	user, err := o.GetUser(ctx, log, u.Name, u.DomainID)
+	if err != nil && !strings.Contains(err.Error(), UserNotFound) {
+		return "", RegistrationFailed, err
+	}
+
+	if user == nil {
+		userID, err := o.CreateUser(ctx, log, u)
+		return userID, RegistrationCreated, err
+	}
+
+	updateOpts := users.UpdateOpts{}
+	changed := false
+	if !user.Enabled {
+		enabled := true
+		updateOpts.Enabled = &enabled
+		changed = true
+	}
+	if u.ProjectID != "" && user.DefaultProjectID != u.ProjectID {
+		updateOpts.DefaultProjectID = u.ProjectID
+		changed = true
+	}
+	if !changed {
+		return user.ID, RegistrationUnchanged, nil
+	}
+
+	_, err = users.Update(ctx, o.GetOSClient(), user.ID, updateOpts).Extract()
+	if err != nil {
+		return user.ID, RegistrationFailed, err
+	}
+
+	return user.ID, RegistrationUpdated, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack/test/helpers"
+)
+
+func TestEnsureRegistration(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+
+	f := helpers.NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+	f.AddRole("admin")
+	f.AddRole("service")
+
+	os, err := openstack.NewOpenStack(ctx, log, f.AuthOpts())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := openstack.Registration{
+		Service: openstack.Service{Name: "glance", Type: "image", Description: "Image", Enabled: true},
+		Endpoints: map[string]string{
+			"internal": "http://glance-internal",
+			"public":   "http://glance-public",
+		},
+		User:  &openstack.User{Name: "glance", Password: "secret", ProjectID: "service", DomainID: "default"},
+		Roles: []string{"admin", "service"},
+	}
+	status, err := os.EnsureRegistration(ctx, log, r)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status.IsReady()).To(BeTrue())
+	g.Expect(status.EndpointIDs).To(HaveLen(2))
+	g.Expect(status.Items).To(HaveEach(HaveField("Action", openstack.RegistrationCreated)))
+	g.Expect(f.GetRoleAssignments(status.UserID)).To(HaveLen(2))
+
+	// a second run changes nothing
+	status, err = os.EnsureRegistration(ctx, log, r)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status.Items).To(HaveEach(HaveField("Action", openstack.RegistrationUnchanged)))
+	g.Expect(f.GetRoleAssignments(status.UserID)).To(HaveLen(2))
+
+	// drift: changed URL, an endpoint in the previous region, a disabled user
+	r.Endpoints["public"] = "https://glance-public"
+	r.Endpoints["admin"] = "http://glance-admin"
+	r.PreviousRegion = "regionOld"
+	f.AddEndpoint(helpers.KeystoneEndpoint{
+		Interface: "admin", Region: "regionOld", RegionID: "regionOld",
+		ServiceID: status.ServiceID, URL: "http://old-admin", Enabled: true,
+	})
+	f.AddUser(helpers.KeystoneUser{ID: status.UserID, Name: "glance", DomainID: "default", DefaultProjectID: "service"})
+
+	status, err = os.EnsureRegistration(ctx, log, r)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status.Drifted()).To(ConsistOf(
+		HaveField("Name", "endpoint admin"),
+		HaveField("Name", "endpoint public"),
+		HaveField("Name", "user glance"),
+	))
+	endpoints := f.GetEndpoints(status.ServiceID)
+	g.Expect(endpoints).To(HaveLen(3))
+	g.Expect(endpoints).To(HaveEach(HaveField("Region", "regionOne")))
+	g.Expect(endpoints).To(ContainElement(HaveField("URL", "https://glance-public")))
+	g.Expect(f.GetUsers()[0].Enabled).To(BeTrue())
+
+	// pruning removes endpoints no longer requested
+	delete(r.Endpoints, "admin")
+	r.PruneEndpoints = true
+	status, err = os.EnsureRegistration(ctx, log, r)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status.Drifted()).To(ConsistOf(HaveField("Name", "endpoint admin")))
+	g.Expect(f.GetEndpoints(status.ServiceID)).To(HaveLen(2))
+}
+
+func TestEnsureRegistrationFailure(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+
+	f := helpers.NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+
+	os, err := openstack.NewOpenStack(ctx, log, f.AuthOpts())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	f.InjectError(http.MethodPost, "/v3/services", http.StatusInternalServerError)
+	status, err := os.EnsureRegistration(ctx, log, openstack.Registration{
+		Service:   openstack.Service{Name: "nova", Type: "compute", Enabled: true},
+		Endpoints: map[string]string{"public": "http://nova-public"},
+		User:      &openstack.User{Name: "nova", ProjectID: "service"},
+		Roles:     []string{"missing"},
+	})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(status.IsReady()).To(BeFalse())
+
+	conditions := condition.Conditions{}
+	status.MarkConditions(&conditions)
+	g.Expect(conditions.IsFalse(condition.KeystoneServiceReadyCondition)).To(BeTrue())
+	g.Expect(conditions.Get(condition.KeystoneEndpointReadyCondition).Message).To(
+		ContainSubstring("endpoint public: service nova not registered"))
+	g.Expect(conditions.IsTrue(openstack.KeystoneUserReadyCondition)).To(BeTrue())
+	g.Expect(conditions.IsFalse(openstack.KeystoneRoleAssignmentReadyCondition)).To(BeTrue())
+}
@@ -98,40 +98,8 @@ func (o *OpenStack) AssignUserRole(
 	userID string,
 	projectID string,
 ) error {
-	role, err := o.GetRole(ctx, log, roleName)
-	if err != nil {
-		return err
-	}
-
-	// validate if user is already assigned to role
-	listAssignmentsOpts := roles.ListAssignmentsOpts{
-		ScopeProjectID: projectID,
-		UserID:         userID,
-		RoleID:         role.ID,
-	}
-	allPages, err := roles.ListAssignments(o.osclient, listAssignmentsOpts).AllPages(ctx)
-	if err != nil {
-		return err
-	}
-
-	assignUser, err := allPages.IsEmpty()
-	if err != nil {
-		return err
-	}
-
-	if assignUser {
-		log.Info(fmt.Sprintf("Assigning userID %s to role %s - %s", userID, role.Name, role.ID))
-
-		err = roles.Assign(ctx, o.osclient, role.ID, roles.AssignOpts{
-			UserID:    userID,
-			ProjectID: projectID,
-		}).ExtractErr()
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	_, err := o.ensureUserRole(ctx, log, roleName, userID, projectID, "")
+	return err
 }
 
 // AssignUserDomainRole - adds user with userID and domainID to role with roleName
@@ -142,38 +110,60 @@ func (o *OpenStack) AssignUserDomainRole(
 	userID string,
 	domainID string,
 ) error {
+	_, err := o.ensureUserRole(ctx, log, roleName, userID, "", domainID)
+	return err
+}
+
+// ensureUserRole - assigns the role with roleName to the user in the
+// project, or in the domain if projectID is empty. Returns true if the
+// role got assigned, false if the user already had it.
+func (o *OpenStack) ensureUserRole(
+	ctx context.Context,
+	log logr.Logger,
+	roleName string,
+	userID string,
+	projectID string,
+	domainID string,
+) (bool, error) {
 	role, err := o.GetRole(ctx, log, roleName)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// validate if user is already assigned to role
 	listAssignmentsOpts := roles.ListAssignmentsOpts{
-		ScopeDomainID: domainID,
-		UserID:        userID,
-		RoleID:        role.ID,
+		UserID: userID,
+		RoleID: role.ID,
+	}
+	assignOpts := roles.AssignOpts{
+		UserID: userID,
+	}
+	if projectID != "" {
+		listAssignmentsOpts.ScopeProjectID = projectID
+		assignOpts.ProjectID = projectID
+	} else {
+		listAssignmentsOpts.ScopeDomainID = domainID
+		assignOpts.DomainID = domainID
 	}
 	allPages, err := roles.ListAssignments(o.osclient, listAssignmentsOpts).AllPages(ctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	assignUser, err := allPages.IsEmpty()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	if assignUser {
-		log.Info(fmt.Sprintf("Assigning userID %s to role %s - %s", userID, role.Name, role.ID))
+	if !assignUser {
+		return false, nil
+	}
 
-		err = roles.Assign(ctx, o.osclient, role.ID, roles.AssignOpts{
-			UserID:   userID,
-			DomainID: domainID,
-		}).ExtractErr()
-		if err != nil {
-			return err
-		}
+	log.Info(fmt.Sprintf("Assigning userID %s to role %s - %s", userID, role.Name, role.ID))
+	err = roles.Assign(ctx, o.osclient, role.ID, assignOpts).ExtractErr()
+	if err != nil {
+		return false, err
 	}
 
-	return nil
+	return true, nil
 }
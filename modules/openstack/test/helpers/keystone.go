@@ -0,0 +1,796 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helpers provides test doubles for the OpenStack APIs used by the
+// openstack module
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+)
+
+const (
+	// KeystoneFixtureToken - token issued by the KeystoneAPIFixture
+	KeystoneFixtureToken = "fixture-token"
+	// KeystoneFixtureUsername - user accepted by the KeystoneAPIFixture
+	KeystoneFixtureUsername = "admin"
+	// KeystoneFixturePassword - password accepted by the KeystoneAPIFixture
+	KeystoneFixturePassword = "12345678"
+)
+
+// KeystoneService - a service registered in the KeystoneAPIFixture
+type KeystoneService struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// KeystoneEndpoint - an endpoint registered in the KeystoneAPIFixture
+type KeystoneEndpoint struct {
+	ID        string `json:"id"`
+	Interface string `json:"interface"`
+	Name      string `json:"name,omitempty"`
+	Region    string `json:"region"`
+	RegionID  string `json:"region_id"`
+	ServiceID string `json:"service_id"`
+	URL       string `json:"url"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// KeystoneUser - a user registered in the KeystoneAPIFixture
+type KeystoneUser struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	DomainID         string `json:"domain_id"`
+	DefaultProjectID string `json:"default_project_id,omitempty"`
+	Enabled          bool   `json:"enabled"`
+	Password         string `json:"password,omitempty"`
+}
+
+// KeystoneRole - a role registered in the KeystoneAPIFixture
+type KeystoneRole struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// KeystoneRoleAssignment - a role assignment of a user in a project or
+// domain of the KeystoneAPIFixture
+type KeystoneRoleAssignment struct {
+	RoleID    string
+	UserID    string
+	ProjectID string
+	DomainID  string
+}
+
+// KeystoneLimit - a project limit registered in the KeystoneAPIFixture
+type KeystoneLimit struct {
+	ID            string `json:"id"`
+	ProjectID     string `json:"project_id"`
+	ServiceID     string `json:"service_id"`
+	RegionID      string `json:"region_id,omitempty"`
+	ResourceName  string `json:"resource_name"`
+	ResourceLimit int    `json:"resource_limit"`
+}
+
+type injectedError struct {
+	method string
+	path   string
+	status int
+}
+
+// KeystoneAPIFixture - minimal fake keystone v3 API serving version
+// discovery, password token issuance with a catalog pointing to itself, and
+// CRUD of services and endpoints. It allows to test the endpoint
+// registration code paths of the openstack module without a control plane.
+// Users, roles, role assignments and project limits are served as well to
+// cover the service user and project setup. A NovaAPIFixture started for
+// the fixture adds a compute endpoint to the catalog.
+//
+// Example usage:
+//
+//	f := helpers.NewKeystoneAPIFixture("regionOne")
+//	defer f.Close()
+//	os, err := openstack.NewOpenStack(ctx, log, f.AuthOpts())
+type KeystoneAPIFixture struct {
+	Server *httptest.Server
+	Region string
+
+	lock       sync.Mutex
+	nextID     int
+	services   map[string]KeystoneService
+	endpoints  map[string]KeystoneEndpoint
+	users      map[string]KeystoneUser
+	roles      map[string]KeystoneRole
+	assigned   []KeystoneRoleAssignment
+	limits     map[string]KeystoneLimit
+	computeURL string
+	errors     []injectedError
+	requests   []string
+}
+
+// NewKeystoneAPIFixture - starts a KeystoneAPIFixture whose identity
+// endpoints are registered in the given region
+func NewKeystoneAPIFixture(region string) *KeystoneAPIFixture {
+	f := &KeystoneAPIFixture{
+		Region:    region,
+		services:  map[string]KeystoneService{},
+		endpoints: map[string]KeystoneEndpoint{},
+		users:     map[string]KeystoneUser{},
+		roles:     map[string]KeystoneRole{},
+		limits:    map[string]KeystoneLimit{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+
+	return f
+}
+
+// Close - stops the server of the fixture
+func (f *KeystoneAPIFixture) Close() {
+	f.Server.Close()
+}
+
+// AuthURL - returns the keystone URL to authenticate against
+func (f *KeystoneAPIFixture) AuthURL() string {
+	return f.Server.URL + "/v3"
+}
+
+// AuthOpts - returns openstack.AuthOpts which authenticate against the
+// fixture in its region
+func (f *KeystoneAPIFixture) AuthOpts() openstack.AuthOpts {
+	return openstack.AuthOpts{
+		AuthURL:    f.AuthURL(),
+		Username:   KeystoneFixtureUsername,
+		Password:   KeystoneFixturePassword,
+		TenantName: "admin",
+		DomainName: "Default",
+		Region:     f.Region,
+	}
+}
+
+// AddService - registers a service in the fixture and returns its ID
+func (f *KeystoneAPIFixture) AddService(s KeystoneService) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if s.ID == "" {
+		s.ID = f.newID()
+	}
+	f.services[s.ID] = s
+
+	return s.ID
+}
+
+// AddEndpoint - registers an endpoint in the fixture and returns its ID
+func (f *KeystoneAPIFixture) AddEndpoint(e KeystoneEndpoint) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if e.ID == "" {
+		e.ID = f.newID()
+	}
+	f.endpoints[e.ID] = e
+
+	return e.ID
+}
+
+// GetServices - returns the registered services sorted by ID
+func (f *KeystoneAPIFixture) GetServices() []KeystoneService {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	services := []KeystoneService{}
+	for _, s := range f.services {
+		services = append(services, s)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+
+	return services
+}
+
+// GetEndpoints - returns the registered endpoints of the service sorted by
+// ID, all endpoints if serviceID is empty
+func (f *KeystoneAPIFixture) GetEndpoints(serviceID string) []KeystoneEndpoint {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	endpoints := []KeystoneEndpoint{}
+	for _, e := range f.endpoints {
+		if serviceID == "" || e.ServiceID == serviceID {
+			endpoints = append(endpoints, e)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].ID < endpoints[j].ID })
+
+	return endpoints
+}
+
+// AddUser - registers a user in the fixture and returns its ID
+func (f *KeystoneAPIFixture) AddUser(u KeystoneUser) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if u.ID == "" {
+		u.ID = f.newID()
+	}
+	f.users[u.ID] = u
+
+	return u.ID
+}
+
+// AddRole - registers a role in the fixture and returns its ID
+func (f *KeystoneAPIFixture) AddRole(name string) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id := f.newID()
+	f.roles[id] = KeystoneRole{ID: id, Name: name}
+
+	return id
+}
+
+// GetUsers - returns the registered users sorted by ID
+func (f *KeystoneAPIFixture) GetUsers() []KeystoneUser {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	users := []KeystoneUser{}
+	for _, u := range f.users {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	return users
+}
+
+// GetRoleAssignments - returns the role assignments of the user
+func (f *KeystoneAPIFixture) GetRoleAssignments(userID string) []KeystoneRoleAssignment {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	assignments := []KeystoneRoleAssignment{}
+	for _, a := range f.assigned {
+		if a.UserID == userID {
+			assignments = append(assignments, a)
+		}
+	}
+
+	return assignments
+}
+
+// GetLimits - returns the project limits sorted by resource name
+func (f *KeystoneAPIFixture) GetLimits(projectID string) []KeystoneLimit {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	result := []KeystoneLimit{}
+	for _, l := range f.limits {
+		if l.ProjectID == projectID {
+			result = append(result, l)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ResourceName < result[j].ResourceName })
+
+	return result
+}
+
+// InjectError - makes the next request with the method to a path starting
+// with the prefix fail with the given HTTP status, e.g.
+// InjectError(http.MethodPost, "/v3/endpoints", http.StatusInternalServerError)
+func (f *KeystoneAPIFixture) InjectError(method string, pathPrefix string, status int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.errors = append(f.errors, injectedError{method: method, path: pathPrefix, status: status})
+}
+
+// GetRequests - returns the "METHOD path" of all requests served so far
+func (f *KeystoneAPIFixture) GetRequests() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([]string{}, f.requests...)
+}
+
+// setComputeURL - makes the catalog point the compute endpoints to the URL
+func (f *KeystoneAPIFixture) setComputeURL(url string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.computeURL = url
+}
+
+// newID - returns a new unique ID, the lock must be held
+func (f *KeystoneAPIFixture) newID() string {
+	f.nextID++
+	return fmt.Sprintf("%032x", f.nextID)
+}
+
+func (f *KeystoneAPIFixture) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	for i, e := range f.errors {
+		if e.method == r.Method && strings.HasPrefix(r.URL.Path, e.path) {
+			f.errors = append(f.errors[:i], f.errors[i+1:]...)
+			writeError(w, e.status, "injected error")
+			return
+		}
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		f.handleVersions(w)
+	case path == "/v3/auth/tokens" && r.Method == http.MethodPost:
+		f.handleTokens(w, r)
+	case r.Header.Get("X-Auth-Token") != KeystoneFixtureToken:
+		writeError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+	case strings.HasPrefix(path, "/v3/services"):
+		f.handleServices(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "/v3/services"), "/"))
+	case strings.HasPrefix(path, "/v3/endpoints"):
+		f.handleEndpoints(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "/v3/endpoints"), "/"))
+	case strings.HasPrefix(path, "/v3/users"):
+		f.handleUsers(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "/v3/users"), "/"))
+	case strings.HasPrefix(path, "/v3/limits"):
+		f.handleLimits(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "/v3/limits"), "/"))
+	case path == "/v3/roles":
+		f.handleRoles(w, r)
+	case path == "/v3/role_assignments" && r.Method == http.MethodGet:
+		f.handleRoleAssignments(w, r)
+	case (strings.HasPrefix(path, "/v3/projects/") || strings.HasPrefix(path, "/v3/domains/")) && r.Method == http.MethodPut:
+		f.handleAssign(w, path)
+	default:
+		writeError(w, http.StatusNotFound, "Could not find "+r.URL.Path)
+	}
+}
+
+func (f *KeystoneAPIFixture) handleVersions(w http.ResponseWriter) {
+	writeJSON(w, http.StatusMultipleChoices, map[string]interface{}{
+		"versions": map[string]interface{}{
+			"values": []interface{}{
+				map[string]interface{}{
+					"id":     "v3.14",
+					"status": "stable",
+					"links": []interface{}{
+						map[string]interface{}{"rel": "self", "href": f.Server.URL + "/v3/"},
+					},
+				},
+			},
+		},
+	})
+}
+
+func (f *KeystoneAPIFixture) handleTokens(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Auth struct {
+			Identity struct {
+				Password struct {
+					User struct {
+						Name     string `json:"name"`
+						Password string `json:"password"`
+					} `json:"user"`
+				} `json:"password"`
+			} `json:"identity"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	user := req.Auth.Identity.Password.User
+	if user.Name != KeystoneFixtureUsername || user.Password != KeystoneFixturePassword {
+		writeError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+		return
+	}
+
+	endpoints := []interface{}{}
+	computeEndpoints := []interface{}{}
+	for _, iface := range []string{"admin", "internal", "public"} {
+		endpoints = append(endpoints, map[string]interface{}{
+			"id":        "identity-" + iface,
+			"interface": iface,
+			"region":    f.Region,
+			"region_id": f.Region,
+			"url":       f.AuthURL(),
+		})
+		if f.computeURL != "" {
+			computeEndpoints = append(computeEndpoints, map[string]interface{}{
+				"id":        "compute-" + iface,
+				"interface": iface,
+				"region":    f.Region,
+				"region_id": f.Region,
+				"url":       f.computeURL,
+			})
+		}
+	}
+	catalog := []interface{}{
+		map[string]interface{}{
+			"id":        "identity",
+			"type":      "identity",
+			"name":      "keystone",
+			"endpoints": endpoints,
+		},
+	}
+	if len(computeEndpoints) > 0 {
+		catalog = append(catalog, map[string]interface{}{
+			"id":        "compute",
+			"type":      "compute",
+			"name":      "nova",
+			"endpoints": computeEndpoints,
+		})
+	}
+
+	now := time.Now().UTC()
+	w.Header().Set("X-Subject-Token", KeystoneFixtureToken)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"token": map[string]interface{}{
+			"methods":    []string{"password"},
+			"issued_at":  now.Format(time.RFC3339Nano),
+			"expires_at": now.Add(time.Hour).Format(time.RFC3339Nano),
+			"user": map[string]interface{}{
+				"id":   "admin",
+				"name": user.Name,
+			},
+			"catalog": catalog,
+		},
+	})
+}
+
+func (f *KeystoneAPIFixture) handleServices(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		services := []KeystoneService{}
+		for _, s := range f.services {
+			if t := query.Get("type"); t != "" && s.Type != t {
+				continue
+			}
+			if n := query.Get("name"); n != "" && s.Name != n {
+				continue
+			}
+			services = append(services, s)
+		}
+		sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+		writeJSON(w, http.StatusOK, map[string]interface{}{"services": services})
+	case id == "" && r.Method == http.MethodPost:
+		var req struct {
+			Service KeystoneService `json:"service"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		req.Service.ID = f.newID()
+		f.services[req.Service.ID] = req.Service
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"service": req.Service})
+	default:
+		s, ok := f.services[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Could not find service: "+id)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"service": s})
+		case http.MethodPatch:
+			req := struct {
+				Service *KeystoneService `json:"service"`
+			}{Service: &s}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			s.ID = id
+			f.services[id] = s
+			writeJSON(w, http.StatusOK, map[string]interface{}{"service": s})
+		case http.MethodDelete:
+			delete(f.services, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+		}
+	}
+}
+
+func (f *KeystoneAPIFixture) handleEndpoints(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		endpoints := []KeystoneEndpoint{}
+		for _, e := range f.endpoints {
+			if s := query.Get("service_id"); s != "" && e.ServiceID != s {
+				continue
+			}
+			if i := query.Get("interface"); i != "" && e.Interface != i {
+				continue
+			}
+			if rg := query.Get("region_id"); rg != "" && e.RegionID != rg {
+				continue
+			}
+			endpoints = append(endpoints, e)
+		}
+		sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].ID < endpoints[j].ID })
+		writeJSON(w, http.StatusOK, map[string]interface{}{"endpoints": endpoints})
+	case id == "" && r.Method == http.MethodPost:
+		var req struct {
+			Endpoint KeystoneEndpoint `json:"endpoint"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, ok := f.services[req.Endpoint.ServiceID]; !ok {
+			writeError(w, http.StatusBadRequest, "Could not find service: "+req.Endpoint.ServiceID)
+			return
+		}
+		req.Endpoint.ID = f.newID()
+		req.Endpoint.RegionID = req.Endpoint.Region
+		req.Endpoint.Enabled = true
+		f.endpoints[req.Endpoint.ID] = req.Endpoint
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"endpoint": req.Endpoint})
+	default:
+		e, ok := f.endpoints[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Could not find endpoint: "+id)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"endpoint": e})
+		case http.MethodPatch:
+			req := struct {
+				Endpoint *KeystoneEndpoint `json:"endpoint"`
+			}{Endpoint: &e}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			e.ID = id
+			e.RegionID = e.Region
+			f.endpoints[id] = e
+			writeJSON(w, http.StatusOK, map[string]interface{}{"endpoint": e})
+		case http.MethodDelete:
+			delete(f.endpoints, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+		}
+	}
+}
+
+func (f *KeystoneAPIFixture) handleUsers(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		users := []KeystoneUser{}
+		for _, u := range f.users {
+			if n := query.Get("name"); n != "" && u.Name != n {
+				continue
+			}
+			if d := query.Get("domain_id"); d != "" && u.DomainID != d {
+				continue
+			}
+			users = append(users, u)
+		}
+		sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+		writeJSON(w, http.StatusOK, map[string]interface{}{"users": users})
+	case id == "" && r.Method == http.MethodPost:
+		req := struct {
+			User KeystoneUser `json:"user"`
+		}{User: KeystoneUser{Enabled: true}}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		req.User.ID = f.newID()
+		f.users[req.User.ID] = req.User
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"user": req.User})
+	default:
+		u, ok := f.users[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Could not find user: "+id)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"user": u})
+		case http.MethodPatch:
+			req := struct {
+				User *KeystoneUser `json:"user"`
+			}{User: &u}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			u.ID = id
+			f.users[id] = u
+			writeJSON(w, http.StatusOK, map[string]interface{}{"user": u})
+		case http.MethodDelete:
+			delete(f.users, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+		}
+	}
+}
+
+func (f *KeystoneAPIFixture) handleRoles(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		roles := []KeystoneRole{}
+		for _, role := range f.roles {
+			if n := r.URL.Query().Get("name"); n != "" && role.Name != n {
+				continue
+			}
+			roles = append(roles, role)
+		}
+		sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+		writeJSON(w, http.StatusOK, map[string]interface{}{"roles": roles})
+	case http.MethodPost:
+		var req struct {
+			Role KeystoneRole `json:"role"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		req.Role.ID = f.newID()
+		f.roles[req.Role.ID] = req.Role
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"role": req.Role})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+func (f *KeystoneAPIFixture) handleRoleAssignments(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	assignments := []interface{}{}
+	for _, a := range f.assigned {
+		if u := query.Get("user.id"); u != "" && a.UserID != u {
+			continue
+		}
+		if role := query.Get("role.id"); role != "" && a.RoleID != role {
+			continue
+		}
+		if p := query.Get("scope.project.id"); p != "" && a.ProjectID != p {
+			continue
+		}
+		if d := query.Get("scope.domain.id"); d != "" && a.DomainID != d {
+			continue
+		}
+		scope := map[string]interface{}{}
+		if a.ProjectID != "" {
+			scope["project"] = map[string]interface{}{"id": a.ProjectID}
+		} else {
+			scope["domain"] = map[string]interface{}{"id": a.DomainID}
+		}
+		assignments = append(assignments, map[string]interface{}{
+			"role":  map[string]interface{}{"id": a.RoleID},
+			"user":  map[string]interface{}{"id": a.UserID},
+			"scope": scope,
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"role_assignments": assignments})
+}
+
+// handleAssign - serves PUT /v3/{projects,domains}/<id>/users/<user>/roles/<role>
+func (f *KeystoneAPIFixture) handleAssign(w http.ResponseWriter, path string) {
+	parts := strings.Split(strings.TrimPrefix(path, "/v3/"), "/")
+	if len(parts) != 6 || parts[2] != "users" || parts[4] != "roles" {
+		writeError(w, http.StatusNotFound, "Could not find "+path)
+		return
+	}
+	if _, ok := f.roles[parts[5]]; !ok {
+		writeError(w, http.StatusNotFound, "Could not find role: "+parts[5])
+		return
+	}
+	a := KeystoneRoleAssignment{RoleID: parts[5], UserID: parts[3]}
+	if parts[0] == "projects" {
+		a.ProjectID = parts[1]
+	} else {
+		a.DomainID = parts[1]
+	}
+	for _, existing := range f.assigned {
+		if existing == a {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	f.assigned = append(f.assigned, a)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *KeystoneAPIFixture) handleLimits(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		query := r.URL.Query()
+		result := []KeystoneLimit{}
+		for _, l := range f.limits {
+			if p := query.Get("project_id"); p != "" && l.ProjectID != p {
+				continue
+			}
+			if s := query.Get("service_id"); s != "" && l.ServiceID != s {
+				continue
+			}
+			if rg := query.Get("region_id"); rg != "" && l.RegionID != rg {
+				continue
+			}
+			if n := query.Get("resource_name"); n != "" && l.ResourceName != n {
+				continue
+			}
+			result = append(result, l)
+		}
+		sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+		writeJSON(w, http.StatusOK, map[string]interface{}{"limits": result})
+	case id == "" && r.Method == http.MethodPost:
+		var req struct {
+			Limits []KeystoneLimit `json:"limits"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for i := range req.Limits {
+			req.Limits[i].ID = f.newID()
+			f.limits[req.Limits[i].ID] = req.Limits[i]
+		}
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"limits": req.Limits})
+	case r.Method == http.MethodPatch:
+		l, ok := f.limits[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Could not find limit: "+id)
+			return
+		}
+		req := struct {
+			Limit *KeystoneLimit `json:"limit"`
+		}{Limit: &l}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		l.ID = id
+		f.limits[id] = l
+		writeJSON(w, http.StatusOK, map[string]interface{}{"limit": l})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"message": message,
+			"title":   http.StatusText(status),
+		},
+	})
+}
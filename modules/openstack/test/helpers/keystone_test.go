@@ -0,0 +1,113 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+)
+
+func TestKeystoneAPIFixtureRegisterServiceEndpoints(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+
+	f := NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+
+	m, err := openstack.NewMultiRegion(ctx, log, []openstack.KeystoneTarget{
+		{Name: "regionOne", AuthOpts: f.AuthOpts()},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(m.GetClient("regionOne")).ToNot(BeNil())
+
+	s := openstack.Service{Name: "glance", Type: "image", Enabled: true}
+	err = m.RegisterServiceEndpoints(ctx, log, s, map[string]map[string]string{
+		"": {
+			"internal": "http://glance-internal",
+			"public":   "http://glance-public",
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(m.IsReady()).To(BeTrue())
+
+	services := f.GetServices()
+	g.Expect(services).To(HaveLen(1))
+	g.Expect(services[0].Name).To(Equal("glance"))
+	g.Expect(services[0].Type).To(Equal("image"))
+
+	endpoints := f.GetEndpoints(services[0].ID)
+	g.Expect(endpoints).To(HaveLen(2))
+	for _, e := range endpoints {
+		g.Expect(e.Region).To(Equal("regionOne"))
+		g.Expect(e.URL).To(Equal("http://glance-" + e.Interface))
+	}
+
+	// re-registering with a changed URL updates the existing endpoint
+	err = m.RegisterServiceEndpoints(ctx, log, s, map[string]map[string]string{
+		"": {
+			"internal": "http://glance-internal",
+			"public":   "https://glance-public",
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(f.GetServices()).To(HaveLen(1))
+	endpoints = f.GetEndpoints(services[0].ID)
+	g.Expect(endpoints).To(HaveLen(2))
+	g.Expect(endpoints).To(ContainElement(HaveField("URL", "https://glance-public")))
+
+	err = m.DeleteServiceEndpoints(ctx, log, s, true)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(f.GetServices()).To(BeEmpty())
+	g.Expect(f.GetEndpoints("")).To(BeEmpty())
+}
+
+func TestKeystoneAPIFixtureAuthFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	f := NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+
+	opts := f.AuthOpts()
+	opts.Password = "wrong"
+	_, err := openstack.NewOpenStack(context.TODO(), logr.Discard(), opts)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestKeystoneAPIFixtureInjectError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+
+	f := NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+
+	os, err := openstack.NewOpenStack(ctx, log, f.AuthOpts())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	f.InjectError(http.MethodPost, "/v3/services", http.StatusInternalServerError)
+	_, err = os.CreateService(ctx, log, openstack.Service{Name: "nova", Type: "compute"})
+	g.Expect(err).To(HaveOccurred())
+
+	// the error is only injected once
+	_, err = os.CreateService(ctx, log, openstack.Service{Name: "nova", Type: "compute"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(f.GetRequests()).To(ContainElement("POST /v3/services"))
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NovaAggregate - a host aggregate registered in the NovaAPIFixture
+type NovaAggregate struct {
+	ID               int               `json:"id"`
+	Name             string            `json:"name"`
+	AvailabilityZone string            `json:"availability_zone,omitempty"`
+	Hosts            []string          `json:"hosts"`
+	Metadata         map[string]string `json:"metadata"`
+}
+
+// DefaultComputeQuotas - the compute quotas of projects which got none set
+var DefaultComputeQuotas = map[string]int{"instances": 10, "cores": 20, "ram": 51200}
+
+// NovaAPIFixture - minimal fake nova v2.1 API serving the quota sets of
+// projects and the host aggregates. It registers itself as the compute
+// endpoint in the catalog of the KeystoneAPIFixture it got started for and
+// accepts the tokens issued by it.
+//
+// Example usage:
+//
+//	f := helpers.NewKeystoneAPIFixture("regionOne")
+//	defer f.Close()
+//	nova := helpers.NewNovaAPIFixture(f)
+//	defer nova.Close()
+//	os, err := openstack.GetNovaOpenStackClient(ctx, log, f.AuthOpts(), endpointOpts)
+type NovaAPIFixture struct {
+	Server *httptest.Server
+
+	lock   sync.Mutex
+	nextID int
+	quotas map[string]map[string]int
+	aggs   map[int]*NovaAggregate
+}
+
+// NewNovaAPIFixture - starts a NovaAPIFixture and adds it as compute
+// endpoint to the catalog of the keystone fixture
+func NewNovaAPIFixture(keystone *KeystoneAPIFixture) *NovaAPIFixture {
+	f := &NovaAPIFixture{
+		quotas: map[string]map[string]int{},
+		aggs:   map[int]*NovaAggregate{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	keystone.setComputeURL(f.ComputeURL())
+
+	return f
+}
+
+// Close - stops the server of the fixture
+func (f *NovaAPIFixture) Close() {
+	f.Server.Close()
+}
+
+// ComputeURL - returns the URL of the compute endpoint
+func (f *NovaAPIFixture) ComputeURL() string {
+	return f.Server.URL + "/v2.1"
+}
+
+// GetAggregates - returns the host aggregates sorted by ID
+func (f *NovaAPIFixture) GetAggregates() []NovaAggregate {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	result := []NovaAggregate{}
+	for _, a := range f.aggs {
+		result = append(result, *a)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+
+	return result
+}
+
+// GetComputeQuotas - returns the compute quotas of the project
+func (f *NovaAPIFixture) GetComputeQuotas(projectID string) map[string]int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.computeQuotas(projectID)
+}
+
+// computeQuotas - returns the compute quotas of the project, the lock must
+// be held
+func (f *NovaAPIFixture) computeQuotas(projectID string) map[string]int {
+	quotas := map[string]int{}
+	for k, v := range DefaultComputeQuotas {
+		quotas[k] = v
+	}
+	for k, v := range f.quotas[projectID] {
+		quotas[k] = v
+	}
+	return quotas
+}
+
+func (f *NovaAPIFixture) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case r.Header.Get("X-Auth-Token") != KeystoneFixtureToken:
+		writeError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+	case strings.HasPrefix(path, "/v2.1/os-quota-sets/"):
+		f.handleComputeQuotas(w, r, strings.TrimPrefix(path, "/v2.1/os-quota-sets/"))
+	case strings.HasPrefix(path, "/v2.1/os-aggregates"):
+		f.handleAggregates(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "/v2.1/os-aggregates"), "/"))
+	default:
+		writeError(w, http.StatusNotFound, "Could not find "+r.URL.Path)
+	}
+}
+
+func (f *NovaAPIFixture) handleComputeQuotas(w http.ResponseWriter, r *http.Request, projectID string) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"quota_set": f.computeQuotas(projectID)})
+	case http.MethodPut:
+		var req struct {
+			QuotaSet map[string]int `json:"quota_set"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if f.quotas[projectID] == nil {
+			f.quotas[projectID] = map[string]int{}
+		}
+		for k, v := range req.QuotaSet {
+			f.quotas[projectID][k] = v
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"quota_set": f.computeQuotas(projectID)})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+func (f *NovaAPIFixture) handleAggregates(w http.ResponseWriter, r *http.Request, path string) {
+	if path == "" {
+		switch r.Method {
+		case http.MethodGet:
+			result := []NovaAggregate{}
+			for _, a := range f.aggs {
+				result = append(result, *a)
+			}
+			sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+			writeJSON(w, http.StatusOK, map[string]interface{}{"aggregates": result})
+		case http.MethodPost:
+			var req struct {
+				Aggregate NovaAggregate `json:"aggregate"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			f.nextID++
+			a := &NovaAggregate{
+				ID:               f.nextID,
+				Name:             req.Aggregate.Name,
+				AvailabilityZone: req.Aggregate.AvailabilityZone,
+				Hosts:            []string{},
+				Metadata:         map[string]string{},
+			}
+			if a.AvailabilityZone != "" {
+				a.Metadata["availability_zone"] = a.AvailabilityZone
+			}
+			f.aggs[a.ID] = a
+			writeJSON(w, http.StatusOK, map[string]interface{}{"aggregate": a})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+		}
+		return
+	}
+
+	var id int
+	if _, err := fmt.Sscanf(strings.TrimSuffix(path, "/action"), "%d", &id); err != nil || f.aggs[id] == nil {
+		writeError(w, http.StatusNotFound, "Could not find aggregate: "+path)
+		return
+	}
+	a := f.aggs[id]
+
+	switch {
+	case r.Method == http.MethodDelete:
+		if len(a.Hosts) > 0 {
+			writeError(w, http.StatusBadRequest, "aggregate has hosts")
+			return
+		}
+		delete(f.aggs, id)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/action"):
+		var req struct {
+			AddHost *struct {
+				Host string `json:"host"`
+			} `json:"add_host"`
+			RemoveHost *struct {
+				Host string `json:"host"`
+			} `json:"remove_host"`
+			SetMetadata *struct {
+				Metadata map[string]*string `json:"metadata"`
+			} `json:"set_metadata"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		switch {
+		case req.AddHost != nil:
+			a.Hosts = append(a.Hosts, req.AddHost.Host)
+		case req.RemoveHost != nil:
+			hosts := []string{}
+			for _, h := range a.Hosts {
+				if h != req.RemoveHost.Host {
+					hosts = append(hosts, h)
+				}
+			}
+			a.Hosts = hosts
+		case req.SetMetadata != nil:
+			for k, v := range req.SetMetadata.Metadata {
+				if v == nil {
+					delete(a.Metadata, k)
+					continue
+				}
+				a.Metadata[k] = *v
+			}
+			a.AvailabilityZone = a.Metadata["availability_zone"]
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"aggregate": a})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 Red Hat
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	gophercloud "github.com/gophercloud/gophercloud/v2"
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+
+	"github.com/openstack-k8s-operators/lib-common/modules/openstack"
+)
+
+func TestNovaAPIFixtureCatalog(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	log := logr.Discard()
+	endpointOpts := gophercloud.EndpointOpts{Region: "regionOne", Availability: gophercloud.AvailabilityInternal}
+
+	f := NewKeystoneAPIFixture("regionOne")
+	defer f.Close()
+
+	// without a nova fixture the catalog has no compute endpoint
+	_, err := openstack.GetNovaOpenStackClient(ctx, log, f.AuthOpts(), endpointOpts)
+	g.Expect(err).To(HaveOccurred())
+
+	nova := NewNovaAPIFixture(f)
+	defer nova.Close()
+
+	os, err := openstack.GetNovaOpenStackClient(ctx, log, f.AuthOpts(), endpointOpts)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = os.EnsureAvailabilityZone(ctx, log, "edge1", []string{"compute-0"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(nova.GetAggregates()).To(HaveLen(1))
+	g.Expect(f.GetRequests()).ToNot(ContainElement(ContainSubstring("os-aggregates")))
+}
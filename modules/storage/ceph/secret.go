@@ -0,0 +1,197 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceph
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// keyLength - length of a decoded cephx key: type, creation time, length
+// and the 16 byte secret
+const keyLength = 28
+
+var (
+	confKeyRegexp    = regexp.MustCompile(`^([A-Za-z0-9_-]+)\.conf$`)
+	keyringKeyRegexp = regexp.MustCompile(`^([A-Za-z0-9_-]+)\.client\.([A-Za-z0-9_.-]+)\.keyring$`)
+	fsidRegexp       = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// ClusterSummary - a ceph cluster found in a secret
+type ClusterSummary struct {
+	// Name - cluster name, the prefix of the conf and keyring keys
+	Name string
+	// FSID - fsid of the cluster
+	FSID string
+	// MonHosts - the mon_host of the cluster
+	MonHosts string
+	// Users - the client users with a keyring
+	Users []string
+}
+
+// SecretSummary - the result of ValidateSecretData
+type SecretSummary struct {
+	// Clusters - the clusters with a conf file, sorted by name
+	Clusters []ClusterSummary
+	// Errors - the problems found
+	Errors []string
+}
+
+// Valid - returns true if no problems were found
+func (s SecretSummary) Valid() bool {
+	return len(s.Errors) == 0
+}
+
+// String - returns the summary in a form suitable for a condition message
+func (s SecretSummary) String() string {
+	if !s.Valid() {
+		return strings.Join(s.Errors, "; ")
+	}
+	clusters := []string{}
+	for _, c := range s.Clusters {
+		clusters = append(clusters, fmt.Sprintf("%s (fsid %s, users %s)", c.Name, c.FSID, strings.Join(c.Users, ",")))
+	}
+	return "ceph clusters " + strings.Join(clusters, ", ")
+}
+
+// ValidateSecretData - validates the data of a user provided ceph secret,
+// holding the <cluster>.conf and <cluster>.client.<user>.keyring files
+// mounted into /etc/ceph of the glance, cinder and nova pods. Keys with other
+// names are ignored. Checks:
+// - there is at least one conf and one keyring
+// - each conf has a [global] section with a valid fsid and mon_host
+// - each keyring has a [client.<user>] section with a valid cephx key
+// - each keyring has the conf of its cluster
+//
+// Example:
+//
+//	summary := ceph.ValidateSecretData(secret.Data)
+//	if !summary.Valid() {
+//		instance.Status.Conditions.Set(condition.FalseCondition(
+//			condition.InputReadyCondition,
+//			condition.ErrorReason,
+//			condition.SeverityWarning,
+//			condition.InputReadyErrorMessage,
+//			summary.String()))
+//	}
+func ValidateSecretData(data map[string][]byte) SecretSummary {
+	summary := SecretSummary{Errors: []string{}}
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	clusters := map[string]*ClusterSummary{}
+	keyrings := 0
+	for _, k := range keys {
+		m := confKeyRegexp.FindStringSubmatch(k)
+		if m == nil {
+			continue
+		}
+		global := parseSections(string(data[k]))["global"]
+		c := &ClusterSummary{
+			Name:     m[1],
+			FSID:     global["fsid"],
+			MonHosts: global["mon_host"],
+			Users:    []string{},
+		}
+		if !fsidRegexp.MatchString(c.FSID) {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: invalid or missing fsid in [global]", k))
+		}
+		if c.MonHosts == "" {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: missing mon_host in [global]", k))
+		}
+		clusters[c.Name] = c
+		summary.Clusters = append(summary.Clusters, *c)
+	}
+
+	for _, k := range keys {
+		m := keyringKeyRegexp.FindStringSubmatch(k)
+		if m == nil {
+			continue
+		}
+		keyrings++
+		cluster, user := m[1], m[2]
+		section, ok := parseSections(string(data[k]))["client."+user]
+		if !ok {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: missing [client.%s] section", k, user))
+		} else if err := validateKey(section["key"]); err != nil {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: %s", k, err))
+		}
+		if _, ok := clusters[cluster]; !ok {
+			summary.Errors = append(summary.Errors, fmt.Sprintf("%s: missing %s.conf", k, cluster))
+			continue
+		}
+		clusters[cluster].Users = append(clusters[cluster].Users, user)
+	}
+
+	for i := range summary.Clusters {
+		summary.Clusters[i].Users = clusters[summary.Clusters[i].Name].Users
+	}
+	if len(summary.Clusters) == 0 {
+		summary.Errors = append(summary.Errors, "no <cluster>.conf found")
+	}
+	if keyrings == 0 {
+		summary.Errors = append(summary.Errors, "no <cluster>.client.<user>.keyring found")
+	}
+
+	return summary
+}
+
+// validateKey - checks key is a base64 encoded cephx key
+func validateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("missing key")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(decoded) != keyLength {
+		return fmt.Errorf("invalid key, expected the base64 encoded cephx key")
+	}
+	return nil
+}
+
+// parseSections - returns the keys of the sections of a ceph conf or keyring
+// file. Ceph treats spaces and underscores in option names the same, they
+// are returned with underscores.
+func parseSections(in string) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	var current map[string]string
+	for _, line := range strings.Split(in, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(strings.Trim(line, "[]"))
+			current = map[string]string{}
+			sections[name] = current
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if current == nil || len(kv) != 2 {
+			continue
+		}
+		name := strings.Join(strings.Fields(strings.TrimSpace(kv[0])), "_")
+		current[name] = strings.TrimSpace(kv[1])
+	}
+	return sections
+}
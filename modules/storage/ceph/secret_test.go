@@ -0,0 +1,70 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceph
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testConf = `[global]
+fsid = 5fe62cc7-0392-4a32-8466-081ce0ea970f
+mon host = [v2:192.168.122.100:3300/0,v1:192.168.122.100:6789/0]
+`
+	testKeyring = `[client.openstack]
+    key = AQCmlh5kAAAAABAAv7LYTAwVwdTQ6RV+0vmzuw==
+    caps mon = "profile rbd"
+`
+)
+
+func TestValidateSecretData(t *testing.T) {
+	g := NewWithT(t)
+
+	summary := ValidateSecretData(map[string][]byte{
+		"ceph.conf":                     []byte(testConf),
+		"ceph.client.openstack.keyring": []byte(testKeyring),
+		"other":                         []byte("ignored"),
+	})
+	g.Expect(summary.Errors).To(BeEmpty())
+	g.Expect(summary.Valid()).To(BeTrue())
+	g.Expect(summary.Clusters).To(Equal([]ClusterSummary{{
+		Name:     "ceph",
+		FSID:     "5fe62cc7-0392-4a32-8466-081ce0ea970f",
+		MonHosts: "[v2:192.168.122.100:3300/0,v1:192.168.122.100:6789/0]",
+		Users:    []string{"openstack"},
+	}}))
+	g.Expect(summary.String()).To(Equal("ceph clusters ceph (fsid 5fe62cc7-0392-4a32-8466-081ce0ea970f, users openstack)"))
+
+	summary = ValidateSecretData(map[string][]byte{
+		"az1.conf":                     []byte("[global]\nfsid = not-a-uuid\n"),
+		"az1.client.openstack.keyring": []byte("[client.openstack]\nkey = c2hvcnQ=\n"),
+		"az2.client.glance.keyring":    []byte("[client.openstack]\nkey = AQCmlh5kAAAAABAAv7LYTAwVwdTQ6RV+0vmzuw==\n"),
+	})
+	g.Expect(summary.Valid()).To(BeFalse())
+	g.Expect(summary.Errors).To(Equal([]string{
+		"az1.conf: invalid or missing fsid in [global]",
+		"az1.conf: missing mon_host in [global]",
+		"az1.client.openstack.keyring: invalid key, expected the base64 encoded cephx key",
+		"az2.client.glance.keyring: missing [client.glance] section",
+		"az2.client.glance.keyring: missing az2.conf",
+	}))
+
+	summary = ValidateSecretData(map[string][]byte{})
+	g.Expect(summary.String()).To(Equal("no <cluster>.conf found; no <cluster>.client.<user>.keyring found"))
+}
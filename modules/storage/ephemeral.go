@@ -0,0 +1,74 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewEphemeralVolume - returns a generic ephemeral Volume with a per pod PVC
+// of the requested size. If storageClassName is empty the default
+// StorageClass is used, if accessModes is empty ReadWriteOnce is used.
+func NewEphemeralVolume(
+	name string,
+	storageClassName string,
+	size resource.Quantity,
+	accessModes []corev1.PersistentVolumeAccessMode,
+	labels map[string]string,
+) Volume {
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
+	claimSpec := corev1.PersistentVolumeClaimSpec{
+		AccessModes: accessModes,
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: size,
+			},
+		},
+	}
+	if storageClassName != "" {
+		claimSpec.StorageClassName = &storageClassName
+	}
+
+	return Volume{
+		Name: name,
+		VolumeSource: VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec:       claimSpec,
+				},
+			},
+		},
+	}
+}
+
+// GetEphemeralPvcName - returns the name of the PVC kubernetes creates for a
+// generic ephemeral volume of a pod. The PVC is owned by the pod and gets
+// deleted together with the pod, no cleanup is required by the operator.
+func GetEphemeralPvcName(podName string, volumeName string) string {
+	return podName + "-" + volumeName
+}
+
+// IsEphemeral - returns true if the volume is a generic ephemeral volume
+func (s *Volume) IsEphemeral() bool {
+	return s.Ephemeral != nil
+}
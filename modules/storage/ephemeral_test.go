@@ -0,0 +1,74 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestEphemeralVolume(t *testing.T) {
+	t.Run("Render into core volume", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vol := NewEphemeralVolume("scratch", "fast", resource.MustParse("10Gi"), nil, map[string]string{"foo": "bar"})
+		g.Expect(vol.IsEphemeral()).To(BeTrue())
+
+		coreVol, err := vol.ToCoreVolume()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(coreVol.Name).To(Equal("scratch"))
+		g.Expect(coreVol.Ephemeral).ToNot(BeNil())
+
+		tmpl := coreVol.Ephemeral.VolumeClaimTemplate
+		g.Expect(tmpl.Labels).To(HaveKeyWithValue("foo", "bar"))
+		g.Expect(*tmpl.Spec.StorageClassName).To(Equal("fast"))
+		g.Expect(tmpl.Spec.AccessModes).To(Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}))
+		size := tmpl.Spec.Resources.Requests[corev1.ResourceStorage]
+		g.Expect(size.String()).To(Equal("10Gi"))
+
+		g.Expect(GetEphemeralPvcName("glance-0", "scratch")).To(Equal("glance-0-scratch"))
+	})
+
+	t.Run("Default StorageClass", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vol := NewEphemeralVolume("scratch", "", resource.MustParse("1Gi"), nil, nil)
+		g.Expect(vol.Ephemeral.VolumeClaimTemplate.Spec.StorageClassName).To(BeNil())
+	})
+
+	t.Run("Validate missing volumeClaimTemplate", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vm := VolMounts{
+			Volumes: []Volume{
+				{
+					Name:         "scratch",
+					VolumeSource: VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{}},
+				},
+			},
+			Mounts: []corev1.VolumeMount{{Name: "scratch", MountPath: "/var/tmp/scratch"}},
+		}
+		errs := vm.Validate(field.NewPath("extraVol"), nil)
+		g.Expect(errs).To(HaveLen(1))
+		g.Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+	})
+}
@@ -5,6 +5,7 @@ go 1.24.4
 require (
 	github.com/onsi/gomega v1.39.1
 	k8s.io/api v0.31.14
+	k8s.io/apimachinery v0.31.14
 )
 
 require (
@@ -24,7 +25,6 @@ require (
 	golang.org/x/text v0.33.0 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	k8s.io/apimachinery v0.31.14 // indirect
 	k8s.io/klog/v2 v2.130.1 // indirect
 	k8s.io/utils v0.0.0-20250820121507-0af2bda4dd1d // indirect; indirect // indirect
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect; indirect // indirect
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// GuardrailAction defines how a guardrail violation gets reported
+type GuardrailAction string
+
+const (
+	// GuardrailAllow - the violation is accepted silently
+	GuardrailAllow GuardrailAction = "Allow"
+	// GuardrailWarn - the violation is reported as a warning
+	GuardrailWarn GuardrailAction = "Warn"
+	// GuardrailDeny - the violation is reported as an error
+	GuardrailDeny GuardrailAction = "Deny"
+)
+
+// DefaultSensitiveHostPaths are host directories which give access to the
+// host or to other workloads when mounted into a pod. A hostPath volume
+// for one of these paths, a path below them, or a parent of them, is
+// considered sensitive. "/" only matches the host root itself.
+var DefaultSensitiveHostPaths = []string{
+	"/",
+	"/boot",
+	"/dev",
+	"/etc",
+	"/proc",
+	"/root",
+	"/run/containerd",
+	"/run/crio",
+	"/sys",
+	"/var/lib/containers",
+	"/var/lib/kubelet",
+	"/var/run/containerd",
+	"/var/run/crio",
+	"/var/run/docker.sock",
+}
+
+// MountPolicy defines the guardrails for user provided extraMounts
+// +kubebuilder:object:generate:=false
+type MountPolicy struct {
+	// HostPath - action for any hostPath volume
+	HostPath GuardrailAction
+	// SensitiveHostPath - action for hostPath volumes of SensitiveHostPaths
+	SensitiveHostPath GuardrailAction
+	// PrivilegedMount - action for mounts which require a privileged
+	// container, e.g. Bidirectional mount propagation
+	PrivilegedMount GuardrailAction
+	// SensitiveHostPaths - the sensitive host paths, DefaultSensitiveHostPaths if nil
+	SensitiveHostPaths []string
+	// AllowedHostPaths - hostPaths (and paths below) which are exempt from
+	// the HostPath and SensitiveHostPath guardrails, e.g. /var/lib/iscsi
+	AllowedHostPaths []string
+}
+
+// DefaultMountPolicy warns about hostPath volumes and privileged mounts,
+// and denies sensitive host paths
+var DefaultMountPolicy = MountPolicy{
+	HostPath:          GuardrailWarn,
+	SensitiveHostPath: GuardrailDeny,
+	PrivilegedMount:   GuardrailWarn,
+}
+
+// ValidateExtraMountsPolicy - checks the extraMounts against the policy.
+// Violations are returned as warnings or errors depending on the
+// GuardrailAction of the policy. basePath is the field path of the list.
+// The warnings can be returned as admission.Warnings from a webhook.
+func ValidateExtraMountsPolicy(
+	basePath *field.Path,
+	extraMounts []VolMounts,
+	policy MountPolicy,
+) ([]string, field.ErrorList) {
+	allWarn := []string{}
+	allErrs := field.ErrorList{}
+
+	report := func(action GuardrailAction, fldPath *field.Path, msg string) {
+		switch action {
+		case GuardrailWarn:
+			allWarn = append(allWarn, fmt.Sprintf("%s: %s", fldPath.String(), msg))
+		case GuardrailDeny:
+			allErrs = append(allErrs, field.Forbidden(fldPath, msg))
+		}
+	}
+
+	sensitivePaths := policy.SensitiveHostPaths
+	if sensitivePaths == nil {
+		sensitivePaths = DefaultSensitiveHostPaths
+	}
+
+	for i, vm := range extraMounts {
+		for j, vol := range vm.Volumes {
+			if vol.HostPath == nil {
+				continue
+			}
+
+			fldPath := basePath.Index(i).Child("volumes").Index(j).Child("hostPath", "path")
+			hostPath := path.Clean(vol.HostPath.Path)
+			if isAllowedHostPath(hostPath, policy.AllowedHostPaths) {
+				continue
+			}
+
+			if sensitive, ok := getSensitiveHostPath(hostPath, sensitivePaths); ok {
+				report(policy.SensitiveHostPath, fldPath,
+					fmt.Sprintf("hostPath %s exposes the sensitive host path %s", vol.HostPath.Path, sensitive))
+				continue
+			}
+			report(policy.HostPath, fldPath,
+				fmt.Sprintf("hostPath %s mounts a host directory", vol.HostPath.Path))
+		}
+
+		for j, m := range vm.Mounts {
+			if m.MountPropagation != nil && *m.MountPropagation == corev1.MountPropagationBidirectional {
+				fldPath := basePath.Index(i).Child("mounts").Index(j).Child("mountPropagation")
+				report(policy.PrivilegedMount, fldPath,
+					fmt.Sprintf("mount %s with Bidirectional propagation requires a privileged container", m.Name))
+			}
+		}
+	}
+
+	return allWarn, allErrs
+}
+
+// isAllowedHostPath - returns true if p is one of, or below one of, the allowed paths
+func isAllowedHostPath(p string, allowed []string) bool {
+	for _, a := range allowed {
+		if isSubPath(p, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// getSensitiveHostPath - returns the sensitive path p is, is below, or is a parent of
+func getSensitiveHostPath(p string, sensitive []string) (string, bool) {
+	for _, s := range sensitive {
+		s = path.Clean(s)
+		if p == s {
+			return s, true
+		}
+		if s == "/" {
+			continue
+		}
+		if isSubPath(p, s) || isSubPath(s, p) {
+			return s, true
+		}
+	}
+	return "", false
+}
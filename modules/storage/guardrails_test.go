@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func getHostPathVolMounts(hostPath string, propagation *corev1.MountPropagationMode) VolMounts {
+	return VolMounts{
+		Volumes: []Volume{
+			{
+				Name: "host",
+				VolumeSource: VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: hostPath},
+				},
+			},
+		},
+		Mounts: []corev1.VolumeMount{
+			{
+				Name:             "host",
+				MountPath:        "/mnt/host",
+				MountPropagation: propagation,
+			},
+		},
+	}
+}
+
+func TestValidateExtraMountsPolicy(t *testing.T) {
+	basePath := field.NewPath("spec").Child("extraMounts")
+	bidirectional := corev1.MountPropagationBidirectional
+
+	tests := []struct {
+		name      string
+		mounts    []VolMounts
+		policy    MountPolicy
+		wantWarns int
+		wantErrs  int
+	}{
+		{
+			name:   "no hostPath",
+			mounts: []VolMounts{getVolMounts(nil, "ceph", "/etc/ceph")},
+			policy: DefaultMountPolicy,
+		},
+		{
+			name:      "hostPath warning",
+			mounts:    []VolMounts{getHostPathVolMounts("/var/lib/iscsi", nil)},
+			policy:    DefaultMountPolicy,
+			wantWarns: 1,
+		},
+		{
+			name:   "allowed hostPath",
+			mounts: []VolMounts{getHostPathVolMounts("/var/lib/iscsi/nodes", nil)},
+			policy: MountPolicy{
+				HostPath:         GuardrailDeny,
+				AllowedHostPaths: []string{"/var/lib/iscsi"},
+			},
+		},
+		{
+			name:     "sensitive hostPath below",
+			mounts:   []VolMounts{getHostPathVolMounts("/etc/kubernetes", nil)},
+			policy:   DefaultMountPolicy,
+			wantErrs: 1,
+		},
+		{
+			name:     "sensitive hostPath parent",
+			mounts:   []VolMounts{getHostPathVolMounts("/var/lib", nil)},
+			policy:   DefaultMountPolicy,
+			wantErrs: 1,
+		},
+		{
+			name:      "host root",
+			mounts:    []VolMounts{getHostPathVolMounts("/", nil)},
+			policy:    MountPolicy{SensitiveHostPath: GuardrailWarn},
+			wantWarns: 1,
+		},
+		{
+			name:      "privileged mount",
+			mounts:    []VolMounts{getHostPathVolMounts("/var/lib/iscsi", &bidirectional)},
+			policy:    DefaultMountPolicy,
+			wantWarns: 2,
+		},
+		{
+			name:   "allow all",
+			mounts: []VolMounts{getHostPathVolMounts("/etc", &bidirectional)},
+			policy: MountPolicy{
+				HostPath:          GuardrailAllow,
+				SensitiveHostPath: GuardrailAllow,
+				PrivilegedMount:   GuardrailAllow,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			warns, errs := ValidateExtraMountsPolicy(basePath, tt.mounts, tt.policy)
+			g.Expect(warns).To(HaveLen(tt.wantWarns))
+			g.Expect(errs).To(HaveLen(tt.wantErrs))
+			for _, e := range errs {
+				g.Expect(e.Type).To(Equal(field.ErrorTypeForbidden))
+			}
+		})
+	}
+}
@@ -93,6 +93,15 @@ type VolumeSource struct {
 
 	// projected items for all in one resources secrets, configmaps, and downward API
 	Projected *corev1.ProjectedVolumeSource `json:"projected,omitempty" protobuf:"bytes,26,opt,name=projected"`
+
+	// ephemeral represents a volume that is handled by a cluster storage driver.
+	// The volume's lifecycle is tied to the pod that defines it - it will be created before the pod starts,
+	// and deleted when the pod is removed. A PVC gets created per pod from the volumeClaimTemplate,
+	// named <pod name>-<volume name>, and owned by the pod, so it gets garbage collected with the pod.
+	// Use it for scratch space which needs the performance or size of a StorageClass backed volume.
+	// More info: https://kubernetes.io/docs/concepts/storage/ephemeral-volumes/#generic-ephemeral-volumes
+	// +optional
+	Ephemeral *corev1.EphemeralVolumeSource `json:"ephemeral,omitempty" protobuf:"bytes,29,opt,name=ephemeral"`
 }
 
 // Volume our slimmed down version of Volume
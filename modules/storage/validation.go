@@ -0,0 +1,258 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultReservedMountPaths are the paths used by the operators to mount
+// the service config and the kolla config. An extraMount must not mount
+// to, or below, any of these paths.
+var DefaultReservedMountPaths = []string{
+	"/etc/config",
+	"/var/lib/kolla",
+}
+
+// ReferenceLookup is used to validate that the Secrets and ConfigMaps an
+// extraMount references exist. A nil func skips the check for the kind.
+// +kubebuilder:object:generate:=false
+type ReferenceLookup struct {
+	// SecretExists - returns true if the Secret with the name exists
+	SecretExists func(name string) (bool, error)
+	// ConfigMapExists - returns true if the ConfigMap with the name exists
+	ConfigMapExists func(name string) (bool, error)
+}
+
+// ValidateExtraMounts - validates a list of VolMounts, usable in webhooks.
+// basePath is the field path of the list. Each entry gets validated with
+// VolMounts.Validate and mount paths must be unique across all entries
+// which get propagated to the same service.
+//
+// example usage:
+//
+//	errs := ValidateExtraMounts(
+//		field.NewPath("spec").Child("extraMounts").Index(0).Child("extraVol"),
+//		spec.ExtraMounts[0].VolMounts,
+//		DefaultReservedMountPaths)
+func ValidateExtraMounts(
+	basePath *field.Path,
+	extraMounts []VolMounts,
+	reservedPaths []string,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i := range extraMounts {
+		allErrs = append(allErrs, extraMounts[i].Validate(basePath.Index(i), reservedPaths)...)
+	}
+
+	// duplicate mount paths across entries which propagate to the same service
+	for i := range extraMounts {
+		for j := 0; j < i; j++ {
+			if !propagationOverlaps(extraMounts[i].Propagation, extraMounts[j].Propagation) {
+				continue
+			}
+			for mi, m := range extraMounts[i].Mounts {
+				for _, other := range extraMounts[j].Mounts {
+					if path.Clean(m.MountPath) == path.Clean(other.MountPath) {
+						allErrs = append(allErrs, field.Duplicate(
+							basePath.Index(i).Child("mounts").Index(mi).Child("mountPath"), m.MountPath))
+					}
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// Validate - validates the VolMounts, basePath is the field path of the
+// VolMounts. It checks
+// - the propagation list has no empty or duplicate entries
+// - volume names are unique
+// - ephemeral volumes have a volumeClaimTemplate with a storage request
+// - each mount references a volume of the VolMounts
+// - mount paths are absolute, unique, and do not collide with the reserved paths
+// - the mountPropagation mode is valid, Bidirectional only for hostPath volumes
+func (v *VolMounts) Validate(
+	basePath *field.Path,
+	reservedPaths []string,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	propagations := map[PropagationType]bool{}
+	for i, p := range v.Propagation {
+		pPath := basePath.Child("propagation").Index(i)
+		if p == "" {
+			allErrs = append(allErrs, field.Invalid(pPath, p, "propagation must not be empty"))
+			continue
+		}
+		if propagations[p] {
+			allErrs = append(allErrs, field.Duplicate(pPath, p))
+		}
+		propagations[p] = true
+	}
+
+	volumes := map[string]*Volume{}
+	for i := range v.Volumes {
+		vol := &v.Volumes[i]
+		if _, ok := volumes[vol.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(basePath.Child("volumes").Index(i).Child("name"), vol.Name))
+			continue
+		}
+		volumes[vol.Name] = vol
+
+		if vol.Ephemeral != nil {
+			ePath := basePath.Child("volumes").Index(i).Child("ephemeral", "volumeClaimTemplate")
+			if vol.Ephemeral.VolumeClaimTemplate == nil {
+				allErrs = append(allErrs, field.Required(ePath, "volumeClaimTemplate is required for ephemeral volumes"))
+			} else if _, ok := vol.Ephemeral.VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage]; !ok {
+				allErrs = append(allErrs, field.Required(ePath.Child("spec", "resources", "requests", "storage"),
+					"storage request is required for ephemeral volumes"))
+			}
+		}
+	}
+
+	mountPaths := map[string]bool{}
+	for i, m := range v.Mounts {
+		mPath := basePath.Child("mounts").Index(i)
+
+		vol, ok := volumes[m.Name]
+		if !ok {
+			allErrs = append(allErrs, field.NotFound(mPath.Child("name"), m.Name))
+		}
+
+		if !path.IsAbs(m.MountPath) {
+			allErrs = append(allErrs, field.Invalid(mPath.Child("mountPath"), m.MountPath, "must be an absolute path"))
+			continue
+		}
+
+		cleanPath := path.Clean(m.MountPath)
+		if mountPaths[cleanPath] {
+			allErrs = append(allErrs, field.Duplicate(mPath.Child("mountPath"), m.MountPath))
+		}
+		mountPaths[cleanPath] = true
+
+		for _, reserved := range reservedPaths {
+			if isSubPath(cleanPath, reserved) {
+				allErrs = append(allErrs, field.Invalid(mPath.Child("mountPath"), m.MountPath,
+					fmt.Sprintf("collides with the reserved path %s", reserved)))
+			}
+		}
+
+		if m.MountPropagation != nil {
+			switch *m.MountPropagation {
+			case corev1.MountPropagationNone, corev1.MountPropagationHostToContainer:
+			case corev1.MountPropagationBidirectional:
+				if ok && vol.HostPath == nil {
+					allErrs = append(allErrs, field.Invalid(mPath.Child("mountPropagation"), *m.MountPropagation,
+						"Bidirectional mount propagation is only supported for hostPath volumes"))
+				}
+			default:
+				allErrs = append(allErrs, field.NotSupported(mPath.Child("mountPropagation"), *m.MountPropagation,
+					[]string{
+						string(corev1.MountPropagationNone),
+						string(corev1.MountPropagationHostToContainer),
+						string(corev1.MountPropagationBidirectional),
+					}))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateExtraMountsReferences - validates that the Secrets and ConfigMaps
+// referenced by the volumes of the extraMounts exist. Optional references
+// are not checked. basePath is the field path of the list.
+func ValidateExtraMountsReferences(
+	basePath *field.Path,
+	extraMounts []VolMounts,
+	lookup ReferenceLookup,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	check := func(fldPath *field.Path, name string, exists func(name string) (bool, error)) {
+		if exists == nil {
+			return
+		}
+		found, err := exists(name)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath, err))
+			return
+		}
+		if !found {
+			allErrs = append(allErrs, field.NotFound(fldPath, name))
+		}
+	}
+
+	for i, vm := range extraMounts {
+		for j, vol := range vm.Volumes {
+			volPath := basePath.Index(i).Child("volumes").Index(j)
+
+			if s := vol.Secret; s != nil && (s.Optional == nil || !*s.Optional) {
+				check(volPath.Child("secret", "secretName"), s.SecretName, lookup.SecretExists)
+			}
+			if c := vol.ConfigMap; c != nil && (c.Optional == nil || !*c.Optional) {
+				check(volPath.Child("configMap", "name"), c.Name, lookup.ConfigMapExists)
+			}
+			if p := vol.Projected; p != nil {
+				for k, src := range p.Sources {
+					srcPath := volPath.Child("projected", "sources").Index(k)
+					if s := src.Secret; s != nil && (s.Optional == nil || !*s.Optional) {
+						check(srcPath.Child("secret", "name"), s.Name, lookup.SecretExists)
+					}
+					if c := src.ConfigMap; c != nil && (c.Optional == nil || !*c.Optional) {
+						check(srcPath.Child("configMap", "name"), c.Name, lookup.ConfigMapExists)
+					}
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// isSubPath - returns true if p is the same path as, or below, parent
+func isSubPath(p string, parent string) bool {
+	parent = path.Clean(parent)
+	return p == parent || strings.HasPrefix(p, strings.TrimSuffix(parent, "/")+"/")
+}
+
+// propagationOverlaps - returns true if two propagation lists get mounted
+// to at least one common service
+func propagationOverlaps(a []PropagationType, b []PropagationType) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, p := range a {
+		if p == PropagationEverywhere || canPropagate(p, b) {
+			return true
+		}
+	}
+	for _, p := range b {
+		if p == PropagationEverywhere {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func getVolMounts(propagation []PropagationType, volName string, mountPath string) VolMounts {
+	return VolMounts{
+		Propagation: propagation,
+		Volumes: []Volume{
+			{
+				Name: volName,
+				VolumeSource: VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: volName},
+				},
+			},
+		},
+		Mounts: []corev1.VolumeMount{
+			{
+				Name:      volName,
+				MountPath: mountPath,
+			},
+		},
+	}
+}
+
+func TestValidateExtraMounts(t *testing.T) {
+	basePath := field.NewPath("spec").Child("extraMounts")
+	bidirectional := corev1.MountPropagationBidirectional
+
+	tests := []struct {
+		name        string
+		extraMounts func() []VolMounts
+		wantErrs    []string
+	}{
+		{
+			name: "valid extraMounts",
+			extraMounts: func() []VolMounts {
+				return []VolMounts{
+					getVolMounts([]PropagationType{"Glance"}, "ceph", "/etc/ceph"),
+					getVolMounts([]PropagationType{"Cinder"}, "ceph2", "/etc/ceph"),
+				}
+			},
+		},
+		{
+			name: "duplicate mount path in overlapping propagation",
+			extraMounts: func() []VolMounts {
+				return []VolMounts{
+					getVolMounts([]PropagationType{"Glance"}, "ceph", "/etc/ceph"),
+					getVolMounts([]PropagationType{PropagationEverywhere}, "ceph2", "/etc/ceph/"),
+				}
+			},
+			wantErrs: []string{"spec.extraMounts[1].mounts[0].mountPath"},
+		},
+		{
+			name: "reserved path",
+			extraMounts: func() []VolMounts {
+				return []VolMounts{
+					getVolMounts(nil, "cfg", "/var/lib/kolla/config_files/foo"),
+					getVolMounts(nil, "cfg2", "/etc/configs"),
+				}
+			},
+			wantErrs: []string{"spec.extraMounts[0].mounts[0].mountPath"},
+		},
+		{
+			name: "mount of unknown volume and relative path",
+			extraMounts: func() []VolMounts {
+				vm := getVolMounts(nil, "ceph", "etc/ceph")
+				vm.Mounts[0].Name = "foo"
+				return []VolMounts{vm}
+			},
+			wantErrs: []string{
+				"spec.extraMounts[0].mounts[0].name",
+				"spec.extraMounts[0].mounts[0].mountPath",
+			},
+		},
+		{
+			name: "duplicate propagation and volume",
+			extraMounts: func() []VolMounts {
+				vm := getVolMounts([]PropagationType{"Glance", "Glance"}, "ceph", "/etc/ceph")
+				vm.Volumes = append(vm.Volumes, vm.Volumes[0])
+				return []VolMounts{vm}
+			},
+			wantErrs: []string{
+				"spec.extraMounts[0].propagation[1]",
+				"spec.extraMounts[0].volumes[1].name",
+			},
+		},
+		{
+			name: "bidirectional propagation for non hostPath volume",
+			extraMounts: func() []VolMounts {
+				vm := getVolMounts(nil, "ceph", "/etc/ceph")
+				vm.Mounts[0].MountPropagation = &bidirectional
+				return []VolMounts{vm}
+			},
+			wantErrs: []string{"spec.extraMounts[0].mounts[0].mountPropagation"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := ValidateExtraMounts(basePath, tt.extraMounts(), DefaultReservedMountPaths)
+			fields := []string{}
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			if len(tt.wantErrs) == 0 {
+				g.Expect(errs).To(BeEmpty())
+			} else {
+				g.Expect(fields).To(ConsistOf(tt.wantErrs))
+			}
+		})
+	}
+}
+
+func TestValidateExtraMountsReferences(t *testing.T) {
+	g := NewWithT(t)
+	optional := true
+
+	vm := getVolMounts(nil, "ceph", "/etc/ceph")
+	vm.Volumes = append(vm.Volumes,
+		Volume{
+			Name: "missing-optional",
+			VolumeSource: VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: "missing", Optional: &optional},
+			},
+		},
+		Volume{
+			Name: "cm",
+			VolumeSource: VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "missing-cm"},
+				},
+			},
+		},
+	)
+
+	lookup := ReferenceLookup{
+		SecretExists: func(name string) (bool, error) {
+			return name == "ceph", nil
+		},
+		ConfigMapExists: func(_ string) (bool, error) {
+			return false, nil
+		},
+	}
+
+	errs := ValidateExtraMountsReferences(field.NewPath("extraMounts"), []VolMounts{vm}, lookup)
+	g.Expect(errs).To(HaveLen(1))
+	g.Expect(errs[0].Type).To(Equal(field.ErrorTypeNotFound))
+	g.Expect(errs[0].Field).To(Equal("extraMounts[0].volumes[2].configMap.name"))
+}
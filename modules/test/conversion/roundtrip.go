@@ -0,0 +1,269 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion provides fuzz-style round-trip tests for the
+// conversion webhooks of CRDs with multiple versions
+package conversion
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/gomega" // nolint:revive
+)
+
+// DataAnnotation - the annotation the conversion package of the common
+// module stores the fields the converted version can not hold in. It is
+// ignored when comparing the round-tripped objects. Same value as
+// conversion.DataAnnotation of the common module, which is not imported to
+// keep the test module free of a dependency on it.
+const DataAnnotation = "conversion.openstack.org/data"
+
+const (
+	// defaultIterations - random objects per direction if not set
+	defaultIterations = 100
+	// maxDepth - depth of nested fields filled, to terminate on recursive
+	// types
+	maxDepth = 8
+	// maxLen - max length of the random slices and maps
+	maxLen = 3
+)
+
+// Filler - fills v, a settable value of a type the default filling does not
+// handle, e.g. one which needs a valid format
+type Filler func(r *rand.Rand, v reflect.Value)
+
+// RoundTripTest - tests that the conversion between the hub version H and a
+// spoke version S is lossless, like the API server requires it: random hub
+// objects converted to the spoke and back, and random spoke objects
+// converted to the hub and back, must not change.
+type RoundTripTest[H any, S any] struct {
+	// NewHub - returns an empty hub object
+	NewHub func() H
+	// NewSpoke - returns an empty spoke object
+	NewSpoke func() S
+	// ConvertTo - converts the spoke to the hub, e.g. its ConvertTo method
+	ConvertTo func(spoke S, hub H) error
+	// ConvertFrom - converts the hub to the spoke, e.g. its ConvertFrom method
+	ConvertFrom func(spoke S, hub H) error
+	// Fillers - fill the values of the types the default filling can not,
+	// e.g. resource.Quantity, or which must be valid for the conversion
+	Fillers map[reflect.Type]Filler
+	// Iterations - random objects per direction, 100 if zero
+	Iterations int
+	// Seed - seed of the random objects, to reproduce failures
+	Seed int64
+}
+
+// Run - runs the round-trips
+//
+// Example:
+//
+//	func TestKeystoneAPIConversion(t *testing.T) {
+//		conversion.RoundTripTest[*v1beta2.KeystoneAPI, *v1beta1.KeystoneAPI]{
+//			NewHub:   func() *v1beta2.KeystoneAPI { return &v1beta2.KeystoneAPI{} },
+//			NewSpoke: func() *v1beta1.KeystoneAPI { return &v1beta1.KeystoneAPI{} },
+//			ConvertTo: func(spoke *v1beta1.KeystoneAPI, hub *v1beta2.KeystoneAPI) error {
+//				return spoke.ConvertTo(hub)
+//			},
+//			ConvertFrom: func(spoke *v1beta1.KeystoneAPI, hub *v1beta2.KeystoneAPI) error {
+//				return spoke.ConvertFrom(hub)
+//			},
+//		}.Run(NewWithT(t))
+//	}
+func (rt RoundTripTest[H, S]) Run(g Gomega) {
+	iterations := rt.Iterations
+	if iterations == 0 {
+		iterations = defaultIterations
+	}
+	r := rand.New(rand.NewSource(rt.Seed)) // #nosec G404
+
+	for i := 0; i < iterations; i++ {
+		hub := rt.NewHub()
+		Fill(r, hub, rt.Fillers)
+		want := deepCopy(hub)
+
+		spoke := rt.NewSpoke()
+		g.Expect(rt.ConvertFrom(spoke, hub)).To(Succeed())
+		got := rt.NewHub()
+		g.Expect(rt.ConvertTo(spoke, got)).To(Succeed())
+		removeDataAnnotation(got)
+		g.Expect(got).To(Equal(want), "hub -> spoke -> hub round-trip %d with seed %d", i, rt.Seed)
+	}
+
+	for i := 0; i < iterations; i++ {
+		spoke := rt.NewSpoke()
+		Fill(r, spoke, rt.Fillers)
+		want := deepCopy(spoke)
+
+		hub := rt.NewHub()
+		g.Expect(rt.ConvertTo(spoke, hub)).To(Succeed())
+		got := rt.NewSpoke()
+		g.Expect(rt.ConvertFrom(got, hub)).To(Succeed())
+		removeDataAnnotation(got)
+		g.Expect(got).To(Equal(want), "spoke -> hub -> spoke round-trip %d with seed %d", i, rt.Seed)
+	}
+}
+
+// annotated - the annotation accessors of metav1.Object
+type annotated interface {
+	GetAnnotations() map[string]string
+	SetAnnotations(map[string]string)
+}
+
+// removeDataAnnotation - removes the DataAnnotation of obj, if it has one
+func removeDataAnnotation(obj interface{}) {
+	o, ok := obj.(annotated)
+	if !ok {
+		return
+	}
+	annotations := o.GetAnnotations()
+	if _, ok := annotations[DataAnnotation]; !ok {
+		return
+	}
+	delete(annotations, DataAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	o.SetAnnotations(annotations)
+}
+
+// deepCopy - returns a deep copy of obj
+func deepCopy[T any](obj T) T {
+	c := reflect.New(reflect.TypeOf(obj)).Elem()
+	copyValue(c, reflect.ValueOf(obj))
+	return c.Interface().(T)
+}
+
+// copyValue - deep copies src to dst, unexported fields are copied shallow
+func copyValue(dst reflect.Value, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(src.Type().Elem()))
+		copyValue(dst.Elem(), src.Elem())
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		for _, k := range src.MapKeys() {
+			v := reflect.New(src.Type().Elem()).Elem()
+			copyValue(v, src.MapIndex(k))
+			dst.SetMapIndex(k, v)
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// Fill - fills obj, a pointer, with random values of r: all exported
+// fields get set, strings to letters only. Values of the types in fillers
+// are filled by them, unexported fields, interfaces and channels are left
+// unset. The TypeMeta and the metadata of an API object are left unset as
+// well, they are not converted but set by the API server.
+func Fill(r *rand.Rand, obj interface{}, fillers map[reflect.Type]Filler) {
+	v := reflect.ValueOf(obj).Elem()
+	if v.Kind() != reflect.Struct {
+		fill(r, v, fillers, 0)
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !v.Field(i).CanSet() || (f.Anonymous && f.Type.Name() == "TypeMeta") ||
+			strings.Split(f.Tag.Get("json"), ",")[0] == "metadata" {
+			continue
+		}
+		fill(r, v.Field(i), fillers, 1)
+	}
+}
+
+// fill - fills v with random values, see Fill
+func fill(r *rand.Rand, v reflect.Value, fillers map[reflect.Type]Filler, depth int) {
+	if f, ok := fillers[v.Type()]; ok {
+		f(r, v)
+		return
+	}
+	if depth > maxDepth {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(r.Int63n(128))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(r.Int63n(128)))
+	case reflect.Float32, reflect.Float64:
+		// whole numbers, which survive the conversion to JSON unchanged
+		v.SetFloat(float64(r.Int63n(128)))
+	case reflect.String:
+		v.SetString(randomString(r))
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(r, v.Elem(), fillers, depth+1)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				fill(r, v.Field(i), fillers, depth+1)
+			}
+		}
+	case reflect.Slice:
+		n := 1 + r.Intn(maxLen)
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			fill(r, v.Index(i), fillers, depth+1)
+		}
+	case reflect.Map:
+		n := 1 + r.Intn(maxLen)
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			fill(r, key, fillers, depth+1)
+			value := reflect.New(v.Type().Elem()).Elem()
+			fill(r, value, fillers, depth+1)
+			v.SetMapIndex(key, value)
+		}
+	}
+}
+
+// randomString - returns a non empty string of lowercase letters
+func randomString(r *rand.Rand) string {
+	b := make([]byte, 1+r.Intn(8))
+	for i := range b {
+		b[i] = byte('a' + r.Intn(26))
+	}
+	return string(b)
+}
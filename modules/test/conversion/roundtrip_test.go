@@ -0,0 +1,129 @@
+/*
+Copyright 2026 Red Hat
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	. "github.com/onsi/gomega" // nolint:revive
+)
+
+type TypeMeta struct {
+	Kind string `json:"kind,omitempty"`
+}
+
+type objectMeta struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type hubObject struct {
+	TypeMeta   `json:",inline"`
+	ObjectMeta objectMeta `json:"metadata,omitempty"`
+	Cluster    *string    `json:"cluster,omitempty"`
+	Replicas   int32      `json:"replicas,omitempty"`
+	Labels     []string   `json:"labels,omitempty"`
+}
+
+type spokeObject struct {
+	ObjectMeta  objectMeta `json:"metadata,omitempty"`
+	ClusterName string     `json:"clusterName,omitempty"`
+	Replicas    int32      `json:"replicas,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
+}
+
+func convertTo(spoke *spokeObject, hub *hubObject) error {
+	if spoke.ClusterName != "" {
+		hub.Cluster = &spoke.ClusterName
+	}
+	hub.Replicas = spoke.Replicas
+	hub.Labels = spoke.Labels
+	return nil
+}
+
+func convertFrom(spoke *spokeObject, hub *hubObject) error {
+	if hub.Cluster != nil {
+		spoke.ClusterName = *hub.Cluster
+	}
+	spoke.Replicas = hub.Replicas
+	spoke.Labels = hub.Labels
+	// like conversion.MarshalData
+	spoke.ObjectMeta.Annotations = map[string]string{DataAnnotation: "{}"}
+	return nil
+}
+
+func (o *spokeObject) GetAnnotations() map[string]string {
+	return o.ObjectMeta.Annotations
+}
+
+func (o *spokeObject) SetAnnotations(a map[string]string) {
+	o.ObjectMeta.Annotations = a
+}
+
+func TestRoundTrip(t *testing.T) {
+	rt := RoundTripTest[*hubObject, *spokeObject]{
+		NewHub:      func() *hubObject { return &hubObject{} },
+		NewSpoke:    func() *spokeObject { return &spokeObject{} },
+		ConvertTo:   convertTo,
+		ConvertFrom: convertFrom,
+		Seed:        1,
+	}
+
+	t.Run("lossless conversion", func(t *testing.T) {
+		rt.Run(NewWithT(t))
+	})
+
+	t.Run("lossy conversion", func(t *testing.T) {
+		g := NewWithT(t)
+
+		failures := []string{}
+		lossy := rt
+		lossy.ConvertFrom = func(spoke *spokeObject, hub *hubObject) error {
+			spoke.Replicas = hub.Replicas
+			return nil
+		}
+		lossy.Run(NewGomega(func(message string, _ ...int) {
+			failures = append(failures, message)
+		}))
+		g.Expect(failures).ToNot(BeEmpty())
+		g.Expect(failures[0]).To(ContainSubstring("hub -> spoke -> hub round-trip 0 with seed 1"))
+	})
+}
+
+func TestFill(t *testing.T) {
+	g := NewWithT(t)
+
+	r := rand.New(rand.NewSource(1)) // #nosec G404
+	obj := &hubObject{}
+	Fill(r, obj, map[reflect.Type]Filler{
+		reflect.TypeOf(int32(0)): func(_ *rand.Rand, v reflect.Value) { v.SetInt(3) },
+	})
+	g.Expect(obj.TypeMeta).To(Equal(TypeMeta{}))
+	g.Expect(obj.ObjectMeta).To(Equal(objectMeta{}))
+	g.Expect(obj.Cluster).ToNot(BeNil())
+	g.Expect(*obj.Cluster).To(MatchRegexp("^[a-z]+$"))
+	g.Expect(obj.Replicas).To(Equal(int32(3)))
+	g.Expect(obj.Labels).ToNot(BeEmpty())
+
+	c := deepCopy(obj)
+	g.Expect(c).To(Equal(obj))
+	*c.Cluster = "changed"
+	c.Labels[0] = "changed"
+	g.Expect(*obj.Cluster).ToNot(Equal("changed"))
+	g.Expect(obj.Labels[0]).ToNot(Equal("changed"))
+}
@@ -157,3 +157,39 @@ func GetOpenShiftCRDDir(crdName string, goModPath string) (string, error) {
 
 	return path, nil
 }
+
+// GetCertManagerCRDDir returns the absolute path of the directory holding the
+// cert-manager custom resource definitions (Certificate, Issuer) to be added
+// to envtest.Environment.CRDDirectoryPaths
+func GetCertManagerCRDDir(goModPath string) (string, error) {
+	return GetOpenShiftCRDDir("cert-manager/v1", goModPath)
+}
+
+// GetMariaDBCRDDir returns the absolute path of the directory holding the
+// mariadb-operator custom resource definitions (Galera, MariaDBDatabase,
+// MariaDBAccount) based on the mariadb-operator/api version in go.mod
+func GetMariaDBCRDDir(goModPath string) (string, error) {
+	return GetCRDDirFromModule(
+		"github.com/openstack-k8s-operators/mariadb-operator/api", goModPath, "bases")
+}
+
+// OpenShiftCRDNames - the OpenShift CRDs shipped in openshift_crds which
+// GetOpenShiftCRDDirs returns
+var OpenShiftCRDNames = []string{"config/v1", "route/v1", "security/v1", "image/v1"}
+
+// GetOpenShiftCRDDirs returns the absolute paths of the directories holding
+// the OpenShift custom resource definitions (Network config, Route,
+// SecurityContextConstraints, ImageStream) to run functional tests outside
+// of an OpenShift cluster
+func GetOpenShiftCRDDirs(goModPath string) ([]string, error) {
+	paths := []string{}
+	for _, crdName := range OpenShiftCRDNames {
+		path, err := GetOpenShiftCRDDir(crdName, goModPath)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
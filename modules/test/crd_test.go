@@ -141,3 +141,24 @@ require (
 		g.Expect(err).Should(MatchError("cannot find github.com/openstack-k8s-operators/lib-common/modules/test in go.mod file"))
 	})
 }
+
+func TestGetOpenShiftCRDDirs(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	mod := []byte(`module foo
+go 1.21
+require (
+	github.com/openstack-k8s-operators/lib-common/modules/test v0.0.0-20220630111354-9f8383d4a2ea
+)
+	`)
+	modPath := filepath.Join(dir, "go.mod")
+	err := os.WriteFile(modPath, mod, 0644)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	paths, err := GetOpenShiftCRDDirs(modPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(paths).To(HaveLen(len(OpenShiftCRDNames)))
+	g.Expect(paths).To(ContainElement(MatchRegexp("/openshift_crds/security/v1$")))
+	g.Expect(paths).To(ContainElement(MatchRegexp("/openshift_crds/image/v1$")))
+}